@@ -1,18 +1,26 @@
 package anthropic
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/instill-ai/component/ai"
 	"github.com/instill-ai/component/internal/util/httpclient"
 )
 
+const apiName = "Anthropic"
+
 type anthropicClient struct {
 	httpClient *httpclient.Client
 }
 
 func newClient(apiKey string, baseURL string, logger *zap.Logger) *anthropicClient {
-	c := httpclient.New("Anthropic", baseURL,
+	c := httpclient.New(apiName, baseURL,
 		httpclient.WithLogger(logger),
 		httpclient.WithEndUserError(new(errBody)),
 	)
@@ -32,6 +40,95 @@ func (cl *anthropicClient) generateTextChat(request messagesReq) (messagesResp,
 	return resp, nil
 }
 
+// streamEvent holds the fields of the different events of a streamed
+// response.
+type streamEvent struct {
+	Type         string       `json:"type"`
+	Index        int          `json:"index"`
+	Message      messagesResp `json:"message"`
+	ContentBlock content      `json:"content_block"`
+	Delta        struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage usage   `json:"usage"`
+	Error errBody `json:"error"`
+}
+
+func (cl *anthropicClient) streamTextChat(ctx context.Context, request messagesReq, onUpdate func(messagesResp) error) (messagesResp, error) {
+	resp := messagesResp{}
+	restyResp, err := cl.httpClient.R().SetContext(ctx).SetBody(request).SetDoNotParseResponse(true).Post(messagesPath)
+	if err != nil {
+		return resp, err
+	}
+	if err := httpclient.UnparsedResponseError(apiName, restyResp, new(errBody)); err != nil {
+		return resp, err
+	}
+
+	body := restyResp.RawBody()
+	defer body.Close()
+
+	// The input of the tool uses is streamed as partial JSON strings, which
+	// are only valid once the content block is complete.
+	toolInputs := map[int]*strings.Builder{}
+	err = ai.ScanEvents(body, func(data []byte) error {
+		event := streamEvent{}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("unmarshalling stream event: %w", err)
+		}
+
+		switch event.Type {
+		case "message_start":
+			resp = event.Message
+			resp.Content = []content{}
+		case "content_block_start":
+			block := event.ContentBlock
+			if block.Type == "tool_use" {
+				toolInputs[event.Index] = new(strings.Builder)
+			}
+			resp.Content = append(resp.Content, block)
+		case "content_block_delta":
+			if event.Index >= len(resp.Content) {
+				return fmt.Errorf("received a delta for unknown content block %d", event.Index)
+			}
+			switch event.Delta.Type {
+			case "text_delta":
+				resp.Content[event.Index].Text += event.Delta.Text
+			case "input_json_delta":
+				if b, ok := toolInputs[event.Index]; ok {
+					b.WriteString(event.Delta.PartialJSON)
+				}
+			}
+		case "content_block_stop":
+			b, ok := toolInputs[event.Index]
+			if !ok || event.Index >= len(resp.Content) {
+				return nil
+			}
+
+			input := map[string]any{}
+			if b.Len() > 0 {
+				if err := json.Unmarshal([]byte(b.String()), &input); err != nil {
+					return fmt.Errorf("unmarshalling tool input: %w", err)
+				}
+			}
+			resp.Content[event.Index].Input = input
+		case "message_delta":
+			resp.StopReason = event.Delta.StopReason
+			resp.Usage.OutputTokens = event.Usage.OutputTokens
+		case "error":
+			return fmt.Errorf("streaming response: %s", event.Error.Error.Message)
+		default:
+			return nil
+		}
+
+		return onUpdate(resp)
+	})
+
+	return resp, err
+}
+
 type errBody struct {
 	Error struct {
 		Message string `json:"message"`
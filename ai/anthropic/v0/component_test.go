@@ -2,6 +2,7 @@ package anthropic
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -187,6 +188,14 @@ func (m *MockAnthropicClient) generateTextChat(request messagesReq) (messagesRes
 	return resp, nil
 }
 
+func (m *MockAnthropicClient) streamTextChat(_ context.Context, request messagesReq, onUpdate func(messagesResp) error) (messagesResp, error) {
+	resp, _ := m.generateTextChat(request)
+	if err := onUpdate(resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 func TestComponent_Generation(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -204,7 +213,8 @@ func TestComponent_Generation(t *testing.T) {
 	}{
 		input: map[string]any{"prompt": "Hi! What's your name?", "chat-history": mockHistory},
 		wantResp: MessagesOutput{
-			Text: "Hi! My name is Claude. (messageCount: 3)",
+			Text:       "Hi! My name is Claude. (messageCount: 3)",
+			StopReason: "end_turn",
 			Usage: messagesUsage{
 				InputTokens:  10,
 				OutputTokens: 25,
@@ -242,3 +252,153 @@ func TestComponent_Generation(t *testing.T) {
 
 	})
 }
+
+func TestBuildRequest(t *testing.T) {
+	c := qt.New(t)
+
+	// The smallest valid PDF header, which is enough for the MIME type
+	// detection.
+	pdf := base64.StdEncoding.EncodeToString([]byte("%PDF-1.4\n%%EOF\n"))
+
+	in := MessagesInput{
+		ModelName:    "claude-3-5-sonnet-20240620",
+		MaxNewTokens: 500,
+		SystemMsg:    "You are a helpful assistant.",
+		ChatHistory: []ChatMessage{
+			{Role: "user", Content: []MultiModalContent{{Type: "text", Text: "What's the weather in Taipei?"}}},
+			{Role: "assistant", Content: []MultiModalContent{
+				{Type: "tool_use", ID: "toolu_01", Name: "get_weather", Input: map[string]any{"city": "Taipei"}},
+			}},
+		},
+		ToolResults:     []ToolResult{{ToolUseID: "toolu_01", Content: "28°C, sunny"}},
+		PromptDocuments: []string{pdf},
+		Prompt:          "Summarise the weather and the document.",
+		Tools: []Tool{{
+			Name:        "get_weather",
+			Description: "Get the current weather of a city.",
+			InputSchema: map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+		}},
+		ToolChoice: "get_weather",
+		Cache:      CacheSettings{SystemMessage: true, ChatHistory: true, PromptDocuments: true},
+	}
+
+	got, err := buildRequest(in)
+	c.Assert(err, qt.IsNil)
+
+	want := fmt.Sprintf(`{
+  "model": "claude-3-5-sonnet-20240620",
+  "max_tokens": 500,
+  "metadata": null,
+  "system": [{"type": "text", "text": "You are a helpful assistant.", "cache_control": {"type": "ephemeral"}}],
+  "tools": [{
+    "name": "get_weather",
+    "description": "Get the current weather of a city.",
+    "input_schema": {"type": "object", "properties": {"city": {"type": "string"}}}
+  }],
+  "tool_choice": {"type": "tool", "name": "get_weather"},
+  "messages": [
+    {"role": "user", "content": [{"type": "text", "text": "What's the weather in Taipei?"}]},
+    {"role": "assistant", "content": [
+      {"type": "tool_use", "id": "toolu_01", "name": "get_weather", "input": {"city": "Taipei"}, "cache_control": {"type": "ephemeral"}}
+    ]},
+    {"role": "user", "content": [
+      {"type": "tool_result", "tool_use_id": "toolu_01", "content": "28°C, sunny"},
+      {"type": "document", "source": {"type": "base64", "media_type": "application/pdf", "data": %q}, "cache_control": {"type": "ephemeral"}},
+      {"type": "text", "text": "Summarise the weather and the document."}
+    ]}
+  ]
+}`, pdf)
+
+	b, err := json.Marshal(got)
+	c.Assert(err, qt.IsNil)
+	c.Check(string(b), qt.JSONEquals, json.RawMessage(want))
+
+	c.Run("nok - unsupported document", func(c *qt.C) {
+		png := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n"))
+		_, err := buildRequest(MessagesInput{PromptDocuments: []string{png}})
+		c.Check(err, qt.ErrorMatches, "unsupported document extension, expected pdf, got png")
+	})
+}
+
+func TestComponent_Stream(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	events := []string{
+		`{"type": "message_start", "message": {"id": "msg_01", "type": "message", "role": "assistant", "content": [], "model": "claude-3-5-sonnet-20240620", "usage": {"input_tokens": 25, "output_tokens": 1, "cache_creation_input_tokens": 0, "cache_read_input_tokens": 1800}}}`,
+		`{"type": "content_block_start", "index": 0, "content_block": {"type": "text", "text": ""}}`,
+		`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Let me check "}}`,
+		`{"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "the weather."}}`,
+		`{"type": "content_block_stop", "index": 0}`,
+		`{"type": "content_block_start", "index": 1, "content_block": {"type": "tool_use", "id": "toolu_01", "name": "get_weather", "input": {}}}`,
+		`{"type": "content_block_delta", "index": 1, "delta": {"type": "input_json_delta", "partial_json": "{\"city\": "}}`,
+		`{"type": "content_block_delta", "index": 1, "delta": {"type": "input_json_delta", "partial_json": "\"Taipei\"}"}}`,
+		`{"type": "content_block_stop", "index": 1}`,
+		`{"type": "message_delta", "delta": {"stop_reason": "tool_use"}, "usage": {"output_tokens": 42}}`,
+		`{"type": "message_stop"}`,
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := messagesReq{}
+		c.Check(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+		c.Check(req.Stream, qt.IsTrue)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, e := range events {
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", e)
+		}
+	})
+
+	anthropicServer := httptest.NewServer(h)
+	c.Cleanup(anthropicServer.Close)
+
+	setup, err := structpb.NewStruct(map[string]any{
+		"base-path": anthropicServer.URL,
+		"api-key":   apiKey,
+	})
+	c.Assert(err, qt.IsNil)
+
+	cmp := Init(base.Component{})
+	exec, err := cmp.CreateExecution(base.ComponentExecution{
+		Component: cmp,
+		Setup:     setup,
+		Task:      TextGenerationTask,
+	})
+	c.Assert(err, qt.IsNil)
+
+	pbIn, err := structpb.NewStruct(map[string]any{
+		"prompt":         "What's the weather in Taipei?",
+		"model-name":     "claude-3-5-sonnet-20240620",
+		"max-new-tokens": 500,
+		"stream":         true,
+	})
+	c.Assert(err, qt.IsNil)
+
+	ir, ow, eh, job := base.GenerateMockJob(c)
+	ir.ReadMock.Return(pbIn, nil)
+
+	var got *structpb.Struct
+	ow.WriteMock.Set(func(ctx context.Context, output *structpb.Struct) error {
+		got = output
+		return nil
+	})
+	eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+		c.Errorf("unexpected error: %v", err)
+	})
+
+	err = exec.Execute(ctx, []*base.Job{job})
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.IsNotNil)
+
+	c.Check(`{
+  "text": "Let me check the weather.",
+  "tool-uses": [{"id": "toolu_01", "name": "get_weather", "input": {"city": "Taipei"}}],
+  "stop-reason": "tool_use",
+  "usage": {
+    "input-tokens": 25,
+    "output-tokens": 42,
+    "cache-creation-input-tokens": 0,
+    "cache-read-input-tokens": 1800
+  }
+}`, qt.JSONEquals, got.AsMap())
+}
@@ -5,13 +5,16 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 
 	_ "embed"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/instill-ai/component/ai"
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
 )
 
 const (
@@ -43,6 +46,9 @@ type component struct {
 
 type AnthropicClient interface {
 	generateTextChat(request messagesReq) (messagesResp, error)
+	// streamTextChat sends a streamed request and calls onUpdate with the
+	// response accumulated after each event.
+	streamTextChat(ctx context.Context, request messagesReq, onUpdate func(messagesResp) error) (messagesResp, error)
 }
 
 // These structs are used to send the request /  parse the response from the API, this following their naming convension.
@@ -64,22 +70,30 @@ type messagesReq struct {
 	Metadata      interface{} `json:"metadata"`
 	StopSequences []string    `json:"stop_sequences,omitempty"`
 	Stream        bool        `json:"stream,omitempty"`
-	System        string      `json:"system,omitempty"`
+	System        []content   `json:"system,omitempty"`
 	Temperature   float32     `json:"temperature,omitempty"`
 	TopK          int         `json:"top_k,omitempty"`
 	TopP          float32     `json:"top_p,omitempty"`
+	Tools         []tool      `json:"tools,omitempty"`
+	ToolChoice    *toolChoice `json:"tool_choice,omitempty"`
 }
 
 type MessagesInput struct {
-	ChatHistory  []ChatMessage `json:"chat-history"`
-	MaxNewTokens int           `json:"max-new-tokens"`
-	ModelName    string        `json:"model-name"`
-	Prompt       string        `json:"prompt"`
-	PromptImages []string      `json:"prompt-images"`
-	Seed         int           `json:"seed"`
-	SystemMsg    string        `json:"system-message"`
-	Temperature  float32       `json:"temperature"`
-	TopK         int           `json:"top-k"`
+	ChatHistory     []ChatMessage `json:"chat-history"`
+	MaxNewTokens    int           `json:"max-new-tokens"`
+	ModelName       string        `json:"model-name"`
+	Prompt          string        `json:"prompt"`
+	PromptImages    []string      `json:"prompt-images"`
+	PromptDocuments []string      `json:"prompt-documents"`
+	Seed            int           `json:"seed"`
+	SystemMsg       string        `json:"system-message"`
+	Temperature     float32       `json:"temperature"`
+	TopK            int           `json:"top-k"`
+	Tools           []Tool        `json:"tools"`
+	ToolChoice      string        `json:"tool-choice"`
+	ToolResults     []ToolResult  `json:"tool-results"`
+	Cache           CacheSettings `json:"cache"`
+	Stream          bool          `json:"stream"`
 }
 
 type ChatMessage struct {
@@ -87,24 +101,66 @@ type ChatMessage struct {
 	Content []MultiModalContent `json:"content"`
 }
 
+// MultiModalContent is a content block of a chat message. Besides the text
+// and the images, it holds the tool uses of the assistant messages and their
+// results in the user messages.
 type MultiModalContent struct {
-	ImageURL URL    `json:"image-url"`
-	Text     string `json:"text"`
-	Type     string `json:"type"`
+	ImageURL  URL            `json:"image-url"`
+	Text      string         `json:"text"`
+	Type      string         `json:"type"`
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Input     map[string]any `json:"input"`
+	ToolUseID string         `json:"tool-use-id"`
+	IsError   bool           `json:"is-error"`
 }
 
 type URL struct {
 	URL string `json:"url"`
 }
 
+// Tool is a tool that the model may use.
+type Tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"input-schema"`
+}
+
+// ToolResult is the result of a tool use requested in the last message of
+// the chat history.
+type ToolResult struct {
+	ToolUseID string `json:"tool-use-id"`
+	Content   string `json:"content"`
+	IsError   bool   `json:"is-error"`
+}
+
+// CacheSettings defines the prompt caching breakpoints. A cached prefix of
+// the request is reused by the following requests with the same prefix.
+type CacheSettings struct {
+	SystemMessage   bool `json:"system-message"`
+	ChatHistory     bool `json:"chat-history"`
+	PromptDocuments bool `json:"prompt-documents"`
+}
+
 type MessagesOutput struct {
-	Text  string        `json:"text"`
-	Usage messagesUsage `json:"usage"`
+	Text       string        `json:"text"`
+	ToolUses   []ToolUse     `json:"tool-uses,omitempty"`
+	StopReason string        `json:"stop-reason,omitempty"`
+	Usage      messagesUsage `json:"usage"`
+}
+
+// ToolUse is a request of the model to use a tool.
+type ToolUse struct {
+	ID    string         `json:"id"`
+	Name  string         `json:"name"`
+	Input map[string]any `json:"input"`
 }
 
 type messagesUsage struct {
-	InputTokens  int `json:"input-tokens"`
-	OutputTokens int `json:"output-tokens"`
+	InputTokens              int `json:"input-tokens"`
+	OutputTokens             int `json:"output-tokens"`
+	CacheCreationInputTokens int `json:"cache-creation-input-tokens"`
+	CacheReadInputTokens     int `json:"cache-read-input-tokens"`
 }
 
 type message struct {
@@ -113,23 +169,58 @@ type message struct {
 }
 
 type usage struct {
-	InputTokens  int `json:"input_tokens"`
-	OutputTokens int `json:"output_tokens"`
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
 }
 
-// doesn't support anthropic tools at the moment
+// content is a content block. The text, image and document blocks are
+// inputs, the tool_use blocks are generated by the model and the tool_result
+// blocks hold the results of the tool uses.
 type content struct {
 	Type   string  `json:"type"`
 	Text   string  `json:"text,omitempty"`
 	Source *source `json:"source,omitempty"`
+
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Input any    `json:"input,omitempty"`
+
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	Content   string `json:"content,omitempty"`
+	IsError   bool   `json:"is_error,omitempty"`
+
+	CacheControl *cacheControl `json:"cache_control,omitempty"`
 }
 
 type source struct {
 	Type      string `json:"type"`
-	MediaType string `json:"media_type"`
-	Data      string `json:"data"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type tool struct {
+	Name         string         `json:"name"`
+	Description  string         `json:"description,omitempty"`
+	InputSchema  map[string]any `json:"input_schema"`
+	CacheControl *cacheControl  `json:"cache_control,omitempty"`
+}
+
+type toolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
 }
 
+type cacheControl struct {
+	Type string `json:"type"`
+}
+
+// ephemeralCache marks the end of a cached prefix. It is the only cache type
+// supported by the API.
+var ephemeralCache = &cacheControl{Type: "ephemeral"}
+
 func Init(bc base.Component) *component {
 	once.Do(func() {
 		comp = &component{Component: bc}
@@ -144,7 +235,7 @@ func Init(bc base.Component) *component {
 type execution struct {
 	base.ComponentExecution
 
-	execute                func(*structpb.Struct) (*structpb.Struct, error)
+	execute                func(*structpb.Struct, *base.Job, context.Context) (*structpb.Struct, error)
 	client                 AnthropicClient
 	usesInstillCredentials bool
 }
@@ -209,10 +300,10 @@ func (e *execution) UsesInstillCredentials() bool {
 }
 
 func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
-	return base.SequentialExecutor(ctx, jobs, e.execute)
+	return base.ConcurrentExecutor(ctx, jobs, e.execute)
 }
 
-func (e *execution) generateText(in *structpb.Struct) (*structpb.Struct, error) {
+func (e *execution) generateText(in *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
 
 	var inputStruct MessagesInput
 	err := base.ConvertFromStructpb(in, &inputStruct)
@@ -220,83 +311,225 @@ func (e *execution) generateText(in *structpb.Struct) (*structpb.Struct, error)
 		return nil, err
 	}
 
-	prompt := inputStruct.Prompt
+	req, err := buildRequest(inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp messagesResp
+	if req.Stream {
+		w := ai.NewStreamWriter(ctx, job)
+		resp, err = e.client.streamTextChat(ctx, req, func(partial messagesResp) error {
+			return w.Update(convertResponse(partial))
+		})
+	} else {
+		resp, err = e.client.generateTextChat(req)
+	}
+	if err != nil {
+		return nil, err
+	}
 
+	output, err := base.ConvertToStructpb(convertResponse(resp))
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func buildRequest(inputStruct MessagesInput) (messagesReq, error) {
 	messages := []message{}
 
 	chatHistory := inputStruct.ChatHistory
 
 	for _, chatMessage := range chatHistory {
-		contents := getContents(chatMessage)
+		contents, err := getContents(chatMessage)
+		if err != nil {
+			return messagesReq{}, err
+		}
 		message := message{Role: chatMessage.Role, Content: contents}
 		messages = append(messages, message)
 	}
 
-	finalMessage := message{
-		Role:    "user",
-		Content: []content{{Type: "text", Text: prompt}},
+	// The cached prefix ends with the chat history, so the conversation can
+	// be continued without sending the previous messages again.
+	if inputStruct.Cache.ChatHistory && len(messages) > 0 {
+		last := messages[len(messages)-1].Content
+		if len(last) > 0 {
+			last[len(last)-1].CacheControl = ephemeralCache
+		}
+	}
+
+	// The tool results must precede any other content in the message that
+	// follows the tool uses.
+	finalMessage := message{Role: "user"}
+	for _, r := range inputStruct.ToolResults {
+		finalMessage.Content = append(finalMessage.Content, content{
+			Type:      "tool_result",
+			ToolUseID: r.ToolUseID,
+			Content:   r.Content,
+			IsError:   r.IsError,
+		})
+	}
+
+	for _, document := range inputStruct.PromptDocuments {
+		document, err := getDocument(document)
+		if err != nil {
+			return messagesReq{}, err
+		}
+		finalMessage.Content = append(finalMessage.Content, document)
+	}
+	if inputStruct.Cache.PromptDocuments && len(inputStruct.PromptDocuments) > 0 {
+		finalMessage.Content[len(finalMessage.Content)-1].CacheControl = ephemeralCache
+	}
+
+	if inputStruct.Prompt != "" {
+		finalMessage.Content = append(finalMessage.Content, content{Type: "text", Text: inputStruct.Prompt})
 	}
 
 	promptImages := inputStruct.PromptImages
 	for _, image := range promptImages {
-		extension := base.GetBase64FileExtension(image)
-		// check if the image extension is supported
-		if !slices.Contains(supportedImageExtensions, extension) {
-			return nil, fmt.Errorf("unsupported image extension, expected one of: %v , got %s", supportedImageExtensions, extension)
-		}
-		image := content{
-			Type:   "image",
-			Source: &source{Type: "base64", MediaType: fmt.Sprintf("image/%s", extension), Data: base.TrimBase64Mime(image)},
+		image, err := getImage(image)
+		if err != nil {
+			return messagesReq{}, err
 		}
 		finalMessage.Content = append(finalMessage.Content, image)
 	}
 
-	messages = append(messages, finalMessage)
+	if len(finalMessage.Content) > 0 {
+		messages = append(messages, finalMessage)
+	}
 
 	req := messagesReq{
 		Messages:    messages,
 		Model:       inputStruct.ModelName,
 		MaxTokens:   inputStruct.MaxNewTokens,
-		System:      inputStruct.SystemMsg,
 		TopK:        inputStruct.TopK,
 		Temperature: float32(inputStruct.Temperature),
+		Stream:      inputStruct.Stream,
 	}
 
-	resp, err := e.client.generateTextChat(req)
+	if inputStruct.SystemMsg != "" {
+		req.System = []content{{Type: "text", Text: inputStruct.SystemMsg}}
+	}
 
-	if err != nil {
-		return nil, err
+	for _, t := range inputStruct.Tools {
+		req.Tools = append(req.Tools, tool{Name: t.Name, Description: t.Description, InputSchema: t.InputSchema})
+	}
+
+	// The tools precede the system message in the cached prefix, so they are
+	// cached with it.
+	if inputStruct.Cache.SystemMessage {
+		switch {
+		case len(req.System) > 0:
+			req.System[len(req.System)-1].CacheControl = ephemeralCache
+		case len(req.Tools) > 0:
+			req.Tools[len(req.Tools)-1].CacheControl = ephemeralCache
+		}
 	}
 
+	switch inputStruct.ToolChoice {
+	case "":
+	case "auto", "any", "none":
+		req.ToolChoice = &toolChoice{Type: inputStruct.ToolChoice}
+	default:
+		req.ToolChoice = &toolChoice{Type: "tool", Name: inputStruct.ToolChoice}
+	}
+
+	return req, nil
+}
+
+func convertResponse(resp messagesResp) MessagesOutput {
 	outputStruct := MessagesOutput{
-		Text: "",
+		Text:       "",
+		StopReason: resp.StopReason,
 		Usage: messagesUsage{
-			InputTokens:  resp.Usage.InputTokens,
-			OutputTokens: resp.Usage.OutputTokens,
+			InputTokens:              resp.Usage.InputTokens,
+			OutputTokens:             resp.Usage.OutputTokens,
+			CacheCreationInputTokens: resp.Usage.CacheCreationInputTokens,
+			CacheReadInputTokens:     resp.Usage.CacheReadInputTokens,
 		},
 	}
 	for _, c := range resp.Content {
-		outputStruct.Text += c.Text
+		switch c.Type {
+		case "tool_use":
+			input, _ := c.Input.(map[string]any)
+			outputStruct.ToolUses = append(outputStruct.ToolUses, ToolUse{ID: c.ID, Name: c.Name, Input: input})
+		default:
+			outputStruct.Text += c.Text
+		}
 	}
 
-	output, err := base.ConvertToStructpb(outputStruct)
-	if err != nil {
-		return nil, err
-	}
-	return output, nil
+	return outputStruct
 }
 
-func getContents(chatMessage ChatMessage) []content {
+func getContents(chatMessage ChatMessage) ([]content, error) {
 	contents := []content{}
 	for _, multiModalContent := range chatMessage.Content {
-		if multiModalContent.Type == "text" {
+		switch multiModalContent.Type {
+		case "text":
 			contentReq := content{
 				Type: "text",
 				Text: multiModalContent.Text,
 			}
 			contents = append(contents, contentReq)
+		case "image_url":
+			image, err := getImage(multiModalContent.ImageURL.URL)
+			if err != nil {
+				return nil, err
+			}
+			contents = append(contents, image)
+		case "tool_use":
+			input := multiModalContent.Input
+			if input == nil {
+				input = map[string]any{}
+			}
+			contents = append(contents, content{
+				Type:  "tool_use",
+				ID:    multiModalContent.ID,
+				Name:  multiModalContent.Name,
+				Input: input,
+			})
+		case "tool_result":
+			contents = append(contents, content{
+				Type:      "tool_result",
+				ToolUseID: multiModalContent.ToolUseID,
+				Content:   multiModalContent.Text,
+				IsError:   multiModalContent.IsError,
+			})
 		}
 	}
 
-	return contents
+	return contents, nil
+}
+
+// getImage returns the image block of an image URL or a base64-encoded image.
+func getImage(image string) (content, error) {
+	if strings.HasPrefix(image, "http://") || strings.HasPrefix(image, "https://") {
+		return content{Type: "image", Source: &source{Type: "url", URL: image}}, nil
+	}
+
+	dataURL := util.GetDataURL(image)
+	extension := base.GetBase64FileExtension(dataURL)
+	// check if the image extension is supported
+	if !slices.Contains(supportedImageExtensions, extension) {
+		return content{}, fmt.Errorf("unsupported image extension, expected one of: %v , got %s", supportedImageExtensions, extension)
+	}
+
+	return content{
+		Type:   "image",
+		Source: &source{Type: "base64", MediaType: fmt.Sprintf("image/%s", extension), Data: base.TrimBase64Mime(dataURL)},
+	}, nil
+}
+
+// getDocument returns the document block of a base64-encoded PDF file.
+func getDocument(document string) (content, error) {
+	dataURL := util.GetDataURL(document)
+	if extension := base.GetBase64FileExtension(dataURL); extension != "pdf" {
+		return content{}, fmt.Errorf("unsupported document extension, expected pdf, got %s", extension)
+	}
+
+	return content{
+		Type:   "document",
+		Source: &source{Type: "base64", MediaType: "application/pdf", Data: base.TrimBase64Mime(dataURL)},
+	}, nil
 }
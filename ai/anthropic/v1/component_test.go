@@ -0,0 +1,225 @@
+package anthropicv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	apiKey  = "123"
+	errResp = `{"type": "error", "error": {"type": "authentication_error", "message": "invalid x-api-key"}}`
+
+	messageResp = `{
+  "role": "assistant",
+  "content": [{"type": "text", "text": "Ahoy!"}],
+  "stop_reason": "max_tokens",
+  "usage": {"input_tokens": 12, "output_tokens": 3}
+}`
+
+	streamResp = `event: message_start
+data: {"type": "message_start", "message": {"role": "assistant", "content": [], "usage": {"input_tokens": 12, "output_tokens": 1}}}
+
+event: content_block_start
+data: {"type": "content_block_start", "index": 0, "content_block": {"type": "text", "text": ""}}
+
+event: content_block_delta
+data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Ah"}}
+
+event: content_block_delta
+data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "oy!"}}
+
+event: message_delta
+data: {"type": "message_delta", "delta": {"stop_reason": "end_turn"}, "usage": {"output_tokens": 3}}
+
+event: message_stop
+data: {"type": "message_stop"}
+`
+)
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	testcases := []struct {
+		name       string
+		parameter  map[string]any
+		status     int
+		resp       string
+		wantReq    string
+		wantCalls  int
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:      "ok - 2 choices",
+			parameter: map[string]any{"max-tokens": 3, "n": 2},
+			status:    http.StatusOK,
+			resp:      messageResp,
+			wantReq: `{
+  "model": "claude-3-haiku-20240307",
+  "system": "You are a pirate.",
+  "messages": [{"role": "user", "content": [
+    {"type": "text", "text": "What's on this flag?"},
+    {"type": "image", "source": {"type": "url", "url": "https://example.com/flag.png"}}
+  ]}],
+  "max_tokens": 3
+}`,
+			wantCalls: 2,
+			wantOutput: `{
+  "data": {"choices": [
+    {"finish-reason": "length", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}},
+    {"finish-reason": "length", "index": 1, "message": {"content": "Ahoy!", "role": "assistant"}}
+  ]},
+  "metadata": {"usage": {"completion-tokens": 6, "prompt-tokens": 24, "total-tokens": 30}}
+}`,
+		},
+		{
+			name:      "ok - stream",
+			parameter: map[string]any{"stream": true, "temperature": 0.5},
+			status:    http.StatusOK,
+			resp:      streamResp,
+			wantReq: `{
+  "model": "claude-3-haiku-20240307",
+  "system": "You are a pirate.",
+  "messages": [{"role": "user", "content": [
+    {"type": "text", "text": "What's on this flag?"},
+    {"type": "image", "source": {"type": "url", "url": "https://example.com/flag.png"}}
+  ]}],
+  "max_tokens": 1024,
+  "temperature": 0.5,
+  "stream": true
+}`,
+			wantCalls: 1,
+			wantOutput: `{
+  "data": {"choices": [{"finish-reason": "stop", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}}]},
+  "metadata": {"usage": {"completion-tokens": 3, "prompt-tokens": 12, "total-tokens": 15}}
+}`,
+		},
+		{
+			name:      "nok - stream 401",
+			parameter: map[string]any{"stream": true},
+			status:    http.StatusUnauthorized,
+			resp:      errResp,
+			wantCalls: 1,
+			wantErr:   "Anthropic responded with a 401 status code. invalid x-api-key",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			var calls int
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, messagesPath)
+				c.Check(r.Header.Get("X-Api-Key"), qt.Equals, apiKey)
+				c.Check(r.Header.Get("anthropic-version"), qt.Equals, apiVersion)
+
+				if tc.wantReq != "" {
+					body, err := io.ReadAll(r.Body)
+					c.Assert(err, qt.IsNil)
+					c.Check(body, qt.JSONEquals, json.RawMessage(tc.wantReq))
+				}
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				w.WriteHeader(tc.status)
+				fmt.Fprintln(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"base-path": srv.URL,
+				"api-key":   apiKey,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      chatcompletions.TextChatTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{
+					"model": "claude-3-haiku-20240307",
+					"messages": []any{
+						map[string]any{"role": "system", "content": []any{map[string]any{"type": "text", "text": "You are a pirate."}}},
+						map[string]any{"role": "user", "content": []any{
+							map[string]any{"type": "text", "text": "What's on this flag?"},
+							map[string]any{"type": "image-url", "image-url": "https://example.com/flag.png"},
+						}},
+					},
+				},
+				"parameter": tc.parameter,
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				// The creation time of the choices is the time of the
+				// request.
+				for _, choice := range output.GetFields()["data"].GetStructValue().GetFields()["choices"].GetListValue().GetValues() {
+					c.Check(choice.GetStructValue().GetFields()["created"].GetNumberValue() > 0, qt.IsTrue)
+					delete(choice.GetStructValue().GetFields(), "created")
+				}
+
+				c.Check(tc.wantOutput, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(errmsg.Message(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+			c.Check(calls, qt.Equals, tc.wantCalls)
+		})
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	c := qt.New(t)
+
+	// A 1x1 PNG image.
+	png := "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg=="
+
+	chatInput := func(image string) ai.TextChatInput {
+		return ai.TextChatInput{Data: ai.InputData{Messages: []ai.InputMessage{{
+			Role:     "user",
+			Contents: []ai.Content{{Type: "image-base64", ImageBase64: image}},
+		}}}}
+	}
+
+	req, err := buildRequest(chatInput(png))
+	c.Assert(err, qt.IsNil)
+	c.Check(req.Messages[0].Content, qt.DeepEquals, []content{
+		{Type: "image", Source: &source{Type: "base64", MediaType: "image/png", Data: png}},
+	})
+
+	// A BMP header.
+	bmp := "Qk0eAAAAAAAAABoAAAAMAAAAAQABAAEAGAAAAP8A"
+	_, err = buildRequest(chatInput(bmp))
+	c.Check(err, qt.ErrorMatches, "unsupported image extension, expected one of: .* , got bmp")
+}
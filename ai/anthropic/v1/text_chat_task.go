@@ -0,0 +1,234 @@
+package anthropicv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	messagesPath = "/v1/messages"
+
+	// defaultMaxTokens is used when the input doesn't specify the maximum
+	// number of tokens, as the Anthropic API requires it.
+	defaultMaxTokens = 1024
+)
+
+var supportedImageExtensions = []string{"jpeg", "png", "gif", "webp"}
+
+// finishReasons maps the Anthropic stop reasons to the shared finish reasons.
+var finishReasons = map[string]string{
+	"end_turn":      ai.FinishReasonStop,
+	"stop_sequence": ai.FinishReasonStop,
+	"max_tokens":    ai.FinishReasonLength,
+	"tool_use":      ai.FinishReasonToolCalls,
+	"refusal":       ai.FinishReasonContentFilter,
+}
+
+type messagesReq struct {
+	Model       string    `json:"model"`
+	Messages    []message `json:"messages"`
+	MaxTokens   int       `json:"max_tokens"`
+	System      string    `json:"system,omitempty"`
+	Temperature *float32  `json:"temperature,omitempty"`
+	TopP        *float32  `json:"top_p,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
+}
+
+type message struct {
+	Role    string    `json:"role"`
+	Content []content `json:"content"`
+}
+
+type content struct {
+	Type   string  `json:"type"`
+	Text   string  `json:"text,omitempty"`
+	Source *source `json:"source,omitempty"`
+}
+
+type source struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type messagesResp struct {
+	Role       string    `json:"role"`
+	Content    []content `json:"content"`
+	StopReason string    `json:"stop_reason"`
+	Usage      usage     `json:"usage"`
+}
+
+type usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// streamEvent holds the fields of the different events of a streamed
+// response.
+type streamEvent struct {
+	Type    string       `json:"type"`
+	Message messagesResp `json:"message"`
+	Delta   struct {
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage usage   `json:"usage"`
+	Error errBody `json:"error"`
+}
+
+// ExecuteTextChat generates the chat completion of the input with an
+// Anthropic client. The Messages API generates a single message per request,
+// so a request is sent for each of the requested choices.
+func ExecuteTextChat(input ai.TextChatInput, client httpclient.IClient, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	req, err := buildRequest(input)
+	if err != nil {
+		return nil, err
+	}
+
+	output := ai.TextChatOutput{Data: ai.OutputData{Choices: []ai.Choice{}}}
+	w := ai.NewStreamWriter(ctx, job)
+	for i := range input.Parameter.NumChoices() {
+		output.Data.Choices = append(output.Data.Choices, ai.Choice{
+			Index:   i,
+			Message: ai.OutputMessage{Role: ai.RoleAssistant},
+			Created: int(time.Now().Unix()),
+		})
+
+		var u usage
+		if req.Stream {
+			u, err = stream(ctx, client, req, &output, w)
+		} else {
+			u, err = send(ctx, client, req, &output.Data.Choices[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		output.Metadata.Usage.Add(ai.Usage{
+			CompletionTokens: u.OutputTokens,
+			PromptTokens:     u.InputTokens,
+			TotalTokens:      u.InputTokens + u.OutputTokens,
+		})
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+func buildRequest(input ai.TextChatInput) (messagesReq, error) {
+	system, conversation := ai.SplitSystemMessages(input.Data.Messages)
+
+	p := input.Parameter
+	req := messagesReq{
+		Model:       input.Data.Model,
+		Messages:    make([]message, len(conversation)),
+		MaxTokens:   defaultMaxTokens,
+		System:      system,
+		Temperature: p.Temperature,
+		TopP:        p.TopP,
+		Stream:      p.Stream,
+	}
+	if p.MaxTokens != nil {
+		req.MaxTokens = *p.MaxTokens
+	}
+
+	for i, m := range conversation {
+		req.Messages[i] = message{Role: m.Role, Content: make([]content, 0, len(m.Contents))}
+		for _, c := range m.Contents {
+			switch c.Type {
+			case ai.ContentTypeText:
+				req.Messages[i].Content = append(req.Messages[i].Content, content{Type: "text", Text: c.Text})
+			case ai.ContentTypeImageURL:
+				req.Messages[i].Content = append(req.Messages[i].Content, content{
+					Type:   "image",
+					Source: &source{Type: "url", URL: c.ImageURL},
+				})
+			case ai.ContentTypeImageBase64:
+				dataURL := util.GetDataURL(c.ImageBase64)
+				extension := base.GetBase64FileExtension(dataURL)
+				if !slices.Contains(supportedImageExtensions, extension) {
+					return req, fmt.Errorf("unsupported image extension, expected one of: %v , got %s", supportedImageExtensions, extension)
+				}
+
+				req.Messages[i].Content = append(req.Messages[i].Content, content{
+					Type:   "image",
+					Source: &source{Type: "base64", MediaType: "image/" + extension, Data: base.TrimBase64Mime(dataURL)},
+				})
+			}
+		}
+	}
+
+	return req, nil
+}
+
+func send(ctx context.Context, client httpclient.IClient, req messagesReq, choice *ai.Choice) (usage, error) {
+	resp := messagesResp{}
+	if _, err := client.R().SetContext(ctx).SetBody(req).SetResult(&resp).Post(messagesPath); err != nil {
+		return usage{}, err
+	}
+
+	for _, c := range resp.Content {
+		choice.Message.Content += c.Text
+	}
+	choice.FinishReason = finishReason(resp.StopReason)
+
+	return resp.Usage, nil
+}
+
+// stream streams the response into the last choice of the output.
+func stream(ctx context.Context, client httpclient.IClient, req messagesReq, output *ai.TextChatOutput, w *ai.StreamWriter) (usage, error) {
+	resp, err := client.R().SetContext(ctx).SetBody(req).SetDoNotParseResponse(true).Post(messagesPath)
+	if err != nil {
+		return usage{}, err
+	}
+	if err := httpclient.UnparsedResponseError(apiName, resp, new(errBody)); err != nil {
+		return usage{}, err
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	var u usage
+	choice := &output.Data.Choices[len(output.Data.Choices)-1]
+	err = ai.ScanEvents(body, func(data []byte) error {
+		event := streamEvent{}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("unmarshalling stream event: %w", err)
+		}
+
+		switch event.Type {
+		case "message_start":
+			u = event.Message.Usage
+		case "content_block_delta":
+			choice.Message.Content += event.Delta.Text
+		case "message_delta":
+			choice.FinishReason = finishReason(event.Delta.StopReason)
+			u.OutputTokens = event.Usage.OutputTokens
+		case "error":
+			return fmt.Errorf("streaming response: %s", event.Error.Error.Message)
+		default:
+			return nil
+		}
+
+		return w.Update(*output)
+	})
+
+	return u, err
+}
+
+func finishReason(stopReason string) string {
+	if r, ok := finishReasons[stopReason]; ok {
+		return r
+	}
+	return stopReason
+}
@@ -0,0 +1,70 @@
+package ai
+
+type SpeechRecognitionInput struct {
+	Data      SpeechRecognitionInputData `json:"data"`
+	Parameter SpeechRecognitionParameter `json:"parameter,omitempty"`
+}
+
+type SpeechRecognitionInputData struct {
+	Model string `json:"model"`
+	// Audio is the base64-encoded audio file, optionally as a data URL.
+	Audio string `json:"audio"`
+}
+
+type SpeechRecognitionParameter struct {
+	// Language is the ISO-639-1 code of the audio language. It is detected
+	// when empty.
+	Language    string   `json:"language,omitempty"`
+	Prompt      string   `json:"prompt,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+	// Translate translates the speech into English instead of transcribing
+	// it.
+	Translate      bool `json:"translate,omitempty"`
+	WordTimestamps bool `json:"word-timestamps,omitempty"`
+}
+
+type SpeechRecognitionOutput struct {
+	Data SpeechRecognitionOutputData `json:"data"`
+}
+
+type SpeechRecognitionOutputData struct {
+	Text     string `json:"text"`
+	Language string `json:"language,omitempty"`
+	// Duration is the duration of the audio, in seconds.
+	Duration float32 `json:"duration"`
+	Words    []Word  `json:"words,omitempty"`
+}
+
+// Word is a recognized word and its position in the audio, in seconds.
+type Word struct {
+	Word  string  `json:"word"`
+	Start float32 `json:"start"`
+	End   float32 `json:"end"`
+}
+
+type TextToSpeechInput struct {
+	Data      TextToSpeechInputData `json:"data"`
+	Parameter TextToSpeechParameter `json:"parameter,omitempty"`
+}
+
+type TextToSpeechInputData struct {
+	Model string `json:"model"`
+	Text  string `json:"text"`
+	Voice string `json:"voice"`
+}
+
+type TextToSpeechParameter struct {
+	// Format is the audio format, e.g. "mp3" or "wav".
+	Format string   `json:"format,omitempty"`
+	Speed  *float32 `json:"speed,omitempty"`
+	Stream bool     `json:"stream,omitempty"`
+}
+
+type TextToSpeechOutput struct {
+	Data TextToSpeechOutputData `json:"data"`
+}
+
+type TextToSpeechOutputData struct {
+	// Audio is the generated audio as a data URL.
+	Audio string `json:"audio"`
+}
@@ -0,0 +1,50 @@
+package coherev1
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	apiName = "Cohere"
+	host    = "https://api.cohere.com"
+)
+
+// reference: https://docs.cohere.com/reference/chat
+
+// NewClient returns an HTTP client for the Cohere API.
+func NewClient(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
+	c := httpclient.New(apiName, getBasePath(setup),
+		httpclient.WithLogger(logger),
+		httpclient.WithEndUserError(new(errBody)),
+	)
+
+	c.SetAuthToken(getAPIKey(setup))
+	return c
+}
+
+type errBody struct {
+	Msg string `json:"message"`
+}
+
+func (e errBody) Message() string {
+	return e.Msg
+}
+
+// getBasePath returns Cohere's API URL. This configuration param allows us to
+// override the API the component will point to. It isn't meant to be exposed
+// to users. Rather, it can serve to test the logic against a fake server.
+func getBasePath(setup *structpb.Struct) string {
+	v, ok := setup.GetFields()["base-path"]
+	if !ok {
+		return host
+	}
+	return v.GetStringValue()
+}
+
+func getAPIKey(setup *structpb.Struct) string {
+	return setup.GetFields()[chatcompletions.CfgAPIKey].GetStringValue()
+}
@@ -0,0 +1,187 @@
+package coherev1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	apiKey  = "123"
+	errResp = `{"message": "invalid api token"}`
+
+	chatResponse = `{
+  "finish_reason": "MAX_TOKENS",
+  "message": {"role": "assistant", "content": [{"type": "text", "text": "Ahoy!"}]},
+  "usage": {"billed_units": {"input_tokens": 10, "output_tokens": 3}, "tokens": {"input_tokens": 12, "output_tokens": 3}}
+}`
+
+	streamResponse = `event: message-start
+data: {"type": "message-start", "delta": {"message": {"role": "assistant"}}}
+
+event: content-delta
+data: {"type": "content-delta", "index": 0, "delta": {"message": {"content": {"text": "Ah"}}}}
+
+event: content-delta
+data: {"type": "content-delta", "index": 0, "delta": {"message": {"content": {"text": "oy!"}}}}
+
+event: message-end
+data: {"type": "message-end", "delta": {"finish_reason": "COMPLETE", "usage": {"tokens": {"input_tokens": 12, "output_tokens": 3}}}}
+`
+)
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	testcases := []struct {
+		name       string
+		parameter  map[string]any
+		status     int
+		resp       string
+		wantReq    string
+		wantCalls  int
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:      "ok - 2 choices",
+			parameter: map[string]any{"max-tokens": 3, "n": 2},
+			status:    http.StatusOK,
+			resp:      chatResponse,
+			wantReq: `{
+  "model": "command-r",
+  "messages": [
+    {"role": "system", "content": "You are a pirate."},
+    {"role": "user", "content": "How are you?"}
+  ],
+  "max_tokens": 3,
+  "stream": false
+}`,
+			wantCalls: 2,
+			wantOutput: `{
+  "data": {"choices": [
+    {"finish-reason": "length", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}},
+    {"finish-reason": "length", "index": 1, "message": {"content": "Ahoy!", "role": "assistant"}}
+  ]},
+  "metadata": {"usage": {"completion-tokens": 6, "prompt-tokens": 24, "total-tokens": 30}}
+}`,
+		},
+		{
+			name:      "ok - stream",
+			parameter: map[string]any{"stream": true, "top-p": 0.5, "seed": 42},
+			status:    http.StatusOK,
+			resp:      streamResponse,
+			wantReq: `{
+  "model": "command-r",
+  "messages": [
+    {"role": "system", "content": "You are a pirate."},
+    {"role": "user", "content": "How are you?"}
+  ],
+  "p": 0.5,
+  "seed": 42,
+  "stream": true
+}`,
+			wantCalls: 1,
+			wantOutput: `{
+  "data": {"choices": [{"finish-reason": "stop", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}}]},
+  "metadata": {"usage": {"completion-tokens": 3, "prompt-tokens": 12, "total-tokens": 15}}
+}`,
+		},
+		{
+			name:      "nok - stream 401",
+			parameter: map[string]any{"stream": true},
+			status:    http.StatusUnauthorized,
+			resp:      errResp,
+			wantCalls: 1,
+			wantErr:   "Cohere responded with a 401 status code. invalid api token",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			var calls int
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, chatPath)
+				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKey)
+
+				if tc.wantReq != "" {
+					body, err := io.ReadAll(r.Body)
+					c.Assert(err, qt.IsNil)
+					c.Check(body, qt.JSONEquals, json.RawMessage(tc.wantReq))
+				}
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				w.WriteHeader(tc.status)
+				fmt.Fprintln(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"base-path": srv.URL,
+				"api-key":   apiKey,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      chatcompletions.TextChatTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{
+					"model": "command-r",
+					"messages": []any{
+						map[string]any{"role": "system", "content": []any{map[string]any{"type": "text", "text": "You are a pirate."}}},
+						map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "How are you?"}}},
+					},
+				},
+				"parameter": tc.parameter,
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				// The creation time of the choices is the time of the
+				// request.
+				for _, choice := range output.GetFields()["data"].GetStructValue().GetFields()["choices"].GetListValue().GetValues() {
+					c.Check(choice.GetStructValue().GetFields()["created"].GetNumberValue() > 0, qt.IsTrue)
+					delete(choice.GetStructValue().GetFields(), "created")
+				}
+
+				c.Check(tc.wantOutput, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(errmsg.Message(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+			c.Check(calls, qt.Equals, tc.wantCalls)
+		})
+	}
+}
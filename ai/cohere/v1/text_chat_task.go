@@ -0,0 +1,173 @@
+package coherev1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const chatPath = "/v2/chat"
+
+// finishReasons maps the Cohere finish reasons to the shared finish reasons.
+var finishReasons = map[string]string{
+	"COMPLETE":      ai.FinishReasonStop,
+	"STOP_SEQUENCE": ai.FinishReasonStop,
+	"MAX_TOKENS":    ai.FinishReasonLength,
+	"TOOL_CALL":     ai.FinishReasonToolCalls,
+}
+
+// chatReq is the body of a chat request. The messages of the Cohere v2 API
+// have the same format as the chat completions ones.
+type chatReq struct {
+	Model       string                    `json:"model"`
+	Messages    []chatcompletions.Message `json:"messages"`
+	MaxTokens   *int                      `json:"max_tokens,omitempty"`
+	Temperature *float32                  `json:"temperature,omitempty"`
+	P           *float32                  `json:"p,omitempty"`
+	Seed        *int                      `json:"seed,omitempty"`
+	Stream      bool                      `json:"stream"`
+}
+
+type chatResp struct {
+	FinishReason string `json:"finish_reason"`
+	Message      struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"message"`
+	Usage usage `json:"usage"`
+}
+
+type usage struct {
+	Tokens struct {
+		InputTokens  float64 `json:"input_tokens"`
+		OutputTokens float64 `json:"output_tokens"`
+	} `json:"tokens"`
+}
+
+// streamEvent holds the fields of the different events of a streamed
+// response.
+type streamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Message struct {
+			Content struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+		Usage        usage  `json:"usage"`
+	} `json:"delta"`
+}
+
+// ExecuteTextChat generates the chat completion of the input with a Cohere
+// client. The Chat API generates a single message per request, so a request
+// is sent for each of the requested choices.
+func ExecuteTextChat(input ai.TextChatInput, client httpclient.IClient, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	p := input.Parameter
+	req := chatReq{
+		Model:       input.Data.Model,
+		Messages:    chatcompletions.BuildMessages(input.Data.Messages),
+		MaxTokens:   p.MaxTokens,
+		Temperature: p.Temperature,
+		P:           p.TopP,
+		Seed:        p.Seed,
+		Stream:      p.Stream,
+	}
+
+	output := ai.TextChatOutput{Data: ai.OutputData{Choices: []ai.Choice{}}}
+	w := ai.NewStreamWriter(ctx, job)
+	for i := range p.NumChoices() {
+		output.Data.Choices = append(output.Data.Choices, ai.Choice{
+			Index:   i,
+			Message: ai.OutputMessage{Role: ai.RoleAssistant},
+			Created: int(time.Now().Unix()),
+		})
+
+		var u usage
+		var err error
+		if req.Stream {
+			u, err = stream(ctx, client, req, &output, w)
+		} else {
+			u, err = send(ctx, client, req, &output.Data.Choices[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		in, out := int(u.Tokens.InputTokens), int(u.Tokens.OutputTokens)
+		output.Metadata.Usage.Add(ai.Usage{
+			CompletionTokens: out,
+			PromptTokens:     in,
+			TotalTokens:      in + out,
+		})
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+func send(ctx context.Context, client httpclient.IClient, req chatReq, choice *ai.Choice) (usage, error) {
+	resp := chatResp{}
+	if _, err := client.R().SetContext(ctx).SetBody(req).SetResult(&resp).Post(chatPath); err != nil {
+		return usage{}, err
+	}
+
+	for _, c := range resp.Message.Content {
+		choice.Message.Content += c.Text
+	}
+	choice.FinishReason = finishReason(resp.FinishReason)
+
+	return resp.Usage, nil
+}
+
+// stream streams the response into the last choice of the output.
+func stream(ctx context.Context, client httpclient.IClient, req chatReq, output *ai.TextChatOutput, w *ai.StreamWriter) (usage, error) {
+	resp, err := client.R().SetContext(ctx).SetBody(req).SetDoNotParseResponse(true).Post(chatPath)
+	if err != nil {
+		return usage{}, err
+	}
+	if err := httpclient.UnparsedResponseError(apiName, resp, new(errBody)); err != nil {
+		return usage{}, err
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	var u usage
+	choice := &output.Data.Choices[len(output.Data.Choices)-1]
+	err = ai.ScanEvents(body, func(data []byte) error {
+		event := streamEvent{}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("unmarshalling stream event: %w", err)
+		}
+
+		switch event.Type {
+		case "content-delta":
+			choice.Message.Content += event.Delta.Message.Content.Text
+		case "message-end":
+			choice.FinishReason = finishReason(event.Delta.FinishReason)
+			u = event.Delta.Usage
+		default:
+			return nil
+		}
+
+		return w.Update(*output)
+	})
+
+	return u, err
+}
+
+func finishReason(reason string) string {
+	if r, ok := finishReasons[reason]; ok {
+		return r
+	}
+	return reason
+}
@@ -0,0 +1,33 @@
+package ai
+
+type EmbeddingInput struct {
+	Data      EmbeddingInputData `json:"data"`
+	Parameter EmbeddingParameter `json:"parameter,omitempty"`
+}
+
+type EmbeddingInputData struct {
+	Model string   `json:"model"`
+	Texts []string `json:"texts"`
+}
+
+type EmbeddingParameter struct {
+	// Dimensions shortens the embeddings, for the models that support it.
+	Dimensions *int `json:"dimensions,omitempty"`
+}
+
+type EmbeddingOutput struct {
+	Data     EmbeddingOutputData `json:"data"`
+	Metadata Metadata            `json:"metadata"`
+}
+
+type EmbeddingOutputData struct {
+	Embeddings []Embedding `json:"embeddings"`
+}
+
+type Embedding struct {
+	// Index is the position of the embedded text in the input.
+	Index  int       `json:"index"`
+	Vector []float32 `json:"vector"`
+	// The Unix timestamp (in seconds) of when the embedding was created.
+	Created int `json:"created"`
+}
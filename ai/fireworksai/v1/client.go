@@ -0,0 +1,52 @@
+package fireworksaiv1
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	apiName = "Fireworks AI"
+	host    = "https://api.fireworks.ai/inference"
+)
+
+// reference: https://docs.fireworks.ai/api-reference
+
+// NewClient returns an HTTP client for the Fireworks AI API.
+func NewClient(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
+	c := httpclient.New(apiName, getBasePath(setup),
+		httpclient.WithLogger(logger),
+		httpclient.WithEndUserError(new(errBody)),
+	)
+
+	c.SetAuthToken(getAPIKey(setup))
+	return c
+}
+
+type errBody struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (e errBody) Message() string {
+	return e.Error.Message
+}
+
+// getBasePath returns Fireworks AI's API URL. This configuration param allows us to
+// override the API the component will point to. It isn't meant to be exposed
+// to users. Rather, it can serve to test the logic against a fake server.
+func getBasePath(setup *structpb.Struct) string {
+	v, ok := setup.GetFields()["base-path"]
+	if !ok {
+		return host
+	}
+	return v.GetStringValue()
+}
+
+func getAPIKey(setup *structpb.Struct) string {
+	return setup.GetFields()[chatcompletions.CfgAPIKey].GetStringValue()
+}
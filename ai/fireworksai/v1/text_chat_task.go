@@ -0,0 +1,37 @@
+package fireworksaiv1
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	completionsPath = "/v1/chat/completions"
+	chatModelPrefix = "accounts/fireworks/models/"
+)
+
+// ExecuteTextChat generates the chat completion of the input with a
+// Fireworks AI client.
+func ExecuteTextChat(input ai.TextChatInput, client httpclient.IClient, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	req := chatcompletions.NewRequest(input)
+
+	// The models of the component's list are served by the Fireworks AI
+	// account, while fully qualified names can reference other accounts.
+	if !strings.Contains(req.Model, "/") {
+		req.Model = chatModelPrefix + req.Model
+	}
+
+	output, err := chatcompletions.Send(ctx, client, completionsPath, req, job, apiName, new(errBody))
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(output)
+}
@@ -0,0 +1,131 @@
+package groqv1
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	apiKey  = "123"
+	errResp = `{"error": {"message": "Invalid API Key"}}`
+)
+
+var chatInput = map[string]any{
+	"data": map[string]any{
+		"model": "llama3-8b-8192",
+		"messages": []any{
+			map[string]any{"role": "system", "content": []any{map[string]any{"type": "text", "text": "You are a pirate."}}},
+			map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Hi"}}},
+		},
+	},
+	"parameter": map[string]any{"max-tokens": 50, "top-p": 0.5},
+}
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	testcases := []struct {
+		name       string
+		status     int
+		resp       string
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+			resp: `{
+  "created": 1718000000,
+  "choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "Ahoy!"}}],
+  "usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15}
+}`,
+			wantOutput: `{
+  "data": {"choices": [{"finish-reason": "stop", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}, "created": 1718000000}]},
+  "metadata": {"usage": {"completion-tokens": 3, "prompt-tokens": 12, "total-tokens": 15}}
+}`,
+		},
+		{
+			name:    "nok - 401",
+			status:  http.StatusUnauthorized,
+			resp:    errResp,
+			wantErr: "Groq responded with a 401 status code. Invalid API Key",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, completionsPath)
+				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKey)
+
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{
+					"model": "llama3-8b-8192",
+					"messages": []any{
+						map[string]any{"role": "system", "content": "You are a pirate."},
+						map[string]any{"role": "user", "content": "Hi"},
+					},
+					"max_tokens": 50,
+					"top_p":      0.5,
+					"stream":     false,
+				})
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				w.WriteHeader(tc.status)
+				fmt.Fprintln(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"base-path": srv.URL,
+				"api-key":   apiKey,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      chatcompletions.TextChatTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(chatInput)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				c.Check(tc.wantOutput, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(errmsg.Message(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+
+}
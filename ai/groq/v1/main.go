@@ -0,0 +1,36 @@
+//go:generate compogen readme ./config ./README.mdx
+package groqv1
+
+import (
+	"sync"
+
+	_ "embed"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+)
+
+var (
+	//go:embed config/definition.json
+	definitionJSON []byte
+	//go:embed config/setup.json
+	setupJSON []byte
+	//go:embed config/tasks.json
+	tasksJSON []byte
+
+	once sync.Once
+	comp *chatcompletions.Component
+)
+
+// Init returns an initialized Groq component.
+func Init(bc base.Component) *chatcompletions.Component {
+	once.Do(func() {
+		comp = chatcompletions.NewComponent(bc, NewClient, ExecuteTextChat)
+		err := comp.LoadDefinition(definitionJSON, setupJSON, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	return comp
+}
@@ -0,0 +1,27 @@
+package groqv1
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const completionsPath = "/v1/chat/completions"
+
+// ExecuteTextChat generates the chat completion of the input with a Groq
+// client.
+func ExecuteTextChat(input ai.TextChatInput, client httpclient.IClient, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	req := chatcompletions.NewRequest(input)
+
+	output, err := chatcompletions.Send(ctx, client, completionsPath, req, job, apiName, new(errBody))
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(output)
+}
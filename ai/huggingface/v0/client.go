@@ -2,34 +2,109 @@ package huggingface
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/instill-ai/component/internal/chatcompletions"
 	"github.com/instill-ai/component/internal/util/httpclient"
 )
 
 const (
-	modelsPath = "/models/"
+	apiName = "Hugging Face"
+
+	modelsPath          = "/models/"
+	chatCompletionsPath = "/v1/chat/completions"
+
+	// The models of the Inference API and the scaled-to-zero Inference
+	// Endpoints respond with a 503 status code while they're loading. The
+	// requests are retried until the model is loaded.
+	modelLoadingRetryCount   = 5
+	modelLoadingWaitTime     = time.Second
+	modelLoadingMaxWaitTime  = time.Minute
+	modelLoadingDefaultDelay = 10 * time.Second
 )
 
-func newClient(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
-	c := httpclient.New("Hugging Face", getBaseURL(setup),
+// newClient returns a client for the Hugging Face API that serves a task. The
+// task can be served by a dedicated Inference Endpoint instead of the base
+// URL.
+func newClient(setup *structpb.Struct, task string, logger *zap.Logger) *httpclient.Client {
+	baseURL := getBaseURL(setup)
+	if endpoint := getTaskEndpoint(setup, task); endpoint != "" {
+		baseURL = endpoint
+	}
+
+	c := httpclient.New(apiName, baseURL,
 		httpclient.WithLogger(logger),
 		httpclient.WithEndUserError(new(errBody)),
 	)
 
 	c.SetAuthToken(getAPIKey(setup))
 
+	c.SetRetryCount(modelLoadingRetryCount)
+	c.SetRetryWaitTime(modelLoadingWaitTime)
+	c.SetRetryMaxWaitTime(modelLoadingMaxWaitTime)
+	c.AddRetryCondition(isModelLoading)
+	c.SetRetryAfter(modelLoadingDelay)
+
 	return c
 }
 
+// isModelLoading determines whether the client retries a request. The
+// streamed chat requests are excluded: their responses aren't parsed, so the
+// client can't read the estimated loading time nor close the body of the
+// failed attempts. These requests are retried by the chat task.
+func isModelLoading(resp *resty.Response, _ error) bool {
+	if resp == nil || resp.StatusCode() != http.StatusServiceUnavailable {
+		return false
+	}
+
+	if req, ok := resp.Request.Body.(chatcompletions.Request); ok && req.Stream {
+		return false
+	}
+
+	return true
+}
+
+// isModelLoadingError returns whether a request failed because the model is
+// loading.
+func isModelLoadingError(err error) bool {
+	respErr := new(httpclient.ResponseError)
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusServiceUnavailable
+}
+
+// modelLoadingDelay returns the time to wait before retrying a request to a
+// loading model.
+func modelLoadingDelay(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	loading := errBody{}
+	if err := json.Unmarshal(resp.Body(), &loading); err != nil {
+		return modelLoadingDefaultDelay, nil
+	}
+
+	return loading.loadingDelay(), nil
+}
+
 type errBody struct {
 	// Error can be either a string or a string array.
 	Error json.RawMessage `json:"error,omitempty"`
+	// EstimatedTime is the time in seconds the model needs to load.
+	EstimatedTime float64 `json:"estimated_time,omitempty"`
+}
+
+// loadingDelay returns the loading time estimated by the API, or a default
+// delay if the response has no estimation.
+func (e errBody) loadingDelay() time.Duration {
+	if e.EstimatedTime <= 0 {
+		return modelLoadingDefaultDelay
+	}
+
+	return min(time.Duration(e.EstimatedTime*float64(time.Second)), modelLoadingMaxWaitTime)
 }
 
 func (e errBody) Message() string {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -362,3 +363,318 @@ func testTask(c *qt.C, p taskParams) {
 		})
 	}
 }
+
+func TestComponent_ExecuteTextEmbeddings(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	embeddingsModel := "sentence-transformers/all-MiniLM-L6-v2"
+	testcases := []struct {
+		name         string
+		taskEndpoint bool
+		resp         string
+		wantVectors  string
+	}{
+		{
+			name:        "ok - sentence embeddings",
+			resp:        `[[0.1, 0.2], [0.3, 0.4]]`,
+			wantVectors: `[[0.1, 0.2], [0.3, 0.4]]`,
+		},
+		{
+			name:        "ok - token embeddings",
+			resp:        `[[[0.1, 0.2], [0.3, 0.4]], [[0.5, 0.5]]]`,
+			wantVectors: `[[0.2, 0.3], [0.5, 0.5]]`,
+		},
+		{
+			name:         "ok - task endpoint",
+			taskEndpoint: true,
+			resp:         `[[0.1, 0.2], [0.3, 0.4]]`,
+			wantVectors:  `[[0.1, 0.2], [0.3, 0.4]]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			wantPath := modelsPath + embeddingsModel
+			if tc.taskEndpoint {
+				wantPath = "/"
+			}
+
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, wantPath)
+				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKey)
+
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{"inputs": []any{"Hello", "World"}})
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprint(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup := map[string]any{"api-key": apiKey, "base-url": srv.URL}
+			if tc.taskEndpoint {
+				setup["base-url"] = "http://no-such.host"
+				setup["endpoints"] = map[string]any{textEmbeddingsTask: srv.URL}
+			}
+			pbSetup, err := structpb.NewStruct(setup)
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     pbSetup,
+				Task:      textEmbeddingsTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{"model": embeddingsModel, "texts": []any{"Hello", "World"}},
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Set(func(ctx context.Context, output *structpb.Struct) error {
+				embeddings := output.GetFields()["data"].GetStructValue().GetFields()["embeddings"].GetListValue().AsSlice()
+				vectors := make([]any, len(embeddings))
+				for i, e := range embeddings {
+					c.Check(e.(map[string]any)["index"], qt.Equals, float64(i))
+					vectors[i] = e.(map[string]any)["vector"]
+				}
+				c.Check(tc.wantVectors, qt.JSONEquals, vectors)
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Errorf("unexpected error: %v", err)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
+
+func TestComponent_ExecuteTextGenerationChat(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	chatModel := "meta-llama/Meta-Llama-3.1-8B-Instruct"
+	wantOutput := `{
+  "data": {"choices": [{"created": 1721236000, "finish-reason": "stop", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}}]},
+  "metadata": {"usage": {"completion-tokens": 2, "prompt-tokens": 5, "total-tokens": 7}}
+}`
+
+	testcases := []struct {
+		name           string
+		customEndpoint bool
+		stream         bool
+		contentType    string
+		resp           string
+	}{
+		{
+			name:        "ok",
+			contentType: httpclient.MIMETypeJSON,
+			resp: `{
+  "created": 1721236000,
+  "choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "Ahoy!"}}],
+  "usage": {"prompt_tokens": 5, "completion_tokens": 2, "total_tokens": 7}
+}`,
+		},
+		{
+			name:           "ok - stream from custom endpoint",
+			customEndpoint: true,
+			stream:         true,
+			contentType:    "text/event-stream",
+			resp: `data: {"created": 1721236000, "choices": [{"index": 0, "delta": {"role": "assistant", "content": "Ah"}}]}
+
+data: {"created": 1721236000, "choices": [{"index": 0, "delta": {"content": "oy!"}, "finish_reason": "stop"}], "usage": {"prompt_tokens": 5, "completion_tokens": 2, "total_tokens": 7}}
+
+data: [DONE]
+
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			wantPath := modelsPath + chatModel + chatCompletionsPath
+			if tc.customEndpoint {
+				wantPath = chatCompletionsPath
+			}
+
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, wantPath)
+				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKey)
+
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{
+					"model":      chatModel,
+					"messages":   []any{map[string]any{"role": "user", "content": "Say hi like a pirate."}},
+					"max_tokens": 20,
+					"stream":     tc.stream,
+				})
+
+				w.Header().Set("Content-Type", tc.contentType)
+				fmt.Fprint(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"api-key":            apiKey,
+				"base-url":           srv.URL,
+				"is-custom-endpoint": tc.customEndpoint,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      textGenerationChatTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{
+					"model": chatModel,
+					"messages": []any{
+						map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Say hi like a pirate."}}},
+					},
+				},
+				"parameter": map[string]any{"max-tokens": 20, "stream": tc.stream},
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Set(func(ctx context.Context, output *structpb.Struct) error {
+				c.Check(wantOutput, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Errorf("unexpected error: %v", err)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
+
+func TestComponent_ModelLoading(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	chatMessages := []any{
+		map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Say hi like a pirate."}}},
+	}
+
+	testcases := []struct {
+		name        string
+		task        string
+		input       map[string]any
+		contentType string
+		resp        string
+		wantOutput  string
+	}{
+		{
+			name:        "ok - text generation",
+			task:        textGenerationTask,
+			input:       map[string]any{"model": "gpt2", "inputs": testInput},
+			contentType: httpclient.MIMETypeJSON,
+			resp:        `[{"generated_text": "text response"}]`,
+			wantOutput:  `{"generated-text": "text response"}`,
+		},
+		{
+			name: "ok - streamed chat",
+			task: textGenerationChatTask,
+			input: map[string]any{
+				"data":      map[string]any{"model": "gpt2", "messages": chatMessages},
+				"parameter": map[string]any{"stream": true},
+			},
+			contentType: "text/event-stream",
+			resp: `data: {"created": 1721236000, "choices": [{"index": 0, "delta": {"role": "assistant", "content": "Ahoy!"}, "finish_reason": "stop"}]}
+
+data: [DONE]
+
+`,
+			wantOutput: `{
+  "data": {"choices": [{"created": 1721236000, "finish-reason": "stop", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}}]},
+  "metadata": {"usage": {"completion-tokens": 0, "prompt-tokens": 0, "total-tokens": 0}}
+}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			var attempts int
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+
+				if attempts == 1 {
+					w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+					w.WriteHeader(http.StatusServiceUnavailable)
+					fmt.Fprint(w, `{"error": "Model gpt2 is currently loading", "estimated_time": 0.5}`)
+					return
+				}
+
+				w.Header().Set("Content-Type", tc.contentType)
+				fmt.Fprint(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"api-key":  apiKey,
+				"base-url": srv.URL,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			var output *structpb.Struct
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Set(func(ctx context.Context, out *structpb.Struct) error {
+				output = out
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Errorf("unexpected error: %v", err)
+			})
+
+			// The request is retried after the estimated loading time.
+			start := time.Now()
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+			c.Check(tc.wantOutput, qt.JSONEquals, output.AsMap())
+			c.Check(attempts, qt.Equals, 2)
+			c.Check(time.Since(start) < modelLoadingDefaultDelay, qt.IsTrue)
+		})
+	}
+}
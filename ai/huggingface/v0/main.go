@@ -26,7 +26,6 @@ const (
 	tokenClassificationTask    = "TASK_TOKEN_CLASSIFICATION"
 	translationTask            = "TASK_TRANSLATION"
 	zeroShotClassificationTask = "TASK_ZERO_SHOT_CLASSIFICATION"
-	featureExtractionTask      = "TASK_FEATURE_EXTRACTION"
 	questionAnsweringTask      = "TASK_QUESTION_ANSWERING"
 	tableQuestionAnsweringTask = "TASK_TABLE_QUESTION_ANSWERING"
 	sentenceSimilarityTask     = "TASK_SENTENCE_SIMILARITY"
@@ -37,6 +36,8 @@ const (
 	imageToTextTask            = "TASK_IMAGE_TO_TEXT"
 	speechRecognitionTask      = "TASK_SPEECH_RECOGNITION"
 	audioClassificationTask    = "TASK_AUDIO_CLASSIFICATION"
+	textEmbeddingsTask         = "TASK_TEXT_EMBEDDINGS"
+	textGenerationChatTask     = "TASK_TEXT_GENERATION_CHAT"
 )
 
 var (
@@ -87,6 +88,21 @@ func isCustomEndpoint(setup *structpb.Struct) bool {
 	return setup.GetFields()["is-custom-endpoint"].GetBoolValue()
 }
 
+// getTaskEndpoint returns the URL of the dedicated Inference Endpoint that
+// serves a task, if any.
+func getTaskEndpoint(setup *structpb.Struct, task string) string {
+	return setup.GetFields()["endpoints"].GetStructValue().GetFields()[task].GetStringValue()
+}
+
+// getModel returns the model of the input. The tasks built on the shared AI
+// types hold it in the data field.
+func getModel(input *structpb.Struct) string {
+	if data, ok := input.GetFields()["data"]; ok {
+		return data.GetStructValue().GetFields()["model"].GetStringValue()
+	}
+	return input.GetFields()["model"].GetStringValue()
+}
+
 func wrapSliceInStruct(data []byte, key string) (*structpb.Struct, error) {
 	var list []any
 	if err := json.Unmarshal(data, &list); err != nil {
@@ -107,7 +123,10 @@ func wrapSliceInStruct(data []byte, key string) (*structpb.Struct, error) {
 
 func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 
-	client := newClient(e.Setup, e.GetLogger())
+	client := newClient(e.Setup, e.Task, e.GetLogger())
+	// The dedicated Inference Endpoints serve a single model, at the root
+	// path.
+	customEndpoint := isCustomEndpoint(e.Setup) || getTaskEndpoint(e.Setup, e.Task) != ""
 
 	for _, job := range jobs {
 		input, err := job.Input.Read(ctx)
@@ -116,8 +135,8 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 			continue
 		}
 		path := "/"
-		if !isCustomEndpoint(e.Setup) {
-			path = modelsPath + input.GetFields()["model"].GetStringValue()
+		if !customEndpoint {
+			path = modelsPath + getModel(input)
 		}
 
 		output := &structpb.Struct{}
@@ -314,40 +333,6 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 				continue
 			}
 
-		// case featureExtractionTask:
-		// TODO: fix this task
-		// 	inputStruct := FeatureExtractionRequest{}
-		// 	err := base.ConvertFromStructpb(input, &inputStruct)
-		// 	if err != nil {
-		// 		return nil, err
-		// 	}
-		// 	jsonBody, _ := json.Marshal(inputStruct)
-		// 	resp, err := doer.MakeHFAPIRequest(jsonBody, model)
-		// 	if err != nil {
-		// 		return nil, err
-		// 	}
-		// 	threeDArr := [][][]float64{}
-		// 	err = json.Unmarshal(resp, &threeDArr)
-		// 	if err != nil {
-		// 		return nil, err
-		// 	}
-		// 	if len(threeDArr) <= 0 {
-		// 		return nil, errors.New("invalid response")
-		// 	}
-		// 	nestedArr := threeDArr[0]
-		// 	features := structpb.ListValue{}
-		// 	features.Values = make([]*structpb.Value, len(nestedArr))
-		// 	for i, innerArr := range nestedArr {
-		// 		innerValues := make([]*structpb.Value, len(innerArr))
-		// 		for j := range innerArr {
-		// 			innerValues[j] = &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: innerArr[j]}}
-		// 		}
-		// 		features.Values[i] = &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: innerValues}}}
-		// 	}
-		// 	output := structpb.Struct{
-		// 		Fields: map[string]*structpb.Value{"feature": {Kind: &structpb.Value_ListValue{ListValue: &features}}},
-		// 	}
-		// 	outputs = append(outputs, &output)
 		case questionAnsweringTask:
 			inputStruct := QuestionAnsweringRequest{}
 			if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
@@ -608,6 +593,20 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 				continue
 			}
 
+		case textEmbeddingsTask:
+			output, err = executeTextEmbeddings(ctx, client, path, input)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+		case textGenerationChatTask:
+			output, err = executeTextGenerationChat(ctx, client, path, input, job)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
 		default:
 			job.Error.Error(ctx, errmsg.AddMessage(
 				fmt.Errorf("not supported task: %s", e.Task),
@@ -626,7 +625,7 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 }
 
 func (c *component) Test(sysVars map[string]any, setup *structpb.Struct) error {
-	req := newClient(setup, c.GetLogger()).R()
+	req := newClient(setup, "", c.GetLogger()).R()
 	resp, err := req.Get("")
 	if err != nil {
 		return err
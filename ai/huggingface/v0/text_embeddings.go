@@ -0,0 +1,88 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+type textEmbeddingsReq struct {
+	Inputs []string `json:"inputs"`
+}
+
+// executeTextEmbeddings embeds the input texts with a feature extraction
+// model, e.g. a sentence transformer served by the Inference API or by Text
+// Embeddings Inference on an Inference Endpoint.
+func executeTextEmbeddings(ctx context.Context, client httpclient.IClient, path string, input *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := ai.EmbeddingInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, err
+	}
+	if len(inputStruct.Data.Texts) == 0 {
+		return nil, fmt.Errorf("no text to embed")
+	}
+
+	req := client.R().SetContext(ctx).SetBody(textEmbeddingsReq{Inputs: inputStruct.Data.Texts})
+	resp, err := post(req, path)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors, err := parseEmbeddings(resp.Body())
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(inputStruct.Data.Texts) {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(vectors), len(inputStruct.Data.Texts))
+	}
+
+	created := int(time.Now().Unix())
+	output := ai.EmbeddingOutput{Data: ai.EmbeddingOutputData{Embeddings: make([]ai.Embedding, len(vectors))}}
+	for i, v := range vectors {
+		output.Data.Embeddings[i] = ai.Embedding{Index: i, Vector: v, Created: created}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+// parseEmbeddings reads the embeddings of a feature extraction response. The
+// sentence transformers return an embedding per text, while the other
+// models return an embedding per token, which are averaged.
+func parseEmbeddings(body []byte) ([][]float32, error) {
+	var sentences [][]float32
+	if err := json.Unmarshal(body, &sentences); err == nil {
+		return sentences, nil
+	}
+
+	var tokens [][][]float32
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("unexpected feature extraction response: %w", err)
+	}
+
+	sentences = make([][]float32, len(tokens))
+	for i, t := range tokens {
+		if len(t) == 0 {
+			return nil, fmt.Errorf("no token embedding for text %d", i)
+		}
+
+		mean := make([]float32, len(t[0]))
+		for _, v := range t {
+			for j := range mean {
+				mean[j] += v[j]
+			}
+		}
+		for j := range mean {
+			mean[j] /= float32(len(t))
+		}
+		sentences[i] = mean
+	}
+
+	return sentences, nil
+}
@@ -0,0 +1,48 @@
+package huggingface
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/chatcompletions"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+// executeTextGenerationChat sends a chat request to the OpenAI-compatible
+// Messages API of Text Generation Inference, which serves the chat models on
+// the Inference API and the Inference Endpoints. The path of the model is
+// used as the prefix of the chat completions route.
+func executeTextGenerationChat(ctx context.Context, client httpclient.IClient, path string, input *structpb.Struct, job *base.Job) (*structpb.Struct, error) {
+	inputStruct := ai.TextChatInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	req := chatcompletions.NewRequest(inputStruct)
+	chatPath := strings.TrimSuffix(path, "/") + chatCompletionsPath
+
+	// The client doesn't retry the streamed requests while the model loads,
+	// so they are retried here.
+	for attempt := 0; ; attempt++ {
+		errBody := new(errBody)
+		output, err := chatcompletions.Send(ctx, client, chatPath, req, job, apiName, errBody)
+		if err == nil {
+			return base.ConvertToStructpb(output)
+		}
+
+		if !req.Stream || attempt == modelLoadingRetryCount || !isModelLoadingError(err) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(errBody.loadingDelay()):
+		}
+	}
+}
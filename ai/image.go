@@ -0,0 +1,41 @@
+package ai
+
+// ImageInput is the input of the image generation tasks. The prompt is used
+// to generate and edit images, while the image is the source of the edits
+// and variations.
+type ImageInput struct {
+	Data      ImageInputData `json:"data"`
+	Parameter ImageParameter `json:"parameter,omitempty"`
+}
+
+type ImageInputData struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt,omitempty"`
+	// Image and Mask are base64-encoded images, optionally as data URLs. The
+	// transparent areas of the mask indicate where the image is edited.
+	Image string `json:"image,omitempty"`
+	Mask  string `json:"mask,omitempty"`
+}
+
+type ImageParameter struct {
+	N       *int   `json:"n,omitempty"`
+	Size    string `json:"size,omitempty"`
+	Quality string `json:"quality,omitempty"`
+	Style   string `json:"style,omitempty"`
+}
+
+type ImageOutput struct {
+	Data ImageOutputData `json:"data"`
+}
+
+type ImageOutputData struct {
+	Images []Image `json:"images"`
+}
+
+type Image struct {
+	// Image is the generated image as a data URL.
+	Image string `json:"image"`
+	// RevisedPrompt is the prompt used to generate the image, if the model
+	// rewrote the input prompt.
+	RevisedPrompt string `json:"revised-prompt,omitempty"`
+}
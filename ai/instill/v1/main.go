@@ -0,0 +1,180 @@
+//go:generate compogen readme ./config ./README.mdx
+package instillv1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	_ "embed"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+
+	modelPB "github.com/instill-ai/protogen-go/model/model/v1alpha"
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+const (
+	TextChatTask = "TASK_CHAT"
+)
+
+var (
+	//go:embed config/definition.json
+	definitionJSON []byte
+	//go:embed config/tasks.json
+	tasksJSON []byte
+
+	once sync.Once
+	comp *component
+)
+
+type component struct {
+	base.Component
+}
+
+type execution struct {
+	base.ComponentExecution
+	execute func(*structpb.Struct, *base.Job, context.Context) (*structpb.Struct, error)
+}
+
+// Init returns an initialized Instill Model component.
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{Component: bc}
+		err := comp.LoadDefinition(definitionJSON, nil, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	return comp
+}
+
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	e := &execution{ComponentExecution: x}
+
+	switch x.Task {
+	case TextChatTask:
+		e.execute = e.executeTextChat
+	default:
+		return nil, fmt.Errorf("unknown task: %s", x.Task)
+	}
+
+	return e, nil
+}
+
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	return base.ConcurrentExecutor(ctx, jobs, e.execute)
+}
+
+func getModelServerURL(vars map[string]any) string {
+	if v, ok := vars["__MODEL_BACKEND"]; ok {
+		return v.(string)
+	}
+	return ""
+}
+
+func getRequestMetadata(vars map[string]any) metadata.MD {
+	md := metadata.Pairs(
+		"Authorization", util.GetHeaderAuthorization(vars),
+		"Instill-User-Uid", util.GetInstillUserUID(vars),
+		"Instill-Auth-Type", "user",
+	)
+
+	if requester := util.GetInstillRequesterUID(vars); requester != "" {
+		md.Set("Instill-Requester-Uid", requester)
+	}
+
+	return md
+}
+
+func (c *component) Test(sysVars map[string]any, setup *structpb.Struct) error {
+	gRPCClient, gRPCClientConn := initModelPublicServiceClient(getModelServerURL(sysVars))
+	if gRPCClientConn != nil {
+		defer gRPCClientConn.Close()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(sysVars))
+	_, err := gRPCClient.ListModels(ctx, &modelPB.ListModelsRequest{})
+	return err
+}
+
+// GetDefinition generates the enum of the model input field with the chat
+// models served on Instill Model.
+func (c *component) GetDefinition(sysVars map[string]any, compConfig *base.ComponentConfig) (*pb.ComponentDefinition, error) {
+	oriDef, err := c.Component.GetDefinition(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if sysVars == nil && compConfig == nil {
+		return oriDef, nil
+	}
+	def := proto.Clone(oriDef).(*pb.ComponentDefinition)
+
+	if getModelServerURL(sysVars) == "" {
+		return def, nil
+	}
+
+	gRPCClient, gRPCClientConn := initModelPublicServiceClient(getModelServerURL(sysVars))
+	if gRPCClientConn != nil {
+		defer gRPCClientConn.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(sysVars))
+
+	pageToken := ""
+	pageSize := int32(100)
+	modelNames := &structpb.ListValue{}
+	for {
+		resp, err := gRPCClient.ListModels(ctx, &modelPB.ListModelsRequest{PageToken: &pageToken, PageSize: &pageSize, View: modelPB.View_VIEW_BASIC.Enum()})
+		if err != nil {
+			return def, nil
+		}
+		for _, m := range resp.Models {
+			if m.Task.String() != TextChatTask {
+				continue
+			}
+			namePaths := strings.Split(m.Name, "/")
+			for _, v := range m.Versions {
+				modelNames.Values = append(modelNames.Values, structpb.NewStringValue(fmt.Sprintf("%s/%s/%s", namePaths[1], namePaths[3], v)))
+			}
+		}
+
+		pageToken = resp.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	for _, sch := range def.Spec.ComponentSpecification.Fields["oneOf"].GetListValue().Values {
+		task := sch.GetStructValue().Fields["properties"].GetStructValue().Fields["task"].GetStructValue().Fields["const"].GetStringValue()
+		if task != TextChatTask {
+			continue
+		}
+
+		model := sch.GetStructValue().
+			Fields["properties"].GetStructValue().
+			Fields["input"].GetStructValue().
+			Fields["properties"].GetStructValue().
+			Fields["data"].GetStructValue().
+			Fields["properties"].GetStructValue().
+			Fields["model"].GetStructValue()
+		if model != nil {
+			model.Fields["enum"] = structpb.NewListValue(modelNames)
+		}
+	}
+
+	return def, nil
+}
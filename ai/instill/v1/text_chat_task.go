@@ -0,0 +1,120 @@
+package instillv1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+
+	modelPB "github.com/instill-ai/protogen-go/model/model/v1alpha"
+)
+
+// chatReq is the input of a chat model on Instill Model, which follows the
+// shared chat format. The model is referenced by the trigger request and the
+// model backend doesn't stream the responses, so these fields aren't sent.
+type chatReq struct {
+	Data struct {
+		Messages []ai.InputMessage `json:"messages"`
+	} `json:"data"`
+	Parameter chatParameter `json:"parameter"`
+}
+
+type chatParameter struct {
+	MaxTokens   *int     `json:"max-tokens,omitempty"`
+	Seed        *int     `json:"seed,omitempty"`
+	N           *int     `json:"n,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+	TopP        *float32 `json:"top-p,omitempty"`
+}
+
+func (e *execution) executeTextChat(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.TextChatInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to TextChatInput: %w", err)
+	}
+
+	gRPCClient, gRPCClientConn := initModelPublicServiceClient(getModelServerURL(e.SystemVariables))
+	if gRPCClientConn != nil {
+		defer gRPCClientConn.Close()
+	}
+
+	return ExecuteTextChat(inputStruct, gRPCClient, e.SystemVariables, ctx)
+}
+
+// ExecuteTextChat generates the chat completion of the input with a model
+// served on Instill Model. The whole output is returned at once, as the
+// model backend doesn't stream the responses.
+func ExecuteTextChat(input ai.TextChatInput, client modelPB.ModelPublicServiceClient, sysVars map[string]any, ctx context.Context) (*structpb.Struct, error) {
+	if client == nil {
+		return nil, fmt.Errorf("uninitialized client")
+	}
+
+	nsID, modelID, version, err := parseModelName(input.Data.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	taskInput, err := base.ConvertToStructpb(buildRequest(input))
+	if err != nil {
+		return nil, err
+	}
+
+	taskOutputs, err := trigger(ctx, client, sysVars, nsID, modelID, version, []*structpb.Struct{taskInput})
+	if err != nil {
+		return nil, err
+	}
+	if len(taskOutputs) == 0 {
+		return nil, fmt.Errorf("invalid output: %v for model: %s", taskOutputs, input.Data.Model)
+	}
+
+	output := ai.TextChatOutput{}
+	if err := base.ConvertFromStructpb(taskOutputs[0], &output); err != nil {
+		return nil, fmt.Errorf("converting model output: %w", err)
+	}
+	if output.Data.Choices == nil {
+		output.Data.Choices = []ai.Choice{}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+// parseModelName splits a model name in the namespace/model/version format.
+func parseModelName(name string) (nsID, modelID, version string, err error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid model name %q, expected namespace/model/version", name)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func buildRequest(input ai.TextChatInput) chatReq {
+	req := chatReq{}
+
+	req.Data.Messages = make([]ai.InputMessage, len(input.Data.Messages))
+	for i, m := range input.Data.Messages {
+		req.Data.Messages[i] = ai.InputMessage{Role: m.Role, Name: m.Name, Contents: make([]ai.Content, len(m.Contents))}
+		for j, c := range m.Contents {
+			// The model backend expects the images as data URLs.
+			if c.Type == ai.ContentTypeImageBase64 {
+				c.ImageBase64 = util.GetDataURL(c.ImageBase64)
+			}
+			req.Data.Messages[i].Contents[j] = c
+		}
+	}
+
+	p := input.Parameter
+	req.Parameter = chatParameter{
+		MaxTokens:   p.MaxTokens,
+		Seed:        p.Seed,
+		N:           p.N,
+		Temperature: p.Temperature,
+		TopP:        p.TopP,
+	}
+
+	return req
+}
@@ -0,0 +1,51 @@
+package instillv1
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+)
+
+func TestBuildRequest(t *testing.T) {
+	c := qt.New(t)
+
+	// A 1x1 PNG image.
+	png := "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg=="
+	n := 2
+
+	req, err := base.ConvertToStructpb(buildRequest(ai.TextChatInput{
+		Data: ai.InputData{
+			Model: "admin/llava/v0.1.0",
+			Messages: []ai.InputMessage{{
+				Role: "user",
+				Contents: []ai.Content{
+					{Type: "text", Text: "What's on this flag?"},
+					{Type: "image-base64", ImageBase64: png},
+				},
+			}},
+		},
+		Parameter: ai.Parameter{N: &n, Stream: true},
+	}))
+	c.Assert(err, qt.IsNil)
+	c.Check(`{
+  "data": {"messages": [{"role": "user", "content": [
+    {"type": "text", "text": "What's on this flag?"},
+    {"type": "image-base64", "image-base64": "data:image/png;base64,`+png+`"}
+  ]}]},
+  "parameter": {"n": 2}
+}`, qt.JSONEquals, req.AsMap())
+}
+
+func TestParseModelName(t *testing.T) {
+	c := qt.New(t)
+
+	ns, model, version, err := parseModelName("admin/llava/v0.1.0")
+	c.Assert(err, qt.IsNil)
+	c.Check([]string{ns, model, version}, qt.DeepEquals, []string{"admin", "llava", "v0.1.0"})
+
+	_, _, _, err = parseModelName("llava")
+	c.Check(err, qt.ErrorMatches, `invalid model name "llava", expected namespace/model/version`)
+}
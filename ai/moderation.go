@@ -0,0 +1,28 @@
+package ai
+
+type ModerationInput struct {
+	Data ModerationInputData `json:"data"`
+}
+
+type ModerationInputData struct {
+	Model string   `json:"model,omitempty"`
+	Texts []string `json:"texts"`
+}
+
+type ModerationOutput struct {
+	Data ModerationOutputData `json:"data"`
+}
+
+type ModerationOutputData struct {
+	// Results holds the moderation of each input text, in the same order.
+	Results []ModerationResult `json:"results"`
+}
+
+type ModerationResult struct {
+	Flagged bool `json:"flagged"`
+	// Categories tells whether each category, e.g. "hate" or
+	// "self-harm/intent", is flagged. CategoryScores holds the confidence of
+	// the model for each category.
+	Categories     map[string]bool    `json:"categories"`
+	CategoryScores map[string]float32 `json:"category-scores"`
+}
@@ -1,6 +1,9 @@
 package ollama
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 
@@ -69,44 +72,185 @@ func (c *OllamaClient) CheckModelAvailability(modelName string) bool {
 }
 
 type PullModelRequest struct {
-	Name   string `json:"name"`
-	Stream bool   `json:"stream"`
+	Name     string `json:"name"`
+	Insecure bool   `json:"insecure,omitempty"`
+	Stream   bool   `json:"stream"`
 }
 
 type PullModelResponse struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
 }
 
 func (c *OllamaClient) Pull(modelName string) error {
-	request := &PullModelRequest{
-		Name:   modelName,
-		Stream: false,
+	_, err := c.PullModel(context.Background(), PullModelRequest{Name: modelName}, nil)
+	return err
+}
+
+// PullModel downloads a model from the Ollama library. If onProgress isn't
+// nil, the progress of the download is streamed and onProgress is called
+// with each status update. Cancelling the context stops the download.
+func (c *OllamaClient) PullModel(ctx context.Context, request PullModelRequest, onProgress func(PullModelResponse) error) (PullModelResponse, error) {
+	response := PullModelResponse{}
+	request.Stream = onProgress != nil
+	if !request.Stream {
+		req := c.httpClient.R().SetContext(ctx).SetResult(&response).SetBody(request)
+		if _, err := req.Post("/api/pull"); err != nil {
+			return response, err
+		}
+		return response, nil
+	}
+
+	resp, err := c.httpClient.R().SetContext(ctx).SetBody(request).SetDoNotParseResponse(true).Post("/api/pull")
+	if err != nil {
+		return response, err
 	}
-	response := &PullModelResponse{}
+	if err := httpclient.UnparsedResponseError("Ollama", resp, new(errBody)); err != nil {
+		return response, err
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	// The progress is streamed as newline-delimited JSON objects. The errors
+	// that occur during the download are sent in the stream.
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		update := struct {
+			PullModelResponse
+			Error string `json:"error"`
+		}{}
+		if err := json.Unmarshal(scanner.Bytes(), &update); err != nil {
+			return response, fmt.Errorf("unmarshalling pull progress: %w", err)
+		}
+		if update.Error != "" {
+			return response, fmt.Errorf("error when pulling model: %s", update.Error)
+		}
+
+		response = update.PullModelResponse
+		if err := onProgress(response); err != nil {
+			return response, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return response, fmt.Errorf("reading pull progress: %w", err)
+	}
+
+	return response, nil
+}
+
+type DeleteModelRequest struct {
+	Name string `json:"name"`
+}
+
+type DeleteModelResponse struct {
+}
+
+func (c *OllamaClient) DeleteModel(request DeleteModelRequest) (DeleteModelResponse, error) {
+	response := DeleteModelResponse{}
+	req := c.httpClient.R().SetBody(request)
+	if _, err := req.Delete("/api/delete"); err != nil {
+		return response, err
+	}
+	return response, nil
+}
+
+type ShowModelRequest struct {
+	Name    string `json:"name"`
+	Verbose bool   `json:"verbose,omitempty"`
+}
+
+type ModelDetails struct {
+	ParentModel       string   `json:"parent_model"`
+	Format            string   `json:"format"`
+	Family            string   `json:"family"`
+	Families          []string `json:"families"`
+	ParameterSize     string   `json:"parameter_size"`
+	QuantizationLevel string   `json:"quantization_level"`
+}
+
+type ShowModelResponse struct {
+	License    string         `json:"license"`
+	Modelfile  string         `json:"modelfile"`
+	Parameters string         `json:"parameters"`
+	Template   string         `json:"template"`
+	Details    ModelDetails   `json:"details"`
+	ModelInfo  map[string]any `json:"model_info"`
+	ModifiedAt string         `json:"modified_at"`
+}
+
+func (c *OllamaClient) ShowModel(request ShowModelRequest) (ShowModelResponse, error) {
+	response := ShowModelResponse{}
 	req := c.httpClient.R().SetResult(&response).SetBody(request)
-	if _, err := req.Post("/api/pull"); err != nil {
-		return err
+	if _, err := req.Post("/api/show"); err != nil {
+		return response, err
 	}
-	return nil
+	return response, nil
+}
 
+// ensureModel checks that a model is available on the server and pulls it
+// if the client is configured to do so.
+func (c *OllamaClient) ensureModel(modelName string) error {
+	if c.CheckModelAvailability(modelName) {
+		return nil
+	}
+	if !c.autoPull {
+		return fmt.Errorf("model %s is not available", modelName)
+	}
+	if err := c.Pull(modelName); err != nil {
+		return fmt.Errorf("error when auto pulling model %v", err)
+	}
+	return nil
 }
 
 type OllamaChatMessage struct {
-	Role    string   `json:"role"`
-	Content string   `json:"content"`
-	Images  []string `json:"images,omitempty"`
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	Images    []string         `json:"images,omitempty"`
+	ToolCalls []OllamaToolCall `json:"tool_calls,omitempty"`
+}
+
+type OllamaToolCall struct {
+	Function OllamaToolCallFunction `json:"function"`
+}
+
+type OllamaToolCallFunction struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type OllamaTool struct {
+	Type     string             `json:"type"`
+	Function OllamaToolFunction `json:"function"`
+}
+
+type OllamaToolFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters"`
 }
 
 type OllamaOptions struct {
 	Temperature float32 `json:"temperature,omitempty"`
 	TopK        int     `json:"top_k,omitempty"`
 	Seed        int     `json:"seed,omitempty"`
+	NumCtx      int     `json:"num_ctx,omitempty"`
 }
 
 type ChatRequest struct {
-	Model    string              `json:"model"`
-	Messages []OllamaChatMessage `json:"messages"`
-	Stream   bool                `json:"stream"`
-	Options  OllamaOptions       `json:"options"`
+	Model     string              `json:"model"`
+	Messages  []OllamaChatMessage `json:"messages"`
+	Stream    bool                `json:"stream"`
+	Options   OllamaOptions       `json:"options"`
+	Format    string              `json:"format,omitempty"`
+	KeepAlive string              `json:"keep_alive,omitempty"`
+	Tools     []OllamaTool        `json:"tools,omitempty"`
 }
 
 type ChatResponse struct {
@@ -125,16 +269,8 @@ type ChatResponse struct {
 
 func (c *OllamaClient) Chat(request ChatRequest) (ChatResponse, error) {
 	response := ChatResponse{}
-	isAvailable := c.CheckModelAvailability(request.Model)
-
-	if !isAvailable && !c.autoPull {
-		return response, fmt.Errorf("model %s is not available", request.Model)
-	}
-	if !isAvailable {
-		err := c.Pull(request.Model)
-		if err != nil {
-			return response, fmt.Errorf("error when auto pulling model %v", err)
-		}
+	if err := c.ensureModel(request.Model); err != nil {
+		return response, err
 	}
 	req := c.httpClient.R().SetResult(&response).SetBody(request)
 	if _, err := req.Post("/api/chat"); err != nil {
@@ -154,16 +290,8 @@ type EmbedResponse struct {
 
 func (c *OllamaClient) Embed(request EmbedRequest) (EmbedResponse, error) {
 	response := EmbedResponse{}
-	isAvailable := c.CheckModelAvailability(request.Model)
-
-	if !isAvailable && !c.autoPull {
-		return response, fmt.Errorf("model %s is not available", request.Model)
-	}
-	if !isAvailable {
-		err := c.Pull(request.Model)
-		if err != nil {
-			return response, fmt.Errorf("error when auto pulling model %v", err)
-		}
+	if err := c.ensureModel(request.Model); err != nil {
+		return response, err
 	}
 	req := c.httpClient.R().SetResult(&response).SetBody(request)
 	if _, err := req.Post("/api/embeddings"); err != nil {
@@ -172,6 +300,33 @@ func (c *OllamaClient) Embed(request EmbedRequest) (EmbedResponse, error) {
 	return response, nil
 }
 
+type EmbedBatchRequest struct {
+	Model     string   `json:"model"`
+	Input     []string `json:"input"`
+	KeepAlive string   `json:"keep_alive,omitempty"`
+}
+
+type EmbedBatchResponse struct {
+	Model           string      `json:"model"`
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+}
+
+// EmbedBatch computes the embeddings of several texts in a single request.
+// Unlike Embed, it uses the /api/embed endpoint, which normalizes the
+// embeddings.
+func (c *OllamaClient) EmbedBatch(request EmbedBatchRequest) (EmbedBatchResponse, error) {
+	response := EmbedBatchResponse{}
+	if err := c.ensureModel(request.Model); err != nil {
+		return response, err
+	}
+	req := c.httpClient.R().SetResult(&response).SetBody(request)
+	if _, err := req.Post("/api/embed"); err != nil {
+		return response, fmt.Errorf("error when sending embeddings request %v", err)
+	}
+	return response, nil
+}
+
 func (c *OllamaClient) IsAutoPull() bool {
 	return c.autoPull
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gojuno/minimock/v3"
@@ -62,6 +64,43 @@ func TestComponent_Tasks(t *testing.T) {
 		}).
 		Then(EmbedResponse{}, fmt.Errorf("error when sending embeddings request %s", `model "snowflake-arctic-embed:23m" not found, try pulling it first`))
 
+	OllamaClientMock.ChatMock.
+		When(ChatRequest{
+			Model:     "llama3.1",
+			Options:   OllamaOptions{NumCtx: 8192},
+			Format:    "json",
+			KeepAlive: "10m",
+			Messages:  []OllamaChatMessage{{Role: "user", Content: "What's the weather in Taipei?", Images: []string{}}},
+			Tools: []OllamaTool{{
+				Type: "function",
+				Function: OllamaToolFunction{
+					Name:        "get_weather",
+					Description: "Get the current weather of a city.",
+					Parameters:  map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+				},
+			}},
+		}).
+		Then(ChatResponse{
+			Model: "llama3.1",
+			Message: OllamaChatMessage{
+				Role: "assistant",
+				ToolCalls: []OllamaToolCall{
+					{Function: OllamaToolCallFunction{Name: "get_weather", Arguments: map[string]any{"city": "Taipei"}}},
+				},
+			},
+			Done:       true,
+			DoneReason: "stop",
+		}, nil)
+	OllamaClientMock.EmbedBatchMock.
+		When(EmbedBatchRequest{
+			Model: "snowflake-arctic-embed:22m",
+			Input: []string{"Scotland", "Wales"},
+		}).
+		Then(EmbedBatchResponse{
+			Model:      "snowflake-arctic-embed:22m",
+			Embeddings: [][]float32{{0.1, 0.2}, {0.3, 0.4}},
+		}, nil)
+
 	c.Run("ok - task text generation", func(c *qt.C) {
 		setup, err := structpb.NewStruct(map[string]any{
 			"endpoint":  "http://localhost:8080",
@@ -176,4 +215,324 @@ func TestComponent_Tasks(t *testing.T) {
 
 	})
 
+	c.Run("ok - task text generation with tools", func(c *qt.C) {
+		setup, err := structpb.NewStruct(map[string]any{
+			"endpoint":  "http://localhost:8080",
+			"auto-pull": true,
+		})
+		c.Assert(err, qt.IsNil)
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskTextGenerationChat},
+			client:             OllamaClientMock,
+		}
+		e.execute = e.TaskTextGenerationChat
+
+		pbIn, err := base.ConvertToStructpb(map[string]any{
+			"model":      "llama3.1",
+			"prompt":     "What's the weather in Taipei?",
+			"num-ctx":    8192,
+			"format":     "json",
+			"keep-alive": "10m",
+			"tools": []map[string]any{{
+				"name":        "get_weather",
+				"description": "Get the current weather of a city.",
+				"parameters":  map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+			}},
+		})
+		c.Assert(err, qt.IsNil)
+
+		ir, ow, eh, job := base.GenerateMockJob(c)
+		ir.ReadMock.Return(pbIn, nil)
+		ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+			c.Check(`{"text": "", "tool-calls": [{"name": "get_weather", "arguments": {"city": "Taipei"}}]}`, qt.JSONEquals, output.AsMap())
+			return nil
+		})
+		eh.ErrorMock.Optional()
+
+		err = e.Execute(ctx, []*base.Job{job})
+		c.Assert(err, qt.IsNil)
+	})
+
+	c.Run("ok - task batch embedding", func(c *qt.C) {
+		setup, err := structpb.NewStruct(map[string]any{
+			"endpoint":  "http://localhost:8080",
+			"auto-pull": true,
+		})
+		c.Assert(err, qt.IsNil)
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskTextEmbeddings},
+			client:             OllamaClientMock,
+		}
+		e.execute = e.TaskTextEmbeddings
+
+		pbIn, err := base.ConvertToStructpb(map[string]any{"model": "snowflake-arctic-embed:22m", "texts": []string{"Scotland", "Wales"}})
+		c.Assert(err, qt.IsNil)
+
+		ir, ow, eh, job := base.GenerateMockJob(c)
+		ir.ReadMock.Return(pbIn, nil)
+		ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+			wantJSON, err := json.Marshal(TaskTextEmbeddingsOutput{Embeddings: [][]float32{{0.1, 0.2}, {0.3, 0.4}}})
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+			return nil
+		})
+		eh.ErrorMock.Optional()
+
+		err = e.Execute(ctx, []*base.Job{job})
+		c.Assert(err, qt.IsNil)
+	})
+
+	c.Run("nok - task embedding with text and texts", func(c *qt.C) {
+		setup, err := structpb.NewStruct(map[string]any{
+			"endpoint":  "http://localhost:8080",
+			"auto-pull": true,
+		})
+		c.Assert(err, qt.IsNil)
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskTextEmbeddings},
+			client:             OllamaClientMock,
+		}
+		e.execute = e.TaskTextEmbeddings
+
+		pbIn, err := base.ConvertToStructpb(map[string]any{"model": "snowflake-arctic-embed:22m", "text": "England", "texts": []string{"Scotland", "Wales"}})
+		c.Assert(err, qt.IsNil)
+
+		ir, ow, eh, job := base.GenerateMockJob(c)
+		ir.ReadMock.Return(pbIn, nil)
+		ow.WriteMock.Optional().Return(nil)
+		eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+			c.Check(err, qt.ErrorMatches, "only one of text and texts can be provided")
+		})
+
+		err = e.Execute(ctx, []*base.Job{job})
+		c.Assert(err, qt.IsNil)
+	})
+}
+
+func TestComponent_ModelManagement(t *testing.T) {
+	mc := minimock.NewController(t)
+	c := qt.New(t)
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+	ctx := context.Background()
+
+	OllamaClientMock := NewOllamaClientInterfaceMock(mc)
+	OllamaClientMock.PullModelMock.Set(func(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error) (PullModelResponse, error) {
+		if p1.Name != "llama3.1" {
+			return PullModelResponse{}, fmt.Errorf("error when pulling model: pull model manifest: file does not exist")
+		}
+
+		// The stream writer sends every 10th update, so 2 partial outputs
+		// are expected.
+		for i := range 25 {
+			progress := PullModelResponse{Status: "pulling 8eeb52dfb3bb", Digest: "sha256:8eeb52dfb3bb", Total: 25, Completed: int64(i)}
+			if err := f1(progress); err != nil {
+				return PullModelResponse{}, err
+			}
+		}
+		return PullModelResponse{Status: "success"}, nil
+	})
+	OllamaClientMock.DeleteModelMock.
+		When(DeleteModelRequest{Name: "llama3.1"}).
+		Then(DeleteModelResponse{}, nil)
+	OllamaClientMock.ShowModelMock.
+		When(ShowModelRequest{Name: "llama3.1"}).
+		Then(ShowModelResponse{
+			Modelfile:  "FROM llama3.1",
+			Parameters: "stop \"<|eot_id|>\"",
+			Template:   "{{ .Prompt }}",
+			Details: ModelDetails{
+				Format:            "gguf",
+				Family:            "llama",
+				Families:          []string{"llama"},
+				ParameterSize:     "8.0B",
+				QuantizationLevel: "Q4_0",
+			},
+			ModelInfo:  map[string]any{"llama.context_length": 131072},
+			ModifiedAt: "2024-07-25T13:37:00.000000000+08:00",
+		}, nil)
+
+	testcases := []struct {
+		name       string
+		task       string
+		input      map[string]any
+		wantWrites int
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:       "ok - pull model",
+			task:       TaskPullModel,
+			input:      map[string]any{"model": "llama3.1"},
+			wantWrites: 3,
+			wantOutput: `{"status": "success"}`,
+		},
+		{
+			name:    "nok - pull model",
+			task:    TaskPullModel,
+			input:   map[string]any{"model": "llama42"},
+			wantErr: "error when pulling model: pull model manifest: file does not exist",
+		},
+		{
+			name:       "ok - delete model",
+			task:       TaskDeleteModel,
+			input:      map[string]any{"model": "llama3.1"},
+			wantWrites: 1,
+			wantOutput: `{"status": "success"}`,
+		},
+		{
+			name:       "ok - show model",
+			task:       TaskShowModel,
+			input:      map[string]any{"model": "llama3.1"},
+			wantWrites: 1,
+			wantOutput: `{
+  "license": "",
+  "modelfile": "FROM llama3.1",
+  "parameters": "stop \"<|eot_id|>\"",
+  "template": "{{ .Prompt }}",
+  "details": {
+    "parent-model": "",
+    "format": "gguf",
+    "family": "llama",
+    "families": ["llama"],
+    "parameter-size": "8.0B",
+    "quantization-level": "Q4_0"
+  },
+  "model-info": {"llama.context_length": 131072},
+  "modified-at": "2024-07-25T13:37:00.000000000+08:00"
+}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"endpoint":  "http://localhost:8080",
+				"auto-pull": false,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := connector.CreateExecution(base.ComponentExecution{Component: connector, Setup: setup, Task: tc.task})
+			c.Assert(err, qt.IsNil)
+
+			e := exec.(*execution)
+			e.client = OllamaClientMock
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+
+			var writes int
+			var got *structpb.Struct
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				writes++
+				got = output
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+
+			c.Check(writes, qt.Equals, tc.wantWrites)
+			if tc.wantOutput != "" {
+				c.Assert(got, qt.IsNotNil)
+				c.Check(tc.wantOutput, qt.JSONEquals, got.AsMap())
+			}
+		})
+	}
+}
+
+func TestClient_PullModel(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name         string
+		body         string
+		wantProgress []PullModelResponse
+		wantErr      string
+	}{
+		{
+			name: "ok",
+			body: `{"status": "pulling manifest"}
+{"status": "pulling 8eeb52dfb3bb", "digest": "sha256:8eeb52dfb3bb", "total": 100, "completed": 50}
+{"status": "success"}
+`,
+			wantProgress: []PullModelResponse{
+				{Status: "pulling manifest"},
+				{Status: "pulling 8eeb52dfb3bb", Digest: "sha256:8eeb52dfb3bb", Total: 100, Completed: 50},
+				{Status: "success"},
+			},
+		},
+		{
+			name: "nok - error in stream",
+			body: `{"status": "pulling manifest"}
+{"error": "pull model manifest: file does not exist"}
+`,
+			wantProgress: []PullModelResponse{{Status: "pulling manifest"}},
+			wantErr:      "error when pulling model: pull model manifest: file does not exist",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.URL.Path, qt.Equals, "/api/pull")
+
+				req := PullModelRequest{}
+				c.Check(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+				c.Check(req, qt.DeepEquals, PullModelRequest{Name: "llama3.1", Stream: true})
+
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				fmt.Fprint(w, tc.body)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			var progress []PullModelResponse
+			client := NewClient(srv.URL, false, zap.NewNop())
+			resp, err := client.PullModel(context.Background(), PullModelRequest{Name: "llama3.1"}, func(p PullModelResponse) error {
+				progress = append(progress, p)
+				return nil
+			})
+
+			c.Check(progress, qt.DeepEquals, tc.wantProgress)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(resp, qt.DeepEquals, PullModelResponse{Status: "success"})
+		})
+	}
+
+	c.Run("nok - cancelled", func(c *qt.C) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			fmt.Fprintln(w, `{"status": "pulling manifest"}`)
+			w.(http.Flusher).Flush()
+
+			// The download only stops when the client goes away.
+			<-r.Context().Done()
+		})
+
+		srv := httptest.NewServer(h)
+		c.Cleanup(srv.Close)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client := NewClient(srv.URL, false, zap.NewNop())
+		_, err := client.PullModel(ctx, PullModelRequest{Name: "llama3.1"}, func(p PullModelResponse) error {
+			cancel()
+			return nil
+		})
+		c.Check(err, qt.ErrorMatches, ".*context canceled")
+	})
 }
@@ -16,6 +16,9 @@ import (
 const (
 	TaskTextGenerationChat = "TASK_TEXT_GENERATION_CHAT"
 	TaskTextEmbeddings     = "TASK_TEXT_EMBEDDINGS"
+	TaskPullModel          = "TASK_PULL_MODEL"
+	TaskDeleteModel        = "TASK_DELETE_MODEL"
+	TaskShowModel          = "TASK_SHOW_MODEL"
 )
 
 var (
@@ -53,17 +56,21 @@ func Init(bc base.Component) *component {
 type OllamaClientInterface interface {
 	Chat(ChatRequest) (ChatResponse, error)
 	Embed(EmbedRequest) (EmbedResponse, error)
+	EmbedBatch(EmbedBatchRequest) (EmbedBatchResponse, error)
+	PullModel(context.Context, PullModelRequest, func(PullModelResponse) error) (PullModelResponse, error)
+	DeleteModel(DeleteModelRequest) (DeleteModelResponse, error)
+	ShowModel(ShowModelRequest) (ShowModelResponse, error)
 	IsAutoPull() bool
 }
 
 type execution struct {
 	base.ComponentExecution
 	client  OllamaClientInterface
-	execute func(*structpb.Struct) (*structpb.Struct, error)
+	execute func(*structpb.Struct, *base.Job, context.Context) (*structpb.Struct, error)
 }
 
 func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
-	return base.SequentialExecutor(ctx, jobs, e.execute)
+	return base.ConcurrentExecutor(ctx, jobs, e.execute)
 }
 
 func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
@@ -81,6 +88,12 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.TaskTextGenerationChat
 	case TaskTextEmbeddings:
 		e.execute = e.TaskTextEmbeddings
+	case TaskPullModel:
+		e.execute = e.TaskPullModel
+	case TaskDeleteModel:
+		e.execute = e.TaskDeleteModel
+	case TaskShowModel:
+		e.execute = e.TaskShowModel
 	default:
 		return nil, fmt.Errorf("unsupported task")
 	}
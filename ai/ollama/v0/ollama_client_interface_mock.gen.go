@@ -5,6 +5,7 @@ package ollama
 //go:generate minimock -i github.com/instill-ai/component/ai/ollama/v0.OllamaClientInterface -o ollama_client_interface_mock.gen.go -n OllamaClientInterfaceMock -p ollama
 
 import (
+	"context"
 	_ "embed"
 	"sync"
 	mm_atomic "sync/atomic"
@@ -24,17 +25,41 @@ type OllamaClientInterfaceMock struct {
 	beforeChatCounter uint64
 	ChatMock          mOllamaClientInterfaceMockChat
 
+	funcDeleteModel          func(d1 DeleteModelRequest) (d2 DeleteModelResponse, err error)
+	inspectFuncDeleteModel   func(d1 DeleteModelRequest)
+	afterDeleteModelCounter  uint64
+	beforeDeleteModelCounter uint64
+	DeleteModelMock          mOllamaClientInterfaceMockDeleteModel
+
 	funcEmbed          func(e1 EmbedRequest) (e2 EmbedResponse, err error)
 	inspectFuncEmbed   func(e1 EmbedRequest)
 	afterEmbedCounter  uint64
 	beforeEmbedCounter uint64
 	EmbedMock          mOllamaClientInterfaceMockEmbed
 
+	funcEmbedBatch          func(e1 EmbedBatchRequest) (e2 EmbedBatchResponse, err error)
+	inspectFuncEmbedBatch   func(e1 EmbedBatchRequest)
+	afterEmbedBatchCounter  uint64
+	beforeEmbedBatchCounter uint64
+	EmbedBatchMock          mOllamaClientInterfaceMockEmbedBatch
+
 	funcIsAutoPull          func() (b1 bool)
 	inspectFuncIsAutoPull   func()
 	afterIsAutoPullCounter  uint64
 	beforeIsAutoPullCounter uint64
 	IsAutoPullMock          mOllamaClientInterfaceMockIsAutoPull
+
+	funcPullModel          func(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error) (p2 PullModelResponse, err error)
+	inspectFuncPullModel   func(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error)
+	afterPullModelCounter  uint64
+	beforePullModelCounter uint64
+	PullModelMock          mOllamaClientInterfaceMockPullModel
+
+	funcShowModel          func(s1 ShowModelRequest) (s2 ShowModelResponse, err error)
+	inspectFuncShowModel   func(s1 ShowModelRequest)
+	afterShowModelCounter  uint64
+	beforeShowModelCounter uint64
+	ShowModelMock          mOllamaClientInterfaceMockShowModel
 }
 
 // NewOllamaClientInterfaceMock returns a mock for OllamaClientInterface
@@ -48,11 +73,23 @@ func NewOllamaClientInterfaceMock(t minimock.Tester) *OllamaClientInterfaceMock
 	m.ChatMock = mOllamaClientInterfaceMockChat{mock: m}
 	m.ChatMock.callArgs = []*OllamaClientInterfaceMockChatParams{}
 
+	m.DeleteModelMock = mOllamaClientInterfaceMockDeleteModel{mock: m}
+	m.DeleteModelMock.callArgs = []*OllamaClientInterfaceMockDeleteModelParams{}
+
 	m.EmbedMock = mOllamaClientInterfaceMockEmbed{mock: m}
 	m.EmbedMock.callArgs = []*OllamaClientInterfaceMockEmbedParams{}
 
+	m.EmbedBatchMock = mOllamaClientInterfaceMockEmbedBatch{mock: m}
+	m.EmbedBatchMock.callArgs = []*OllamaClientInterfaceMockEmbedBatchParams{}
+
 	m.IsAutoPullMock = mOllamaClientInterfaceMockIsAutoPull{mock: m}
 
+	m.PullModelMock = mOllamaClientInterfaceMockPullModel{mock: m}
+	m.PullModelMock.callArgs = []*OllamaClientInterfaceMockPullModelParams{}
+
+	m.ShowModelMock = mOllamaClientInterfaceMockShowModel{mock: m}
+	m.ShowModelMock.callArgs = []*OllamaClientInterfaceMockShowModelParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
@@ -351,6 +388,299 @@ func (m *OllamaClientInterfaceMock) MinimockChatInspect() {
 	}
 }
 
+type mOllamaClientInterfaceMockDeleteModel struct {
+	optional           bool
+	mock               *OllamaClientInterfaceMock
+	defaultExpectation *OllamaClientInterfaceMockDeleteModelExpectation
+	expectations       []*OllamaClientInterfaceMockDeleteModelExpectation
+
+	callArgs []*OllamaClientInterfaceMockDeleteModelParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OllamaClientInterfaceMockDeleteModelExpectation specifies expectation struct of the OllamaClientInterface.DeleteModel
+type OllamaClientInterfaceMockDeleteModelExpectation struct {
+	mock      *OllamaClientInterfaceMock
+	params    *OllamaClientInterfaceMockDeleteModelParams
+	paramPtrs *OllamaClientInterfaceMockDeleteModelParamPtrs
+	results   *OllamaClientInterfaceMockDeleteModelResults
+	Counter   uint64
+}
+
+// OllamaClientInterfaceMockDeleteModelParams contains parameters of the OllamaClientInterface.DeleteModel
+type OllamaClientInterfaceMockDeleteModelParams struct {
+	d1 DeleteModelRequest
+}
+
+// OllamaClientInterfaceMockDeleteModelParamPtrs contains pointers to parameters of the OllamaClientInterface.DeleteModel
+type OllamaClientInterfaceMockDeleteModelParamPtrs struct {
+	d1 *DeleteModelRequest
+}
+
+// OllamaClientInterfaceMockDeleteModelResults contains results of the OllamaClientInterface.DeleteModel
+type OllamaClientInterfaceMockDeleteModelResults struct {
+	d2  DeleteModelResponse
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) Optional() *mOllamaClientInterfaceMockDeleteModel {
+	mmDeleteModel.optional = true
+	return mmDeleteModel
+}
+
+// Expect sets up expected params for OllamaClientInterface.DeleteModel
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) Expect(d1 DeleteModelRequest) *mOllamaClientInterfaceMockDeleteModel {
+	if mmDeleteModel.mock.funcDeleteModel != nil {
+		mmDeleteModel.mock.t.Fatalf("OllamaClientInterfaceMock.DeleteModel mock is already set by Set")
+	}
+
+	if mmDeleteModel.defaultExpectation == nil {
+		mmDeleteModel.defaultExpectation = &OllamaClientInterfaceMockDeleteModelExpectation{}
+	}
+
+	if mmDeleteModel.defaultExpectation.paramPtrs != nil {
+		mmDeleteModel.mock.t.Fatalf("OllamaClientInterfaceMock.DeleteModel mock is already set by ExpectParams functions")
+	}
+
+	mmDeleteModel.defaultExpectation.params = &OllamaClientInterfaceMockDeleteModelParams{d1}
+	for _, e := range mmDeleteModel.expectations {
+		if minimock.Equal(e.params, mmDeleteModel.defaultExpectation.params) {
+			mmDeleteModel.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteModel.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteModel
+}
+
+// ExpectD1Param1 sets up expected param d1 for OllamaClientInterface.DeleteModel
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) ExpectD1Param1(d1 DeleteModelRequest) *mOllamaClientInterfaceMockDeleteModel {
+	if mmDeleteModel.mock.funcDeleteModel != nil {
+		mmDeleteModel.mock.t.Fatalf("OllamaClientInterfaceMock.DeleteModel mock is already set by Set")
+	}
+
+	if mmDeleteModel.defaultExpectation == nil {
+		mmDeleteModel.defaultExpectation = &OllamaClientInterfaceMockDeleteModelExpectation{}
+	}
+
+	if mmDeleteModel.defaultExpectation.params != nil {
+		mmDeleteModel.mock.t.Fatalf("OllamaClientInterfaceMock.DeleteModel mock is already set by Expect")
+	}
+
+	if mmDeleteModel.defaultExpectation.paramPtrs == nil {
+		mmDeleteModel.defaultExpectation.paramPtrs = &OllamaClientInterfaceMockDeleteModelParamPtrs{}
+	}
+	mmDeleteModel.defaultExpectation.paramPtrs.d1 = &d1
+
+	return mmDeleteModel
+}
+
+// Inspect accepts an inspector function that has same arguments as the OllamaClientInterface.DeleteModel
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) Inspect(f func(d1 DeleteModelRequest)) *mOllamaClientInterfaceMockDeleteModel {
+	if mmDeleteModel.mock.inspectFuncDeleteModel != nil {
+		mmDeleteModel.mock.t.Fatalf("Inspect function is already set for OllamaClientInterfaceMock.DeleteModel")
+	}
+
+	mmDeleteModel.mock.inspectFuncDeleteModel = f
+
+	return mmDeleteModel
+}
+
+// Return sets up results that will be returned by OllamaClientInterface.DeleteModel
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) Return(d2 DeleteModelResponse, err error) *OllamaClientInterfaceMock {
+	if mmDeleteModel.mock.funcDeleteModel != nil {
+		mmDeleteModel.mock.t.Fatalf("OllamaClientInterfaceMock.DeleteModel mock is already set by Set")
+	}
+
+	if mmDeleteModel.defaultExpectation == nil {
+		mmDeleteModel.defaultExpectation = &OllamaClientInterfaceMockDeleteModelExpectation{mock: mmDeleteModel.mock}
+	}
+	mmDeleteModel.defaultExpectation.results = &OllamaClientInterfaceMockDeleteModelResults{d2, err}
+	return mmDeleteModel.mock
+}
+
+// Set uses given function f to mock the OllamaClientInterface.DeleteModel method
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) Set(f func(d1 DeleteModelRequest) (d2 DeleteModelResponse, err error)) *OllamaClientInterfaceMock {
+	if mmDeleteModel.defaultExpectation != nil {
+		mmDeleteModel.mock.t.Fatalf("Default expectation is already set for the OllamaClientInterface.DeleteModel method")
+	}
+
+	if len(mmDeleteModel.expectations) > 0 {
+		mmDeleteModel.mock.t.Fatalf("Some expectations are already set for the OllamaClientInterface.DeleteModel method")
+	}
+
+	mmDeleteModel.mock.funcDeleteModel = f
+	return mmDeleteModel.mock
+}
+
+// When sets expectation for the OllamaClientInterface.DeleteModel which will trigger the result defined by the following
+// Then helper
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) When(d1 DeleteModelRequest) *OllamaClientInterfaceMockDeleteModelExpectation {
+	if mmDeleteModel.mock.funcDeleteModel != nil {
+		mmDeleteModel.mock.t.Fatalf("OllamaClientInterfaceMock.DeleteModel mock is already set by Set")
+	}
+
+	expectation := &OllamaClientInterfaceMockDeleteModelExpectation{
+		mock:   mmDeleteModel.mock,
+		params: &OllamaClientInterfaceMockDeleteModelParams{d1},
+	}
+	mmDeleteModel.expectations = append(mmDeleteModel.expectations, expectation)
+	return expectation
+}
+
+// Then sets up OllamaClientInterface.DeleteModel return parameters for the expectation previously defined by the When method
+func (e *OllamaClientInterfaceMockDeleteModelExpectation) Then(d2 DeleteModelResponse, err error) *OllamaClientInterfaceMock {
+	e.results = &OllamaClientInterfaceMockDeleteModelResults{d2, err}
+	return e.mock
+}
+
+// Times sets number of times OllamaClientInterface.DeleteModel should be invoked
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) Times(n uint64) *mOllamaClientInterfaceMockDeleteModel {
+	if n == 0 {
+		mmDeleteModel.mock.t.Fatalf("Times of OllamaClientInterfaceMock.DeleteModel mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeleteModel.expectedInvocations, n)
+	return mmDeleteModel
+}
+
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) invocationsDone() bool {
+	if len(mmDeleteModel.expectations) == 0 && mmDeleteModel.defaultExpectation == nil && mmDeleteModel.mock.funcDeleteModel == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteModel.mock.afterDeleteModelCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteModel.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeleteModel implements OllamaClientInterface
+func (mmDeleteModel *OllamaClientInterfaceMock) DeleteModel(d1 DeleteModelRequest) (d2 DeleteModelResponse, err error) {
+	mm_atomic.AddUint64(&mmDeleteModel.beforeDeleteModelCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteModel.afterDeleteModelCounter, 1)
+
+	if mmDeleteModel.inspectFuncDeleteModel != nil {
+		mmDeleteModel.inspectFuncDeleteModel(d1)
+	}
+
+	mm_params := OllamaClientInterfaceMockDeleteModelParams{d1}
+
+	// Record call args
+	mmDeleteModel.DeleteModelMock.mutex.Lock()
+	mmDeleteModel.DeleteModelMock.callArgs = append(mmDeleteModel.DeleteModelMock.callArgs, &mm_params)
+	mmDeleteModel.DeleteModelMock.mutex.Unlock()
+
+	for _, e := range mmDeleteModel.DeleteModelMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.d2, e.results.err
+		}
+	}
+
+	if mmDeleteModel.DeleteModelMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteModel.DeleteModelMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteModel.DeleteModelMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteModel.DeleteModelMock.defaultExpectation.paramPtrs
+
+		mm_got := OllamaClientInterfaceMockDeleteModelParams{d1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.d1 != nil && !minimock.Equal(*mm_want_ptrs.d1, mm_got.d1) {
+				mmDeleteModel.t.Errorf("OllamaClientInterfaceMock.DeleteModel got unexpected parameter d1, want: %#v, got: %#v%s\n", *mm_want_ptrs.d1, mm_got.d1, minimock.Diff(*mm_want_ptrs.d1, mm_got.d1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteModel.t.Errorf("OllamaClientInterfaceMock.DeleteModel got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteModel.DeleteModelMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteModel.t.Fatal("No results are set for the OllamaClientInterfaceMock.DeleteModel")
+		}
+		return (*mm_results).d2, (*mm_results).err
+	}
+	if mmDeleteModel.funcDeleteModel != nil {
+		return mmDeleteModel.funcDeleteModel(d1)
+	}
+	mmDeleteModel.t.Fatalf("Unexpected call to OllamaClientInterfaceMock.DeleteModel. %v", d1)
+	return
+}
+
+// DeleteModelAfterCounter returns a count of finished OllamaClientInterfaceMock.DeleteModel invocations
+func (mmDeleteModel *OllamaClientInterfaceMock) DeleteModelAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteModel.afterDeleteModelCounter)
+}
+
+// DeleteModelBeforeCounter returns a count of OllamaClientInterfaceMock.DeleteModel invocations
+func (mmDeleteModel *OllamaClientInterfaceMock) DeleteModelBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteModel.beforeDeleteModelCounter)
+}
+
+// Calls returns a list of arguments used in each call to OllamaClientInterfaceMock.DeleteModel.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteModel *mOllamaClientInterfaceMockDeleteModel) Calls() []*OllamaClientInterfaceMockDeleteModelParams {
+	mmDeleteModel.mutex.RLock()
+
+	argCopy := make([]*OllamaClientInterfaceMockDeleteModelParams, len(mmDeleteModel.callArgs))
+	copy(argCopy, mmDeleteModel.callArgs)
+
+	mmDeleteModel.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteModelDone returns true if the count of the DeleteModel invocations corresponds
+// the number of defined expectations
+func (m *OllamaClientInterfaceMock) MinimockDeleteModelDone() bool {
+	if m.DeleteModelMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.DeleteModelMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeleteModelMock.invocationsDone()
+}
+
+// MinimockDeleteModelInspect logs each unmet expectation
+func (m *OllamaClientInterfaceMock) MinimockDeleteModelInspect() {
+	for _, e := range m.DeleteModelMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.DeleteModel with params: %#v", *e.params)
+		}
+	}
+
+	afterDeleteModelCounter := mm_atomic.LoadUint64(&m.afterDeleteModelCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteModelMock.defaultExpectation != nil && afterDeleteModelCounter < 1 {
+		if m.DeleteModelMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OllamaClientInterfaceMock.DeleteModel")
+		} else {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.DeleteModel with params: %#v", *m.DeleteModelMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteModel != nil && afterDeleteModelCounter < 1 {
+		m.t.Error("Expected call to OllamaClientInterfaceMock.DeleteModel")
+	}
+
+	if !m.DeleteModelMock.invocationsDone() && afterDeleteModelCounter > 0 {
+		m.t.Errorf("Expected %d calls to OllamaClientInterfaceMock.DeleteModel but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteModelMock.expectedInvocations), afterDeleteModelCounter)
+	}
+}
+
 type mOllamaClientInterfaceMockEmbed struct {
 	optional           bool
 	mock               *OllamaClientInterfaceMock
@@ -644,26 +974,41 @@ func (m *OllamaClientInterfaceMock) MinimockEmbedInspect() {
 	}
 }
 
-type mOllamaClientInterfaceMockIsAutoPull struct {
+type mOllamaClientInterfaceMockEmbedBatch struct {
 	optional           bool
 	mock               *OllamaClientInterfaceMock
-	defaultExpectation *OllamaClientInterfaceMockIsAutoPullExpectation
-	expectations       []*OllamaClientInterfaceMockIsAutoPullExpectation
+	defaultExpectation *OllamaClientInterfaceMockEmbedBatchExpectation
+	expectations       []*OllamaClientInterfaceMockEmbedBatchExpectation
+
+	callArgs []*OllamaClientInterfaceMockEmbedBatchParams
+	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// OllamaClientInterfaceMockIsAutoPullExpectation specifies expectation struct of the OllamaClientInterface.IsAutoPull
-type OllamaClientInterfaceMockIsAutoPullExpectation struct {
-	mock *OllamaClientInterfaceMock
+// OllamaClientInterfaceMockEmbedBatchExpectation specifies expectation struct of the OllamaClientInterface.EmbedBatch
+type OllamaClientInterfaceMockEmbedBatchExpectation struct {
+	mock      *OllamaClientInterfaceMock
+	params    *OllamaClientInterfaceMockEmbedBatchParams
+	paramPtrs *OllamaClientInterfaceMockEmbedBatchParamPtrs
+	results   *OllamaClientInterfaceMockEmbedBatchResults
+	Counter   uint64
+}
 
-	results *OllamaClientInterfaceMockIsAutoPullResults
-	Counter uint64
+// OllamaClientInterfaceMockEmbedBatchParams contains parameters of the OllamaClientInterface.EmbedBatch
+type OllamaClientInterfaceMockEmbedBatchParams struct {
+	e1 EmbedBatchRequest
 }
 
-// OllamaClientInterfaceMockIsAutoPullResults contains results of the OllamaClientInterface.IsAutoPull
-type OllamaClientInterfaceMockIsAutoPullResults struct {
-	b1 bool
+// OllamaClientInterfaceMockEmbedBatchParamPtrs contains pointers to parameters of the OllamaClientInterface.EmbedBatch
+type OllamaClientInterfaceMockEmbedBatchParamPtrs struct {
+	e1 *EmbedBatchRequest
+}
+
+// OllamaClientInterfaceMockEmbedBatchResults contains results of the OllamaClientInterface.EmbedBatch
+type OllamaClientInterfaceMockEmbedBatchResults struct {
+	e2  EmbedBatchResponse
+	err error
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -671,97 +1016,375 @@ type OllamaClientInterfaceMockIsAutoPullResults struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Optional() *mOllamaClientInterfaceMockIsAutoPull {
-	mmIsAutoPull.optional = true
-	return mmIsAutoPull
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) Optional() *mOllamaClientInterfaceMockEmbedBatch {
+	mmEmbedBatch.optional = true
+	return mmEmbedBatch
 }
 
-// Expect sets up expected params for OllamaClientInterface.IsAutoPull
-func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Expect() *mOllamaClientInterfaceMockIsAutoPull {
-	if mmIsAutoPull.mock.funcIsAutoPull != nil {
-		mmIsAutoPull.mock.t.Fatalf("OllamaClientInterfaceMock.IsAutoPull mock is already set by Set")
+// Expect sets up expected params for OllamaClientInterface.EmbedBatch
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) Expect(e1 EmbedBatchRequest) *mOllamaClientInterfaceMockEmbedBatch {
+	if mmEmbedBatch.mock.funcEmbedBatch != nil {
+		mmEmbedBatch.mock.t.Fatalf("OllamaClientInterfaceMock.EmbedBatch mock is already set by Set")
 	}
 
-	if mmIsAutoPull.defaultExpectation == nil {
-		mmIsAutoPull.defaultExpectation = &OllamaClientInterfaceMockIsAutoPullExpectation{}
+	if mmEmbedBatch.defaultExpectation == nil {
+		mmEmbedBatch.defaultExpectation = &OllamaClientInterfaceMockEmbedBatchExpectation{}
 	}
 
-	return mmIsAutoPull
+	if mmEmbedBatch.defaultExpectation.paramPtrs != nil {
+		mmEmbedBatch.mock.t.Fatalf("OllamaClientInterfaceMock.EmbedBatch mock is already set by ExpectParams functions")
+	}
+
+	mmEmbedBatch.defaultExpectation.params = &OllamaClientInterfaceMockEmbedBatchParams{e1}
+	for _, e := range mmEmbedBatch.expectations {
+		if minimock.Equal(e.params, mmEmbedBatch.defaultExpectation.params) {
+			mmEmbedBatch.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmEmbedBatch.defaultExpectation.params)
+		}
+	}
+
+	return mmEmbedBatch
 }
 
-// Inspect accepts an inspector function that has same arguments as the OllamaClientInterface.IsAutoPull
-func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Inspect(f func()) *mOllamaClientInterfaceMockIsAutoPull {
-	if mmIsAutoPull.mock.inspectFuncIsAutoPull != nil {
-		mmIsAutoPull.mock.t.Fatalf("Inspect function is already set for OllamaClientInterfaceMock.IsAutoPull")
+// ExpectE1Param1 sets up expected param e1 for OllamaClientInterface.EmbedBatch
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) ExpectE1Param1(e1 EmbedBatchRequest) *mOllamaClientInterfaceMockEmbedBatch {
+	if mmEmbedBatch.mock.funcEmbedBatch != nil {
+		mmEmbedBatch.mock.t.Fatalf("OllamaClientInterfaceMock.EmbedBatch mock is already set by Set")
 	}
 
-	mmIsAutoPull.mock.inspectFuncIsAutoPull = f
+	if mmEmbedBatch.defaultExpectation == nil {
+		mmEmbedBatch.defaultExpectation = &OllamaClientInterfaceMockEmbedBatchExpectation{}
+	}
 
-	return mmIsAutoPull
+	if mmEmbedBatch.defaultExpectation.params != nil {
+		mmEmbedBatch.mock.t.Fatalf("OllamaClientInterfaceMock.EmbedBatch mock is already set by Expect")
+	}
+
+	if mmEmbedBatch.defaultExpectation.paramPtrs == nil {
+		mmEmbedBatch.defaultExpectation.paramPtrs = &OllamaClientInterfaceMockEmbedBatchParamPtrs{}
+	}
+	mmEmbedBatch.defaultExpectation.paramPtrs.e1 = &e1
+
+	return mmEmbedBatch
 }
 
-// Return sets up results that will be returned by OllamaClientInterface.IsAutoPull
-func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Return(b1 bool) *OllamaClientInterfaceMock {
-	if mmIsAutoPull.mock.funcIsAutoPull != nil {
-		mmIsAutoPull.mock.t.Fatalf("OllamaClientInterfaceMock.IsAutoPull mock is already set by Set")
+// Inspect accepts an inspector function that has same arguments as the OllamaClientInterface.EmbedBatch
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) Inspect(f func(e1 EmbedBatchRequest)) *mOllamaClientInterfaceMockEmbedBatch {
+	if mmEmbedBatch.mock.inspectFuncEmbedBatch != nil {
+		mmEmbedBatch.mock.t.Fatalf("Inspect function is already set for OllamaClientInterfaceMock.EmbedBatch")
 	}
 
-	if mmIsAutoPull.defaultExpectation == nil {
-		mmIsAutoPull.defaultExpectation = &OllamaClientInterfaceMockIsAutoPullExpectation{mock: mmIsAutoPull.mock}
+	mmEmbedBatch.mock.inspectFuncEmbedBatch = f
+
+	return mmEmbedBatch
+}
+
+// Return sets up results that will be returned by OllamaClientInterface.EmbedBatch
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) Return(e2 EmbedBatchResponse, err error) *OllamaClientInterfaceMock {
+	if mmEmbedBatch.mock.funcEmbedBatch != nil {
+		mmEmbedBatch.mock.t.Fatalf("OllamaClientInterfaceMock.EmbedBatch mock is already set by Set")
 	}
-	mmIsAutoPull.defaultExpectation.results = &OllamaClientInterfaceMockIsAutoPullResults{b1}
-	return mmIsAutoPull.mock
+
+	if mmEmbedBatch.defaultExpectation == nil {
+		mmEmbedBatch.defaultExpectation = &OllamaClientInterfaceMockEmbedBatchExpectation{mock: mmEmbedBatch.mock}
+	}
+	mmEmbedBatch.defaultExpectation.results = &OllamaClientInterfaceMockEmbedBatchResults{e2, err}
+	return mmEmbedBatch.mock
 }
 
-// Set uses given function f to mock the OllamaClientInterface.IsAutoPull method
-func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Set(f func() (b1 bool)) *OllamaClientInterfaceMock {
-	if mmIsAutoPull.defaultExpectation != nil {
-		mmIsAutoPull.mock.t.Fatalf("Default expectation is already set for the OllamaClientInterface.IsAutoPull method")
+// Set uses given function f to mock the OllamaClientInterface.EmbedBatch method
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) Set(f func(e1 EmbedBatchRequest) (e2 EmbedBatchResponse, err error)) *OllamaClientInterfaceMock {
+	if mmEmbedBatch.defaultExpectation != nil {
+		mmEmbedBatch.mock.t.Fatalf("Default expectation is already set for the OllamaClientInterface.EmbedBatch method")
 	}
 
-	if len(mmIsAutoPull.expectations) > 0 {
-		mmIsAutoPull.mock.t.Fatalf("Some expectations are already set for the OllamaClientInterface.IsAutoPull method")
+	if len(mmEmbedBatch.expectations) > 0 {
+		mmEmbedBatch.mock.t.Fatalf("Some expectations are already set for the OllamaClientInterface.EmbedBatch method")
 	}
 
-	mmIsAutoPull.mock.funcIsAutoPull = f
-	return mmIsAutoPull.mock
+	mmEmbedBatch.mock.funcEmbedBatch = f
+	return mmEmbedBatch.mock
 }
 
-// Times sets number of times OllamaClientInterface.IsAutoPull should be invoked
-func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Times(n uint64) *mOllamaClientInterfaceMockIsAutoPull {
+// When sets expectation for the OllamaClientInterface.EmbedBatch which will trigger the result defined by the following
+// Then helper
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) When(e1 EmbedBatchRequest) *OllamaClientInterfaceMockEmbedBatchExpectation {
+	if mmEmbedBatch.mock.funcEmbedBatch != nil {
+		mmEmbedBatch.mock.t.Fatalf("OllamaClientInterfaceMock.EmbedBatch mock is already set by Set")
+	}
+
+	expectation := &OllamaClientInterfaceMockEmbedBatchExpectation{
+		mock:   mmEmbedBatch.mock,
+		params: &OllamaClientInterfaceMockEmbedBatchParams{e1},
+	}
+	mmEmbedBatch.expectations = append(mmEmbedBatch.expectations, expectation)
+	return expectation
+}
+
+// Then sets up OllamaClientInterface.EmbedBatch return parameters for the expectation previously defined by the When method
+func (e *OllamaClientInterfaceMockEmbedBatchExpectation) Then(e2 EmbedBatchResponse, err error) *OllamaClientInterfaceMock {
+	e.results = &OllamaClientInterfaceMockEmbedBatchResults{e2, err}
+	return e.mock
+}
+
+// Times sets number of times OllamaClientInterface.EmbedBatch should be invoked
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) Times(n uint64) *mOllamaClientInterfaceMockEmbedBatch {
 	if n == 0 {
-		mmIsAutoPull.mock.t.Fatalf("Times of OllamaClientInterfaceMock.IsAutoPull mock can not be zero")
+		mmEmbedBatch.mock.t.Fatalf("Times of OllamaClientInterfaceMock.EmbedBatch mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmIsAutoPull.expectedInvocations, n)
-	return mmIsAutoPull
+	mm_atomic.StoreUint64(&mmEmbedBatch.expectedInvocations, n)
+	return mmEmbedBatch
 }
 
-func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) invocationsDone() bool {
-	if len(mmIsAutoPull.expectations) == 0 && mmIsAutoPull.defaultExpectation == nil && mmIsAutoPull.mock.funcIsAutoPull == nil {
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) invocationsDone() bool {
+	if len(mmEmbedBatch.expectations) == 0 && mmEmbedBatch.defaultExpectation == nil && mmEmbedBatch.mock.funcEmbedBatch == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmIsAutoPull.mock.afterIsAutoPullCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmIsAutoPull.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmEmbedBatch.mock.afterEmbedBatchCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmEmbedBatch.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// IsAutoPull implements OllamaClientInterface
-func (mmIsAutoPull *OllamaClientInterfaceMock) IsAutoPull() (b1 bool) {
-	mm_atomic.AddUint64(&mmIsAutoPull.beforeIsAutoPullCounter, 1)
-	defer mm_atomic.AddUint64(&mmIsAutoPull.afterIsAutoPullCounter, 1)
+// EmbedBatch implements OllamaClientInterface
+func (mmEmbedBatch *OllamaClientInterfaceMock) EmbedBatch(e1 EmbedBatchRequest) (e2 EmbedBatchResponse, err error) {
+	mm_atomic.AddUint64(&mmEmbedBatch.beforeEmbedBatchCounter, 1)
+	defer mm_atomic.AddUint64(&mmEmbedBatch.afterEmbedBatchCounter, 1)
 
-	if mmIsAutoPull.inspectFuncIsAutoPull != nil {
-		mmIsAutoPull.inspectFuncIsAutoPull()
+	if mmEmbedBatch.inspectFuncEmbedBatch != nil {
+		mmEmbedBatch.inspectFuncEmbedBatch(e1)
 	}
 
-	if mmIsAutoPull.IsAutoPullMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmIsAutoPull.IsAutoPullMock.defaultExpectation.Counter, 1)
+	mm_params := OllamaClientInterfaceMockEmbedBatchParams{e1}
 
-		mm_results := mmIsAutoPull.IsAutoPullMock.defaultExpectation.results
-		if mm_results == nil {
-			mmIsAutoPull.t.Fatal("No results are set for the OllamaClientInterfaceMock.IsAutoPull")
+	// Record call args
+	mmEmbedBatch.EmbedBatchMock.mutex.Lock()
+	mmEmbedBatch.EmbedBatchMock.callArgs = append(mmEmbedBatch.EmbedBatchMock.callArgs, &mm_params)
+	mmEmbedBatch.EmbedBatchMock.mutex.Unlock()
+
+	for _, e := range mmEmbedBatch.EmbedBatchMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.e2, e.results.err
+		}
+	}
+
+	if mmEmbedBatch.EmbedBatchMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmEmbedBatch.EmbedBatchMock.defaultExpectation.Counter, 1)
+		mm_want := mmEmbedBatch.EmbedBatchMock.defaultExpectation.params
+		mm_want_ptrs := mmEmbedBatch.EmbedBatchMock.defaultExpectation.paramPtrs
+
+		mm_got := OllamaClientInterfaceMockEmbedBatchParams{e1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.e1 != nil && !minimock.Equal(*mm_want_ptrs.e1, mm_got.e1) {
+				mmEmbedBatch.t.Errorf("OllamaClientInterfaceMock.EmbedBatch got unexpected parameter e1, want: %#v, got: %#v%s\n", *mm_want_ptrs.e1, mm_got.e1, minimock.Diff(*mm_want_ptrs.e1, mm_got.e1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmEmbedBatch.t.Errorf("OllamaClientInterfaceMock.EmbedBatch got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmEmbedBatch.EmbedBatchMock.defaultExpectation.results
+		if mm_results == nil {
+			mmEmbedBatch.t.Fatal("No results are set for the OllamaClientInterfaceMock.EmbedBatch")
+		}
+		return (*mm_results).e2, (*mm_results).err
+	}
+	if mmEmbedBatch.funcEmbedBatch != nil {
+		return mmEmbedBatch.funcEmbedBatch(e1)
+	}
+	mmEmbedBatch.t.Fatalf("Unexpected call to OllamaClientInterfaceMock.EmbedBatch. %v", e1)
+	return
+}
+
+// EmbedBatchAfterCounter returns a count of finished OllamaClientInterfaceMock.EmbedBatch invocations
+func (mmEmbedBatch *OllamaClientInterfaceMock) EmbedBatchAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEmbedBatch.afterEmbedBatchCounter)
+}
+
+// EmbedBatchBeforeCounter returns a count of OllamaClientInterfaceMock.EmbedBatch invocations
+func (mmEmbedBatch *OllamaClientInterfaceMock) EmbedBatchBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEmbedBatch.beforeEmbedBatchCounter)
+}
+
+// Calls returns a list of arguments used in each call to OllamaClientInterfaceMock.EmbedBatch.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmEmbedBatch *mOllamaClientInterfaceMockEmbedBatch) Calls() []*OllamaClientInterfaceMockEmbedBatchParams {
+	mmEmbedBatch.mutex.RLock()
+
+	argCopy := make([]*OllamaClientInterfaceMockEmbedBatchParams, len(mmEmbedBatch.callArgs))
+	copy(argCopy, mmEmbedBatch.callArgs)
+
+	mmEmbedBatch.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockEmbedBatchDone returns true if the count of the EmbedBatch invocations corresponds
+// the number of defined expectations
+func (m *OllamaClientInterfaceMock) MinimockEmbedBatchDone() bool {
+	if m.EmbedBatchMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.EmbedBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.EmbedBatchMock.invocationsDone()
+}
+
+// MinimockEmbedBatchInspect logs each unmet expectation
+func (m *OllamaClientInterfaceMock) MinimockEmbedBatchInspect() {
+	for _, e := range m.EmbedBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.EmbedBatch with params: %#v", *e.params)
+		}
+	}
+
+	afterEmbedBatchCounter := mm_atomic.LoadUint64(&m.afterEmbedBatchCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.EmbedBatchMock.defaultExpectation != nil && afterEmbedBatchCounter < 1 {
+		if m.EmbedBatchMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OllamaClientInterfaceMock.EmbedBatch")
+		} else {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.EmbedBatch with params: %#v", *m.EmbedBatchMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcEmbedBatch != nil && afterEmbedBatchCounter < 1 {
+		m.t.Error("Expected call to OllamaClientInterfaceMock.EmbedBatch")
+	}
+
+	if !m.EmbedBatchMock.invocationsDone() && afterEmbedBatchCounter > 0 {
+		m.t.Errorf("Expected %d calls to OllamaClientInterfaceMock.EmbedBatch but found %d calls",
+			mm_atomic.LoadUint64(&m.EmbedBatchMock.expectedInvocations), afterEmbedBatchCounter)
+	}
+}
+
+type mOllamaClientInterfaceMockIsAutoPull struct {
+	optional           bool
+	mock               *OllamaClientInterfaceMock
+	defaultExpectation *OllamaClientInterfaceMockIsAutoPullExpectation
+	expectations       []*OllamaClientInterfaceMockIsAutoPullExpectation
+
+	expectedInvocations uint64
+}
+
+// OllamaClientInterfaceMockIsAutoPullExpectation specifies expectation struct of the OllamaClientInterface.IsAutoPull
+type OllamaClientInterfaceMockIsAutoPullExpectation struct {
+	mock *OllamaClientInterfaceMock
+
+	results *OllamaClientInterfaceMockIsAutoPullResults
+	Counter uint64
+}
+
+// OllamaClientInterfaceMockIsAutoPullResults contains results of the OllamaClientInterface.IsAutoPull
+type OllamaClientInterfaceMockIsAutoPullResults struct {
+	b1 bool
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Optional() *mOllamaClientInterfaceMockIsAutoPull {
+	mmIsAutoPull.optional = true
+	return mmIsAutoPull
+}
+
+// Expect sets up expected params for OllamaClientInterface.IsAutoPull
+func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Expect() *mOllamaClientInterfaceMockIsAutoPull {
+	if mmIsAutoPull.mock.funcIsAutoPull != nil {
+		mmIsAutoPull.mock.t.Fatalf("OllamaClientInterfaceMock.IsAutoPull mock is already set by Set")
+	}
+
+	if mmIsAutoPull.defaultExpectation == nil {
+		mmIsAutoPull.defaultExpectation = &OllamaClientInterfaceMockIsAutoPullExpectation{}
+	}
+
+	return mmIsAutoPull
+}
+
+// Inspect accepts an inspector function that has same arguments as the OllamaClientInterface.IsAutoPull
+func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Inspect(f func()) *mOllamaClientInterfaceMockIsAutoPull {
+	if mmIsAutoPull.mock.inspectFuncIsAutoPull != nil {
+		mmIsAutoPull.mock.t.Fatalf("Inspect function is already set for OllamaClientInterfaceMock.IsAutoPull")
+	}
+
+	mmIsAutoPull.mock.inspectFuncIsAutoPull = f
+
+	return mmIsAutoPull
+}
+
+// Return sets up results that will be returned by OllamaClientInterface.IsAutoPull
+func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Return(b1 bool) *OllamaClientInterfaceMock {
+	if mmIsAutoPull.mock.funcIsAutoPull != nil {
+		mmIsAutoPull.mock.t.Fatalf("OllamaClientInterfaceMock.IsAutoPull mock is already set by Set")
+	}
+
+	if mmIsAutoPull.defaultExpectation == nil {
+		mmIsAutoPull.defaultExpectation = &OllamaClientInterfaceMockIsAutoPullExpectation{mock: mmIsAutoPull.mock}
+	}
+	mmIsAutoPull.defaultExpectation.results = &OllamaClientInterfaceMockIsAutoPullResults{b1}
+	return mmIsAutoPull.mock
+}
+
+// Set uses given function f to mock the OllamaClientInterface.IsAutoPull method
+func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Set(f func() (b1 bool)) *OllamaClientInterfaceMock {
+	if mmIsAutoPull.defaultExpectation != nil {
+		mmIsAutoPull.mock.t.Fatalf("Default expectation is already set for the OllamaClientInterface.IsAutoPull method")
+	}
+
+	if len(mmIsAutoPull.expectations) > 0 {
+		mmIsAutoPull.mock.t.Fatalf("Some expectations are already set for the OllamaClientInterface.IsAutoPull method")
+	}
+
+	mmIsAutoPull.mock.funcIsAutoPull = f
+	return mmIsAutoPull.mock
+}
+
+// Times sets number of times OllamaClientInterface.IsAutoPull should be invoked
+func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) Times(n uint64) *mOllamaClientInterfaceMockIsAutoPull {
+	if n == 0 {
+		mmIsAutoPull.mock.t.Fatalf("Times of OllamaClientInterfaceMock.IsAutoPull mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmIsAutoPull.expectedInvocations, n)
+	return mmIsAutoPull
+}
+
+func (mmIsAutoPull *mOllamaClientInterfaceMockIsAutoPull) invocationsDone() bool {
+	if len(mmIsAutoPull.expectations) == 0 && mmIsAutoPull.defaultExpectation == nil && mmIsAutoPull.mock.funcIsAutoPull == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmIsAutoPull.mock.afterIsAutoPullCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmIsAutoPull.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// IsAutoPull implements OllamaClientInterface
+func (mmIsAutoPull *OllamaClientInterfaceMock) IsAutoPull() (b1 bool) {
+	mm_atomic.AddUint64(&mmIsAutoPull.beforeIsAutoPullCounter, 1)
+	defer mm_atomic.AddUint64(&mmIsAutoPull.afterIsAutoPullCounter, 1)
+
+	if mmIsAutoPull.inspectFuncIsAutoPull != nil {
+		mmIsAutoPull.inspectFuncIsAutoPull()
+	}
+
+	if mmIsAutoPull.IsAutoPullMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmIsAutoPull.IsAutoPullMock.defaultExpectation.Counter, 1)
+
+		mm_results := mmIsAutoPull.IsAutoPullMock.defaultExpectation.results
+		if mm_results == nil {
+			mmIsAutoPull.t.Fatal("No results are set for the OllamaClientInterfaceMock.IsAutoPull")
 		}
 		return (*mm_results).b1
 	}
@@ -823,15 +1446,665 @@ func (m *OllamaClientInterfaceMock) MinimockIsAutoPullInspect() {
 	}
 }
 
+type mOllamaClientInterfaceMockPullModel struct {
+	optional           bool
+	mock               *OllamaClientInterfaceMock
+	defaultExpectation *OllamaClientInterfaceMockPullModelExpectation
+	expectations       []*OllamaClientInterfaceMockPullModelExpectation
+
+	callArgs []*OllamaClientInterfaceMockPullModelParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OllamaClientInterfaceMockPullModelExpectation specifies expectation struct of the OllamaClientInterface.PullModel
+type OllamaClientInterfaceMockPullModelExpectation struct {
+	mock      *OllamaClientInterfaceMock
+	params    *OllamaClientInterfaceMockPullModelParams
+	paramPtrs *OllamaClientInterfaceMockPullModelParamPtrs
+	results   *OllamaClientInterfaceMockPullModelResults
+	Counter   uint64
+}
+
+// OllamaClientInterfaceMockPullModelParams contains parameters of the OllamaClientInterface.PullModel
+type OllamaClientInterfaceMockPullModelParams struct {
+	ctx context.Context
+	p1  PullModelRequest
+	f1  func(PullModelResponse) error
+}
+
+// OllamaClientInterfaceMockPullModelParamPtrs contains pointers to parameters of the OllamaClientInterface.PullModel
+type OllamaClientInterfaceMockPullModelParamPtrs struct {
+	ctx *context.Context
+	p1  *PullModelRequest
+	f1  *func(PullModelResponse) error
+}
+
+// OllamaClientInterfaceMockPullModelResults contains results of the OllamaClientInterface.PullModel
+type OllamaClientInterfaceMockPullModelResults struct {
+	p2  PullModelResponse
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) Optional() *mOllamaClientInterfaceMockPullModel {
+	mmPullModel.optional = true
+	return mmPullModel
+}
+
+// Expect sets up expected params for OllamaClientInterface.PullModel
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) Expect(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error) *mOllamaClientInterfaceMockPullModel {
+	if mmPullModel.mock.funcPullModel != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Set")
+	}
+
+	if mmPullModel.defaultExpectation == nil {
+		mmPullModel.defaultExpectation = &OllamaClientInterfaceMockPullModelExpectation{}
+	}
+
+	if mmPullModel.defaultExpectation.paramPtrs != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by ExpectParams functions")
+	}
+
+	mmPullModel.defaultExpectation.params = &OllamaClientInterfaceMockPullModelParams{ctx, p1, f1}
+	for _, e := range mmPullModel.expectations {
+		if minimock.Equal(e.params, mmPullModel.defaultExpectation.params) {
+			mmPullModel.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmPullModel.defaultExpectation.params)
+		}
+	}
+
+	return mmPullModel
+}
+
+// ExpectCtxParam1 sets up expected param ctx for OllamaClientInterface.PullModel
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) ExpectCtxParam1(ctx context.Context) *mOllamaClientInterfaceMockPullModel {
+	if mmPullModel.mock.funcPullModel != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Set")
+	}
+
+	if mmPullModel.defaultExpectation == nil {
+		mmPullModel.defaultExpectation = &OllamaClientInterfaceMockPullModelExpectation{}
+	}
+
+	if mmPullModel.defaultExpectation.params != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Expect")
+	}
+
+	if mmPullModel.defaultExpectation.paramPtrs == nil {
+		mmPullModel.defaultExpectation.paramPtrs = &OllamaClientInterfaceMockPullModelParamPtrs{}
+	}
+	mmPullModel.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmPullModel
+}
+
+// ExpectP1Param2 sets up expected param p1 for OllamaClientInterface.PullModel
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) ExpectP1Param2(p1 PullModelRequest) *mOllamaClientInterfaceMockPullModel {
+	if mmPullModel.mock.funcPullModel != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Set")
+	}
+
+	if mmPullModel.defaultExpectation == nil {
+		mmPullModel.defaultExpectation = &OllamaClientInterfaceMockPullModelExpectation{}
+	}
+
+	if mmPullModel.defaultExpectation.params != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Expect")
+	}
+
+	if mmPullModel.defaultExpectation.paramPtrs == nil {
+		mmPullModel.defaultExpectation.paramPtrs = &OllamaClientInterfaceMockPullModelParamPtrs{}
+	}
+	mmPullModel.defaultExpectation.paramPtrs.p1 = &p1
+
+	return mmPullModel
+}
+
+// ExpectF1Param3 sets up expected param f1 for OllamaClientInterface.PullModel
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) ExpectF1Param3(f1 func(PullModelResponse) error) *mOllamaClientInterfaceMockPullModel {
+	if mmPullModel.mock.funcPullModel != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Set")
+	}
+
+	if mmPullModel.defaultExpectation == nil {
+		mmPullModel.defaultExpectation = &OllamaClientInterfaceMockPullModelExpectation{}
+	}
+
+	if mmPullModel.defaultExpectation.params != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Expect")
+	}
+
+	if mmPullModel.defaultExpectation.paramPtrs == nil {
+		mmPullModel.defaultExpectation.paramPtrs = &OllamaClientInterfaceMockPullModelParamPtrs{}
+	}
+	mmPullModel.defaultExpectation.paramPtrs.f1 = &f1
+
+	return mmPullModel
+}
+
+// Inspect accepts an inspector function that has same arguments as the OllamaClientInterface.PullModel
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) Inspect(f func(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error)) *mOllamaClientInterfaceMockPullModel {
+	if mmPullModel.mock.inspectFuncPullModel != nil {
+		mmPullModel.mock.t.Fatalf("Inspect function is already set for OllamaClientInterfaceMock.PullModel")
+	}
+
+	mmPullModel.mock.inspectFuncPullModel = f
+
+	return mmPullModel
+}
+
+// Return sets up results that will be returned by OllamaClientInterface.PullModel
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) Return(p2 PullModelResponse, err error) *OllamaClientInterfaceMock {
+	if mmPullModel.mock.funcPullModel != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Set")
+	}
+
+	if mmPullModel.defaultExpectation == nil {
+		mmPullModel.defaultExpectation = &OllamaClientInterfaceMockPullModelExpectation{mock: mmPullModel.mock}
+	}
+	mmPullModel.defaultExpectation.results = &OllamaClientInterfaceMockPullModelResults{p2, err}
+	return mmPullModel.mock
+}
+
+// Set uses given function f to mock the OllamaClientInterface.PullModel method
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) Set(f func(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error) (p2 PullModelResponse, err error)) *OllamaClientInterfaceMock {
+	if mmPullModel.defaultExpectation != nil {
+		mmPullModel.mock.t.Fatalf("Default expectation is already set for the OllamaClientInterface.PullModel method")
+	}
+
+	if len(mmPullModel.expectations) > 0 {
+		mmPullModel.mock.t.Fatalf("Some expectations are already set for the OllamaClientInterface.PullModel method")
+	}
+
+	mmPullModel.mock.funcPullModel = f
+	return mmPullModel.mock
+}
+
+// When sets expectation for the OllamaClientInterface.PullModel which will trigger the result defined by the following
+// Then helper
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) When(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error) *OllamaClientInterfaceMockPullModelExpectation {
+	if mmPullModel.mock.funcPullModel != nil {
+		mmPullModel.mock.t.Fatalf("OllamaClientInterfaceMock.PullModel mock is already set by Set")
+	}
+
+	expectation := &OllamaClientInterfaceMockPullModelExpectation{
+		mock:   mmPullModel.mock,
+		params: &OllamaClientInterfaceMockPullModelParams{ctx, p1, f1},
+	}
+	mmPullModel.expectations = append(mmPullModel.expectations, expectation)
+	return expectation
+}
+
+// Then sets up OllamaClientInterface.PullModel return parameters for the expectation previously defined by the When method
+func (e *OllamaClientInterfaceMockPullModelExpectation) Then(p2 PullModelResponse, err error) *OllamaClientInterfaceMock {
+	e.results = &OllamaClientInterfaceMockPullModelResults{p2, err}
+	return e.mock
+}
+
+// Times sets number of times OllamaClientInterface.PullModel should be invoked
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) Times(n uint64) *mOllamaClientInterfaceMockPullModel {
+	if n == 0 {
+		mmPullModel.mock.t.Fatalf("Times of OllamaClientInterfaceMock.PullModel mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmPullModel.expectedInvocations, n)
+	return mmPullModel
+}
+
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) invocationsDone() bool {
+	if len(mmPullModel.expectations) == 0 && mmPullModel.defaultExpectation == nil && mmPullModel.mock.funcPullModel == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmPullModel.mock.afterPullModelCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmPullModel.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// PullModel implements OllamaClientInterface
+func (mmPullModel *OllamaClientInterfaceMock) PullModel(ctx context.Context, p1 PullModelRequest, f1 func(PullModelResponse) error) (p2 PullModelResponse, err error) {
+	mm_atomic.AddUint64(&mmPullModel.beforePullModelCounter, 1)
+	defer mm_atomic.AddUint64(&mmPullModel.afterPullModelCounter, 1)
+
+	if mmPullModel.inspectFuncPullModel != nil {
+		mmPullModel.inspectFuncPullModel(ctx, p1, f1)
+	}
+
+	mm_params := OllamaClientInterfaceMockPullModelParams{ctx, p1, f1}
+
+	// Record call args
+	mmPullModel.PullModelMock.mutex.Lock()
+	mmPullModel.PullModelMock.callArgs = append(mmPullModel.PullModelMock.callArgs, &mm_params)
+	mmPullModel.PullModelMock.mutex.Unlock()
+
+	for _, e := range mmPullModel.PullModelMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.p2, e.results.err
+		}
+	}
+
+	if mmPullModel.PullModelMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmPullModel.PullModelMock.defaultExpectation.Counter, 1)
+		mm_want := mmPullModel.PullModelMock.defaultExpectation.params
+		mm_want_ptrs := mmPullModel.PullModelMock.defaultExpectation.paramPtrs
+
+		mm_got := OllamaClientInterfaceMockPullModelParams{ctx, p1, f1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmPullModel.t.Errorf("OllamaClientInterfaceMock.PullModel got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
+				mmPullModel.t.Errorf("OllamaClientInterfaceMock.PullModel got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
+			}
+
+			if mm_want_ptrs.f1 != nil && !minimock.Equal(*mm_want_ptrs.f1, mm_got.f1) {
+				mmPullModel.t.Errorf("OllamaClientInterfaceMock.PullModel got unexpected parameter f1, want: %#v, got: %#v%s\n", *mm_want_ptrs.f1, mm_got.f1, minimock.Diff(*mm_want_ptrs.f1, mm_got.f1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmPullModel.t.Errorf("OllamaClientInterfaceMock.PullModel got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmPullModel.PullModelMock.defaultExpectation.results
+		if mm_results == nil {
+			mmPullModel.t.Fatal("No results are set for the OllamaClientInterfaceMock.PullModel")
+		}
+		return (*mm_results).p2, (*mm_results).err
+	}
+	if mmPullModel.funcPullModel != nil {
+		return mmPullModel.funcPullModel(ctx, p1, f1)
+	}
+	mmPullModel.t.Fatalf("Unexpected call to OllamaClientInterfaceMock.PullModel. %v %v %v", ctx, p1, f1)
+	return
+}
+
+// PullModelAfterCounter returns a count of finished OllamaClientInterfaceMock.PullModel invocations
+func (mmPullModel *OllamaClientInterfaceMock) PullModelAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPullModel.afterPullModelCounter)
+}
+
+// PullModelBeforeCounter returns a count of OllamaClientInterfaceMock.PullModel invocations
+func (mmPullModel *OllamaClientInterfaceMock) PullModelBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPullModel.beforePullModelCounter)
+}
+
+// Calls returns a list of arguments used in each call to OllamaClientInterfaceMock.PullModel.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmPullModel *mOllamaClientInterfaceMockPullModel) Calls() []*OllamaClientInterfaceMockPullModelParams {
+	mmPullModel.mutex.RLock()
+
+	argCopy := make([]*OllamaClientInterfaceMockPullModelParams, len(mmPullModel.callArgs))
+	copy(argCopy, mmPullModel.callArgs)
+
+	mmPullModel.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockPullModelDone returns true if the count of the PullModel invocations corresponds
+// the number of defined expectations
+func (m *OllamaClientInterfaceMock) MinimockPullModelDone() bool {
+	if m.PullModelMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.PullModelMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.PullModelMock.invocationsDone()
+}
+
+// MinimockPullModelInspect logs each unmet expectation
+func (m *OllamaClientInterfaceMock) MinimockPullModelInspect() {
+	for _, e := range m.PullModelMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.PullModel with params: %#v", *e.params)
+		}
+	}
+
+	afterPullModelCounter := mm_atomic.LoadUint64(&m.afterPullModelCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.PullModelMock.defaultExpectation != nil && afterPullModelCounter < 1 {
+		if m.PullModelMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OllamaClientInterfaceMock.PullModel")
+		} else {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.PullModel with params: %#v", *m.PullModelMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcPullModel != nil && afterPullModelCounter < 1 {
+		m.t.Error("Expected call to OllamaClientInterfaceMock.PullModel")
+	}
+
+	if !m.PullModelMock.invocationsDone() && afterPullModelCounter > 0 {
+		m.t.Errorf("Expected %d calls to OllamaClientInterfaceMock.PullModel but found %d calls",
+			mm_atomic.LoadUint64(&m.PullModelMock.expectedInvocations), afterPullModelCounter)
+	}
+}
+
+type mOllamaClientInterfaceMockShowModel struct {
+	optional           bool
+	mock               *OllamaClientInterfaceMock
+	defaultExpectation *OllamaClientInterfaceMockShowModelExpectation
+	expectations       []*OllamaClientInterfaceMockShowModelExpectation
+
+	callArgs []*OllamaClientInterfaceMockShowModelParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OllamaClientInterfaceMockShowModelExpectation specifies expectation struct of the OllamaClientInterface.ShowModel
+type OllamaClientInterfaceMockShowModelExpectation struct {
+	mock      *OllamaClientInterfaceMock
+	params    *OllamaClientInterfaceMockShowModelParams
+	paramPtrs *OllamaClientInterfaceMockShowModelParamPtrs
+	results   *OllamaClientInterfaceMockShowModelResults
+	Counter   uint64
+}
+
+// OllamaClientInterfaceMockShowModelParams contains parameters of the OllamaClientInterface.ShowModel
+type OllamaClientInterfaceMockShowModelParams struct {
+	s1 ShowModelRequest
+}
+
+// OllamaClientInterfaceMockShowModelParamPtrs contains pointers to parameters of the OllamaClientInterface.ShowModel
+type OllamaClientInterfaceMockShowModelParamPtrs struct {
+	s1 *ShowModelRequest
+}
+
+// OllamaClientInterfaceMockShowModelResults contains results of the OllamaClientInterface.ShowModel
+type OllamaClientInterfaceMockShowModelResults struct {
+	s2  ShowModelResponse
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) Optional() *mOllamaClientInterfaceMockShowModel {
+	mmShowModel.optional = true
+	return mmShowModel
+}
+
+// Expect sets up expected params for OllamaClientInterface.ShowModel
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) Expect(s1 ShowModelRequest) *mOllamaClientInterfaceMockShowModel {
+	if mmShowModel.mock.funcShowModel != nil {
+		mmShowModel.mock.t.Fatalf("OllamaClientInterfaceMock.ShowModel mock is already set by Set")
+	}
+
+	if mmShowModel.defaultExpectation == nil {
+		mmShowModel.defaultExpectation = &OllamaClientInterfaceMockShowModelExpectation{}
+	}
+
+	if mmShowModel.defaultExpectation.paramPtrs != nil {
+		mmShowModel.mock.t.Fatalf("OllamaClientInterfaceMock.ShowModel mock is already set by ExpectParams functions")
+	}
+
+	mmShowModel.defaultExpectation.params = &OllamaClientInterfaceMockShowModelParams{s1}
+	for _, e := range mmShowModel.expectations {
+		if minimock.Equal(e.params, mmShowModel.defaultExpectation.params) {
+			mmShowModel.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmShowModel.defaultExpectation.params)
+		}
+	}
+
+	return mmShowModel
+}
+
+// ExpectS1Param1 sets up expected param s1 for OllamaClientInterface.ShowModel
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) ExpectS1Param1(s1 ShowModelRequest) *mOllamaClientInterfaceMockShowModel {
+	if mmShowModel.mock.funcShowModel != nil {
+		mmShowModel.mock.t.Fatalf("OllamaClientInterfaceMock.ShowModel mock is already set by Set")
+	}
+
+	if mmShowModel.defaultExpectation == nil {
+		mmShowModel.defaultExpectation = &OllamaClientInterfaceMockShowModelExpectation{}
+	}
+
+	if mmShowModel.defaultExpectation.params != nil {
+		mmShowModel.mock.t.Fatalf("OllamaClientInterfaceMock.ShowModel mock is already set by Expect")
+	}
+
+	if mmShowModel.defaultExpectation.paramPtrs == nil {
+		mmShowModel.defaultExpectation.paramPtrs = &OllamaClientInterfaceMockShowModelParamPtrs{}
+	}
+	mmShowModel.defaultExpectation.paramPtrs.s1 = &s1
+
+	return mmShowModel
+}
+
+// Inspect accepts an inspector function that has same arguments as the OllamaClientInterface.ShowModel
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) Inspect(f func(s1 ShowModelRequest)) *mOllamaClientInterfaceMockShowModel {
+	if mmShowModel.mock.inspectFuncShowModel != nil {
+		mmShowModel.mock.t.Fatalf("Inspect function is already set for OllamaClientInterfaceMock.ShowModel")
+	}
+
+	mmShowModel.mock.inspectFuncShowModel = f
+
+	return mmShowModel
+}
+
+// Return sets up results that will be returned by OllamaClientInterface.ShowModel
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) Return(s2 ShowModelResponse, err error) *OllamaClientInterfaceMock {
+	if mmShowModel.mock.funcShowModel != nil {
+		mmShowModel.mock.t.Fatalf("OllamaClientInterfaceMock.ShowModel mock is already set by Set")
+	}
+
+	if mmShowModel.defaultExpectation == nil {
+		mmShowModel.defaultExpectation = &OllamaClientInterfaceMockShowModelExpectation{mock: mmShowModel.mock}
+	}
+	mmShowModel.defaultExpectation.results = &OllamaClientInterfaceMockShowModelResults{s2, err}
+	return mmShowModel.mock
+}
+
+// Set uses given function f to mock the OllamaClientInterface.ShowModel method
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) Set(f func(s1 ShowModelRequest) (s2 ShowModelResponse, err error)) *OllamaClientInterfaceMock {
+	if mmShowModel.defaultExpectation != nil {
+		mmShowModel.mock.t.Fatalf("Default expectation is already set for the OllamaClientInterface.ShowModel method")
+	}
+
+	if len(mmShowModel.expectations) > 0 {
+		mmShowModel.mock.t.Fatalf("Some expectations are already set for the OllamaClientInterface.ShowModel method")
+	}
+
+	mmShowModel.mock.funcShowModel = f
+	return mmShowModel.mock
+}
+
+// When sets expectation for the OllamaClientInterface.ShowModel which will trigger the result defined by the following
+// Then helper
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) When(s1 ShowModelRequest) *OllamaClientInterfaceMockShowModelExpectation {
+	if mmShowModel.mock.funcShowModel != nil {
+		mmShowModel.mock.t.Fatalf("OllamaClientInterfaceMock.ShowModel mock is already set by Set")
+	}
+
+	expectation := &OllamaClientInterfaceMockShowModelExpectation{
+		mock:   mmShowModel.mock,
+		params: &OllamaClientInterfaceMockShowModelParams{s1},
+	}
+	mmShowModel.expectations = append(mmShowModel.expectations, expectation)
+	return expectation
+}
+
+// Then sets up OllamaClientInterface.ShowModel return parameters for the expectation previously defined by the When method
+func (e *OllamaClientInterfaceMockShowModelExpectation) Then(s2 ShowModelResponse, err error) *OllamaClientInterfaceMock {
+	e.results = &OllamaClientInterfaceMockShowModelResults{s2, err}
+	return e.mock
+}
+
+// Times sets number of times OllamaClientInterface.ShowModel should be invoked
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) Times(n uint64) *mOllamaClientInterfaceMockShowModel {
+	if n == 0 {
+		mmShowModel.mock.t.Fatalf("Times of OllamaClientInterfaceMock.ShowModel mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmShowModel.expectedInvocations, n)
+	return mmShowModel
+}
+
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) invocationsDone() bool {
+	if len(mmShowModel.expectations) == 0 && mmShowModel.defaultExpectation == nil && mmShowModel.mock.funcShowModel == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmShowModel.mock.afterShowModelCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmShowModel.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ShowModel implements OllamaClientInterface
+func (mmShowModel *OllamaClientInterfaceMock) ShowModel(s1 ShowModelRequest) (s2 ShowModelResponse, err error) {
+	mm_atomic.AddUint64(&mmShowModel.beforeShowModelCounter, 1)
+	defer mm_atomic.AddUint64(&mmShowModel.afterShowModelCounter, 1)
+
+	if mmShowModel.inspectFuncShowModel != nil {
+		mmShowModel.inspectFuncShowModel(s1)
+	}
+
+	mm_params := OllamaClientInterfaceMockShowModelParams{s1}
+
+	// Record call args
+	mmShowModel.ShowModelMock.mutex.Lock()
+	mmShowModel.ShowModelMock.callArgs = append(mmShowModel.ShowModelMock.callArgs, &mm_params)
+	mmShowModel.ShowModelMock.mutex.Unlock()
+
+	for _, e := range mmShowModel.ShowModelMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.s2, e.results.err
+		}
+	}
+
+	if mmShowModel.ShowModelMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmShowModel.ShowModelMock.defaultExpectation.Counter, 1)
+		mm_want := mmShowModel.ShowModelMock.defaultExpectation.params
+		mm_want_ptrs := mmShowModel.ShowModelMock.defaultExpectation.paramPtrs
+
+		mm_got := OllamaClientInterfaceMockShowModelParams{s1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.s1 != nil && !minimock.Equal(*mm_want_ptrs.s1, mm_got.s1) {
+				mmShowModel.t.Errorf("OllamaClientInterfaceMock.ShowModel got unexpected parameter s1, want: %#v, got: %#v%s\n", *mm_want_ptrs.s1, mm_got.s1, minimock.Diff(*mm_want_ptrs.s1, mm_got.s1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmShowModel.t.Errorf("OllamaClientInterfaceMock.ShowModel got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmShowModel.ShowModelMock.defaultExpectation.results
+		if mm_results == nil {
+			mmShowModel.t.Fatal("No results are set for the OllamaClientInterfaceMock.ShowModel")
+		}
+		return (*mm_results).s2, (*mm_results).err
+	}
+	if mmShowModel.funcShowModel != nil {
+		return mmShowModel.funcShowModel(s1)
+	}
+	mmShowModel.t.Fatalf("Unexpected call to OllamaClientInterfaceMock.ShowModel. %v", s1)
+	return
+}
+
+// ShowModelAfterCounter returns a count of finished OllamaClientInterfaceMock.ShowModel invocations
+func (mmShowModel *OllamaClientInterfaceMock) ShowModelAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmShowModel.afterShowModelCounter)
+}
+
+// ShowModelBeforeCounter returns a count of OllamaClientInterfaceMock.ShowModel invocations
+func (mmShowModel *OllamaClientInterfaceMock) ShowModelBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmShowModel.beforeShowModelCounter)
+}
+
+// Calls returns a list of arguments used in each call to OllamaClientInterfaceMock.ShowModel.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmShowModel *mOllamaClientInterfaceMockShowModel) Calls() []*OllamaClientInterfaceMockShowModelParams {
+	mmShowModel.mutex.RLock()
+
+	argCopy := make([]*OllamaClientInterfaceMockShowModelParams, len(mmShowModel.callArgs))
+	copy(argCopy, mmShowModel.callArgs)
+
+	mmShowModel.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockShowModelDone returns true if the count of the ShowModel invocations corresponds
+// the number of defined expectations
+func (m *OllamaClientInterfaceMock) MinimockShowModelDone() bool {
+	if m.ShowModelMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ShowModelMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ShowModelMock.invocationsDone()
+}
+
+// MinimockShowModelInspect logs each unmet expectation
+func (m *OllamaClientInterfaceMock) MinimockShowModelInspect() {
+	for _, e := range m.ShowModelMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.ShowModel with params: %#v", *e.params)
+		}
+	}
+
+	afterShowModelCounter := mm_atomic.LoadUint64(&m.afterShowModelCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ShowModelMock.defaultExpectation != nil && afterShowModelCounter < 1 {
+		if m.ShowModelMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OllamaClientInterfaceMock.ShowModel")
+		} else {
+			m.t.Errorf("Expected call to OllamaClientInterfaceMock.ShowModel with params: %#v", *m.ShowModelMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcShowModel != nil && afterShowModelCounter < 1 {
+		m.t.Error("Expected call to OllamaClientInterfaceMock.ShowModel")
+	}
+
+	if !m.ShowModelMock.invocationsDone() && afterShowModelCounter > 0 {
+		m.t.Errorf("Expected %d calls to OllamaClientInterfaceMock.ShowModel but found %d calls",
+			mm_atomic.LoadUint64(&m.ShowModelMock.expectedInvocations), afterShowModelCounter)
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *OllamaClientInterfaceMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
 			m.MinimockChatInspect()
 
+			m.MinimockDeleteModelInspect()
+
 			m.MinimockEmbedInspect()
 
+			m.MinimockEmbedBatchInspect()
+
 			m.MinimockIsAutoPullInspect()
+
+			m.MinimockPullModelInspect()
+
+			m.MinimockShowModelInspect()
 		}
 	})
 }
@@ -856,6 +2129,10 @@ func (m *OllamaClientInterfaceMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockChatDone() &&
+		m.MinimockDeleteModelDone() &&
 		m.MinimockEmbedDone() &&
-		m.MinimockIsAutoPullDone()
+		m.MinimockEmbedBatchDone() &&
+		m.MinimockIsAutoPullDone() &&
+		m.MinimockPullModelDone() &&
+		m.MinimockShowModelDone()
 }
@@ -1,8 +1,12 @@
 package ollama
 
 import (
+	"context"
+	"fmt"
+
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/instill-ai/component/ai"
 	"github.com/instill-ai/component/base"
 )
 
@@ -16,11 +20,29 @@ type TaskTextGenerationChatInput struct {
 	SystemMsg    string        `json:"system-message"`
 	Temperature  float32       `json:"temperature"`
 	TopK         int           `json:"top-k"`
+	NumCtx       int           `json:"num-ctx"`
+	Format       string        `json:"format"`
+	KeepAlive    string        `json:"keep-alive"`
+	Tools        []Tool        `json:"tools"`
 }
 
 type ChatMessage struct {
-	Role    string              `json:"role"`
-	Content []MultiModalContent `json:"content"`
+	Role      string              `json:"role"`
+	Content   []MultiModalContent `json:"content"`
+	ToolCalls []ToolCall          `json:"tool-calls"`
+}
+
+// Tool is a function that the model may call. The results of the calls are
+// sent back in the chat history as messages with the "tool" role.
+type Tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+type ToolCall struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
 }
 
 type MultiModalContent struct {
@@ -34,10 +56,11 @@ type URL struct {
 }
 
 type TaskTextGenerationChatOuput struct {
-	Text string `json:"text"`
+	Text      string     `json:"text"`
+	ToolCalls []ToolCall `json:"tool-calls,omitempty"`
 }
 
-func (e *execution) TaskTextGenerationChat(in *structpb.Struct) (*structpb.Struct, error) {
+func (e *execution) TaskTextGenerationChat(in *structpb.Struct, _ *base.Job, _ context.Context) (*structpb.Struct, error) {
 	input := TaskTextGenerationChatInput{}
 	if err := base.ConvertFromStructpb(in, &input); err != nil {
 		return nil, err
@@ -61,11 +84,17 @@ func (e *execution) TaskTextGenerationChat(in *structpb.Struct) (*structpb.Struc
 				imageContent = append(imageContent, base.TrimBase64Mime(content.ImageURL.URL))
 			}
 		}
-		messages = append(messages, OllamaChatMessage{
+		message := OllamaChatMessage{
 			Role:    msg.Role,
 			Content: textContent,
 			Images:  imageContent,
-		})
+		}
+		for _, tc := range msg.ToolCalls {
+			message.ToolCalls = append(message.ToolCalls, OllamaToolCall{
+				Function: OllamaToolCallFunction{Name: tc.Name, Arguments: tc.Arguments},
+			})
+		}
+		messages = append(messages, message)
 	}
 
 	images := []string{}
@@ -88,7 +117,28 @@ func (e *execution) TaskTextGenerationChat(in *structpb.Struct) (*structpb.Struc
 			Temperature: input.Temperature,
 			TopK:        input.TopK,
 			Seed:        input.Seed,
+			NumCtx:      input.NumCtx,
 		},
+		KeepAlive: input.KeepAlive,
+	}
+
+	switch input.Format {
+	case "", "text":
+	case "json":
+		request.Format = input.Format
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", input.Format)
+	}
+
+	for _, t := range input.Tools {
+		request.Tools = append(request.Tools, OllamaTool{
+			Type: "function",
+			Function: OllamaToolFunction{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Parameters,
+			},
+		})
 	}
 
 	response, err := e.client.Chat(request)
@@ -99,24 +149,50 @@ func (e *execution) TaskTextGenerationChat(in *structpb.Struct) (*structpb.Struc
 	output := TaskTextGenerationChatOuput{
 		Text: response.Message.Content,
 	}
+	for _, tc := range response.Message.ToolCalls {
+		output.ToolCalls = append(output.ToolCalls, ToolCall{
+			Name:      tc.Function.Name,
+			Arguments: tc.Function.Arguments,
+		})
+	}
 	return base.ConvertToStructpb(output)
 }
 
 type TaskTextEmbeddingsInput struct {
-	Text  string `json:"text"`
-	Model string `json:"model"`
+	Text      string   `json:"text"`
+	Texts     []string `json:"texts"`
+	Model     string   `json:"model"`
+	KeepAlive string   `json:"keep-alive"`
 }
 
 type TaskTextEmbeddingsOutput struct {
-	Embedding []float32 `json:"embedding"`
+	Embedding  []float32   `json:"embedding,omitempty"`
+	Embeddings [][]float32 `json:"embeddings,omitempty"`
 }
 
-func (e *execution) TaskTextEmbeddings(in *structpb.Struct) (*structpb.Struct, error) {
+func (e *execution) TaskTextEmbeddings(in *structpb.Struct, _ *base.Job, _ context.Context) (*structpb.Struct, error) {
 	input := TaskTextEmbeddingsInput{}
 	if err := base.ConvertFromStructpb(in, &input); err != nil {
 		return nil, err
 	}
 
+	if len(input.Texts) > 0 {
+		if input.Text != "" {
+			return nil, fmt.Errorf("only one of text and texts can be provided")
+		}
+
+		response, err := e.client.EmbedBatch(EmbedBatchRequest{
+			Model:     input.Model,
+			Input:     input.Texts,
+			KeepAlive: input.KeepAlive,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return base.ConvertToStructpb(TaskTextEmbeddingsOutput{Embeddings: response.Embeddings})
+	}
+
 	request := EmbedRequest{
 		Model:  input.Model,
 		Prompt: input.Text,
@@ -127,6 +203,112 @@ func (e *execution) TaskTextEmbeddings(in *structpb.Struct) (*structpb.Struct, e
 		return nil, err
 	}
 
-	output := TaskTextEmbeddingsOutput(response)
+	output := TaskTextEmbeddingsOutput{Embedding: response.Embedding}
+	return base.ConvertToStructpb(output)
+}
+
+type TaskPullModelInput struct {
+	Model    string `json:"model"`
+	Insecure bool   `json:"insecure"`
+}
+
+type TaskPullModelOutput struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+// TaskPullModel downloads a model, streaming the progress of the download
+// as partial outputs.
+func (e *execution) TaskPullModel(in *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	input := TaskPullModelInput{}
+	if err := base.ConvertFromStructpb(in, &input); err != nil {
+		return nil, err
+	}
+
+	w := ai.NewStreamWriter(ctx, job)
+	request := PullModelRequest{Name: input.Model, Insecure: input.Insecure}
+	response, err := e.client.PullModel(ctx, request, func(progress PullModelResponse) error {
+		return w.Update(TaskPullModelOutput(progress))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(TaskPullModelOutput(response))
+}
+
+type TaskDeleteModelInput struct {
+	Model string `json:"model"`
+}
+
+type TaskDeleteModelOutput struct {
+	Status string `json:"status"`
+}
+
+func (e *execution) TaskDeleteModel(in *structpb.Struct, _ *base.Job, _ context.Context) (*structpb.Struct, error) {
+	input := TaskDeleteModelInput{}
+	if err := base.ConvertFromStructpb(in, &input); err != nil {
+		return nil, err
+	}
+
+	if _, err := e.client.DeleteModel(DeleteModelRequest{Name: input.Model}); err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(TaskDeleteModelOutput{Status: "success"})
+}
+
+type TaskShowModelInput struct {
+	Model   string `json:"model"`
+	Verbose bool   `json:"verbose"`
+}
+
+type TaskShowModelOutput struct {
+	License    string             `json:"license"`
+	Modelfile  string             `json:"modelfile"`
+	Parameters string             `json:"parameters"`
+	Template   string             `json:"template"`
+	Details    ModelDetailsOutput `json:"details"`
+	ModelInfo  map[string]any     `json:"model-info"`
+	ModifiedAt string             `json:"modified-at"`
+}
+
+type ModelDetailsOutput struct {
+	ParentModel       string   `json:"parent-model"`
+	Format            string   `json:"format"`
+	Family            string   `json:"family"`
+	Families          []string `json:"families"`
+	ParameterSize     string   `json:"parameter-size"`
+	QuantizationLevel string   `json:"quantization-level"`
+}
+
+func (e *execution) TaskShowModel(in *structpb.Struct, _ *base.Job, _ context.Context) (*structpb.Struct, error) {
+	input := TaskShowModelInput{}
+	if err := base.ConvertFromStructpb(in, &input); err != nil {
+		return nil, err
+	}
+
+	response, err := e.client.ShowModel(ShowModelRequest{Name: input.Model, Verbose: input.Verbose})
+	if err != nil {
+		return nil, err
+	}
+
+	output := TaskShowModelOutput{
+		License:    response.License,
+		Modelfile:  response.Modelfile,
+		Parameters: response.Parameters,
+		Template:   response.Template,
+		Details:    ModelDetailsOutput(response.Details),
+		ModelInfo:  response.ModelInfo,
+		ModifiedAt: response.ModifiedAt,
+	}
+	if output.ModelInfo == nil {
+		output.ModelInfo = map[string]any{}
+	}
+	if output.Details.Families == nil {
+		output.Details.Families = []string{}
+	}
 	return base.ConvertToStructpb(output)
 }
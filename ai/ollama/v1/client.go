@@ -0,0 +1,89 @@
+package ollamav1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const apiName = "Ollama"
+
+// reference: https://github.com/ollama/ollama/blob/main/docs/api.md
+
+// Client calls the API of an Ollama server.
+type Client struct {
+	httpClient httpclient.IClient
+	autoPull   bool
+}
+
+type setup struct {
+	Endpoint string `json:"endpoint"`
+	AutoPull bool   `json:"auto-pull"`
+}
+
+// NewClient returns a client for the Ollama server of the setup.
+func NewClient(s *structpb.Struct, logger *zap.Logger) (*Client, error) {
+	setupStruct := setup{}
+	if err := base.ConvertFromStructpb(s, &setupStruct); err != nil {
+		return nil, fmt.Errorf("error parsing setup, %v", err)
+	}
+
+	c := httpclient.New(apiName, setupStruct.Endpoint,
+		httpclient.WithLogger(logger),
+		httpclient.WithEndUserError(new(errBody)),
+	)
+
+	return &Client{httpClient: c, autoPull: setupStruct.AutoPull}, nil
+}
+
+type errBody struct {
+	Msg string `json:"error"`
+}
+
+func (e errBody) Message() string {
+	return e.Msg
+}
+
+type listModelsResp struct {
+	Models []modelInfo `json:"models"`
+}
+
+type modelInfo struct {
+	Name string `json:"name"`
+}
+
+type pullReq struct {
+	Name   string `json:"name"`
+	Stream bool   `json:"stream"`
+}
+
+// ensureModel checks that the model is available on the server and, if it
+// isn't, pulls it when the client is configured to do so.
+func (c *Client) ensureModel(ctx context.Context, model string) error {
+	resp := listModelsResp{}
+	if _, err := c.httpClient.R().SetContext(ctx).SetResult(&resp).Get("/api/tags"); err != nil {
+		return err
+	}
+
+	// Models without a tag reference their latest version.
+	if slices.ContainsFunc(resp.Models, func(m modelInfo) bool {
+		return m.Name == model || m.Name == model+":latest"
+	}) {
+		return nil
+	}
+
+	if !c.autoPull {
+		return fmt.Errorf("model %s is not available", model)
+	}
+
+	if _, err := c.httpClient.R().SetContext(ctx).SetBody(pullReq{Name: model}).Post("/api/pull"); err != nil {
+		return fmt.Errorf("error when auto pulling model %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package ollamav1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	chatResponse = `{
+  "created_at": "2024-07-17T10:00:00Z",
+  "message": {"role": "assistant", "content": "Ahoy!"},
+  "done": true,
+  "done_reason": "stop",
+  "prompt_eval_count": 12,
+  "eval_count": 3
+}`
+
+	streamResponse = `{"created_at": "2024-07-17T10:00:00Z", "message": {"role": "assistant", "content": "Ah"}, "done": false}
+{"created_at": "2024-07-17T10:00:01Z", "message": {"role": "assistant", "content": "oy!"}, "done": false}
+{"created_at": "2024-07-17T10:00:01Z", "message": {"role": "assistant", "content": ""}, "done": true, "done_reason": "length", "prompt_eval_count": 12, "eval_count": 3}
+`
+)
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	// A 1x1 PNG image.
+	png := "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg=="
+
+	testcases := []struct {
+		name       string
+		model      string
+		autoPull   bool
+		parameter  map[string]any
+		resp       string
+		wantReq    string
+		wantPull   bool
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:      "ok",
+			model:     "llava",
+			parameter: map[string]any{"max-tokens": 50, "n": 2},
+			resp:      chatResponse,
+			wantReq: `{
+  "model": "llava",
+  "messages": [
+    {"role": "system", "content": "You are a pirate."},
+    {"role": "user", "content": "What's on this flag?", "images": ["` + png + `"]}
+  ],
+  "stream": false,
+  "options": {"num_predict": 50}
+}`,
+			wantOutput: `{
+  "data": {"choices": [
+    {"finish-reason": "stop", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}, "created": 1721210400},
+    {"finish-reason": "stop", "index": 1, "message": {"content": "Ahoy!", "role": "assistant"}, "created": 1721210400}
+  ]},
+  "metadata": {"usage": {"completion-tokens": 6, "prompt-tokens": 24, "total-tokens": 30}}
+}`,
+		},
+		{
+			name:      "ok - stream and auto-pull",
+			model:     "moondream",
+			autoPull:  true,
+			parameter: map[string]any{"stream": true, "seed": 42},
+			resp:      streamResponse,
+			wantPull:  true,
+			wantReq: `{
+  "model": "moondream",
+  "messages": [
+    {"role": "system", "content": "You are a pirate."},
+    {"role": "user", "content": "What's on this flag?", "images": ["` + png + `"]}
+  ],
+  "stream": true,
+  "options": {"seed": 42}
+}`,
+			wantOutput: `{
+  "data": {"choices": [{"finish-reason": "length", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}, "created": 1721210400}]},
+  "metadata": {"usage": {"completion-tokens": 3, "prompt-tokens": 12, "total-tokens": 15}}
+}`,
+		},
+		{
+			name:    "nok - model not available",
+			model:   "moondream",
+			wantErr: "model moondream is not available",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			var pulled bool
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/tags", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprintln(w, `{"models": [{"name": "llava:latest"}]}`)
+			})
+			mux.HandleFunc("POST /api/pull", func(w http.ResponseWriter, r *http.Request) {
+				pulled = true
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprintln(w, `{"status": "success"}`)
+			})
+			mux.HandleFunc("POST "+chatPath, func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, json.RawMessage(tc.wantReq))
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprint(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(mux)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"endpoint":  srv.URL,
+				"auto-pull": tc.autoPull,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      TextChatTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{
+					"model": tc.model,
+					"messages": []any{
+						map[string]any{"role": "system", "content": []any{map[string]any{"type": "text", "text": "You are a pirate."}}},
+						map[string]any{"role": "user", "content": []any{
+							map[string]any{"type": "text", "text": "What's on this flag?"},
+							map[string]any{"type": "image-base64", "image-base64": "data:image/png;base64," + png},
+						}},
+					},
+				},
+				"parameter": tc.parameter,
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				c.Check(tc.wantOutput, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+			c.Check(pulled, qt.Equals, tc.wantPull)
+		})
+	}
+}
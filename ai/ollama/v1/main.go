@@ -0,0 +1,78 @@
+//go:generate compogen readme ./config ./README.mdx --extraContents TASK_CHAT=.compogen/setup-hosting.mdx
+package ollamav1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	_ "embed"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	TextChatTask = "TASK_CHAT"
+)
+
+var (
+	//go:embed config/definition.json
+	definitionJSON []byte
+	//go:embed config/setup.json
+	setupJSON []byte
+	//go:embed config/tasks.json
+	tasksJSON []byte
+
+	once sync.Once
+	comp *component
+)
+
+type component struct {
+	base.Component
+}
+
+type execution struct {
+	base.ComponentExecution
+	client  *Client
+	execute func(*structpb.Struct, *base.Job, context.Context) (*structpb.Struct, error)
+}
+
+// Init returns an initialized Ollama component.
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{Component: bc}
+		err := comp.LoadDefinition(definitionJSON, setupJSON, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	return comp
+}
+
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	client, err := NewClient(x.Setup, x.GetLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	e := &execution{
+		ComponentExecution: x,
+		client:             client,
+	}
+
+	switch x.Task {
+	case TextChatTask:
+		e.execute = e.executeTextChat
+	default:
+		return nil, fmt.Errorf("unknown task: %s", x.Task)
+	}
+
+	return e, nil
+}
+
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	return base.ConcurrentExecutor(ctx, jobs, e.execute)
+}
@@ -0,0 +1,194 @@
+package ollamav1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	chatPath = "/api/chat"
+
+	// maxChunkSize is the maximum size of a line of a streamed response.
+	maxChunkSize = 1024 * 1024
+)
+
+type chatReq struct {
+	Model    string    `json:"model"`
+	Messages []message `json:"messages"`
+	Stream   bool      `json:"stream"`
+	Options  options   `json:"options"`
+}
+
+type message struct {
+	Role    string   `json:"role"`
+	Content string   `json:"content"`
+	Images  []string `json:"images,omitempty"`
+}
+
+type options struct {
+	NumPredict  *int     `json:"num_predict,omitempty"`
+	Seed        *int     `json:"seed,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+	TopP        *float32 `json:"top_p,omitempty"`
+}
+
+// chatResp is the response of a chat request, or a chunk of it when it is
+// streamed. The last chunk holds the finish reason and the token counts.
+type chatResp struct {
+	CreatedAt       time.Time `json:"created_at"`
+	Message         message   `json:"message"`
+	Done            bool      `json:"done"`
+	DoneReason      string    `json:"done_reason"`
+	PromptEvalCount int       `json:"prompt_eval_count"`
+	EvalCount       int       `json:"eval_count"`
+}
+
+func (e *execution) executeTextChat(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.TextChatInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to TextChatInput: %w", err)
+	}
+
+	return ExecuteTextChat(inputStruct, e.client, job, ctx)
+}
+
+// ExecuteTextChat generates the chat completion of the input with an Ollama
+// client. Ollama generates a single message per request, so a request is sent
+// for each of the requested choices.
+func ExecuteTextChat(input ai.TextChatInput, client *Client, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	req, err := buildRequest(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.ensureModel(ctx, req.Model); err != nil {
+		return nil, err
+	}
+
+	output := ai.TextChatOutput{Data: ai.OutputData{Choices: []ai.Choice{}}}
+	w := ai.NewStreamWriter(ctx, job)
+	for i := range input.Parameter.NumChoices() {
+		output.Data.Choices = append(output.Data.Choices, ai.Choice{
+			Index:   i,
+			Message: ai.OutputMessage{Role: ai.RoleAssistant},
+		})
+
+		if req.Stream {
+			err = stream(ctx, client.httpClient, req, &output, w)
+		} else {
+			err = send(ctx, client.httpClient, req, &output)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+func buildRequest(input ai.TextChatInput) (chatReq, error) {
+	p := input.Parameter
+	req := chatReq{
+		Model:    input.Data.Model,
+		Messages: make([]message, len(input.Data.Messages)),
+		Stream:   p.Stream,
+		Options: options{
+			NumPredict:  p.MaxTokens,
+			Seed:        p.Seed,
+			Temperature: p.Temperature,
+			TopP:        p.TopP,
+		},
+	}
+
+	for i, m := range input.Data.Messages {
+		req.Messages[i] = message{Role: m.Role, Content: m.Text()}
+		for _, c := range m.Contents {
+			switch c.Type {
+			case ai.ContentTypeImageBase64:
+				req.Messages[i].Images = append(req.Messages[i].Images, base.TrimBase64Mime(c.ImageBase64))
+			case ai.ContentTypeImageURL:
+				return req, fmt.Errorf("image URLs aren't supported, images must be base64-encoded")
+			}
+		}
+	}
+
+	return req, nil
+}
+
+func send(ctx context.Context, client httpclient.IClient, req chatReq, output *ai.TextChatOutput) error {
+	resp := chatResp{}
+	if _, err := client.R().SetContext(ctx).SetBody(req).SetResult(&resp).Post(chatPath); err != nil {
+		return err
+	}
+
+	addChunk(output, resp)
+	return nil
+}
+
+// stream streams the response into the last choice of the output. The chunks
+// of the response are newline-delimited JSON objects.
+func stream(ctx context.Context, client httpclient.IClient, req chatReq, output *ai.TextChatOutput, w *ai.StreamWriter) error {
+	resp, err := client.R().SetContext(ctx).SetBody(req).SetDoNotParseResponse(true).Post(chatPath)
+	if err != nil {
+		return err
+	}
+	if err := httpclient.UnparsedResponseError(apiName, resp, new(errBody)); err != nil {
+		return err
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxChunkSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		chunk := chatResp{}
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return fmt.Errorf("unmarshalling chat chunk: %w", err)
+		}
+
+		addChunk(output, chunk)
+		if err := w.Update(*output); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading chat stream: %w", err)
+	}
+	return nil
+}
+
+// addChunk adds a response, or a chunk of it, to the last choice of the
+// output. Ollama's done reasons are already the shared finish reasons.
+func addChunk(output *ai.TextChatOutput, chunk chatResp) {
+	choice := &output.Data.Choices[len(output.Data.Choices)-1]
+	choice.Message.Content += chunk.Message.Content
+	if choice.Created == 0 {
+		choice.Created = int(chunk.CreatedAt.Unix())
+	}
+
+	if !chunk.Done {
+		return
+	}
+
+	choice.FinishReason = chunk.DoneReason
+	output.Metadata.Usage.Add(ai.Usage{
+		CompletionTokens: chunk.EvalCount,
+		PromptTokens:     chunk.PromptEvalCount,
+		TotalTokens:      chunk.PromptEvalCount + chunk.EvalCount,
+	})
+}
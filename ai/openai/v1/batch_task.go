@@ -0,0 +1,509 @@
+package openaiv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	filesPath       = "/v1/files"
+	fileContentPath = "/v1/files/{id}/content"
+	batchesPath     = "/v1/batches"
+	batchPath       = "/v1/batches/{id}"
+
+	// batchCompletionWindow is the time frame in which the batch is
+	// processed. It's the only window supported by OpenAI.
+	batchCompletionWindow = "24h"
+)
+
+// batchEndpoints maps the tasks that can be batched to their endpoints.
+var batchEndpoints = map[string]string{
+	TextChatTask:  completionsPath,
+	EmbeddingTask: embeddingsPath,
+}
+
+// CreateBatchInput is the input of the batch creation task. Each request
+// holds the input of a chat or embedding task, in the same format as the
+// synchronous tasks.
+type CreateBatchInput struct {
+	Data      CreateBatchData      `json:"data"`
+	Parameter CreateBatchParameter `json:"parameter,omitempty"`
+}
+
+type CreateBatchData struct {
+	Task     string         `json:"task"`
+	Requests []BatchRequest `json:"requests"`
+}
+
+type BatchRequest struct {
+	// CustomID identifies the request in the batch results.
+	CustomID string          `json:"custom-id"`
+	Input    json.RawMessage `json:"input"`
+}
+
+type CreateBatchParameter struct {
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// BatchInput is the input of the tasks that read a batch.
+type BatchInput struct {
+	Data struct {
+		BatchID string `json:"batch-id"`
+	} `json:"data"`
+}
+
+type BatchOutput struct {
+	Data Batch `json:"data"`
+}
+
+type Batch struct {
+	BatchID       string             `json:"batch-id"`
+	Status        string             `json:"status"`
+	Endpoint      string             `json:"endpoint"`
+	InputFileID   string             `json:"input-file-id"`
+	OutputFileID  string             `json:"output-file-id,omitempty"`
+	ErrorFileID   string             `json:"error-file-id,omitempty"`
+	CreatedAt     int                `json:"created-at"`
+	CompletedAt   int                `json:"completed-at,omitempty"`
+	ExpiresAt     int                `json:"expires-at,omitempty"`
+	RequestCounts BatchRequestCounts `json:"request-counts"`
+	// Errors holds the validation errors of the input file.
+	Errors   []string          `json:"errors,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type BatchRequestCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// BatchResultsOutput holds the results of a batch, in the same order as the
+// batched requests.
+type BatchResultsOutput struct {
+	Data BatchResultsData `json:"data"`
+}
+
+type BatchResultsData struct {
+	BatchID string        `json:"batch-id"`
+	Status  string        `json:"status"`
+	Results []BatchResult `json:"results"`
+}
+
+type BatchResult struct {
+	CustomID   string `json:"custom-id"`
+	StatusCode int    `json:"status-code,omitempty"`
+	// Output is the output of the batched task, in the same format as the
+	// synchronous task.
+	Output any    `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (e *execution) ExecuteCreateBatch(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := CreateBatchInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to CreateBatchInput: %w", err)
+	}
+
+	return ExecuteCreateBatch(inputStruct, e.client, ctx)
+}
+
+func (e *execution) ExecuteGetBatch(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := BatchInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to BatchInput: %w", err)
+	}
+
+	return ExecuteGetBatch(inputStruct, e.client, ctx)
+}
+
+func (e *execution) ExecuteGetBatchResults(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := BatchInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to BatchInput: %w", err)
+	}
+
+	return ExecuteGetBatchResults(inputStruct, e.client, ctx)
+}
+
+// ExecuteCreateBatch builds the input file of a batch from the requests,
+// uploads it and creates the batch. The batch is processed asynchronously
+// by OpenAI.
+func ExecuteCreateBatch(input CreateBatchInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	endpoint, ok := batchEndpoints[input.Data.Task]
+	if !ok {
+		return nil, fmt.Errorf("unsupported batch task: %s", input.Data.Task)
+	}
+	if len(input.Data.Requests) == 0 {
+		return nil, fmt.Errorf("no request to batch")
+	}
+
+	content := &bytes.Buffer{}
+	enc := json.NewEncoder(content)
+	customIDs := make(map[string]bool, len(input.Data.Requests))
+	for i, r := range input.Data.Requests {
+		if r.CustomID == "" {
+			return nil, fmt.Errorf("request %d has no custom ID", i)
+		}
+		if customIDs[r.CustomID] {
+			return nil, fmt.Errorf("duplicate custom ID: %s", r.CustomID)
+		}
+		customIDs[r.CustomID] = true
+
+		body, err := buildBatchBody(input.Data.Task, r.Input)
+		if err != nil {
+			return nil, fmt.Errorf("request %s: %w", r.CustomID, err)
+		}
+
+		line := batchLine{CustomID: r.CustomID, Method: http.MethodPost, URL: endpoint, Body: body}
+		if err := enc.Encode(line); err != nil {
+			return nil, err
+		}
+	}
+
+	fileID, err := uploadBatchFile(ctx, client, content.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := createBatchReq{
+		InputFileID:      fileID,
+		Endpoint:         endpoint,
+		CompletionWindow: batchCompletionWindow,
+		Metadata:         input.Parameter.Metadata,
+	}
+
+	resp := batchResp{}
+	req := client.R().SetContext(ctx).SetResult(&resp).SetBody(createReq)
+	if _, err := req.Post(batchesPath); err != nil {
+		return nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	return base.ConvertToStructpb(BatchOutput{Data: convertBatchResp(resp)})
+}
+
+// ExecuteGetBatch returns the status of a batch.
+func ExecuteGetBatch(input BatchInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	resp, err := getBatch(ctx, client, input.Data.BatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(BatchOutput{Data: convertBatchResp(resp)})
+}
+
+// ExecuteGetBatchResults downloads the output and error files of a batch and
+// converts each result to the output of the batched task. As the results
+// aren't sorted, they are aligned with the requests in the input file.
+func ExecuteGetBatchResults(input BatchInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	b, err := getBatch(ctx, client, input.Data.BatchID)
+	if err != nil {
+		return nil, err
+	}
+	if b.OutputFileID == "" && b.ErrorFileID == "" {
+		return nil, fmt.Errorf("batch %s has no results yet, its status is %s", b.ID, b.Status)
+	}
+
+	output := BatchResultsOutput{Data: BatchResultsData{BatchID: b.ID, Status: b.Status, Results: []BatchResult{}}}
+	positions := map[string]int{}
+	err = readFileLines(ctx, client, b.InputFileID, func(l batchLine) error {
+		positions[l.CustomID] = len(output.Data.Results)
+		output.Data.Results = append(output.Data.Results, BatchResult{
+			CustomID: l.CustomID,
+			Error:    "the request has no result",
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fileID := range []string{b.OutputFileID, b.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+
+		err := readFileLines(ctx, client, fileID, func(l batchResultLine) error {
+			i, ok := positions[l.CustomID]
+			if !ok {
+				return fmt.Errorf("unknown custom ID in batch results: %s", l.CustomID)
+			}
+
+			r, err := convertBatchResult(b, l)
+			if err != nil {
+				return fmt.Errorf("converting result %s: %w", l.CustomID, err)
+			}
+			output.Data.Results[i] = r
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+func buildBatchBody(task string, input json.RawMessage) (any, error) {
+	switch task {
+	case TextChatTask:
+		chatInput := ai.TextChatInput{}
+		if err := json.Unmarshal(input, &chatInput); err != nil {
+			return nil, fmt.Errorf("invalid chat input: %w", err)
+		}
+
+		// The batched requests can't be streamed.
+		chatInput.Parameter.Stream = false
+		return convertToTextChatReq(chatInput), nil
+	case EmbeddingTask:
+		embeddingInput := ai.EmbeddingInput{}
+		if err := json.Unmarshal(input, &embeddingInput); err != nil {
+			return nil, fmt.Errorf("invalid embedding input: %w", err)
+		}
+
+		return buildEmbeddingReq(embeddingInput)
+	default:
+		return nil, fmt.Errorf("unsupported batch task: %s", task)
+	}
+}
+
+// uploadBatchFile uploads the JSONL input file of a batch and returns its
+// ID.
+func uploadBatchFile(ctx context.Context, client httpclient.IClient, content []byte) (string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	util.WriteField(writer, "purpose", "batch")
+
+	// OpenAI requires the .jsonl extension, which can't be detected from the
+	// file content.
+	part, err := writer.CreateFormFile("file", "batch.jsonl")
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(content); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	resp := fileResp{}
+	req := client.R().SetContext(ctx).
+		SetBody(body.Bytes()).
+		SetHeader("Content-Type", writer.FormDataContentType()).
+		SetResult(&resp)
+	if _, err := req.Post(filesPath); err != nil {
+		return "", fmt.Errorf("failed to upload batch file: %w", err)
+	}
+
+	return resp.ID, nil
+}
+
+func getBatch(ctx context.Context, client httpclient.IClient, id string) (batchResp, error) {
+	if id == "" {
+		return batchResp{}, fmt.Errorf("no batch ID")
+	}
+
+	resp := batchResp{}
+	req := client.R().SetContext(ctx).SetResult(&resp).SetPathParam("id", id)
+	if _, err := req.Get(batchPath); err != nil {
+		return batchResp{}, fmt.Errorf("failed to get batch: %w", err)
+	}
+
+	return resp, nil
+}
+
+// readFileLines downloads a JSONL file and decodes its lines one by one, so
+// large files aren't held in memory.
+func readFileLines[T any](ctx context.Context, client httpclient.IClient, fileID string, handle func(T) error) error {
+	resp, err := client.R().SetContext(ctx).
+		SetPathParam("id", fileID).
+		SetDoNotParseResponse(true).
+		Get(fileContentPath)
+	if err != nil {
+		return fmt.Errorf("failed to download file %s: %w", fileID, err)
+	}
+	if err := httpclient.UnparsedResponseError(apiName, resp, new(errBody)); err != nil {
+		return err
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	for {
+		var line T
+		err := dec.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decoding file %s: %w", fileID, err)
+		}
+
+		if err := handle(line); err != nil {
+			return err
+		}
+	}
+}
+
+func convertBatchResult(b batchResp, l batchResultLine) (BatchResult, error) {
+	r := BatchResult{CustomID: l.CustomID}
+	if l.Error != nil {
+		r.Error = l.Error.Message
+		return r, nil
+	}
+	if l.Response == nil {
+		r.Error = "the request has no response"
+		return r, nil
+	}
+
+	r.StatusCode = l.Response.StatusCode
+	if r.StatusCode != http.StatusOK {
+		eb := errBody{}
+		if err := json.Unmarshal(l.Response.Body, &eb); err != nil || eb.Message() == "" {
+			r.Error = http.StatusText(r.StatusCode)
+			return r, nil
+		}
+
+		r.Error = eb.Message()
+		return r, nil
+	}
+
+	switch b.Endpoint {
+	case completionsPath:
+		resp := textChatResp{}
+		if err := json.Unmarshal(l.Response.Body, &resp); err != nil {
+			return r, err
+		}
+
+		output := ai.TextChatOutput{}
+		setOutputStruct(&output, resp)
+		r.Output = output
+	case embeddingsPath:
+		resp := embeddingResp{}
+		if err := json.Unmarshal(l.Response.Body, &resp); err != nil {
+			return r, err
+		}
+
+		// The embedding responses have no creation time, so the completion
+		// time of the batch is used.
+		output, err := convertEmbeddingResp(resp, b.CompletedAt)
+		if err != nil {
+			return r, err
+		}
+		r.Output = output
+	default:
+		// The batches created outside of the component might target other
+		// endpoints, whose responses are returned as they are.
+		var output map[string]any
+		if err := json.Unmarshal(l.Response.Body, &output); err != nil {
+			return r, err
+		}
+		r.Output = output
+	}
+
+	return r, nil
+}
+
+func convertBatchResp(resp batchResp) Batch {
+	b := Batch{
+		BatchID:      resp.ID,
+		Status:       resp.Status,
+		Endpoint:     resp.Endpoint,
+		InputFileID:  resp.InputFileID,
+		OutputFileID: resp.OutputFileID,
+		ErrorFileID:  resp.ErrorFileID,
+		CreatedAt:    resp.CreatedAt,
+		CompletedAt:  resp.CompletedAt,
+		ExpiresAt:    resp.ExpiresAt,
+		RequestCounts: BatchRequestCounts{
+			Total:     resp.RequestCounts.Total,
+			Completed: resp.RequestCounts.Completed,
+			Failed:    resp.RequestCounts.Failed,
+		},
+		Metadata: resp.Metadata,
+	}
+
+	if resp.Errors != nil {
+		for _, e := range resp.Errors.Data {
+			msg := e.Message
+			if e.Line != nil {
+				msg = fmt.Sprintf("line %d: %s", *e.Line, msg)
+			}
+			b.Errors = append(b.Errors, msg)
+		}
+	}
+
+	return b
+}
+
+// batchLine is a request in the input file of a batch.
+type batchLine struct {
+	CustomID string `json:"custom_id"`
+	Method   string `json:"method"`
+	URL      string `json:"url"`
+	Body     any    `json:"body"`
+}
+
+// batchResultLine is a result in the output or error file of a batch.
+type batchResultLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type fileResp struct {
+	ID string `json:"id"`
+}
+
+type createBatchReq struct {
+	InputFileID      string            `json:"input_file_id"`
+	Endpoint         string            `json:"endpoint"`
+	CompletionWindow string            `json:"completion_window"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+type batchResp struct {
+	ID            string `json:"id"`
+	Endpoint      string `json:"endpoint"`
+	Status        string `json:"status"`
+	InputFileID   string `json:"input_file_id"`
+	OutputFileID  string `json:"output_file_id"`
+	ErrorFileID   string `json:"error_file_id"`
+	CreatedAt     int    `json:"created_at"`
+	CompletedAt   int    `json:"completed_at"`
+	ExpiresAt     int    `json:"expires_at"`
+	RequestCounts struct {
+		Total     int `json:"total"`
+		Completed int `json:"completed"`
+		Failed    int `json:"failed"`
+	} `json:"request_counts"`
+	Errors *struct {
+		Data []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Line    *int   `json:"line"`
+		} `json:"data"`
+	} `json:"errors"`
+	Metadata map[string]string `json:"metadata"`
+}
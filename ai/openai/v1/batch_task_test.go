@@ -0,0 +1,299 @@
+package openaiv1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	batchID = "batch_abc123"
+
+	batchJSON = `{
+  "id": "batch_abc123",
+  "endpoint": "/v1/embeddings",
+  "status": %q,
+  "input_file_id": "file-in",
+  "output_file_id": %q,
+  "error_file_id": %q,
+  "created_at": 1721236000,
+  "completed_at": %d,
+  "expires_at": 1721322400,
+  "request_counts": {"total": 3, "completed": %d, "failed": %d},
+  "metadata": {"project": "docs"}
+}`
+
+	outputFile = `{"id": "req_2", "custom_id": "chunk-3", "response": {"status_code": 200, "body": {"data": [{"index": 0, "embedding": [0.5, 0.6]}], "usage": {"prompt_tokens": 1, "total_tokens": 1}}}, "error": null}
+{"id": "req_0", "custom_id": "chunk-1", "response": {"status_code": 200, "body": {"data": [{"index": 0, "embedding": [0.1, 0.2]}], "usage": {"prompt_tokens": 2, "total_tokens": 2}}}, "error": null}
+`
+	errorFile = `{"id": "req_1", "custom_id": "chunk-2", "response": {"status_code": 400, "body": {"error": {"message": "Invalid model."}}}, "error": null}
+`
+)
+
+// batchServer is a local stand-in of the OpenAI files and batches endpoints.
+// The batch is completed as soon as it's created.
+type batchServer struct {
+	c         *qt.C
+	inputFile string
+	completed bool
+}
+
+func (s *batchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c := s.c
+	c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKey)
+
+	w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+	switch r.Method + " " + r.URL.Path {
+	case "POST /v1/files":
+		c.Assert(r.ParseMultipartForm(1<<20), qt.IsNil)
+		c.Check(r.FormValue("purpose"), qt.Equals, "batch")
+
+		f, h, err := r.FormFile("file")
+		c.Assert(err, qt.IsNil)
+		c.Check(h.Filename, qt.Equals, "batch.jsonl")
+
+		b, err := io.ReadAll(f)
+		c.Assert(err, qt.IsNil)
+		s.inputFile = string(b)
+
+		fmt.Fprintln(w, `{"id": "file-in", "object": "file", "purpose": "batch"}`)
+	case "POST /v1/batches":
+		body, err := io.ReadAll(r.Body)
+		c.Assert(err, qt.IsNil)
+		c.Check(body, qt.JSONEquals, map[string]any{
+			"input_file_id":     "file-in",
+			"endpoint":          "/v1/embeddings",
+			"completion_window": "24h",
+			"metadata":          map[string]any{"project": "docs"},
+		})
+
+		s.completed = true
+		fmt.Fprintf(w, batchJSON, "validating", "", "", 0, 0, 0)
+	case "GET /v1/batches/" + batchID:
+		if !s.completed {
+			fmt.Fprintf(w, batchJSON, "in_progress", "", "", 0, 1, 0)
+			return
+		}
+		fmt.Fprintf(w, batchJSON, "completed", "file-out", "file-err", 1721240000, 2, 1)
+	case "GET /v1/files/file-in/content":
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprint(w, s.inputFile)
+	case "GET /v1/files/file-out/content":
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprint(w, outputFile)
+	case "GET /v1/files/file-err/content":
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprint(w, errorFile)
+	default:
+		c.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestComponent_Batch(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	srv := &batchServer{c: c}
+	ts := httptest.NewServer(srv)
+	c.Cleanup(ts.Close)
+
+	setup, err := structpb.NewStruct(map[string]any{
+		"base-path": ts.URL,
+		"api-key":   apiKey,
+	})
+	c.Assert(err, qt.IsNil)
+
+	execute := func(c *qt.C, task string, input map[string]any) (output map[string]any, errMsg string) {
+		exec, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Setup:     setup,
+			Task:      task,
+		})
+		c.Assert(err, qt.IsNil)
+
+		pbIn, err := structpb.NewStruct(input)
+		c.Assert(err, qt.IsNil)
+
+		ir, ow, eh, job := base.GenerateMockJob(c)
+		ir.ReadMock.Return(pbIn, nil)
+		ow.WriteMock.Optional().Set(func(ctx context.Context, o *structpb.Struct) error {
+			output = o.AsMap()
+			return nil
+		})
+		eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+			errMsg = errmsg.MessageOrErr(err)
+		})
+
+		err = exec.Execute(ctx, []*base.Job{job})
+		c.Assert(err, qt.IsNil)
+		return output, errMsg
+	}
+
+	embeddingInput := func(text string) map[string]any {
+		return map[string]any{
+			"data":      map[string]any{"model": "text-embedding-3-small", "texts": []any{text}},
+			"parameter": map[string]any{"dimensions": 2},
+		}
+	}
+	batchInput := map[string]any{"data": map[string]any{"batch-id": batchID}}
+
+	c.Run("nok - results before completion", func(c *qt.C) {
+		_, errMsg := execute(c, GetBatchResultsTask, batchInput)
+		c.Check(errMsg, qt.Equals, "batch batch_abc123 has no results yet, its status is in_progress")
+	})
+
+	c.Run("nok - duplicate custom ID", func(c *qt.C) {
+		_, errMsg := execute(c, CreateBatchTask, map[string]any{
+			"data": map[string]any{
+				"task": EmbeddingTask,
+				"requests": []any{
+					map[string]any{"custom-id": "chunk-1", "input": embeddingInput("Hello")},
+					map[string]any{"custom-id": "chunk-1", "input": embeddingInput("World")},
+				},
+			},
+		})
+		c.Check(errMsg, qt.Equals, "duplicate custom ID: chunk-1")
+	})
+
+	c.Run("ok - create batch", func(c *qt.C) {
+		output, errMsg := execute(c, CreateBatchTask, map[string]any{
+			"data": map[string]any{
+				"task": EmbeddingTask,
+				"requests": []any{
+					map[string]any{"custom-id": "chunk-1", "input": embeddingInput("Hello")},
+					map[string]any{"custom-id": "chunk-2", "input": embeddingInput("World")},
+					map[string]any{"custom-id": "chunk-3", "input": embeddingInput("!")},
+				},
+			},
+			"parameter": map[string]any{"metadata": map[string]any{"project": "docs"}},
+		})
+		c.Assert(errMsg, qt.Equals, "")
+		c.Check(`{
+  "data": {
+    "batch-id": "batch_abc123",
+    "status": "validating",
+    "endpoint": "/v1/embeddings",
+    "input-file-id": "file-in",
+    "created-at": 1721236000,
+    "expires-at": 1721322400,
+    "request-counts": {"total": 3, "completed": 0, "failed": 0},
+    "metadata": {"project": "docs"}
+  }
+}`, qt.JSONEquals, output)
+
+		lines := strings.Split(strings.TrimSpace(srv.inputFile), "\n")
+		c.Assert(lines, qt.HasLen, 3)
+		for i, text := range []string{"Hello", "World", "!"} {
+			var line map[string]any
+			c.Assert(json.Unmarshal([]byte(lines[i]), &line), qt.IsNil)
+			c.Check(line, qt.DeepEquals, map[string]any{
+				"custom_id": fmt.Sprintf("chunk-%d", i+1),
+				"method":    "POST",
+				"url":       "/v1/embeddings",
+				"body": map[string]any{
+					"model":           "text-embedding-3-small",
+					"input":           []any{text},
+					"dimensions":      float64(2),
+					"encoding_format": "float",
+				},
+			})
+		}
+	})
+
+	c.Run("ok - get batch", func(c *qt.C) {
+		output, errMsg := execute(c, GetBatchTask, batchInput)
+		c.Assert(errMsg, qt.Equals, "")
+		c.Check(`{
+  "data": {
+    "batch-id": "batch_abc123",
+    "status": "completed",
+    "endpoint": "/v1/embeddings",
+    "input-file-id": "file-in",
+    "output-file-id": "file-out",
+    "error-file-id": "file-err",
+    "created-at": 1721236000,
+    "completed-at": 1721240000,
+    "expires-at": 1721322400,
+    "request-counts": {"total": 3, "completed": 2, "failed": 1},
+    "metadata": {"project": "docs"}
+  }
+}`, qt.JSONEquals, output)
+	})
+
+	c.Run("ok - get batch results", func(c *qt.C) {
+		output, errMsg := execute(c, GetBatchResultsTask, batchInput)
+		c.Assert(errMsg, qt.Equals, "")
+
+		c.Check(`{
+  "data": {
+    "batch-id": "batch_abc123",
+    "status": "completed",
+    "results": [
+      {
+        "custom-id": "chunk-1",
+        "status-code": 200,
+        "output": {
+          "data": {"embeddings": [{"index": 0, "vector": [0.1, 0.2], "created": 1721240000}]},
+          "metadata": {"usage": {"completion-tokens": 0, "prompt-tokens": 2, "total-tokens": 2}}
+        }
+      },
+      {"custom-id": "chunk-2", "status-code": 400, "error": "Invalid model."},
+      {
+        "custom-id": "chunk-3",
+        "status-code": 200,
+        "output": {
+          "data": {"embeddings": [{"index": 0, "vector": [0.5, 0.6], "created": 1721240000}]},
+          "metadata": {"usage": {"completion-tokens": 0, "prompt-tokens": 1, "total-tokens": 1}}
+        }
+      }
+    ]
+  }
+}`, qt.JSONEquals, output)
+	})
+}
+
+func TestBuildBatchBody(t *testing.T) {
+	c := qt.New(t)
+
+	input := json.RawMessage(`{
+  "data": {
+    "model": "gpt-4o-mini",
+    "messages": [{"role": "user", "content": [{"type": "text", "text": "Hi"}]}]
+  },
+  "parameter": {"max-tokens": 10, "stream": true}
+}`)
+
+	body, err := buildBatchBody(TextChatTask, input)
+	c.Assert(err, qt.IsNil)
+
+	b, err := json.Marshal(body)
+	c.Assert(err, qt.IsNil)
+	c.Check(b, qt.JSONEquals, map[string]any{
+		"model": "gpt-4o-mini",
+		"messages": []any{
+			map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Hi"}}},
+		},
+		"max_tokens": 10,
+		"stream":     false,
+	})
+
+	_, err = buildBatchBody(ModerationTask, input)
+	c.Check(err, qt.ErrorMatches, "unsupported batch task: TASK_MODERATION")
+}
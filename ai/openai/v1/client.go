@@ -7,8 +7,10 @@ import (
 	"github.com/instill-ai/component/internal/util/httpclient"
 )
 
+const apiName = "OpenAI"
+
 func NewClient(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
-	c := httpclient.New("OpenAI", getBasePath(setup),
+	c := httpclient.New(apiName, getBasePath(setup),
 		httpclient.WithLogger(logger),
 		httpclient.WithEndUserError(new(errBody)),
 	)
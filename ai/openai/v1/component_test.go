@@ -0,0 +1,412 @@
+package openaiv1
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const apiKey = "123"
+
+var (
+	// pngB64 is a 1x1 PNG image.
+	pngB64 = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="
+	wavB64 = base64.StdEncoding.EncodeToString([]byte("RIFF\x24\x00\x00\x00WAVEfmt "))
+)
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	testcases := []struct {
+		name     string
+		task     string
+		input    map[string]any
+		wantPath string
+		checkReq func(*qt.C, *http.Request)
+
+		status      int
+		contentType string
+		resp        string
+
+		// ignoreCreated removes the creation timestamp of the embeddings,
+		// which depends on the execution time, before checking the output.
+		ignoreCreated bool
+		wantOutput    string
+		wantErr       string
+	}{
+		{
+			name: "ok - embedding",
+			task: EmbeddingTask,
+			input: map[string]any{
+				"data":      map[string]any{"model": "text-embedding-3-small", "texts": []any{"Hello", "World"}},
+				"parameter": map[string]any{"dimensions": 2},
+			},
+			wantPath: embeddingsPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{
+					"model":           "text-embedding-3-small",
+					"input":           []any{"Hello", "World"},
+					"dimensions":      2,
+					"encoding_format": "float",
+				})
+			},
+			resp: `{
+  "data": [{"index": 1, "embedding": [0.3, 0.4]}, {"index": 0, "embedding": [0.1, 0.2]}],
+  "model": "text-embedding-3-small",
+  "usage": {"prompt_tokens": 2, "total_tokens": 2}
+}`,
+			ignoreCreated: true,
+			wantOutput: `{
+  "data": {"embeddings": [{"index": 0, "vector": [0.1, 0.2]}, {"index": 1, "vector": [0.3, 0.4]}]},
+  "metadata": {"usage": {"completion-tokens": 0, "prompt-tokens": 2, "total-tokens": 2}}
+}`,
+		},
+		{
+			name: "nok - embedding without texts",
+			task: EmbeddingTask,
+			input: map[string]any{
+				"data": map[string]any{"model": "text-embedding-3-small", "texts": []any{}},
+			},
+			wantErr: "no text to embed",
+		},
+		{
+			name: "ok - speech recognition with word timestamps",
+			task: SpeechRecognitionTask,
+			input: map[string]any{
+				"data":      map[string]any{"model": "whisper-1", "audio": "data:audio/wav;base64," + wavB64},
+				"parameter": map[string]any{"language": "en", "word-timestamps": true},
+			},
+			wantPath: transcriptionsPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				c.Assert(r.ParseMultipartForm(1<<20), qt.IsNil)
+				c.Check(r.FormValue("model"), qt.Equals, "whisper-1")
+				c.Check(r.FormValue("language"), qt.Equals, "en")
+				c.Check(r.FormValue("response_format"), qt.Equals, "verbose_json")
+				c.Check(r.FormValue("timestamp_granularities[]"), qt.Equals, "word")
+				c.Check(r.MultipartForm.File["file"], qt.HasLen, 1)
+			},
+			resp: `{
+  "text": "Hello world",
+  "language": "english",
+  "duration": 1.2,
+  "words": [{"word": "Hello", "start": 0, "end": 0.5}, {"word": "world", "start": 0.6, "end": 1.1}]
+}`,
+			wantOutput: `{
+  "data": {
+    "text": "Hello world",
+    "language": "english",
+    "duration": 1.2,
+    "words": [{"word": "Hello", "start": 0, "end": 0.5}, {"word": "world", "start": 0.6, "end": 1.1}]
+  }
+}`,
+		},
+		{
+			name: "ok - speech translation",
+			task: SpeechRecognitionTask,
+			input: map[string]any{
+				"data":      map[string]any{"model": "whisper-1", "audio": wavB64},
+				"parameter": map[string]any{"language": "fr", "translate": true},
+			},
+			wantPath: translationsPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				c.Assert(r.ParseMultipartForm(1<<20), qt.IsNil)
+				c.Check(r.FormValue("language"), qt.Equals, "")
+				c.Check(r.FormValue("timestamp_granularities[]"), qt.Equals, "")
+			},
+			resp:       `{"text": "Hello world", "language": "english", "duration": 1.2}`,
+			wantOutput: `{"data": {"text": "Hello world", "language": "english", "duration": 1.2}}`,
+		},
+		{
+			name: "nok - word timestamps in translation",
+			task: SpeechRecognitionTask,
+			input: map[string]any{
+				"data":      map[string]any{"model": "whisper-1", "audio": wavB64},
+				"parameter": map[string]any{"translate": true, "word-timestamps": true},
+			},
+			wantErr: "word timestamps aren't supported when translating",
+		},
+		{
+			name: "ok - text to speech",
+			task: TextToSpeechTask,
+			input: map[string]any{
+				"data":      map[string]any{"model": "tts-1", "text": "Hello world", "voice": "nova"},
+				"parameter": map[string]any{"format": "wav", "speed": 1.5},
+			},
+			wantPath: speechPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{
+					"model":           "tts-1",
+					"input":           "Hello world",
+					"voice":           "nova",
+					"response_format": "wav",
+					"speed":           1.5,
+				})
+			},
+			contentType: "audio/wav",
+			resp:        "RIFF\x24\x00\x00\x00WAVEfmt ",
+			wantOutput:  `{"data": {"audio": "data:audio/wav;base64,` + wavB64 + `"}}`,
+		},
+		{
+			name: "nok - text to speech 401",
+			task: TextToSpeechTask,
+			input: map[string]any{
+				"data": map[string]any{"model": "tts-1", "text": "Hello world", "voice": "nova"},
+			},
+			wantPath: speechPath,
+			status:   http.StatusUnauthorized,
+			resp:     `{"error": {"message": "Incorrect API key provided."}}`,
+			wantErr:  "OpenAI responded with a 401 status code. Incorrect API key provided.",
+		},
+		{
+			name: "ok - text to image",
+			task: TextToImageTask,
+			input: map[string]any{
+				"data":      map[string]any{"model": "dall-e-3", "prompt": "A cat"},
+				"parameter": map[string]any{"size": "1024x1024", "quality": "hd", "style": "natural"},
+			},
+			wantPath: imageGenerationsPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{
+					"model":           "dall-e-3",
+					"prompt":          "A cat",
+					"size":            "1024x1024",
+					"quality":         "hd",
+					"style":           "natural",
+					"response_format": "b64_json",
+				})
+			},
+			resp:       `{"data": [{"b64_json": "` + pngB64 + `", "revised_prompt": "A fluffy cat"}]}`,
+			wantOutput: `{"data": {"images": [{"image": "data:image/png;base64,` + pngB64 + `", "revised-prompt": "A fluffy cat"}]}}`,
+		},
+		{
+			name: "ok - image edit",
+			task: ImageEditTask,
+			input: map[string]any{
+				"data": map[string]any{
+					"model":  "dall-e-2",
+					"image":  "data:image/png;base64," + pngB64,
+					"mask":   pngB64,
+					"prompt": "Add a hat",
+				},
+				"parameter": map[string]any{"n": 2, "size": "256x256"},
+			},
+			wantPath: imageEditsPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				c.Assert(r.ParseMultipartForm(1<<20), qt.IsNil)
+				c.Check(r.FormValue("model"), qt.Equals, "dall-e-2")
+				c.Check(r.FormValue("prompt"), qt.Equals, "Add a hat")
+				c.Check(r.FormValue("n"), qt.Equals, "2")
+				c.Check(r.FormValue("size"), qt.Equals, "256x256")
+				c.Check(r.FormValue("response_format"), qt.Equals, "b64_json")
+				c.Check(r.MultipartForm.File["image"], qt.HasLen, 1)
+				c.Check(r.MultipartForm.File["mask"], qt.HasLen, 1)
+			},
+			resp:       `{"data": [{"b64_json": "` + pngB64 + `"}, {"b64_json": "` + pngB64 + `"}]}`,
+			wantOutput: `{"data": {"images": [{"image": "data:image/png;base64,` + pngB64 + `"}, {"image": "data:image/png;base64,` + pngB64 + `"}]}}`,
+		},
+		{
+			name: "ok - image variation",
+			task: ImageVariationTask,
+			input: map[string]any{
+				"data": map[string]any{"model": "dall-e-2", "image": pngB64},
+			},
+			wantPath: imageVariationsPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				c.Assert(r.ParseMultipartForm(1<<20), qt.IsNil)
+				c.Check(r.FormValue("prompt"), qt.Equals, "")
+				c.Check(r.MultipartForm.File["image"], qt.HasLen, 1)
+				c.Check(r.MultipartForm.File["mask"], qt.HasLen, 0)
+			},
+			resp:       `{"data": [{"b64_json": "` + pngB64 + `"}]}`,
+			wantOutput: `{"data": {"images": [{"image": "data:image/png;base64,` + pngB64 + `"}]}}`,
+		},
+		{
+			name: "ok - moderation",
+			task: ModerationTask,
+			input: map[string]any{
+				"data": map[string]any{"model": "omni-moderation-latest", "texts": []any{"I love cats"}},
+			},
+			wantPath: moderationsPath,
+			checkReq: func(c *qt.C, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{
+					"model": "omni-moderation-latest",
+					"input": []any{"I love cats"},
+				})
+			},
+			resp: `{
+  "results": [{
+    "flagged": false,
+    "categories": {"hate": false, "self-harm/intent": false},
+    "category_scores": {"hate": 0.25, "self-harm/intent": 0.5}
+  }]
+}`,
+			wantOutput: `{
+  "data": {"results": [{
+    "flagged": false,
+    "categories": {"hate": false, "self-harm/intent": false},
+    "category-scores": {"hate": 0.25, "self-harm/intent": 0.5}
+  }]}
+}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, tc.wantPath)
+				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKey)
+
+				if tc.checkReq != nil {
+					tc.checkReq(c, r)
+				}
+
+				contentType := tc.contentType
+				if contentType == "" {
+					contentType = httpclient.MIMETypeJSON
+				}
+				status := tc.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				w.Header().Set("Content-Type", contentType)
+				w.WriteHeader(status)
+				_, _ = io.WriteString(w, tc.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"base-path": srv.URL,
+				"api-key":   apiKey,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				got := output.AsMap()
+				if tc.ignoreCreated {
+					for _, e := range got["data"].(map[string]any)["embeddings"].([]any) {
+						delete(e.(map[string]any), "created")
+					}
+				}
+				c.Check(tc.wantOutput, qt.JSONEquals, got)
+				return nil
+			})
+
+			var gotErr bool
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				gotErr = true
+				c.Check(errmsg.MessageOrErr(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+			c.Check(gotErr, qt.Equals, tc.wantErr != "")
+		})
+	}
+}
+
+func TestComponent_StreamTextToSpeech(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	// The audio spans several chunks so the partial outputs are written.
+	audio := make([]byte, 30*streamChunkSize+1)
+	for i := range audio {
+		audio[i] = byte(i)
+	}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, qt.Equals, speechPath)
+
+		w.Header().Set("Content-Type", "audio/mpeg")
+		for i := 0; i < len(audio); i += streamChunkSize {
+			end := min(i+streamChunkSize, len(audio))
+			_, _ = w.Write(audio[i:end])
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	srv := httptest.NewServer(h)
+	c.Cleanup(srv.Close)
+
+	setup, err := structpb.NewStruct(map[string]any{
+		"base-path": srv.URL,
+		"api-key":   apiKey,
+	})
+	c.Assert(err, qt.IsNil)
+
+	exec, err := cmp.CreateExecution(base.ComponentExecution{
+		Component: cmp,
+		Setup:     setup,
+		Task:      TextToSpeechTask,
+	})
+	c.Assert(err, qt.IsNil)
+
+	pbIn, err := structpb.NewStruct(map[string]any{
+		"data":      map[string]any{"model": "tts-1", "text": "Hello world", "voice": "alloy"},
+		"parameter": map[string]any{"stream": true},
+	})
+	c.Assert(err, qt.IsNil)
+
+	ir, ow, eh, job := base.GenerateMockJob(c)
+	ir.ReadMock.Return(pbIn, nil)
+
+	var outputs []string
+	ow.WriteMock.Set(func(ctx context.Context, output *structpb.Struct) error {
+		outputs = append(outputs, output.GetFields()["data"].GetStructValue().GetFields()["audio"].GetStringValue())
+		return nil
+	})
+	eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+		c.Errorf("unexpected error: %v", err)
+	})
+
+	err = exec.Execute(ctx, []*base.Job{job})
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(len(outputs) > 1, qt.IsTrue, qt.Commentf("got %d outputs", len(outputs)))
+	c.Check(outputs[len(outputs)-1], qt.Equals, "data:audio/mpeg;base64,"+base64.StdEncoding.EncodeToString(audio))
+	for _, o := range outputs[:len(outputs)-1] {
+		c.Check(len(o) < len(outputs[len(outputs)-1]), qt.IsTrue)
+	}
+}
@@ -0,0 +1,97 @@
+package openaiv1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	embeddingsPath = "/v1/embeddings"
+)
+
+func (e *execution) ExecuteEmbedding(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.EmbeddingInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to EmbeddingInput: %w", err)
+	}
+
+	return ExecuteEmbedding(inputStruct, e.client, ctx)
+}
+
+// ExecuteEmbedding embeds the input texts in a single request.
+func ExecuteEmbedding(input ai.EmbeddingInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	embeddingReq, err := buildEmbeddingReq(input)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := embeddingResp{}
+	req := client.R().SetContext(ctx).SetResult(&resp).SetBody(embeddingReq)
+	if _, err := req.Post(embeddingsPath); err != nil {
+		return nil, fmt.Errorf("failed to send embedding request: %w", err)
+	}
+
+	output, err := convertEmbeddingResp(resp, int(time.Now().Unix()))
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+func buildEmbeddingReq(input ai.EmbeddingInput) (embeddingReq, error) {
+	if len(input.Data.Texts) == 0 {
+		return embeddingReq{}, fmt.Errorf("no text to embed")
+	}
+
+	return embeddingReq{
+		Model:          input.Data.Model,
+		Input:          input.Data.Texts,
+		Dimensions:     input.Parameter.Dimensions,
+		EncodingFormat: "float",
+	}, nil
+}
+
+func convertEmbeddingResp(resp embeddingResp, created int) (ai.EmbeddingOutput, error) {
+	output := ai.EmbeddingOutput{
+		Data: ai.EmbeddingOutputData{Embeddings: make([]ai.Embedding, len(resp.Data))},
+		Metadata: ai.Metadata{Usage: ai.Usage{
+			PromptTokens: resp.Usage.PromptTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		}},
+	}
+
+	// The embeddings are returned in the order of the input texts, but their
+	// index is checked in case the order changes.
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(resp.Data) {
+			return ai.EmbeddingOutput{}, fmt.Errorf("invalid embedding index %d", d.Index)
+		}
+		output.Data.Embeddings[d.Index] = ai.Embedding{Index: d.Index, Vector: d.Embedding, Created: created}
+	}
+
+	return output, nil
+}
+
+type embeddingReq struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	Dimensions     *int     `json:"dimensions,omitempty"`
+	EncodingFormat string   `json:"encoding_format,omitempty"`
+}
+
+type embeddingResp struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Model string      `json:"model"`
+	Usage usageOpenAI `json:"usage"`
+}
@@ -0,0 +1,164 @@
+package openaiv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	imageGenerationsPath = "/v1/images/generations"
+	imageEditsPath       = "/v1/images/edits"
+	imageVariationsPath  = "/v1/images/variations"
+)
+
+func (e *execution) ExecuteTextToImage(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.ImageInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to ImageInput: %w", err)
+	}
+
+	return ExecuteTextToImage(inputStruct, e.client, ctx)
+}
+
+func (e *execution) ExecuteImageEdit(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.ImageInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to ImageInput: %w", err)
+	}
+
+	return ExecuteImageEdit(inputStruct, e.client, ctx)
+}
+
+func (e *execution) ExecuteImageVariation(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.ImageInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to ImageInput: %w", err)
+	}
+
+	return ExecuteImageVariation(inputStruct, e.client, ctx)
+}
+
+// ExecuteTextToImage generates images from a prompt.
+func ExecuteTextToImage(input ai.ImageInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	p := input.Parameter
+	imageReq := imageGenerationReq{
+		Model:          input.Data.Model,
+		Prompt:         input.Data.Prompt,
+		N:              p.N,
+		Size:           p.Size,
+		Quality:        p.Quality,
+		Style:          p.Style,
+		ResponseFormat: "b64_json",
+	}
+
+	resp := imageResp{}
+	req := client.R().SetContext(ctx).SetResult(&resp).SetBody(imageReq)
+	if _, err := req.Post(imageGenerationsPath); err != nil {
+		return nil, fmt.Errorf("failed to send image generation request: %w", err)
+	}
+
+	return convertImageResp(resp)
+}
+
+// ExecuteImageEdit edits an image following a prompt. If a mask is
+// provided, only its transparent areas are edited. Otherwise, the image must
+// have transparent areas.
+func ExecuteImageEdit(input ai.ImageInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	files := map[string]string{"image": input.Data.Image}
+	if input.Data.Mask != "" {
+		files["mask"] = input.Data.Mask
+	}
+
+	return sendImageForm(ctx, client, imageEditsPath, input, files)
+}
+
+// ExecuteImageVariation generates variations of an image.
+func ExecuteImageVariation(input ai.ImageInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	input.Data.Prompt = ""
+	return sendImageForm(ctx, client, imageVariationsPath, input, map[string]string{"image": input.Data.Image})
+}
+
+// sendImageForm sends the multipart requests of the image edits and
+// variations, which upload the source images.
+func sendImageForm(ctx context.Context, client httpclient.IClient, path string, input ai.ImageInput, files map[string]string) (*structpb.Struct, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for _, field := range []string{"image", "mask"} {
+		file, ok := files[field]
+		if !ok {
+			continue
+		}
+
+		b, err := base64.StdEncoding.DecodeString(base.TrimBase64Mime(file))
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", field, err)
+		}
+		if err := util.WriteFile(writer, field, b); err != nil {
+			return nil, err
+		}
+	}
+
+	p := input.Parameter
+	util.WriteField(writer, "model", input.Data.Model)
+	util.WriteField(writer, "prompt", input.Data.Prompt)
+	util.WriteField(writer, "size", p.Size)
+	util.WriteField(writer, "response_format", "b64_json")
+	if p.N != nil {
+		util.WriteField(writer, "n", strconv.Itoa(*p.N))
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	resp := imageResp{}
+	req := client.R().SetContext(ctx).
+		SetBody(body.Bytes()).
+		SetHeader("Content-Type", writer.FormDataContentType()).
+		SetResult(&resp)
+	if _, err := req.Post(path); err != nil {
+		return nil, fmt.Errorf("failed to send image request: %w", err)
+	}
+
+	return convertImageResp(resp)
+}
+
+func convertImageResp(resp imageResp) (*structpb.Struct, error) {
+	output := ai.ImageOutput{Data: ai.ImageOutputData{Images: make([]ai.Image, len(resp.Data))}}
+	for i, d := range resp.Data {
+		output.Data.Images[i] = ai.Image{
+			Image:         util.GetDataURL(d.B64JSON),
+			RevisedPrompt: d.RevisedPrompt,
+		}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type imageGenerationReq struct {
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              *int   `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	Style          string `json:"style,omitempty"`
+	ResponseFormat string `json:"response_format"`
+}
+
+type imageResp struct {
+	Data []struct {
+		B64JSON       string `json:"b64_json"`
+		RevisedPrompt string `json:"revised_prompt"`
+	} `json:"data"`
+}
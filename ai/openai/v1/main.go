@@ -1,3 +1,4 @@
+//go:generate compogen readme ./config ./README.mdx
 package openaiv1
 
 import (
@@ -16,7 +17,18 @@ import (
 const (
 	host = "https://api.openai.com"
 
-	TextChatTask    = "TASK_CHAT"
+	TextChatTask          = "TASK_CHAT"
+	EmbeddingTask         = "TASK_EMBEDDING"
+	SpeechRecognitionTask = "TASK_SPEECH_RECOGNITION"
+	TextToSpeechTask      = "TASK_TEXT_TO_SPEECH"
+	TextToImageTask       = "TASK_TEXT_TO_IMAGE"
+	ImageEditTask         = "TASK_IMAGE_EDIT"
+	ImageVariationTask    = "TASK_IMAGE_VARIATION"
+	ModerationTask        = "TASK_MODERATION"
+	CreateBatchTask       = "TASK_CREATE_BATCH"
+	GetBatchTask          = "TASK_GET_BATCH"
+	GetBatchResultsTask   = "TASK_GET_BATCH_RESULTS"
+
 	cfgAPIKey       = "api-key"
 	cfgOrganization = "organization"
 	retryCount      = 3
@@ -79,6 +91,26 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 	switch x.Task {
 	case TextChatTask:
 		e.execute = e.ExecuteTextChat
+	case EmbeddingTask:
+		e.execute = e.ExecuteEmbedding
+	case SpeechRecognitionTask:
+		e.execute = e.ExecuteSpeechRecognition
+	case TextToSpeechTask:
+		e.execute = e.ExecuteTextToSpeech
+	case TextToImageTask:
+		e.execute = e.ExecuteTextToImage
+	case ImageEditTask:
+		e.execute = e.ExecuteImageEdit
+	case ImageVariationTask:
+		e.execute = e.ExecuteImageVariation
+	case ModerationTask:
+		e.execute = e.ExecuteModeration
+	case CreateBatchTask:
+		e.execute = e.ExecuteCreateBatch
+	case GetBatchTask:
+		e.execute = e.ExecuteGetBatch
+	case GetBatchResultsTask:
+		e.execute = e.ExecuteGetBatchResults
 	default:
 		return nil, fmt.Errorf("unknown task: %s", x.Task)
 	}
@@ -0,0 +1,68 @@
+package openaiv1
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	moderationsPath = "/v1/moderations"
+)
+
+func (e *execution) ExecuteModeration(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.ModerationInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to ModerationInput: %w", err)
+	}
+
+	return ExecuteModeration(inputStruct, e.client, ctx)
+}
+
+// ExecuteModeration classifies whether the input texts are potentially
+// harmful.
+func ExecuteModeration(input ai.ModerationInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	if len(input.Data.Texts) == 0 {
+		return nil, fmt.Errorf("no text to moderate")
+	}
+
+	moderationReq := moderationReq{
+		Model: input.Data.Model,
+		Input: input.Data.Texts,
+	}
+
+	resp := moderationResp{}
+	req := client.R().SetContext(ctx).SetResult(&resp).SetBody(moderationReq)
+	if _, err := req.Post(moderationsPath); err != nil {
+		return nil, fmt.Errorf("failed to send moderation request: %w", err)
+	}
+
+	output := ai.ModerationOutput{Data: ai.ModerationOutputData{Results: make([]ai.ModerationResult, len(resp.Results))}}
+	for i, r := range resp.Results {
+		output.Data.Results[i] = ai.ModerationResult{
+			Flagged:        r.Flagged,
+			Categories:     r.Categories,
+			CategoryScores: r.CategoryScores,
+		}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type moderationReq struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+type moderationResp struct {
+	Results []struct {
+		Flagged        bool               `json:"flagged"`
+		Categories     map[string]bool    `json:"categories"`
+		CategoryScores map[string]float32 `json:"category_scores"`
+	} `json:"results"`
+}
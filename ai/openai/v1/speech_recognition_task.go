@@ -0,0 +1,101 @@
+package openaiv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	transcriptionsPath = "/v1/audio/transcriptions"
+	translationsPath   = "/v1/audio/translations"
+)
+
+func (e *execution) ExecuteSpeechRecognition(input *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.SpeechRecognitionInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to SpeechRecognitionInput: %w", err)
+	}
+
+	return ExecuteSpeechRecognition(inputStruct, e.client, ctx)
+}
+
+// ExecuteSpeechRecognition transcribes an audio file or, if the translation
+// is requested, translates it into English.
+func ExecuteSpeechRecognition(input ai.SpeechRecognitionInput, client httpclient.IClient, ctx context.Context) (*structpb.Struct, error) {
+	p := input.Parameter
+
+	path := transcriptionsPath
+	if p.Translate {
+		// The translations endpoint translates into English and doesn't take
+		// the source language or the timestamp granularities.
+		if p.WordTimestamps {
+			return nil, fmt.Errorf("word timestamps aren't supported when translating")
+		}
+		path = translationsPath
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(base.TrimBase64Mime(input.Data.Audio))
+	if err != nil {
+		return nil, fmt.Errorf("decoding audio: %w", err)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := util.WriteFile(writer, "file", audio); err != nil {
+		return nil, err
+	}
+	util.WriteField(writer, "model", input.Data.Model)
+	util.WriteField(writer, "prompt", p.Prompt)
+	// The verbose format returns the duration and the detected language.
+	util.WriteField(writer, "response_format", "verbose_json")
+	if p.Temperature != nil {
+		util.WriteField(writer, "temperature", strconv.FormatFloat(float64(*p.Temperature), 'f', -1, 32))
+	}
+	if !p.Translate {
+		util.WriteField(writer, "language", p.Language)
+	}
+	if p.WordTimestamps {
+		util.WriteField(writer, "timestamp_granularities[]", "word")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	resp := speechRecognitionResp{}
+	req := client.R().SetContext(ctx).
+		SetBody(body.Bytes()).
+		SetHeader("Content-Type", writer.FormDataContentType()).
+		SetResult(&resp)
+	if _, err := req.Post(path); err != nil {
+		return nil, fmt.Errorf("failed to send speech recognition request: %w", err)
+	}
+
+	output := ai.SpeechRecognitionOutput{
+		Data: ai.SpeechRecognitionOutputData{
+			Text:     resp.Text,
+			Language: resp.Language,
+			Duration: resp.Duration,
+			Words:    resp.Words,
+		},
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type speechRecognitionResp struct {
+	Text     string    `json:"text"`
+	Language string    `json:"language"`
+	Duration float32   `json:"duration"`
+	Words    []ai.Word `json:"words"`
+}
@@ -83,8 +83,8 @@ type O1ModelRequester struct {
 	Client httpclient.IClient
 }
 
-// When it supports streaming, the job and ctx will be used.
-func (r *O1ModelRequester) SendChatRequest(_ *base.Job, _ context.Context) (*structpb.Struct, error) {
+// When it supports streaming, the job will be used.
+func (r *O1ModelRequester) SendChatRequest(_ *base.Job, ctx context.Context) (*structpb.Struct, error) {
 
 	input := r.Input
 	// Note: The o1-series models don't support streaming.
@@ -95,11 +95,10 @@ func (r *O1ModelRequester) SendChatRequest(_ *base.Job, _ context.Context) (*str
 	resp := textChatResp{}
 	client := r.Client
 
-	req := client.R().SetResult(&resp).SetBody(chatReq)
+	req := client.R().SetContext(ctx).SetResult(&resp).SetBody(chatReq)
 
-	if resp, err := req.Post(completionsPath); err != nil {
-		errMsg := resp.Body()
-		return nil, fmt.Errorf("failed to send chat request: %w, %s", err, errMsg)
+	if _, err := req.Post(completionsPath); err != nil {
+		return nil, fmt.Errorf("failed to send chat request: %w", err)
 	}
 
 	outputStruct := ai.TextChatOutput{}
@@ -159,7 +158,7 @@ func (r *ChatModelRequester) SendChatRequest(job *base.Job, ctx context.Context)
 
 func sendRequest(chatReq textChatReq, client httpclient.IClient, job *base.Job, ctx context.Context) (ai.TextChatOutput, error) {
 
-	req := client.SetDoNotParseResponse(true).R().SetBody(chatReq)
+	req := client.R().SetContext(ctx).SetDoNotParseResponse(true).SetBody(chatReq)
 
 	outputStruct := ai.TextChatOutput{}
 	restyResp, err := req.Post(completionsPath)
@@ -168,11 +167,8 @@ func sendRequest(chatReq textChatReq, client httpclient.IClient, job *base.Job,
 		return outputStruct, fmt.Errorf("failed to send chat request: %w", err)
 	}
 
-	if restyResp.StatusCode() != 200 {
-		rawBody := restyResp.RawBody()
-		defer rawBody.Close()
-		bodyBytes, err := io.ReadAll(rawBody)
-		return outputStruct, fmt.Errorf("send request to openai error with error code: %d, msg %s, %s", restyResp.StatusCode(), bodyBytes, err)
+	if err := httpclient.UnparsedResponseError(apiName, restyResp, new(errBody)); err != nil {
+		return outputStruct, err
 	}
 
 	if chatReq.Stream {
@@ -0,0 +1,119 @@
+package openaiv1
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	speechPath = "/v1/audio/speech"
+
+	// streamChunkSize is the size of the audio chunks read from a streamed
+	// speech response.
+	streamChunkSize = 16 * 1024
+)
+
+// audioMIMETypes maps the audio formats to their MIME types.
+var audioMIMETypes = map[string]string{
+	"mp3":  "audio/mpeg",
+	"opus": "audio/opus",
+	"aac":  "audio/aac",
+	"flac": "audio/flac",
+	"wav":  "audio/wav",
+	"pcm":  "audio/pcm",
+}
+
+func (e *execution) ExecuteTextToSpeech(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.TextToSpeechInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to TextToSpeechInput: %w", err)
+	}
+
+	return ExecuteTextToSpeech(inputStruct, e.client, job, ctx)
+}
+
+// ExecuteTextToSpeech generates the speech of a text. When streaming, the
+// audio received so far is written as a partial output while the response
+// is read.
+func ExecuteTextToSpeech(input ai.TextToSpeechInput, client httpclient.IClient, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	p := input.Parameter
+
+	format := p.Format
+	if format == "" {
+		format = "mp3"
+	}
+	mimeType, ok := audioMIMETypes[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported audio format: %s", format)
+	}
+
+	speechReq := speechReq{
+		Model:          input.Data.Model,
+		Input:          input.Data.Text,
+		Voice:          input.Data.Voice,
+		ResponseFormat: format,
+		Speed:          p.Speed,
+	}
+
+	resp, err := client.R().SetContext(ctx).SetBody(speechReq).SetDoNotParseResponse(true).Post(speechPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send speech request: %w", err)
+	}
+	if err := httpclient.UnparsedResponseError(apiName, resp, new(errBody)); err != nil {
+		return nil, err
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	output := func(audio []byte) ai.TextToSpeechOutput {
+		return ai.TextToSpeechOutput{Data: ai.TextToSpeechOutputData{
+			Audio: fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(audio)),
+		}}
+	}
+
+	var audio []byte
+	if !p.Stream {
+		if audio, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("reading audio: %w", err)
+		}
+		return base.ConvertToStructpb(output(audio))
+	}
+
+	w := ai.NewStreamWriter(ctx, job)
+	chunk := make([]byte, streamChunkSize)
+	for {
+		n, err := body.Read(chunk)
+		if n > 0 {
+			audio = append(audio, chunk[:n]...)
+			if err := w.Update(output(audio)); err != nil {
+				return nil, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading audio stream: %w", err)
+		}
+	}
+
+	return base.ConvertToStructpb(output(audio))
+}
+
+type speechReq struct {
+	Model          string   `json:"model"`
+	Input          string   `json:"input"`
+	Voice          string   `json:"voice"`
+	ResponseFormat string   `json:"response_format,omitempty"`
+	Speed          *float32 `json:"speed,omitempty"`
+}
@@ -1,6 +1,8 @@
 package stabilityai
 
 import (
+	"strings"
+
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -18,11 +20,17 @@ func newClient(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
 	return c
 }
 
+// errBody is the error response of the API. The v1 endpoints return a
+// message, while the Stable Image endpoints return a list of errors.
 type errBody struct {
-	Msg string `json:"message"`
+	Msg    string   `json:"message"`
+	Errors []string `json:"errors"`
 }
 
 func (e errBody) Message() string {
+	if e.Msg == "" {
+		return strings.Join(e.Errors, " ")
+	}
 	return e.Msg
 }
 
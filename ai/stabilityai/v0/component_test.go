@@ -2,8 +2,10 @@ package stabilityai
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -347,3 +349,223 @@ func TestComponent_Test(t *testing.T) {
 		c.Check(err, qt.IsNil)
 	})
 }
+
+func TestComponent_ExecuteStableImage(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc).WithInstillCredentials(map[string]any{"apikey": instillSecret})
+
+	image := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("image"))
+	mask := base64.StdEncoding.EncodeToString([]byte("mask"))
+
+	testcases := []struct {
+		name       string
+		task       string
+		input      map[string]any
+		wantPath   string
+		wantFields map[string]string
+		wantFiles  map[string]string
+		gotStatus  int
+		gotResp    string
+		wantResp   string
+		wantErr    string
+	}{
+		{
+			name: "ok - text to image with sd3",
+			task: TextToImageTask,
+			input: map[string]any{
+				"engine":       "sd3-large",
+				"prompts":      []any{"a cat", "a dog", "blurry"},
+				"weights":      []any{1, 0.5, -1},
+				"seed":         42,
+				"aspect-ratio": "16:9",
+				"style-preset": "anime",
+			},
+			wantPath: "/v2beta/stable-image/generate/sd3",
+			wantFields: map[string]string{
+				"prompt":          "a cat, a dog",
+				"negative_prompt": "blurry",
+				"model":           "sd3-large",
+				"mode":            "text-to-image",
+				"seed":            "42",
+				"aspect_ratio":    "16:9",
+				"output_format":   "png",
+			},
+			gotResp:  `{"image": "a", "seed": 42, "finish_reason": "SUCCESS"}`,
+			wantResp: `{"images": ["data:image/png;base64,a"], "seeds": [42], "finish-reasons": ["SUCCESS"]}`,
+		},
+		{
+			name: "ok - image to image with ultra",
+			task: ImageToImageTask,
+			input: map[string]any{
+				"engine":         "stable-image-ultra",
+				"prompts":        []any{"a cat"},
+				"init-image":     image,
+				"image-strength": 0.5,
+			},
+			wantPath: "/v2beta/stable-image/generate/ultra",
+			wantFields: map[string]string{
+				"prompt":        "a cat",
+				"strength":      "0.500000",
+				"output_format": "png",
+			},
+			wantFiles: map[string]string{"image": "image"},
+			gotResp:   `{"image": "a", "seed": 7, "finish_reason": "CONTENT_FILTERED"}`,
+			wantResp:  `{"images": ["data:image/png;base64,a"], "seeds": [7], "finish-reasons": ["CONTENT_FILTERED"]}`,
+		},
+		{
+			name: "ok - inpaint",
+			task: InpaintTask,
+			input: map[string]any{
+				"image":         image,
+				"mask":          mask,
+				"prompt":        "a hat",
+				"grow-mask":     10,
+				"output-format": "webp",
+			},
+			wantPath: "/v2beta/stable-image/edit/inpaint",
+			wantFields: map[string]string{
+				"prompt":        "a hat",
+				"grow_mask":     "10",
+				"output_format": "webp",
+			},
+			wantFiles: map[string]string{"image": "image", "mask": "mask"},
+			gotResp:   `{"image": "a", "seed": 1, "finish_reason": "SUCCESS"}`,
+			wantResp:  `{"images": ["data:image/webp;base64,a"], "seeds": [1], "finish-reasons": ["SUCCESS"]}`,
+		},
+		{
+			name:      "ok - remove background",
+			task:      RemoveBackgroundTask,
+			input:     map[string]any{"image": image},
+			wantPath:  "/v2beta/stable-image/edit/remove-background",
+			wantFiles: map[string]string{"image": "image"},
+			wantFields: map[string]string{
+				"output_format": "png",
+			},
+			gotResp:  `{"image": "a", "finish_reason": "SUCCESS"}`,
+			wantResp: `{"images": ["data:image/png;base64,a"], "seeds": [0], "finish-reasons": ["SUCCESS"]}`,
+		},
+		{
+			name: "nok - search and replace 400",
+			task: SearchAndReplaceTask,
+			input: map[string]any{
+				"image":         image,
+				"prompt":        "a dog",
+				"search-prompt": "cat",
+			},
+			wantPath: "/v2beta/stable-image/edit/search-and-replace",
+			wantFields: map[string]string{
+				"prompt":        "a dog",
+				"search_prompt": "cat",
+				"output_format": "png",
+			},
+			wantFiles: map[string]string{"image": "image"},
+			gotStatus: http.StatusBadRequest,
+			gotResp:   `{"id": "a1b2", "name": "bad_request", "errors": ["image: is required"]}`,
+			wantErr:   "Stability AI responded with a 400 status code. image: is required",
+		},
+		{
+			name:    "nok - outpaint without direction",
+			task:    OutpaintTask,
+			input:   map[string]any{"image": image, "left": 0},
+			wantErr: "at least one direction must be outpainted",
+		},
+		{
+			name:    "nok - conservative upscale without prompt",
+			task:    UpscaleTask,
+			input:   map[string]any{"image": image, "mode": "conservative"},
+			wantErr: "the conservative upscale requires a prompt",
+		},
+		{
+			name: "nok - image to image with core",
+			task: ImageToImageTask,
+			input: map[string]any{
+				"engine":     "stable-image-core",
+				"prompts":    []any{"a cat"},
+				"init-image": image,
+			},
+			wantErr: "stable-image-core doesn't support image-to-image generation",
+		},
+		{
+			name:    "nok - invalid image",
+			task:    RemoveBackgroundTask,
+			input:   map[string]any{"image": "not base64!"},
+			wantErr: "decoding image: illegal base64 data at input byte 3",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, tc.wantPath)
+
+				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKey)
+				c.Check(r.Header.Get("Accept"), qt.Equals, httpclient.MIMETypeJSON)
+
+				c.Assert(r.ParseMultipartForm(1<<20), qt.IsNil)
+				fields := map[string]string{}
+				for k, v := range r.MultipartForm.Value {
+					fields[k] = v[0]
+				}
+				c.Check(fields, qt.DeepEquals, tc.wantFields)
+
+				files := map[string]string{}
+				for k := range r.MultipartForm.File {
+					f, _, err := r.FormFile(k)
+					c.Assert(err, qt.IsNil)
+					b, err := io.ReadAll(f)
+					c.Assert(err, qt.IsNil)
+					files[k] = string(b)
+				}
+				if tc.wantFiles == nil {
+					tc.wantFiles = map[string]string{}
+				}
+				c.Check(files, qt.DeepEquals, tc.wantFiles)
+
+				if tc.gotStatus == 0 {
+					tc.gotStatus = http.StatusOK
+				}
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				w.WriteHeader(tc.gotStatus)
+				fmt.Fprintln(w, tc.gotResp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"base-path": srv.URL,
+				"api-key":   apiKey,
+			})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			var gotErr string
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				c.Check(tc.wantResp, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				gotErr = errmsg.MessageOrErr(err)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+			c.Check(gotErr, qt.Equals, tc.wantErr)
+		})
+	}
+}
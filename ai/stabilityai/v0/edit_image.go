@@ -0,0 +1,152 @@
+package stabilityai
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	upscalePath          = "/v2beta/stable-image/upscale/%s"
+	inpaintPath          = "/v2beta/stable-image/edit/inpaint"
+	outpaintPath         = "/v2beta/stable-image/edit/outpaint"
+	removeBackgroundPath = "/v2beta/stable-image/edit/remove-background"
+	searchAndReplacePath = "/v2beta/stable-image/edit/search-and-replace"
+
+	upscaleModeFast         = "fast"
+	upscaleModeConservative = "conservative"
+)
+
+// EditImageInput is the input of the image edition tasks. Each task uses a
+// subset of the fields.
+type EditImageInput struct {
+	Image          string   `json:"image"`
+	Mask           string   `json:"mask,omitempty"`
+	Prompt         string   `json:"prompt,omitempty"`
+	NegativePrompt string   `json:"negative-prompt,omitempty"`
+	SearchPrompt   string   `json:"search-prompt,omitempty"`
+	Mode           string   `json:"mode,omitempty"`
+	Creativity     *float64 `json:"creativity,omitempty"`
+	GrowMask       *uint32  `json:"grow-mask,omitempty"`
+	Left           *uint32  `json:"left,omitempty"`
+	Right          *uint32  `json:"right,omitempty"`
+	Up             *uint32  `json:"up,omitempty"`
+	Down           *uint32  `json:"down,omitempty"`
+	Seed           *uint32  `json:"seed,omitempty"`
+	OutputFormat   string   `json:"output-format,omitempty"`
+}
+
+func parseEditImageReq(task string, from *structpb.Struct) (stableImageReq, error) {
+	input := EditImageInput{}
+	if err := base.ConvertFromStructpb(from, &input); err != nil {
+		return stableImageReq{}, err
+	}
+
+	if input.Image == "" {
+		return stableImageReq{}, fmt.Errorf("no image given")
+	}
+
+	req := stableImageReq{
+		fields: map[string]string{},
+		images: map[string]string{"image": input.Image},
+	}
+
+	switch task {
+	case UpscaleTask:
+		mode := input.Mode
+		if mode == "" {
+			mode = upscaleModeFast
+		}
+
+		switch mode {
+		case upscaleModeFast:
+		case upscaleModeConservative:
+			if input.Prompt == "" {
+				return stableImageReq{}, fmt.Errorf("the conservative upscale requires a prompt")
+			}
+			req.setPrompts(input)
+			req.setFloat("creativity", input.Creativity)
+			req.setUint("seed", input.Seed)
+		default:
+			return stableImageReq{}, fmt.Errorf("unsupported upscale mode: %s", mode)
+		}
+
+		req.path = fmt.Sprintf(upscalePath, mode)
+	case InpaintTask:
+		if input.Prompt == "" {
+			return stableImageReq{}, fmt.Errorf("no prompt given")
+		}
+
+		// Without a mask, the alpha channel of the image is used as mask.
+		if input.Mask != "" {
+			req.images["mask"] = input.Mask
+		}
+
+		req.path = inpaintPath
+		req.setPrompts(input)
+		req.setUint("grow_mask", input.GrowMask)
+		req.setUint("seed", input.Seed)
+	case OutpaintTask:
+		var extended bool
+		for _, d := range []*uint32{input.Left, input.Right, input.Up, input.Down} {
+			if d != nil && *d > 0 {
+				extended = true
+			}
+		}
+		if !extended {
+			return stableImageReq{}, fmt.Errorf("at least one direction must be outpainted")
+		}
+
+		req.path = outpaintPath
+		req.setUint("left", input.Left)
+		req.setUint("right", input.Right)
+		req.setUint("up", input.Up)
+		req.setUint("down", input.Down)
+		req.setPrompts(input)
+		req.setFloat("creativity", input.Creativity)
+		req.setUint("seed", input.Seed)
+	case RemoveBackgroundTask:
+		req.path = removeBackgroundPath
+	case SearchAndReplaceTask:
+		if input.Prompt == "" || input.SearchPrompt == "" {
+			return stableImageReq{}, fmt.Errorf("both the prompt and the search prompt are required")
+		}
+
+		req.path = searchAndReplacePath
+		req.fields["search_prompt"] = input.SearchPrompt
+		req.setPrompts(input)
+		req.setUint("grow_mask", input.GrowMask)
+		req.setUint("seed", input.Seed)
+	default:
+		return stableImageReq{}, fmt.Errorf("not supported task: %s", task)
+	}
+
+	if input.OutputFormat != "" {
+		req.fields["output_format"] = input.OutputFormat
+	}
+
+	return req, nil
+}
+
+func (r stableImageReq) setPrompts(input EditImageInput) {
+	if input.Prompt != "" {
+		r.fields["prompt"] = input.Prompt
+	}
+	if input.NegativePrompt != "" {
+		r.fields["negative_prompt"] = input.NegativePrompt
+	}
+}
+
+func (r stableImageReq) setUint(name string, v *uint32) {
+	if v != nil {
+		r.fields[name] = fmt.Sprintf("%d", *v)
+	}
+}
+
+func (r stableImageReq) setFloat(name string, v *float64) {
+	if v != nil {
+		r.fields[name] = fmt.Sprintf("%f", *v)
+	}
+}
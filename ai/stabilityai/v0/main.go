@@ -17,8 +17,13 @@ import (
 const (
 	host = "https://api.stability.ai"
 
-	TextToImageTask  = "TASK_TEXT_TO_IMAGE"
-	ImageToImageTask = "TASK_IMAGE_TO_IMAGE"
+	TextToImageTask      = "TASK_TEXT_TO_IMAGE"
+	ImageToImageTask     = "TASK_IMAGE_TO_IMAGE"
+	UpscaleTask          = "TASK_UPSCALE"
+	InpaintTask          = "TASK_INPAINT"
+	OutpaintTask         = "TASK_OUTPAINT"
+	RemoveBackgroundTask = "TASK_REMOVE_BACKGROUND"
+	SearchAndReplaceTask = "TASK_SEARCH_AND_REPLACE"
 
 	cfgAPIKey = "api-key"
 )
@@ -122,8 +127,15 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 			continue
 		}
 		var output *structpb.Struct
-		switch e.Task {
-		case TextToImageTask:
+		switch {
+		case isStableImageTask(e.Task, input):
+			output, err = executeStableImageTask(ctx, client, e.Task, input)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+		case e.Task == TextToImageTask:
 			params, err := parseTextToImageReq(input)
 			if err != nil {
 				job.Error.Error(ctx, err)
@@ -144,7 +156,7 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 				continue
 			}
 
-		case ImageToImageTask:
+		case e.Task == ImageToImageTask:
 			params, err := parseImageToImageReq(input)
 			if err != nil {
 				job.Error.Error(ctx, err)
@@ -0,0 +1,261 @@
+package stabilityai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	generateCorePath  = "/v2beta/stable-image/generate/core"
+	generateUltraPath = "/v2beta/stable-image/generate/ultra"
+	generateSD3Path   = "/v2beta/stable-image/generate/sd3"
+
+	defaultOutputFormat = "png"
+)
+
+// stableImageEngines maps the engines of the Stable Image API to their
+// generation path. These engines are selected like the v1 engines in the
+// text-to-image and image-to-image tasks.
+var stableImageEngines = map[string]string{
+	"stable-image-core":  generateCorePath,
+	"stable-image-ultra": generateUltraPath,
+	"sd3-large":          generateSD3Path,
+	"sd3-large-turbo":    generateSD3Path,
+	"sd3-medium":         generateSD3Path,
+}
+
+// StableImageOutput is the output of the tasks that use the Stable Image
+// API. Each image comes with its seed and finish reason, which tells whether
+// the image was filtered.
+type StableImageOutput struct {
+	Images        []string `json:"images"`
+	Seeds         []uint32 `json:"seeds"`
+	FinishReasons []string `json:"finish-reasons"`
+}
+
+// stableImageReq is a request to the Stable Image API, which takes the
+// parameters and the images as multipart form fields.
+type stableImageReq struct {
+	path   string
+	fields map[string]string
+	// images holds the base64-encoded images by field name.
+	images map[string]string
+}
+
+type stableImageResp struct {
+	Image        string `json:"image"`
+	Seed         uint32 `json:"seed"`
+	FinishReason string `json:"finish_reason"`
+}
+
+// isStableImageTask returns whether a task is executed with the Stable Image
+// API. The generation tasks use it when a Stable Image engine is selected.
+func isStableImageTask(task string, input *structpb.Struct) bool {
+	switch task {
+	case TextToImageTask, ImageToImageTask:
+		_, ok := stableImageEngines[input.GetFields()["engine"].GetStringValue()]
+		return ok
+	case UpscaleTask, InpaintTask, OutpaintTask, RemoveBackgroundTask, SearchAndReplaceTask:
+		return true
+	default:
+		return false
+	}
+}
+
+func executeStableImageTask(ctx context.Context, client httpclient.IClient, task string, input *structpb.Struct) (*structpb.Struct, error) {
+	var req stableImageReq
+	var err error
+	switch task {
+	case TextToImageTask:
+		req, err = parseStableImageTextToImageReq(input)
+	case ImageToImageTask:
+		req, err = parseStableImageImageToImageReq(input)
+	default:
+		req, err = parseEditImageReq(task, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	format := req.fields["output_format"]
+	if format == "" {
+		format = defaultOutputFormat
+		req.fields["output_format"] = format
+	}
+
+	resp, err := req.send(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(StableImageOutput{
+		Images:        []string{fmt.Sprintf("data:image/%s;base64,%s", format, resp.Image)},
+		Seeds:         []uint32{resp.Seed},
+		FinishReasons: []string{resp.FinishReason},
+	})
+}
+
+// parseStableImageTextToImageReq converts the text-to-image input of a
+// Stable Image engine. The prompts with a negative weight form the negative
+// prompt, as the Stable Image API doesn't weight the prompts.
+func parseStableImageTextToImageReq(from *structpb.Struct) (stableImageReq, error) {
+	input := TextToImageInput{}
+	if err := base.ConvertFromStructpb(from, &input); err != nil {
+		return stableImageReq{}, err
+	}
+
+	if err := checkStableImageSamples(input.Samples); err != nil {
+		return stableImageReq{}, err
+	}
+
+	req, err := newStableImageGenerationReq(input.Engine, input.Prompts, input.Weights, input.Seed)
+	if err != nil {
+		return stableImageReq{}, err
+	}
+
+	if input.AspectRatio != nil {
+		req.fields["aspect_ratio"] = *input.AspectRatio
+	}
+	if input.StylePreset != nil && req.path == generateCorePath {
+		req.fields["style_preset"] = *input.StylePreset
+	}
+	if req.path == generateSD3Path {
+		req.fields["mode"] = "text-to-image"
+	}
+
+	return req, nil
+}
+
+// parseStableImageImageToImageReq converts the image-to-image input of a
+// Stable Image engine. The image strength controls how much the image
+// influences the generation.
+func parseStableImageImageToImageReq(from *structpb.Struct) (stableImageReq, error) {
+	input := ImageToImageInput{}
+	if err := base.ConvertFromStructpb(from, &input); err != nil {
+		return stableImageReq{}, err
+	}
+
+	if err := checkStableImageSamples(input.Samples); err != nil {
+		return stableImageReq{}, err
+	}
+
+	req, err := newStableImageGenerationReq(input.Engine, input.Prompts, input.Weights, input.Seed)
+	if err != nil {
+		return stableImageReq{}, err
+	}
+
+	switch req.path {
+	case generateCorePath:
+		return stableImageReq{}, fmt.Errorf("%s doesn't support image-to-image generation", input.Engine)
+	case generateSD3Path:
+		req.fields["mode"] = "image-to-image"
+	}
+
+	if input.ImageStrength == nil {
+		return stableImageReq{}, fmt.Errorf("image strength is required by the %s engine", input.Engine)
+	}
+
+	req.images["image"] = input.InitImage
+	req.setFloat("strength", input.ImageStrength)
+
+	return req, nil
+}
+
+func newStableImageGenerationReq(engine string, prompts []string, weights *[]float64, seed *uint32) (stableImageReq, error) {
+	var positive, negative []string
+	for i, p := range prompts {
+		if weights != nil && len(*weights) > i && (*weights)[i] < 0 {
+			negative = append(negative, p)
+			continue
+		}
+		positive = append(positive, p)
+	}
+
+	if len(positive) == 0 {
+		return stableImageReq{}, fmt.Errorf("no text prompts given")
+	}
+
+	req := stableImageReq{
+		path:   stableImageEngines[engine],
+		fields: map[string]string{"prompt": strings.Join(positive, ", ")},
+		images: map[string]string{},
+	}
+
+	if len(negative) > 0 {
+		req.fields["negative_prompt"] = strings.Join(negative, ", ")
+	}
+	req.setUint("seed", seed)
+	if req.path == generateSD3Path {
+		req.fields["model"] = engine
+	}
+
+	return req, nil
+}
+
+// checkStableImageSamples checks the number of images requested to a Stable
+// Image engine, which generates a single image per request.
+func checkStableImageSamples(samples *uint32) error {
+	if samples != nil && *samples > 1 {
+		return fmt.Errorf("the Stable Image engines generate a single sample per request")
+	}
+	return nil
+}
+
+func (r stableImageReq) send(ctx context.Context, client httpclient.IClient) (stableImageResp, error) {
+	data := &bytes.Buffer{}
+	writer := multipart.NewWriter(data)
+
+	// The fields are sorted so the requests are deterministic.
+	names := make([]string, 0, len(r.images))
+	for name := range r.images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b, err := util.DecodeBase64(r.images[name])
+		if err != nil {
+			return stableImageResp{}, fmt.Errorf("decoding %s: %w", name, err)
+		}
+		if err := util.WriteFile(writer, name, b); err != nil {
+			return stableImageResp{}, err
+		}
+	}
+
+	names = names[:0]
+	for name := range r.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		util.WriteField(writer, name, r.fields[name])
+	}
+	if err := writer.Close(); err != nil {
+		return stableImageResp{}, err
+	}
+
+	// The image is returned as a base64 string in a JSON body when JSON is
+	// accepted, instead of the raw image bytes.
+	resp := stableImageResp{}
+	req := client.R().SetContext(ctx).
+		SetBody(data.Bytes()).
+		SetHeader("Content-Type", writer.FormDataContentType()).
+		SetHeader("Accept", httpclient.MIMETypeJSON).
+		SetResult(&resp)
+	if _, err := req.Post(r.path); err != nil {
+		return stableImageResp{}, err
+	}
+
+	return resp, nil
+}
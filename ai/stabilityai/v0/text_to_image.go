@@ -31,6 +31,7 @@ type TextToImageInput struct {
 	Seed               *uint32    `json:"seed,omitempty"`
 	Steps              *uint32    `json:"steps,omitempty"`
 	StylePreset        *string    `json:"style-preset,omitempty"`
+	AspectRatio        *string    `json:"aspect-ratio,omitempty"`
 }
 
 type TextToImageOutput struct {
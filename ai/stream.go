@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/instill-ai/component/base"
+)
+
+// streamWriteInterval is the number of updates between two writes of a
+// streamed output. As the output contains the whole messages instead of
+// their deltas, writing it on every token would resend the messages each
+// time.
+const streamWriteInterval = 10
+
+// maxEventSize is the maximum size of a server-sent event line.
+const maxEventSize = 1024 * 1024
+
+// StreamWriter writes the partial outputs of a streamed task to a job.
+type StreamWriter struct {
+	ctx     context.Context
+	job     *base.Job
+	updates int
+}
+
+// NewStreamWriter returns a StreamWriter for a job. The final output isn't
+// written by the StreamWriter, as the executor writes the output returned by
+// the task. A nil job discards the partial outputs.
+func NewStreamWriter(ctx context.Context, job *base.Job) *StreamWriter {
+	return &StreamWriter{ctx: ctx, job: job}
+}
+
+// Update records an update of the output and writes it every
+// streamWriteInterval updates.
+func (w *StreamWriter) Update(output any) error {
+	w.updates++
+	if w.updates%streamWriteInterval != 0 || w.job == nil {
+		return nil
+	}
+
+	out, err := base.ConvertToStructpb(output)
+	if err != nil {
+		return fmt.Errorf("converting streamed output: %w", err)
+	}
+	return w.job.Output.Write(w.ctx, out)
+}
+
+// ScanEvents reads a stream of server-sent events and calls handle with the
+// data of each event, until the stream ends or the data is "[DONE]". The
+// event names are ignored, as the providers repeat the event type in the
+// data.
+func ScanEvents(r io.Reader, handle func(data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxEventSize)
+
+	for scanner.Scan() {
+		data, ok := bytes.CutPrefix(scanner.Bytes(), []byte("data:"))
+		if !ok {
+			continue
+		}
+
+		data = bytes.TrimSpace(data)
+		if string(data) == "[DONE]" {
+			return nil
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		if err := handle(data); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading event stream: %w", err)
+	}
+	return nil
+}
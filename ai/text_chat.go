@@ -1,5 +1,30 @@
 package ai
 
+import "strings"
+
+// Finish reasons of a chat choice. They follow the OpenAI values, and the
+// components map the stop reasons of the other providers to them.
+const (
+	FinishReasonStop          = "stop"
+	FinishReasonLength        = "length"
+	FinishReasonContentFilter = "content_filter"
+	FinishReasonToolCalls     = "tool_calls"
+)
+
+// Message roles.
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Content types.
+const (
+	ContentTypeText        = "text"
+	ContentTypeImageURL    = "image-url"
+	ContentTypeImageBase64 = "image-base64"
+)
+
 type TextChatInput struct {
 	Data      InputData `json:"data"`
 	Parameter Parameter `json:"parameter,omitempty"`
@@ -25,6 +50,32 @@ type InputMessage struct {
 	Name     string    `json:"name,omitempty"`
 }
 
+// Text returns the text contents of the message, separated by new lines.
+func (m InputMessage) Text() string {
+	texts := make([]string, 0, len(m.Contents))
+	for _, c := range m.Contents {
+		if c.Type == ContentTypeText {
+			texts = append(texts, c.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// SplitSystemMessages separates the system messages from the conversation,
+// for the providers that take the system prompt as a separate field. The
+// system prompt joins the text of the system messages.
+func SplitSystemMessages(messages []InputMessage) (system string, conversation []InputMessage) {
+	var prompts []string
+	for _, m := range messages {
+		if m.Role == RoleSystem {
+			prompts = append(prompts, m.Text())
+			continue
+		}
+		conversation = append(conversation, m)
+	}
+	return strings.Join(prompts, "\n"), conversation
+}
+
 type Content struct {
 	Type        string `json:"type"`
 	Text        string `json:"text,omitempty"`
@@ -32,6 +83,14 @@ type Content struct {
 	ImageBase64 string `json:"image-base64,omitempty"`
 }
 
+// NumChoices returns the number of choices to generate, which defaults to 1.
+func (p Parameter) NumChoices() int {
+	if p.N == nil || *p.N < 1 {
+		return 1
+	}
+	return *p.N
+}
+
 type TextChatOutput struct {
 	Data     OutputData `json:"data"`
 	Metadata Metadata   `json:"metadata"`
@@ -63,3 +122,10 @@ type Usage struct {
 	PromptTokens     int `json:"prompt-tokens"`
 	TotalTokens      int `json:"total-tokens"`
 }
+
+// Add adds the token counts of another request to the usage.
+func (u *Usage) Add(other Usage) {
+	u.CompletionTokens += other.CompletionTokens
+	u.PromptTokens += other.PromptTokens
+	u.TotalTokens += other.TotalTokens
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSplitSystemMessages(t *testing.T) {
+	c := qt.New(t)
+
+	text := func(role, text string) InputMessage {
+		return InputMessage{Role: role, Contents: []Content{{Type: ContentTypeText, Text: text}}}
+	}
+
+	system, conversation := SplitSystemMessages([]InputMessage{
+		text(RoleSystem, "You are a pirate."),
+		text(RoleUser, "Hi"),
+		{Role: RoleSystem, Contents: []Content{
+			{Type: ContentTypeText, Text: "Be brief."},
+			{Type: ContentTypeImageURL, ImageURL: "https://example.com/flag.png"},
+			{Type: ContentTypeText, Text: "Use emojis."},
+		}},
+		text(RoleAssistant, "Ahoy!"),
+	})
+	c.Check(system, qt.Equals, "You are a pirate.\nBe brief.\nUse emojis.")
+	c.Check(conversation, qt.DeepEquals, []InputMessage{text(RoleUser, "Hi"), text(RoleAssistant, "Ahoy!")})
+}
+
+func TestScanEvents(t *testing.T) {
+	c := qt.New(t)
+
+	stream := `: comment
+event: message
+data: {"text": "Ah"}
+
+data:{"text": "oy"}
+
+data: [DONE]
+data: {"text": "ignored"}
+`
+
+	var got []string
+	err := ScanEvents(strings.NewReader(stream), func(data []byte) error {
+		got = append(got, string(data))
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, []string{`{"text": "Ah"}`, `{"text": "oy"}`})
+
+	err = ScanEvents(strings.NewReader(stream), func(data []byte) error {
+		return fmt.Errorf("invalid event")
+	})
+	c.Check(err, qt.ErrorMatches, "invalid event")
+}
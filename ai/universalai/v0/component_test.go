@@ -1,2 +1,400 @@
-// TODO: chuang8511
 package universalai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	completionsPath = "/v1/chat/completions"
+
+	chatResp = `{
+  "created": 1721236000,
+  "choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "Ahoy!"}}],
+  "usage": {"prompt_tokens": 5, "completion_tokens": 2, "total_tokens": 7}
+}`
+	rateLimitResp    = `{"error": {"message": "Rate limit reached."}}`
+	unauthorizedResp = `{"error": {"message": "Invalid API Key"}}`
+)
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	type response struct {
+		status int
+		body   string
+	}
+
+	wantOutput := func(provider, model string) string {
+		return fmt.Sprintf(`{
+  "data": {"choices": [{"created": 1721236000, "finish-reason": "stop", "index": 0, "message": {"content": "Ahoy!", "role": "assistant"}}]},
+  "metadata": {
+    "usage": {"completion-tokens": 2, "prompt-tokens": 5, "total-tokens": 7},
+    "provider": %q,
+    "model": %q
+  }
+}`, provider, model)
+	}
+
+	testcases := []struct {
+		name       string
+		model      string
+		fallback   []string
+		responses  map[string]response
+		wantModels map[string]string
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:       "ok - provider/model",
+			model:      "groq/llama-3.1-8b-instant",
+			responses:  map[string]response{"groq": {http.StatusOK, chatResp}},
+			wantModels: map[string]string{"groq": "llama-3.1-8b-instant"},
+			wantOutput: wantOutput("groq", "llama-3.1-8b-instant"),
+		},
+		{
+			name:       "ok - OpenAI model without provider",
+			model:      "gpt-4o-mini",
+			responses:  map[string]response{"openai": {http.StatusOK, chatResp}},
+			wantModels: map[string]string{"openai": "gpt-4o-mini"},
+			wantOutput: wantOutput("openai", "gpt-4o-mini"),
+		},
+		{
+			name:       "ok - alias",
+			model:      "fast",
+			responses:  map[string]response{"mistral": {http.StatusOK, chatResp}},
+			wantModels: map[string]string{"mistral": "open-mistral-nemo"},
+			wantOutput: wantOutput("mistral", "open-mistral-nemo"),
+		},
+		{
+			name:     "ok - fallback on rate limit",
+			model:    "groq/llama-3.1-8b-instant",
+			fallback: []string{"fast"},
+			responses: map[string]response{
+				"groq":    {http.StatusTooManyRequests, rateLimitResp},
+				"mistral": {http.StatusOK, chatResp},
+			},
+			wantModels: map[string]string{
+				"groq":    "llama-3.1-8b-instant",
+				"mistral": "open-mistral-nemo",
+			},
+			wantOutput: wantOutput("mistral", "open-mistral-nemo"),
+		},
+		{
+			name:     "nok - no fallback on unauthorized request",
+			model:    "groq/llama-3.1-8b-instant",
+			fallback: []string{"fast"},
+			responses: map[string]response{
+				"groq":    {http.StatusUnauthorized, unauthorizedResp},
+				"mistral": {http.StatusOK, chatResp},
+			},
+			wantModels: map[string]string{"groq": "llama-3.1-8b-instant"},
+			wantErr:    "Groq responded with a 401 status code. Invalid API Key",
+		},
+		{
+			name:     "nok - every model fails",
+			model:    "groq/llama-3.1-8b-instant",
+			fallback: []string{"openai/gpt-4o-mini"},
+			responses: map[string]response{
+				"groq":   {http.StatusTooManyRequests, rateLimitResp},
+				"openai": {http.StatusServiceUnavailable, `{"error": {"message": "Service unavailable."}}`},
+			},
+			wantModels: map[string]string{
+				"groq":   "llama-3.1-8b-instant",
+				"openai": "gpt-4o-mini",
+			},
+			wantErr: "OpenAI responded with a 503 status code. Service unavailable.",
+		},
+		{
+			name:    "nok - unsupported provider",
+			model:   "acme/model-1",
+			wantErr: "unsupported provider: acme",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			apiKeys := map[string]string{
+				"openai":  "openai-key",
+				"groq":    "groq-key",
+				"mistral": "mistral-key",
+			}
+			setup := map[string]any{
+				"api-key":         apiKeys["openai"],
+				"groq-api-key":    apiKeys["groq"],
+				"mistral-api-key": apiKeys["mistral"],
+				"aliases":         map[string]any{"fast": "mistral/open-mistral-nemo"},
+			}
+
+			gotModels := map[string]string{}
+			for provider, resp := range tc.responses {
+				h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					c.Check(r.Method, qt.Equals, http.MethodPost)
+					c.Check(r.URL.Path, qt.Equals, completionsPath)
+					c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKeys[provider])
+
+					body, err := io.ReadAll(r.Body)
+					c.Assert(err, qt.IsNil)
+
+					req := struct {
+						Model string `json:"model"`
+					}{}
+					c.Assert(json.Unmarshal(body, &req), qt.IsNil)
+					gotModels[provider] = req.Model
+
+					w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+					w.WriteHeader(resp.status)
+					fmt.Fprintln(w, resp.body)
+				})
+
+				srv := httptest.NewServer(h)
+				c.Cleanup(srv.Close)
+
+				setup[provider+"-base-path"] = srv.URL
+			}
+
+			pbSetup, err := structpb.NewStruct(setup)
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     pbSetup,
+				Task:      TextChatTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			fallback := make([]any, len(tc.fallback))
+			for i, m := range tc.fallback {
+				fallback[i] = m
+			}
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{
+					"model": tc.model,
+					"messages": []any{
+						map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Say hi like a pirate."}}},
+					},
+					"fallback-models": fallback,
+				},
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				c.Check(tc.wantOutput, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+
+			var gotErr bool
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				gotErr = true
+				c.Check(errmsg.MessageOrErr(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+			c.Check(gotErr, qt.Equals, tc.wantErr != "")
+
+			wantModels := tc.wantModels
+			if wantModels == nil {
+				wantModels = map[string]string{}
+			}
+			c.Check(gotModels, qt.DeepEquals, wantModels)
+		})
+	}
+}
+
+func TestComponent_UsesInstillCredentials(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	cmp := Init(base.Component{}).WithInstillCredentials(map[string]any{"apikey": "instill-key"})
+	c.Cleanup(func() { cmp.instillAPIKey = "" })
+
+	testcases := []struct {
+		name     string
+		model    string
+		fallback []string
+		statuses map[string]int
+		want     bool
+	}{
+		{
+			name:     "OpenAI model",
+			model:    "gpt-4o-mini",
+			statuses: map[string]int{"openai": http.StatusOK},
+			want:     true,
+		},
+		{
+			name:     "other provider",
+			model:    "groq/llama-3.1-8b-instant",
+			statuses: map[string]int{"groq": http.StatusOK},
+			want:     false,
+		},
+		{
+			name:     "fallback from OpenAI",
+			model:    "gpt-4o-mini",
+			fallback: []string{"groq/llama-3.1-8b-instant"},
+			statuses: map[string]int{"openai": http.StatusTooManyRequests, "groq": http.StatusOK},
+			want:     false,
+		},
+		{
+			name:     "fallback to OpenAI",
+			model:    "groq/llama-3.1-8b-instant",
+			fallback: []string{"gpt-4o-mini"},
+			statuses: map[string]int{"groq": http.StatusTooManyRequests, "openai": http.StatusOK},
+			want:     true,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			apiKeys := map[string]string{
+				"openai": "instill-key",
+				"groq":   "groq-key",
+			}
+			setup := map[string]any{"groq-api-key": apiKeys["groq"]}
+
+			for provider, status := range tc.statuses {
+				h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer "+apiKeys[provider])
+
+					w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+					w.WriteHeader(status)
+					if status == http.StatusOK {
+						fmt.Fprintln(w, chatResp)
+						return
+					}
+					fmt.Fprintln(w, rateLimitResp)
+				})
+
+				srv := httptest.NewServer(h)
+				c.Cleanup(srv.Close)
+
+				setup[provider+"-base-path"] = srv.URL
+			}
+
+			pbSetup, err := structpb.NewStruct(setup)
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     pbSetup,
+				Task:      TextChatTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			fallback := make([]any, len(tc.fallback))
+			for i, m := range tc.fallback {
+				fallback[i] = m
+			}
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{
+					"model": tc.model,
+					"messages": []any{
+						map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Say hi like a pirate."}}},
+					},
+					"fallback-models": fallback,
+				},
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Return(nil)
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Fatalf("unexpected error: %v", err)
+			})
+
+			c.Check(exec.UsesInstillCredentials(), qt.IsFalse)
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+			c.Check(exec.UsesInstillCredentials(), qt.Equals, tc.want)
+		})
+	}
+}
+
+func TestResolveModel(t *testing.T) {
+	c := qt.New(t)
+
+	aliases := map[string]string{"smart": "anthropic/claude-3-5-sonnet-20240620"}
+
+	testcases := []struct {
+		id           string
+		wantProvider string
+		wantModel    string
+		wantErr      string
+	}{
+		{id: "cohere/command-r-plus", wantProvider: "cohere", wantModel: "command-r-plus"},
+		{id: "fireworks/accounts/fireworks/models/llama-v3p1-8b-instruct", wantProvider: "fireworks", wantModel: "accounts/fireworks/models/llama-v3p1-8b-instruct"},
+		{id: "ollama/llama3.1:8b", wantProvider: "ollama", wantModel: "llama3.1:8b"},
+		{id: "gpt-4o", wantProvider: "openai", wantModel: "gpt-4o"},
+		{id: "smart", wantProvider: "anthropic", wantModel: "claude-3-5-sonnet-20240620"},
+		{id: "llama3", wantErr: `invalid model "llama3", expected provider/model`},
+		{id: "groq/", wantErr: `invalid model "groq/", expected provider/model`},
+		{id: "acme/model-1", wantErr: "unsupported provider: acme"},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.id, func(c *qt.C) {
+			provider, model, err := resolveModel(tc.id, aliases)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(provider, qt.Equals, tc.wantProvider)
+			c.Check(model, qt.Equals, tc.wantModel)
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "rate limit", err: fmt.Errorf("wrapped: %w", &httpclient.ResponseError{StatusCode: http.StatusTooManyRequests}), want: true},
+		{name: "server error", err: &httpclient.ResponseError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "bad request", err: &httpclient.ResponseError{StatusCode: http.StatusBadRequest}, want: false},
+		{name: "timeout", err: fmt.Errorf("reading stream: %w", context.DeadlineExceeded), want: true},
+		{name: "unreachable provider", err: &url.Error{Op: "Post", URL: "http://localhost:1", Err: fmt.Errorf("connection refused")}, want: true},
+		{name: "other", err: fmt.Errorf("invalid input"), want: false},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			c.Check(isRetryable(context.Background(), tc.err), qt.Equals, tc.want)
+		})
+	}
+
+	c.Run("cancelled execution", func(c *qt.C) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		c.Check(isRetryable(ctx, context.DeadlineExceeded), qt.IsFalse)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	_ "embed"
 
@@ -32,16 +33,28 @@ var (
 	comp *component
 )
 
-// Connector executes queries against OpenAI.
+// component routes the chat requests to the AI platform of the requested
+// model.
 type component struct {
 	base.Component
 
 	instillAPIKey string
 }
 
+// setup holds the setup fields that apply to every provider. The provider
+// credentials are passed to the provider components.
+type setup struct {
+	Aliases map[string]string `json:"aliases"`
+	Timeout int               `json:"timeout"`
+}
+
 type execution struct {
 	base.ComponentExecution
-	usesInstillCredentials bool
+	// injectedInstillKey indicates that the OpenAI API key is the Instill
+	// one.
+	injectedInstillKey     bool
+	usesInstillCredentials atomic.Bool
+	setup                  setup
 	execute                func(*structpb.Struct, *base.Job, context.Context) (*structpb.Struct, error)
 }
 
@@ -59,7 +72,7 @@ func Init(bc base.Component) *component {
 }
 
 func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
-	resolvedSetup, resolved, err := c.resolveSetup(x.Setup)
+	resolvedSetup, injected, err := c.resolveSetup(x.Setup)
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +80,12 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 	x.Setup = resolvedSetup
 
 	e := &execution{
-		ComponentExecution:     x,
-		usesInstillCredentials: resolved,
+		ComponentExecution: x,
+		injectedInstillKey: injected,
+	}
+
+	if err := base.ConvertFromStructpb(x.Setup, &e.setup); err != nil {
+		return nil, fmt.Errorf("error parsing setup, %v", err)
 	}
 
 	switch x.Task {
@@ -86,7 +103,7 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 }
 
 func (e *execution) UsesInstillCredentials() bool {
-	return e.usesInstillCredentials
+	return e.usesInstillCredentials.Load()
 }
 
 // WithInstillCredentials loads Instill credentials into the component, which
@@ -99,7 +116,9 @@ func (c *component) WithInstillCredentials(s map[string]any) *component {
 
 // resolveSetup checks whether the component is configured to use the Instill
 // credentials injected during initialization and, if so, returns a new setup
-// with the secret credential values.
+// with the secret credential values. The returned flag indicates whether the
+// Instill OpenAI API key was injected, which doesn't mean it is used: the
+// requests to other providers use their own keys.
 func (c *component) resolveSetup(setup *structpb.Struct) (*structpb.Struct, bool, error) {
 	if setup == nil || setup.Fields == nil {
 		setup = &structpb.Struct{Fields: map[string]*structpb.Value{}}
@@ -111,8 +130,13 @@ func (c *component) resolveSetup(setup *structpb.Struct) (*structpb.Struct, bool
 		}
 	}
 
+	// The OpenAI API key is optional, as the models might be served by
+	// other providers.
 	if c.instillAPIKey == "" {
-		return nil, false, base.NewUnresolvedCredential(cfgAPIKey)
+		if v, ok := setup.GetFields()[cfgAPIKey]; ok && v.GetStringValue() == base.SecretKeyword {
+			return nil, false, base.NewUnresolvedCredential(cfgAPIKey)
+		}
+		return setup, false, nil
 	}
 
 	setup.GetFields()[cfgAPIKey] = structpb.NewStringValue(c.instillAPIKey)
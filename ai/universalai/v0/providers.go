@@ -0,0 +1,141 @@
+package universalai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+
+	anthropicv1 "github.com/instill-ai/component/ai/anthropic/v1"
+	coherev1 "github.com/instill-ai/component/ai/cohere/v1"
+	fireworksaiv1 "github.com/instill-ai/component/ai/fireworksai/v1"
+	groqv1 "github.com/instill-ai/component/ai/groq/v1"
+	mistralaiv1 "github.com/instill-ai/component/ai/mistralai/v1"
+	ollamav1 "github.com/instill-ai/component/ai/ollama/v1"
+	openaiv1 "github.com/instill-ai/component/ai/openai/v1"
+)
+
+// provider executes the chat task on an AI platform through the component of
+// that platform.
+type provider struct {
+	// setupFields maps the fields of the Universal AI setup to the ones of
+	// the provider's component setup. The base-path fields allow us to
+	// override the provider APIs in tests and aren't meant to be exposed to
+	// users.
+	setupFields map[string]string
+	chat        func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error)
+}
+
+var providers = map[string]provider{
+	"openai": {
+		setupFields: map[string]string{
+			cfgAPIKey:          "api-key",
+			cfgOrganization:    "organization",
+			"openai-base-path": "base-path",
+		},
+		chat: func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+			return openaiv1.ExecuteTextChat(in, openaiv1.NewClient(setup, logger), job, ctx)
+		},
+	},
+	"anthropic": {
+		setupFields: map[string]string{
+			"anthropic-api-key":   "api-key",
+			"anthropic-base-path": "base-path",
+		},
+		chat: func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+			return anthropicv1.ExecuteTextChat(in, anthropicv1.NewClient(setup, logger), job, ctx)
+		},
+	},
+	"mistral": {
+		setupFields: map[string]string{
+			"mistral-api-key":   "api-key",
+			"mistral-base-path": "base-path",
+		},
+		chat: func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+			return mistralaiv1.ExecuteTextChat(in, mistralaiv1.NewClient(setup, logger), job, ctx)
+		},
+	},
+	"groq": {
+		setupFields: map[string]string{
+			"groq-api-key":   "api-key",
+			"groq-base-path": "base-path",
+		},
+		chat: func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+			return groqv1.ExecuteTextChat(in, groqv1.NewClient(setup, logger), job, ctx)
+		},
+	},
+	"ollama": {
+		setupFields: map[string]string{
+			"ollama-endpoint":  "endpoint",
+			"ollama-auto-pull": "auto-pull",
+		},
+		chat: func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+			client, err := ollamav1.NewClient(setup, logger)
+			if err != nil {
+				return nil, err
+			}
+			return ollamav1.ExecuteTextChat(in, client, job, ctx)
+		},
+	},
+	"cohere": {
+		setupFields: map[string]string{
+			"cohere-api-key":   "api-key",
+			"cohere-base-path": "base-path",
+		},
+		chat: func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+			return coherev1.ExecuteTextChat(in, coherev1.NewClient(setup, logger), job, ctx)
+		},
+	},
+	"fireworks": {
+		setupFields: map[string]string{
+			"fireworks-api-key":   "api-key",
+			"fireworks-base-path": "base-path",
+		},
+		chat: func(in ai.TextChatInput, setup *structpb.Struct, logger *zap.Logger, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+			return fireworksaiv1.ExecuteTextChat(in, fireworksaiv1.NewClient(setup, logger), job, ctx)
+		},
+	},
+}
+
+// providerSetup extracts the setup of a provider's component from the
+// Universal AI setup.
+func (p provider) providerSetup(setup *structpb.Struct) *structpb.Struct {
+	s := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for from, to := range p.setupFields {
+		if v, ok := setup.GetFields()[from]; ok {
+			s.Fields[to] = v
+		}
+	}
+	return s
+}
+
+// resolveModel resolves a model alias and splits the model identifier into
+// its provider and model. Identifiers without a provider are OpenAI models,
+// which were the only ones supported by the first version of the component.
+func resolveModel(id string, aliases map[string]string) (providerName, model string, err error) {
+	if target, ok := aliases[id]; ok {
+		id = target
+	}
+
+	providerName, model, found := strings.Cut(id, "/")
+	if !found {
+		if vendor, ok := ModelVendorMap[id]; ok {
+			return vendor, id, nil
+		}
+		return "", "", fmt.Errorf("invalid model %q, expected provider/model", id)
+	}
+
+	if _, ok := providers[providerName]; !ok {
+		return "", "", fmt.Errorf("unsupported provider: %s", providerName)
+	}
+	if model == "" {
+		return "", "", fmt.Errorf("invalid model %q, expected provider/model", id)
+	}
+
+	return providerName, model, nil
+}
@@ -2,41 +2,133 @@ package universalai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"time"
 
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/component/ai"
 	"github.com/instill-ai/component/base"
 	"github.com/instill-ai/component/internal/util/httpclient"
-
-	openaiv1 "github.com/instill-ai/component/ai/openai/v1"
 )
 
+// chatInput holds the input fields that extend the shared chat input.
+type chatInput struct {
+	Data struct {
+		FallbackModels []string `json:"fallback-models"`
+	} `json:"data"`
+}
+
 func (e *execution) ExecuteTextChat(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
 	inputStruct := ai.TextChatInput{}
-
 	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
 		return nil, fmt.Errorf("failed to convert input to TextChatInput: %w", err)
 	}
 
-	x := e.ComponentExecution
-	vendor := ModelVendorMap[inputStruct.Data.Model]
+	extraInput := chatInput{}
+	if err := base.ConvertFromStructpb(input, &extraInput); err != nil {
+		return nil, fmt.Errorf("failed to convert input to chatInput: %w", err)
+	}
+
+	// The models are tried in order until one of them serves the request.
+	// Only the retryable errors fall back to the next model, as the other
+	// ones (e.g. an invalid request) would likely fail with any model.
+	models := append([]string{inputStruct.Data.Model}, extraInput.Data.FallbackModels...)
+
+	var err error
+	for i, id := range models {
+		var output *structpb.Struct
+		output, err = e.chat(inputStruct, id, job, ctx)
+		if err == nil {
+			return output, nil
+		}
 
-	client, err := newClient(x.GetSetup(), x.GetLogger(), vendor)
+		if i == len(models)-1 || !isRetryable(ctx, err) {
+			break
+		}
 
+		e.GetLogger().Warn("Model failed, falling back to the next model",
+			zap.String("model", id),
+			zap.String("fallback", models[i+1]),
+			zap.Error(err),
+		)
+	}
+
+	return nil, err
+}
+
+// chat executes the chat task with the model identified by id. The provider
+// and model that served the request are added to the output metadata. The
+// Instill credentials are only used when OpenAI serves the request with the
+// injected key.
+func (e *execution) chat(in ai.TextChatInput, id string, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	providerName, model, err := resolveModel(id, e.setup.Aliases)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
+		return nil, err
 	}
 
-	switch vendor {
-	case "openai":
-		return openaiv1.ExecuteTextChat(inputStruct, client.(*httpclient.Client), job, ctx)
-	default:
-		return nil, fmt.Errorf("unsupported vendor: %s", vendor)
+	if e.setup.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(e.setup.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	p := providers[providerName]
+	in.Data.Model = model
+	output, err := p.chat(in, p.providerSetup(e.GetSetup()), e.GetLogger(), job, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("executing chat with %s: %w", id, err)
 	}
+
+	if providerName == "openai" && e.injectedInstillKey {
+		e.usesInstillCredentials.Store(true)
+	}
+
+	metadata := output.GetFields()["metadata"].GetStructValue()
+	if metadata == nil {
+		metadata = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		output.Fields["metadata"] = structpb.NewStructValue(metadata)
+	}
+	metadata.Fields["provider"] = structpb.NewStringValue(providerName)
+	metadata.Fields["model"] = structpb.NewStringValue(model)
+
+	return output, nil
+}
+
+// isRetryable determines whether a failed request might be served by another
+// model: rate limits, server errors, timeouts and unreachable providers.
+func isRetryable(ctx context.Context, err error) bool {
+	// The execution has been cancelled, so there's no point in falling back.
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	respErr := new(httpclient.ResponseError)
+	if errors.As(err, &respErr) {
+		switch {
+		case respErr.StatusCode == http.StatusRequestTimeout,
+			respErr.StatusCode == http.StatusTooManyRequests,
+			respErr.StatusCode >= http.StatusInternalServerError:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
 }
 
+// ModelVendorMap holds the OpenAI models that can be referenced without
+// their provider.
 var ModelVendorMap = map[string]string{
 	"o1-preview":             "openai",
 	"o1-mini":                "openai",
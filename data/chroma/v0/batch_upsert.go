@@ -6,10 +6,12 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
 )
 
 type BatchUpsertOutput struct {
-	Status string `json:"status"`
+	Status  string               `json:"status"`
+	Results []batchupsert.Result `json:"results"`
 }
 
 type BatchUpsertInput struct {
@@ -19,6 +21,22 @@ type BatchUpsertInput struct {
 	ArrayMetadata  []map[string]any `json:"array-metadata"`
 	ArrayURI       []string         `json:"array-uri"`
 	ArrayDocument  []string         `json:"array-document"`
+
+	batchupsert.Options
+}
+
+func (in *BatchUpsertInput) validate() error {
+	n := len(in.ArrayVector)
+	if in.ArrayMetadata != nil && len(in.ArrayMetadata) != n {
+		return fmt.Errorf("array-metadata and array-vector must have the same length")
+	}
+	if in.ArrayURI != nil && len(in.ArrayURI) != n {
+		return fmt.Errorf("array-uri and array-vector must have the same length")
+	}
+	if in.ArrayDocument != nil && len(in.ArrayDocument) != n {
+		return fmt.Errorf("array-document and array-vector must have the same length")
+	}
+	return nil
 }
 
 func (e *execution) batchUpsert(in *structpb.Struct) (*structpb.Struct, error) {
@@ -28,45 +46,61 @@ func (e *execution) batchUpsert(in *structpb.Struct) (*structpb.Struct, error) {
 		return nil, err
 	}
 
-	resp := UpsertResp{}
-
-	reqParams := UpsertReq{
-		Embeddings: inputStruct.ArrayVector,
-		Metadatas:  inputStruct.ArrayMetadata,
-		IDs:        inputStruct.ArrayID,
-	}
-	if inputStruct.ArrayDocument != nil {
-		reqParams.Documents = inputStruct.ArrayDocument
-	}
-	if inputStruct.ArrayURI != nil {
-		reqParams.Uris = inputStruct.ArrayURI
+	if err := inputStruct.validate(); err != nil {
+		return nil, err
 	}
 
-	var collID string
-
-	collID, err = getCollectionID(inputStruct.CollectionName, e.client)
+	ids, err := batchupsert.FillIDs(inputStruct.ArrayID, len(inputStruct.ArrayVector), func(i int) []any {
+		return []any{
+			inputStruct.ArrayVector[i],
+			batchupsert.At(inputStruct.ArrayMetadata, i),
+			batchupsert.At(inputStruct.ArrayDocument, i),
+			batchupsert.At(inputStruct.ArrayURI, i),
+		}
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	req := e.client.R().SetBody(reqParams).SetResult(&resp)
-
-	res, err := req.Post(fmt.Sprintf(upsertPath, collID))
-
+	collID, err := getCollectionID(inputStruct.CollectionName, e.client)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to batch upsert item: %s", res.String())
-	}
-
-	if resp.Error != "" && resp.Message != "" {
-		return nil, fmt.Errorf("failed to batch upsert item: %s", resp.Message)
+	results, err := batchupsert.Upsert(ids, inputStruct.Options, func(start, end int) error {
+		resp := UpsertResp{}
+
+		reqParams := UpsertReq{
+			Embeddings: inputStruct.ArrayVector[start:end],
+			Metadatas:  batchupsert.Slice(inputStruct.ArrayMetadata, start, end),
+			IDs:        ids[start:end],
+			Documents:  batchupsert.Slice(inputStruct.ArrayDocument, start, end),
+			Uris:       batchupsert.Slice(inputStruct.ArrayURI, start, end),
+		}
+
+		req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+		res, err := req.Post(fmt.Sprintf(upsertPath, collID))
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode() != 200 {
+			return fmt.Errorf("failed to batch upsert item: %s", res.String())
+		}
+
+		if resp.Error != "" && resp.Message != "" {
+			return fmt.Errorf("failed to batch upsert item: %s", resp.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	outputStruct := UpsertOutput{
-		Status: fmt.Sprintf("Successfully batch upserted %d items", len(inputStruct.ArrayID)),
+	outputStruct := BatchUpsertOutput{
+		Status:  batchupsert.Status("batch upserted", "items", results),
+		Results: results,
 	}
 
 	output, err := base.ConvertToStructpb(outputStruct)
@@ -15,6 +15,15 @@ func newClient(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
 	c.SetHeader("Authorization", "Bearer "+getAPIKey(setup))
 	c.SetHeader("Content-Type", "application/json")
 
+	// Collections are looked up by name in a tenant and database, the
+	// server's defaults are used when they're not set.
+	if tenant := getTenant(setup); tenant != "" {
+		c.SetQueryParam("tenant", tenant)
+	}
+	if database := getDatabase(setup); database != "" {
+		c.SetQueryParam("database", database)
+	}
+
 	return c
 }
 
@@ -25,3 +34,11 @@ func getURL(setup *structpb.Struct) string {
 func getAPIKey(setup *structpb.Struct) string {
 	return setup.GetFields()["api-key"].GetStringValue()
 }
+
+func getTenant(setup *structpb.Struct) string {
+	return setup.GetFields()["tenant"].GetStringValue()
+}
+
+func getDatabase(setup *structpb.Struct) string {
+	return setup.GetFields()["database"].GetStringValue()
+}
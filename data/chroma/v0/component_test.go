@@ -15,6 +15,7 @@ import (
 	qt "github.com/frankban/quicktest"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
 	"github.com/instill-ai/component/internal/util/httpclient"
 	"github.com/instill-ai/x/errmsg"
 )
@@ -48,7 +49,7 @@ func TestComponent_ExecuteQueryTask(t *testing.T) {
 				Status: "Successfully queryed 2 items",
 				Result: Result{
 					Ids:      []string{"mockID1", "mockID2"},
-					Items:    []map[string]any{{"distance": 1, "id": "mockID1", "name": "a", "vector": []float32{0.1, 0.2}}, {"distance": 1, "id": "mockID2", "name": "b", "vector": []float32{0.2, 0.3}}},
+					Items:    []map[string]any{{"distance": 1, "similarity": 0.5, "id": "mockID1", "name": "a", "vector": []float32{0.1, 0.2}}, {"distance": 1, "similarity": 0.5, "id": "mockID2", "name": "b", "vector": []float32{0.2, 0.3}}},
 					Vectors:  [][]float64{{0.1, 0.2}, {0.2, 0.3}},
 					Metadata: []map[string]any{{"name": "a"}, {"name": "b"}},
 				},
@@ -400,6 +401,10 @@ func TestComponent_ExecuteBatchUpsertTask(t *testing.T) {
 			},
 			wantResp: BatchUpsertOutput{
 				Status: "Successfully batch upserted 2 items",
+				Results: []batchupsert.Result{
+					{Index: 0, ID: "mockID1", Success: true},
+					{Index: 1, ID: "mockID2", Success: true},
+				},
 			},
 			wantClientPath:              fmt.Sprintf(upsertPath, "mock-collection-id"),
 			getCollectionWantClientPath: fmt.Sprintf(getCollectionPath, "mock-collection"),
@@ -488,6 +493,84 @@ func TestComponent_ExecuteBatchUpsertTask(t *testing.T) {
 	}
 }
 
+func TestComponent_ExecuteBatchUpsertTaskWithPartialFailure(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	cmp := Init(bc)
+
+	generatedID, err := batchupsert.ContentID([]float64{0.3, 0.4}, map[string]any(nil), "", "")
+	c.Assert(err, qt.IsNil)
+
+	var gotIDs [][]string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+		if r.URL.Path == fmt.Sprintf(getCollectionPath, "mock-collection") {
+			fmt.Fprintln(w, `{"id": "mock-collection-id"}`)
+			return
+		}
+
+		var req UpsertReq
+		c.Assert(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+		gotIDs = append(gotIDs, req.IDs)
+
+		for _, v := range req.Embeddings {
+			if len(v) != 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintln(w, `{"error": "InvalidDimension", "message": "dimension mismatch"}`)
+				return
+			}
+		}
+		fmt.Fprintln(w, `null`)
+	})
+
+	chromaServer := httptest.NewServer(h)
+	c.Cleanup(chromaServer.Close)
+
+	setup, _ := structpb.NewStruct(map[string]any{
+		"api-key": "mock-api-key",
+		"url":     chromaServer.URL,
+	})
+
+	exec, err := cmp.CreateExecution(base.ComponentExecution{
+		Component: cmp,
+		Setup:     setup,
+		Task:      TaskBatchUpsert,
+	})
+	c.Assert(err, qt.IsNil)
+
+	pbIn, err := base.ConvertToStructpb(BatchUpsertInput{
+		CollectionName: "mock-collection",
+		ArrayVector:    [][]float64{{0.1, 0.2}, {0.3, 0.4}, {0.5}},
+		ArrayID:        []string{"mockID1", "", "mockID3"},
+		Options: batchupsert.Options{
+			BatchSize:           2,
+			AllowPartialFailure: true,
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	ir, ow, _, job := base.GenerateMockJob(c)
+	ir.ReadMock.Return(pbIn, nil)
+	ow.WriteMock.Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+		wantJSON, err := json.Marshal(BatchUpsertOutput{
+			Status: "Successfully batch upserted 2 items, 1 failed",
+			Results: []batchupsert.Result{
+				{Index: 0, ID: "mockID1", Success: true},
+				{Index: 1, ID: generatedID, Success: true},
+				{Index: 2, ID: "mockID3", Error: `failed to batch upsert item: {"error": "InvalidDimension", "message": "dimension mismatch"}`},
+			},
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+		return nil
+	})
+
+	err = exec.Execute(ctx, []*base.Job{job})
+	c.Check(err, qt.IsNil)
+	c.Check(gotIDs, qt.DeepEquals, [][]string{{"mockID1", generatedID}, {"mockID3"}})
+}
+
 func TestComponent_ExecuteCreateCollectionTask(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -662,3 +745,275 @@ func TestComponent_ExecuteDeleteCollectionTask(t *testing.T) {
 		})
 	}
 }
+
+func TestComponent_ExecuteItemTasks(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	cmp := Init(bc)
+
+	type clientCall struct {
+		method string
+		path   string
+		req    any
+		resp   string
+	}
+
+	getCollection := clientCall{
+		method: http.MethodGet,
+		path:   fmt.Sprintf(getCollectionPath, "mock-collection"),
+		resp:   `{"id": "mock-collection-id", "name": "mock-collection", "metadata": {"hnsw:space": "cosine"}}`,
+	}
+
+	testcases := []struct {
+		name     string
+		task     string
+		setup    map[string]any
+		input    any
+		wantResp any
+		wantErr  string
+
+		wantQuery   map[string]string
+		clientCalls []clientCall
+	}{
+		{
+			name: "ok to query with document filters",
+			task: TaskQuery,
+			input: QueryInput{
+				CollectionName:      "mock-collection",
+				Vector:              []float64{0.1, 0.2},
+				NResults:            1,
+				DocumentContains:    "cat",
+				DocumentNotContains: "dog",
+			},
+			wantResp: QueryOutput{
+				Status: "Successfully queryed 1 items",
+				Result: Result{
+					Ids:      []string{"mockID1"},
+					Items:    []map[string]any{{"distance": 0.25, "similarity": 0.75, "id": "mockID1", "name": "a", "vector": []float64{0.1, 0.2}, "document": "a cat"}},
+					Vectors:  [][]float64{{0.1, 0.2}},
+					Metadata: []map[string]any{{"name": "a"}},
+				},
+			},
+			clientCalls: []clientCall{
+				getCollection,
+				{
+					method: http.MethodPost,
+					path:   fmt.Sprintf(queryPath, "mock-collection-id"),
+					req: map[string]any{
+						"query_embeddings": [][]float64{{0.1, 0.2}},
+						"where":            nil,
+						"where_document": map[string]any{"$and": []any{
+							map[string]any{"$contains": "cat"},
+							map[string]any{"$not_contains": "dog"},
+						}},
+						"n_results": 1,
+						"include":   []string{"embeddings", "metadatas", "distances", "documents"},
+					},
+					resp: `{
+						"ids": [["mockID1"]],
+						"embeddings": [[[0.1, 0.2]]],
+						"metadatas": [[{"name": "a"}]],
+						"documents": [["a cat"]],
+						"distances": [[0.25]]
+					}`,
+				},
+			},
+		},
+		{
+			name: "ok to get items",
+			task: TaskGet,
+			input: GetInput{
+				CollectionName: "mock-collection",
+				IDs:            []string{"mockID1", "mockID2"},
+			},
+			wantResp: GetOutput{
+				Status: "Successfully got 2 items",
+				Result: Result{
+					Ids: []string{"mockID1", "mockID2"},
+					Items: []map[string]any{
+						{"id": "mockID1", "name": "a", "document": "a cat"},
+						{"id": "mockID2", "name": "b", "document": "a dog"},
+					},
+					Metadata: []map[string]any{{"name": "a"}, {"name": "b"}},
+				},
+			},
+			clientCalls: []clientCall{
+				getCollection,
+				{
+					method: http.MethodPost,
+					path:   fmt.Sprintf(getPath, "mock-collection-id"),
+					req: map[string]any{
+						"ids":     []string{"mockID1", "mockID2"},
+						"include": []string{"metadatas", "documents"},
+					},
+					resp: `{
+						"ids": ["mockID1", "mockID2"],
+						"embeddings": null,
+						"metadatas": [{"name": "a"}, {"name": "b"}],
+						"documents": ["a cat", "a dog"]
+					}`,
+				},
+			},
+		},
+		{
+			name: "ok to update item",
+			task: TaskUpdate,
+			input: UpdateInput{
+				CollectionName: "mock-collection",
+				ID:             "mockID1",
+				Metadata:       map[string]any{"name": "c"},
+			},
+			wantResp: UpdateOutput{Status: "Successfully updated 1 item"},
+			clientCalls: []clientCall{
+				getCollection,
+				{
+					method: http.MethodPost,
+					path:   fmt.Sprintf(updatePath, "mock-collection-id"),
+					req: map[string]any{
+						"ids":       []string{"mockID1"},
+						"metadatas": []any{map[string]any{"name": "c"}},
+					},
+					resp: `null`,
+				},
+			},
+		},
+		{
+			name:    "nok - update without ID",
+			task:    TaskUpdate,
+			input:   UpdateInput{CollectionName: "mock-collection"},
+			wantErr: "id is required",
+		},
+		{
+			name:     "ok to count items",
+			task:     TaskCount,
+			input:    CountInput{CollectionName: "mock-collection"},
+			wantResp: CountOutput{Status: "Successfully counted 3 items", Count: 3},
+			clientCalls: []clientCall{
+				getCollection,
+				{
+					method: http.MethodGet,
+					path:   fmt.Sprintf(countPath, "mock-collection-id"),
+					resp:   `3`,
+				},
+			},
+		},
+		{
+			name: "ok to count filtered items",
+			task: TaskCount,
+			input: CountInput{
+				CollectionName: "mock-collection",
+				Filter:         map[string]any{"name": "a"},
+			},
+			wantResp: CountOutput{Status: "Successfully counted 1 items", Count: 1},
+			clientCalls: []clientCall{
+				getCollection,
+				{
+					method: http.MethodPost,
+					path:   fmt.Sprintf(getPath, "mock-collection-id"),
+					req: map[string]any{
+						"where":   map[string]any{"name": "a"},
+						"include": []string{},
+					},
+					resp: `{"ids": ["mockID1"]}`,
+				},
+			},
+		},
+		{
+			name: "ok to list collections in a tenant database",
+			task: TaskListCollections,
+			setup: map[string]any{
+				"tenant":   "mock-tenant",
+				"database": "mock-database",
+			},
+			input: ListCollectionsInput{Limit: 10},
+			wantResp: ListCollectionsOutput{
+				Status: "Successfully listed 1 collections",
+				Collections: []Collection{
+					{ID: "mock-collection-id", Name: "mock-collection", Metadata: map[string]any{}},
+				},
+			},
+			wantQuery: map[string]string{
+				"tenant":   "mock-tenant",
+				"database": "mock-database",
+				"limit":    "10",
+			},
+			clientCalls: []clientCall{
+				{
+					method: http.MethodGet,
+					path:   listCollectionsPath,
+					resp:   `[{"id": "mock-collection-id", "name": "mock-collection", "metadata": null}]`,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			var calls int
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Assert(calls < len(tc.clientCalls), qt.IsTrue, qt.Commentf("unexpected request %s %s", r.Method, r.URL))
+				call := tc.clientCalls[calls]
+				calls++
+
+				c.Check(r.Method, qt.Equals, call.method)
+				c.Check(r.URL.Path, qt.Equals, call.path)
+				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer mock-api-key")
+				for k, v := range tc.wantQuery {
+					c.Check(r.URL.Query().Get(k), qt.Equals, v)
+				}
+
+				if call.req != nil {
+					body, err := io.ReadAll(r.Body)
+					c.Assert(err, qt.IsNil)
+					c.Check(body, qt.JSONEquals, call.req)
+				}
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprintln(w, call.resp)
+			})
+
+			chromaServer := httptest.NewServer(h)
+			c.Cleanup(chromaServer.Close)
+
+			setupMap := map[string]any{
+				"api-key": "mock-api-key",
+				"url":     chromaServer.URL,
+			}
+			for k, v := range tc.setup {
+				setupMap[k] = v
+			}
+			setup, _ := structpb.NewStruct(setupMap)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				if tc.wantErr != "" {
+					c.Check(err, qt.ErrorMatches, tc.wantErr)
+					return
+				}
+				c.Errorf("unexpected error: %v", err)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+			c.Check(calls, qt.Equals, len(tc.clientCalls))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package chroma
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	countPath = "/api/v1/collections/%s/count"
+)
+
+type CountInput struct {
+	CollectionName      string         `json:"collection-name"`
+	Filter              map[string]any `json:"filter"`
+	FilterDocument      map[string]any `json:"filter-document"`
+	DocumentContains    string         `json:"document-contains"`
+	DocumentNotContains string         `json:"document-not-contains"`
+}
+
+type CountOutput struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// Chroma only counts whole collections, so filtered counts fetch the
+// matching IDs without any of the item fields.
+func (e *execution) count(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CountInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int
+
+	filterDocument := whereDocument(inputStruct.FilterDocument, inputStruct.DocumentContains, inputStruct.DocumentNotContains)
+	if inputStruct.Filter != nil || filterDocument != nil {
+		resp, err := e.getItems(inputStruct.CollectionName, GetReq{
+			Where:         inputStruct.Filter,
+			WhereDocument: filterDocument,
+			Include:       []string{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		count = len(resp.IDs)
+	} else {
+		collID, err := getCollectionID(inputStruct.CollectionName, e.client)
+		if err != nil {
+			return nil, err
+		}
+
+		req := e.client.R().SetResult(&count)
+
+		res, err := req.Get(fmt.Sprintf(countPath, collID))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode() != 200 {
+			return nil, fmt.Errorf("failed to count items: %s", res.String())
+		}
+	}
+
+	outputStruct := CountOutput{
+		Status: fmt.Sprintf("Successfully counted %d items", count),
+		Count:  count,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
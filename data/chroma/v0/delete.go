@@ -21,6 +21,10 @@ type DeleteInput struct {
 	ID             string         `json:"id"`
 	Filter         map[string]any `json:"filter"`
 	FilterDocument map[string]any `json:"filter-document"`
+	// DocumentContains and DocumentNotContains are shortcuts for the
+	// $contains and $not_contains document filters.
+	DocumentContains    string `json:"document-contains"`
+	DocumentNotContains string `json:"document-not-contains"`
 }
 
 type DeleteReq struct {
@@ -38,8 +42,10 @@ func (e *execution) delete(in *structpb.Struct) (*structpb.Struct, error) {
 
 	var resp []string
 
+	filterDocument := whereDocument(inputStruct.FilterDocument, inputStruct.DocumentContains, inputStruct.DocumentNotContains)
+
 	// one of id or filter or filter document should be present otherwise if all empty then error
-	if inputStruct.ID == "" && inputStruct.Filter == nil && inputStruct.FilterDocument == nil {
+	if inputStruct.ID == "" && inputStruct.Filter == nil && filterDocument == nil {
 		return nil, fmt.Errorf("one of id or filter or filter document should be present")
 	}
 
@@ -50,8 +56,8 @@ func (e *execution) delete(in *structpb.Struct) (*structpb.Struct, error) {
 	if inputStruct.Filter != nil {
 		reqParams.Where = inputStruct.Filter
 	}
-	if inputStruct.FilterDocument != nil {
-		reqParams.WhereDocument = inputStruct.FilterDocument
+	if filterDocument != nil {
+		reqParams.WhereDocument = filterDocument
 	}
 
 	var collID string
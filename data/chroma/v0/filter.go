@@ -0,0 +1,24 @@
+package chroma
+
+// whereDocument builds the where_document filter of a request. The contains
+// and not-contains shortcuts are combined with the raw filter with $and.
+func whereDocument(filterDocument map[string]any, contains, notContains string) map[string]any {
+	var conditions []any
+	if len(filterDocument) > 0 {
+		conditions = append(conditions, filterDocument)
+	}
+	if contains != "" {
+		conditions = append(conditions, map[string]any{"$contains": contains})
+	}
+	if notContains != "" {
+		conditions = append(conditions, map[string]any{"$not_contains": notContains})
+	}
+
+	switch len(conditions) {
+	case 0:
+		return nil
+	case 1:
+		return conditions[0].(map[string]any)
+	}
+	return map[string]any{"$and": conditions}
+}
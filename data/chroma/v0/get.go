@@ -0,0 +1,144 @@
+package chroma
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	getPath = "/api/v1/collections/%s/get"
+)
+
+type GetInput struct {
+	CollectionName      string         `json:"collection-name"`
+	IDs                 []string       `json:"ids"`
+	Filter              map[string]any `json:"filter"`
+	FilterDocument      map[string]any `json:"filter-document"`
+	DocumentContains    string         `json:"document-contains"`
+	DocumentNotContains string         `json:"document-not-contains"`
+	Limit               int            `json:"limit"`
+	Offset              int            `json:"offset"`
+	Include             []string       `json:"include"`
+}
+
+type GetOutput struct {
+	Status string `json:"status"`
+	Result Result `json:"result"`
+}
+
+type GetReq struct {
+	IDs           []string       `json:"ids,omitempty"`
+	Where         map[string]any `json:"where,omitempty"`
+	WhereDocument map[string]any `json:"where_document,omitempty"`
+	Limit         int            `json:"limit,omitempty"`
+	Offset        int            `json:"offset,omitempty"`
+	Include       []string       `json:"include"`
+}
+
+type GetResp struct {
+	IDs        []string         `json:"ids"`
+	Metadatas  []map[string]any `json:"metadatas"`
+	Embeddings [][]float64      `json:"embeddings"`
+	Documents  []string         `json:"documents"`
+	Uris       []string         `json:"uris"`
+
+	Detail []map[string]any `json:"detail"`
+}
+
+var defaultGetInclude = []string{"metadatas", "documents"}
+
+// include is optional, metadatas and documents are returned by default
+func (e *execution) get(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct GetInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	reqParams := GetReq{
+		IDs:           inputStruct.IDs,
+		Where:         inputStruct.Filter,
+		WhereDocument: whereDocument(inputStruct.FilterDocument, inputStruct.DocumentContains, inputStruct.DocumentNotContains),
+		Limit:         inputStruct.Limit,
+		Offset:        inputStruct.Offset,
+		Include:       defaultGetInclude,
+	}
+	if inputStruct.Include != nil {
+		reqParams.Include = inputStruct.Include
+	}
+
+	resp, err := e.getItems(inputStruct.CollectionName, reqParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []map[string]any
+	for i, id := range resp.IDs {
+		item := make(map[string]any)
+		if i < len(resp.Metadatas) {
+			for k, v := range resp.Metadatas[i] {
+				if k != "id" {
+					item[k] = v
+				}
+			}
+		}
+		item["id"] = id
+		if i < len(resp.Embeddings) {
+			item["vector"] = resp.Embeddings[i]
+		}
+		if i < len(resp.Documents) {
+			item["document"] = resp.Documents[i]
+		}
+		if i < len(resp.Uris) {
+			item["uri"] = resp.Uris[i]
+		}
+		items = append(items, item)
+	}
+
+	outputStruct := GetOutput{
+		Status: fmt.Sprintf("Successfully got %d items", len(resp.IDs)),
+		Result: Result{
+			Ids:      resp.IDs,
+			Items:    items,
+			Vectors:  resp.Embeddings,
+			Metadata: resp.Metadatas,
+		},
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (e *execution) getItems(collectionName string, reqParams GetReq) (*GetResp, error) {
+	collID, err := getCollectionID(collectionName, e.client)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := GetResp{}
+
+	req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+	res, err := req.Post(fmt.Sprintf(getPath, collID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to get items: %s", res.String())
+	}
+
+	if resp.Detail != nil {
+		return nil, fmt.Errorf("failed to get items: %s", resp.Detail[0]["msg"])
+	}
+
+	return &resp, nil
+}
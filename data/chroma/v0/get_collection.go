@@ -11,12 +11,23 @@ const (
 )
 
 type GetCollectionResp struct {
-	ID string `json:"id"`
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Metadata map[string]any `json:"metadata"`
 
 	Detail []map[string]any `json:"detail"`
 }
 
 func getCollectionID(collectionName string, client *httpclient.Client) (string, error) {
+	coll, err := getCollection(collectionName, client)
+	if err != nil {
+		return "", err
+	}
+
+	return coll.ID, nil
+}
+
+func getCollection(collectionName string, client *httpclient.Client) (*GetCollectionResp, error) {
 	respGetColl := GetCollectionResp{}
 
 	reqGetColl := client.R().SetResult(&respGetColl)
@@ -24,16 +35,37 @@ func getCollectionID(collectionName string, client *httpclient.Client) (string,
 	resGetColl, err := reqGetColl.Get(fmt.Sprintf(getCollectionPath, collectionName))
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if resGetColl.StatusCode() != 200 {
-		return "", fmt.Errorf("failed to get collection: %s", resGetColl.String())
+		return nil, fmt.Errorf("failed to get collection: %s", resGetColl.String())
 	}
 
 	if respGetColl.Detail != nil {
-		return "", fmt.Errorf("failed to get collection: %s", respGetColl.Detail[0]["msg"])
+		return nil, fmt.Errorf("failed to get collection: %s", respGetColl.Detail[0]["msg"])
 	}
 
-	return respGetColl.ID, nil
+	return &respGetColl, nil
+}
+
+// distanceSpace returns the distance function of a collection, set in the
+// hnsw:space metadata field. Chroma defaults to the squared L2 distance.
+func (c *GetCollectionResp) distanceSpace() string {
+	if space, ok := c.Metadata["hnsw:space"].(string); ok && space != "" {
+		return space
+	}
+	return "l2"
+}
+
+// similarity normalises a distance to a similarity score, where higher is
+// more similar, so the scores can be compared with other vector stores.
+func similarity(distance float64, space string) float64 {
+	switch space {
+	case "cosine", "ip":
+		// Chroma returns 1 - cos(a, b) and 1 - a·b respectively.
+		return 1 - distance
+	default:
+		return 1 / (1 + distance)
+	}
 }
@@ -0,0 +1,83 @@
+package chroma
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	listCollectionsPath = "/api/v1/collections"
+)
+
+type ListCollectionsInput struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+type ListCollectionsOutput struct {
+	Status      string       `json:"status"`
+	Collections []Collection `json:"collections"`
+}
+
+type Collection struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+func (e *execution) listCollections(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct ListCollectionsInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []GetCollectionResp
+
+	req := e.client.R().SetResult(&resp)
+	if inputStruct.Limit > 0 {
+		req.SetQueryParam("limit", strconv.Itoa(inputStruct.Limit))
+	}
+	if inputStruct.Offset > 0 {
+		req.SetQueryParam("offset", strconv.Itoa(inputStruct.Offset))
+	}
+
+	res, err := req.Get(listCollectionsPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to list collections: %s", res.String())
+	}
+
+	collections := make([]Collection, 0, len(resp))
+	for _, coll := range resp {
+		metadata := coll.Metadata
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+		collections = append(collections, Collection{
+			ID:       coll.ID,
+			Name:     coll.Name,
+			Metadata: metadata,
+		})
+	}
+
+	outputStruct := ListCollectionsOutput{
+		Status:      fmt.Sprintf("Successfully listed %d collections", len(collections)),
+		Collections: collections,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -22,6 +22,10 @@ const (
 	TaskQuery            = "TASK_QUERY"
 	TaskDeleteCollection = "TASK_DELETE_COLLECTION"
 	TaskCreateCollection = "TASK_CREATE_COLLECTION"
+	TaskGet              = "TASK_GET"
+	TaskUpdate           = "TASK_UPDATE"
+	TaskCount            = "TASK_COUNT"
+	TaskListCollections  = "TASK_LIST_COLLECTIONS"
 )
 
 //go:embed config/definition.json
@@ -78,6 +82,14 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.deleteCollection
 	case TaskCreateCollection:
 		e.execute = e.createCollection
+	case TaskGet:
+		e.execute = e.get
+	case TaskUpdate:
+		e.execute = e.update
+	case TaskCount:
+		e.execute = e.count
+	case TaskListCollections:
+		e.execute = e.listCollections
 	default:
 		return nil, errmsg.AddMessage(
 			fmt.Errorf("not supported task: %s", x.Task),
@@ -29,8 +29,12 @@ type QueryInput struct {
 	Vector         []float64      `json:"vector"`
 	Filter         map[string]any `json:"filter"`
 	FilterDocument map[string]any `json:"filter-document"`
-	NResults       int            `json:"n-results"`
-	Fields         []string       `json:"fields"`
+	// DocumentContains and DocumentNotContains are shortcuts for the
+	// $contains and $not_contains document filters.
+	DocumentContains    string   `json:"document-contains"`
+	DocumentNotContains string   `json:"document-not-contains"`
+	NResults            int      `json:"n-results"`
+	Fields              []string `json:"fields"`
 }
 
 type QueryReq struct {
@@ -69,20 +73,17 @@ func (e *execution) query(in *structpb.Struct) (*structpb.Struct, error) {
 	if inputStruct.Filter != nil {
 		reqParams.Where = inputStruct.Filter
 	}
-	if inputStruct.FilterDocument != nil {
-		reqParams.WhereDocument = inputStruct.FilterDocument
-	}
-
-	var collID string
+	reqParams.WhereDocument = whereDocument(inputStruct.FilterDocument, inputStruct.DocumentContains, inputStruct.DocumentNotContains)
 
-	collID, err = getCollectionID(inputStruct.CollectionName, e.client)
+	coll, err := getCollection(inputStruct.CollectionName, e.client)
 	if err != nil {
 		return nil, err
 	}
+	space := coll.distanceSpace()
 
 	req := e.client.R().SetBody(reqParams).SetResult(&resp)
 
-	res, err := req.Post(fmt.Sprintf(queryPath, collID))
+	res, err := req.Post(fmt.Sprintf(queryPath, coll.ID))
 
 	if err != nil {
 		return nil, err
@@ -140,6 +141,7 @@ func (e *execution) query(in *structpb.Struct) (*structpb.Struct, error) {
 			}
 		}
 		item["distance"] = resp.Distances[0][i]
+		item["similarity"] = similarity(resp.Distances[0][i], space)
 		item["id"] = ids[i]
 		item["vector"] = vectors[i]
 		if len(uris) > 0 {
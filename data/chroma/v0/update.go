@@ -0,0 +1,95 @@
+package chroma
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	updatePath = "/api/v1/collections/%s/update"
+)
+
+type UpdateOutput struct {
+	Status string `json:"status"`
+}
+
+type UpdateInput struct {
+	CollectionName string         `json:"collection-name"`
+	ID             string         `json:"id"`
+	Vector         []float64      `json:"vector"`
+	Metadata       map[string]any `json:"metadata"`
+	Document       string         `json:"document"`
+	URI            string         `json:"uri"`
+}
+
+// UpdateReq only contains the fields to be updated, Chroma keeps the
+// current value of the omitted ones.
+type UpdateReq struct {
+	IDs        []string         `json:"ids"`
+	Embeddings [][]float64      `json:"embeddings,omitempty"`
+	Metadatas  []map[string]any `json:"metadatas,omitempty"`
+	Documents  []string         `json:"documents,omitempty"`
+	Uris       []string         `json:"uris,omitempty"`
+}
+
+// Unlike upsert, update fails if the item doesn't exist.
+func (e *execution) update(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct UpdateInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputStruct.ID == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	reqParams := UpdateReq{
+		IDs: []string{inputStruct.ID},
+	}
+	if inputStruct.Vector != nil {
+		reqParams.Embeddings = [][]float64{inputStruct.Vector}
+	}
+	if inputStruct.Metadata != nil {
+		reqParams.Metadatas = []map[string]any{inputStruct.Metadata}
+	}
+	if inputStruct.Document != "" {
+		reqParams.Documents = []string{inputStruct.Document}
+	}
+	if inputStruct.URI != "" {
+		reqParams.Uris = []string{inputStruct.URI}
+	}
+
+	var collID string
+
+	collID, err = getCollectionID(inputStruct.CollectionName, e.client)
+	if err != nil {
+		return nil, err
+	}
+
+	req := e.client.R().SetBody(reqParams)
+
+	res, err := req.Post(fmt.Sprintf(updatePath, collID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to update item: %s", res.String())
+	}
+
+	outputStruct := UpdateOutput{
+		Status: "Successfully updated 1 item",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -22,6 +22,9 @@ func newClient(setup *structpb.Struct) *ESClient {
 		deleteIndexClient:  es.Indices.Delete,
 		sqlTranslateClient: es.SQL.Translate,
 		bulkClient:         es.Bulk,
+
+		openPointInTimeClient: es.OpenPointInTime,
+		reindexClient:         es.Reindex,
 	}
 }
 
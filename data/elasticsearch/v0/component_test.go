@@ -774,3 +774,515 @@ func TestComponent_ExecuteDeleteIndexTask(t *testing.T) {
 		})
 	}
 }
+
+func mockESResponse(statusCode int, resp any) *esapi.Response {
+	b, _ := json.Marshal(resp)
+	return &esapi.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+		Header:     make(map[string][]string),
+	}
+}
+
+func readBody(c *qt.C, body io.Reader) []byte {
+	b, err := io.ReadAll(body)
+	c.Assert(err, qt.IsNil)
+	return b
+}
+
+func TestComponent_ExecuteSearchTaskPagination(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	hits := []map[string]any{
+		{"_id": "mockID1", "_index": "index_name", "_score": 1, "_source": map[string]any{"city": "New York"}, "sort": []any{10, 3}},
+		{"_id": "mockID2", "_index": "index_name", "_score": 1, "_source": map[string]any{"city": "Paris"}, "sort": []any{12, 7}},
+	}
+	result := SearchResult{
+		IDs: []string{"mockID1", "mockID2"},
+		Documents: []map[string]any{
+			{"_id": "mockID1", "_index": "index_name", "_score": 1, "_source": map[string]any{"city": "New York"}},
+			{"_id": "mockID2", "_index": "index_name", "_score": 1, "_source": map[string]any{"city": "Paris"}},
+		},
+		Data: []map[string]any{{"city": "New York"}, {"city": "Paris"}},
+	}
+	aggregations := map[string]any{
+		"cities": map[string]any{"buckets": []any{
+			map[string]any{"key": "New York", "doc_count": 1},
+			map[string]any{"key": "Paris", "doc_count": 1},
+		}},
+	}
+
+	testcases := []struct {
+		name      string
+		input     SearchInput
+		wantBody  map[string]any
+		wantIndex []string
+		wantPIT   bool
+		wantResp  SearchOutput
+		wantErr   string
+	}{
+		{
+			name: "ok to open point in time with aggregations",
+			input: SearchInput{
+				IndexName:    "index_name",
+				KeepAlive:    "5m",
+				Aggregations: map[string]any{"cities": map[string]any{"terms": map[string]any{"field": "city"}}},
+			},
+			wantPIT: true,
+			wantBody: map[string]any{
+				"aggs": map[string]any{"cities": map[string]any{"terms": map[string]any{"field": "city"}}},
+				"pit":  map[string]any{"id": "pit-1", "keep_alive": "5m"},
+				"sort": []any{map[string]any{"_shard_doc": "asc"}},
+			},
+			wantResp: SearchOutput{
+				Status:       "Successfully searched 2 documents",
+				Result:       result,
+				Aggregations: aggregations,
+				PITID:        "pit-2",
+				SearchAfter:  []any{12, 7},
+			},
+		},
+		{
+			name: "ok to request next page of point in time",
+			input: SearchInput{
+				PITID:       "pit-2",
+				Sort:        []any{map[string]any{"price": "asc"}},
+				SearchAfter: []any{12, 7},
+			},
+			wantBody: map[string]any{
+				"pit":          map[string]any{"id": "pit-2", "keep_alive": "1m"},
+				"sort":         []any{map[string]any{"price": "asc"}},
+				"search_after": []any{12, 7},
+			},
+			wantResp: SearchOutput{
+				Status:      "Successfully searched 2 documents",
+				Result:      result,
+				PITID:       "pit-2",
+				SearchAfter: []any{12, 7},
+			},
+		},
+		{
+			name: "ok to search after without point in time",
+			input: SearchInput{
+				IndexName:   "index_name",
+				Sort:        []any{"price"},
+				SearchAfter: []any{10, 3},
+			},
+			wantIndex: []string{"index_name"},
+			wantBody: map[string]any{
+				"sort":         []any{"price"},
+				"search_after": []any{10, 3},
+			},
+			wantResp: SearchOutput{
+				Status:      "Successfully searched 2 documents",
+				Result:      result,
+				PITID:       "pit-2",
+				SearchAfter: []any{12, 7},
+			},
+		},
+		{
+			name: "nok - search after without sort",
+			input: SearchInput{
+				IndexName:   "index_name",
+				SearchAfter: []any{10, 3},
+			},
+			wantErr: "sort or pit-id must be provided to use search-after",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"api-key":  "mock-api-key",
+				"cloud-id": "mock-cloud-id",
+			})
+			c.Assert(err, qt.IsNil)
+
+			pitOpened := false
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskSearch},
+				client: ESClient{
+					searchClient: func(o ...func(*esapi.SearchRequest)) (*esapi.Response, error) {
+						req := &esapi.SearchRequest{}
+						for _, f := range o {
+							f(req)
+						}
+						c.Check(req.Index, qt.DeepEquals, tc.wantIndex)
+						c.Check(readBody(c, req.Body), qt.JSONEquals, tc.wantBody)
+
+						resp := map[string]any{
+							"hits":   map[string]any{"hits": hits},
+							"pit_id": "pit-2",
+						}
+						if _, ok := tc.wantBody["aggs"]; ok {
+							resp["aggregations"] = aggregations
+						}
+						return mockESResponse(200, resp), nil
+					},
+					openPointInTimeClient: func(index []string, keepAlive string, o ...func(*esapi.OpenPointInTimeRequest)) (*esapi.Response, error) {
+						pitOpened = true
+						c.Check(index, qt.DeepEquals, []string{tc.input.IndexName})
+						c.Check(keepAlive, qt.Equals, tc.input.KeepAlive)
+						return mockESResponse(200, map[string]any{"id": "pit-1"}), nil
+					},
+				},
+			}
+
+			e.execute = e.search
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+			c.Check(pitOpened, qt.Equals, tc.wantPIT)
+		})
+	}
+}
+
+func TestComponent_ExecuteBulkTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name     string
+		input    BulkInput
+		wantBody string
+		wantResp BulkOutput
+		wantErr  string
+	}{
+		{
+			name: "ok to run bulk operations with item errors",
+			input: BulkInput{
+				IndexName: "index_name",
+				Operations: []BulkOperation{
+					{Action: "index", Data: map[string]any{"name": "John Doe"}},
+					{Action: "create", ID: "mockID1", Data: map[string]any{"name": "Jane Smith"}},
+					{Action: "update", ID: "mockID2", Data: map[string]any{"city": "Paris"}, Upsert: true},
+					{Action: "delete", IndexName: "other_index", ID: "mockID3"},
+				},
+			},
+			wantBody: `{"index":{"_index":"index_name"}}
+{"name":"John Doe"}
+{"create":{"_id":"mockID1","_index":"index_name"}}
+{"name":"Jane Smith"}
+{"update":{"_id":"mockID2","_index":"index_name"}}
+{"doc":{"city":"Paris"},"doc_as_upsert":true}
+{"delete":{"_id":"mockID3","_index":"other_index"}}
+`,
+			wantResp: BulkOutput{
+				Status: "Successfully executed 3 of 4 operations",
+				Items: []BulkItem{
+					{Action: "index", IndexName: "index_name", ID: "auto1", Status: 201, Result: "created"},
+					{Action: "create", IndexName: "index_name", ID: "mockID1", Status: 409, Error: "version_conflict_engine_exception: [mockID1]: version conflict, document already exists"},
+					{Action: "update", IndexName: "index_name", ID: "mockID2", Status: 200, Result: "updated"},
+					{Action: "delete", IndexName: "other_index", ID: "mockID3", Status: 404, Result: "not_found"},
+				},
+				ErrorCount: 1,
+			},
+		},
+		{
+			name: "nok - missing id",
+			input: BulkInput{
+				IndexName:  "index_name",
+				Operations: []BulkOperation{{Action: "delete"}},
+			},
+			wantErr: "operation 0: id must be provided",
+		},
+		{
+			name: "nok - missing index",
+			input: BulkInput{
+				Operations: []BulkOperation{{Action: "index", Data: map[string]any{"name": "John Doe"}}},
+			},
+			wantErr: "operation 0: index-name must be provided",
+		},
+		{
+			name: "nok - unsupported action",
+			input: BulkInput{
+				IndexName:  "index_name",
+				Operations: []BulkOperation{{Action: "upsert", ID: "mockID1"}},
+			},
+			wantErr: "operation 0: unsupported action: upsert",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"api-key":  "mock-api-key",
+				"cloud-id": "mock-cloud-id",
+			})
+			c.Assert(err, qt.IsNil)
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskBulk},
+				client: ESClient{
+					bulkClient: func(body io.Reader, o ...func(*esapi.BulkRequest)) (*esapi.Response, error) {
+						c.Check(string(readBody(c, body)), qt.Equals, tc.wantBody)
+
+						return mockESResponse(200, map[string]any{
+							"errors": true,
+							"items": []any{
+								map[string]any{"index": map[string]any{"_index": "index_name", "_id": "auto1", "status": 201, "result": "created"}},
+								map[string]any{"create": map[string]any{"_index": "index_name", "_id": "mockID1", "status": 409, "error": map[string]any{
+									"type":   "version_conflict_engine_exception",
+									"reason": "[mockID1]: version conflict, document already exists",
+								}}},
+								map[string]any{"update": map[string]any{"_index": "index_name", "_id": "mockID2", "status": 200, "result": "updated"}},
+								map[string]any{"delete": map[string]any{"_index": "other_index", "_id": "mockID3", "status": 404, "result": "not_found"}},
+							},
+						}), nil
+					},
+				},
+			}
+
+			e.execute = e.bulk
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+		})
+	}
+}
+
+func TestComponent_ExecuteUpdateByQueryTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name     string
+		input    UpdateByQueryInput
+		wantBody map[string]any
+		wantResp UpdateByQueryOutput
+		wantErr  string
+	}{
+		{
+			name: "ok to update by query",
+			input: UpdateByQueryInput{
+				IndexName: "index_name",
+				Filter:    map[string]any{"term": map[string]any{"city": "New York"}},
+				Script: Script{
+					Source: "ctx._source.visits += params.count",
+					Params: map[string]any{"count": 1},
+				},
+				Conflicts: "proceed",
+				MaxDocs:   100,
+			},
+			wantBody: map[string]any{
+				"query": map[string]any{"term": map[string]any{"city": "New York"}},
+				"script": map[string]any{
+					"source": "ctx._source.visits += params.count",
+					"lang":   "painless",
+					"params": map[string]any{"count": 1},
+				},
+			},
+			wantResp: UpdateByQueryOutput{
+				Status:           "Successfully updated 2 documents",
+				Total:            3,
+				Updated:          2,
+				VersionConflicts: 1,
+				Failures:         []map[string]any{},
+			},
+		},
+		{
+			name: "nok - missing script",
+			input: UpdateByQueryInput{
+				IndexName: "index_name",
+			},
+			wantErr: "script source must be provided",
+		},
+		{
+			name: "nok - unsupported conflicts",
+			input: UpdateByQueryInput{
+				IndexName: "index_name",
+				Script:    Script{Source: "ctx._source.visits++"},
+				Conflicts: "ignore",
+			},
+			wantErr: "unsupported conflicts: ignore",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"api-key":  "mock-api-key",
+				"cloud-id": "mock-cloud-id",
+			})
+			c.Assert(err, qt.IsNil)
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskUpdateByQuery},
+				client: ESClient{
+					updateClient: func(index []string, o ...func(*esapi.UpdateByQueryRequest)) (*esapi.Response, error) {
+						req := &esapi.UpdateByQueryRequest{}
+						for _, f := range o {
+							f(req)
+						}
+						c.Check(index, qt.DeepEquals, []string{tc.input.IndexName})
+						c.Check(req.Conflicts, qt.Equals, tc.input.Conflicts)
+						c.Check(*req.MaxDocs, qt.Equals, tc.input.MaxDocs)
+						c.Check(readBody(c, req.Body), qt.JSONEquals, tc.wantBody)
+
+						return mockESResponse(200, DeleteUpdateResponse{Total: 3, Updated: 2, VersionConflicts: 1}), nil
+					},
+				},
+			}
+
+			e.execute = e.updateByQuery
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+		})
+	}
+}
+
+func TestComponent_ExecuteReindexTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name     string
+		input    ReindexInput
+		wantBody map[string]any
+		wantResp ReindexOutput
+		wantErr  string
+	}{
+		{
+			name: "ok to reindex with filter sql and script",
+			input: ReindexInput{
+				SourceIndex:      "index_name",
+				DestinationIndex: "new_index_name",
+				FilterSQL:        "city = 'New York'",
+				Script:           &Script{Source: "ctx._source.remove('legacy')"},
+				OpType:           "create",
+				Conflicts:        "proceed",
+			},
+			wantBody: map[string]any{
+				"source":    map[string]any{"index": "index_name", "query": map[string]any{}},
+				"dest":      map[string]any{"index": "new_index_name", "op_type": "create"},
+				"script":    map[string]any{"source": "ctx._source.remove('legacy')", "lang": "painless"},
+				"conflicts": "proceed",
+			},
+			wantResp: ReindexOutput{
+				Status:   "Successfully reindexed 2 documents",
+				Total:    3,
+				Created:  2,
+				Failures: []map[string]any{{"id": "mockID3", "status": 400}},
+			},
+		},
+		{
+			name: "nok - missing destination",
+			input: ReindexInput{
+				SourceIndex: "index_name",
+			},
+			wantErr: "source-index and destination-index must be provided",
+		},
+		{
+			name: "nok - unsupported op type",
+			input: ReindexInput{
+				SourceIndex:      "index_name",
+				DestinationIndex: "new_index_name",
+				OpType:           "update",
+			},
+			wantErr: "unsupported op-type: update",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"api-key":  "mock-api-key",
+				"cloud-id": "mock-cloud-id",
+			})
+			c.Assert(err, qt.IsNil)
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskReindex},
+				client: ESClient{
+					reindexClient: func(body io.Reader, o ...func(*esapi.ReindexRequest)) (*esapi.Response, error) {
+						c.Check(readBody(c, body), qt.JSONEquals, tc.wantBody)
+
+						return mockESResponse(200, DeleteUpdateResponse{
+							Total:    3,
+							Created:  2,
+							Failures: []map[string]any{{"id": "mockID3", "status": 400}},
+						}), nil
+					},
+					sqlTranslateClient: func(body io.Reader, o ...func(*esapi.SQLTranslateRequest)) (*esapi.Response, error) {
+						return MockESSQLTranslate(), nil
+					},
+				},
+			}
+
+			e.execute = e.reindex
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+		})
+	}
+}
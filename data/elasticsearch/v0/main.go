@@ -25,6 +25,10 @@ const (
 	TaskDelete       = "TASK_DELETE"
 	TaskCreateIndex  = "TASK_CREATE_INDEX"
 	TaskDeleteIndex  = "TASK_DELETE_INDEX"
+
+	TaskBulk          = "TASK_BULK"
+	TaskUpdateByQuery = "TASK_UPDATE_BY_QUERY"
+	TaskReindex       = "TASK_REINDEX"
 )
 
 var (
@@ -59,6 +63,9 @@ type ESClient struct {
 	deleteIndexClient  esapi.IndicesDelete
 	sqlTranslateClient esapi.SQLTranslate
 	bulkClient         esapi.Bulk
+
+	openPointInTimeClient esapi.OpenPointInTime
+	reindexClient         esapi.Reindex
 }
 
 type ESSearch func(o ...func(*esapi.SearchRequest)) (*esapi.Response, error)
@@ -79,6 +86,10 @@ type ESSQLTranslate func(body io.Reader, o ...func(*esapi.SQLTranslateRequest))
 
 type ESBulk func(body io.Reader, o ...func(*esapi.BulkRequest)) (*esapi.Response, error)
 
+type ESOpenPointInTime func(index []string, keepAlive string, o ...func(*esapi.OpenPointInTimeRequest)) (*esapi.Response, error)
+
+type ESReindex func(body io.Reader, o ...func(*esapi.ReindexRequest)) (*esapi.Response, error)
+
 // Init returns an implementation of IConnector that interacts with Elasticsearch.
 func Init(bc base.Component) *component {
 	once.Do(func() {
@@ -115,6 +126,12 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.deleteIndex
 	case TaskMultiIndex:
 		e.execute = e.multiIndex
+	case TaskBulk:
+		e.execute = e.bulk
+	case TaskUpdateByQuery:
+		e.execute = e.updateByQuery
+	case TaskReindex:
+		e.execute = e.reindex
 	default:
 		return nil, errmsg.AddMessage(
 			fmt.Errorf("not supported task: %s", x.Task),
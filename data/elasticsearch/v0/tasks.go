@@ -55,11 +55,20 @@ type SearchInput struct {
 	Query     string         `json:"query"`
 	IndexName string         `json:"index-name"`
 	Size      int            `json:"size"`
+
+	Aggregations map[string]any `json:"aggregations"`
+	Sort         []any          `json:"sort"`
+	PITID        string         `json:"pit-id"`
+	KeepAlive    string         `json:"keep-alive"`
+	SearchAfter  []any          `json:"search-after"`
 }
 
 type SearchOutput struct {
-	Result SearchResult `json:"result"`
-	Status string       `json:"status"`
+	Result       SearchResult   `json:"result"`
+	Status       string         `json:"status"`
+	Aggregations map[string]any `json:"aggregations,omitempty"`
+	PITID        string         `json:"pit-id,omitempty"`
+	SearchAfter  []any          `json:"search-after,omitempty"`
 }
 
 type VectorSearchInput struct {
@@ -109,14 +118,19 @@ type SearchResponse struct {
 		MaxScore float64 `json:"max_score"`
 		Hits     []Hit   `json:"hits"`
 	} `json:"hits"`
+	Aggregations map[string]any `json:"aggregations"`
+	PITID        string         `json:"pit_id"`
 }
 
 type DeleteUpdateResponse struct {
-	Took     int  `json:"took"`
-	TimedOut bool `json:"timed_out"`
-	Total    int  `json:"total"`
-	Deleted  int  `json:"deleted"`
-	Updated  int  `json:"updated"`
+	Took             int              `json:"took"`
+	TimedOut         bool             `json:"timed_out"`
+	Total            int              `json:"total"`
+	Deleted          int              `json:"deleted"`
+	Updated          int              `json:"updated"`
+	Created          int              `json:"created"`
+	VersionConflicts int              `json:"version_conflicts"`
+	Failures         []map[string]any `json:"failures"`
 }
 
 type MultiIndexResponse struct {
@@ -130,6 +144,7 @@ type Hit struct {
 	ID     string         `json:"_id"`
 	Score  float64        `json:"_score"`
 	Source map[string]any `json:"_source"`
+	Sort   []any          `json:"sort"`
 }
 
 type DeleteInput struct {
@@ -290,7 +305,11 @@ func MultiIndexDocument(es *esapi.Bulk, inputStruct MultiIndexInput) (int, error
 // id is optional, empty means no id, choose one (id, filter, or filter-sql)
 // filter-sql is optional, empty means no filter-sql, choose one (id, filter, or filter-sql)
 // query is optional, empty means no query, only for full text search
-func SearchDocument(es *esapi.Search, esSQLTranslate *esapi.SQLTranslate, inputStruct SearchInput) ([]Hit, error) {
+// aggregations is optional, empty means no aggregations
+// keep-alive is optional, opens a point in time if pit-id is empty
+// pit-id is optional, empty means the index is searched directly
+// search-after is optional, the sort values of the last hit of the previous page
+func SearchDocument(es *esapi.Search, esSQLTranslate *esapi.SQLTranslate, esOpenPIT *esapi.OpenPointInTime, inputStruct SearchInput) (*SearchResponse, error) {
 	indexName := inputStruct.IndexName
 	query := inputStruct.Query
 	minScore := inputStruct.MinScore
@@ -299,6 +318,13 @@ func SearchDocument(es *esapi.Search, esSQLTranslate *esapi.SQLTranslate, inputS
 	size := inputStruct.Size
 	fields := inputStruct.Fields
 	id := inputStruct.ID
+	sort := inputStruct.Sort
+	pitID := inputStruct.PITID
+	keepAlive := inputStruct.KeepAlive
+
+	if len(inputStruct.SearchAfter) > 0 && len(sort) == 0 && pitID == "" {
+		return nil, fmt.Errorf("sort or pit-id must be provided to use search-after")
+	}
 
 	queryJSON := map[string]any{}
 
@@ -320,6 +346,36 @@ func SearchDocument(es *esapi.Search, esSQLTranslate *esapi.SQLTranslate, inputS
 	if len(fields) > 0 {
 		queryJSON["_source"] = fields
 	}
+	if len(inputStruct.Aggregations) > 0 {
+		queryJSON["aggs"] = inputStruct.Aggregations
+	}
+
+	if keepAlive != "" && pitID == "" {
+		var err error
+		pitID, err = OpenPointInTime(esOpenPIT, indexName, keepAlive)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if pitID != "" {
+		if keepAlive == "" {
+			keepAlive = defaultKeepAlive
+		}
+		queryJSON["pit"] = map[string]any{"id": pitID, "keep_alive": keepAlive}
+
+		// _shard_doc is the most efficient tiebreaker to page through a
+		// point in time when no sort is provided.
+		if len(sort) == 0 {
+			sort = []any{map[string]any{"_shard_doc": "asc"}}
+		}
+	}
+
+	if len(sort) > 0 {
+		queryJSON["sort"] = sort
+	}
+	if len(inputStruct.SearchAfter) > 0 {
+		queryJSON["search_after"] = inputStruct.SearchAfter
+	}
 
 	filterJSON, err := json.Marshal(queryJSON)
 	if err != nil {
@@ -330,7 +386,11 @@ func SearchDocument(es *esapi.Search, esSQLTranslate *esapi.SQLTranslate, inputS
 
 	esClient := ESSearch(*es)
 	res, err := esClient(func(r *esapi.SearchRequest) {
-		r.Index = []string{indexName}
+		// The index is part of the point in time, so it can't be set in the
+		// request.
+		if pitID == "" {
+			r.Index = []string{indexName}
+		}
 		r.Body = body
 		r.Query = query
 		r.TrackTotalHits = true
@@ -353,7 +413,38 @@ func SearchDocument(es *esapi.Search, esSQLTranslate *esapi.SQLTranslate, inputS
 		return nil, err
 	}
 
-	return response.Hits.Hits, nil
+	if response.PITID == "" {
+		response.PITID = pitID
+	}
+
+	return &response, nil
+}
+
+const defaultKeepAlive = "1m"
+
+type OpenPointInTimeResponse struct {
+	ID string `json:"id"`
+}
+
+func OpenPointInTime(es *esapi.OpenPointInTime, indexName string, keepAlive string) (string, error) {
+	esClient := ESOpenPointInTime(*es)
+
+	res, err := esClient([]string{indexName}, keepAlive)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("error opening point in time: %s", res.Status())
+	}
+
+	var response OpenPointInTimeResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return "", err
+	}
+
+	return response.ID, nil
 }
 
 // Only support vector search for now, for semantic search, we can use external model on other component combined with vector search
@@ -665,7 +756,7 @@ func (e *execution) search(in *structpb.Struct) (*structpb.Struct, error) {
 		return nil, err
 	}
 
-	resultTemp, err := SearchDocument(&e.client.searchClient, &e.client.sqlTranslateClient, inputStruct)
+	response, err := SearchDocument(&e.client.searchClient, &e.client.sqlTranslateClient, &e.client.openPointInTimeClient, inputStruct)
 
 	if err != nil {
 		return nil, err
@@ -675,7 +766,7 @@ func (e *execution) search(in *structpb.Struct) (*structpb.Struct, error) {
 	var documents []map[string]any
 	var data []map[string]any
 
-	for _, hit := range resultTemp {
+	for _, hit := range response.Hits.Hits {
 		hitMap := make(map[string]any)
 		hitMap["_index"] = hit.Index
 		hitMap["_id"] = hit.ID
@@ -699,7 +790,14 @@ func (e *execution) search(in *structpb.Struct) (*structpb.Struct, error) {
 			Documents: documents,
 			Data:      data,
 		},
-		Status: fmt.Sprintf("Successfully searched %d documents", len(documents)),
+		Status:       fmt.Sprintf("Successfully searched %d documents", len(documents)),
+		Aggregations: response.Aggregations,
+		PITID:        response.PITID,
+	}
+
+	// The sort values of the last hit are used to request the next page.
+	if hits := response.Hits.Hits; len(hits) > 0 {
+		outputStruct.SearchAfter = hits[len(hits)-1].Sort
 	}
 
 	output, err := base.ConvertToStructpb(outputStruct)
@@ -860,3 +958,466 @@ func (e *execution) multiIndex(in *structpb.Struct) (*structpb.Struct, error) {
 	}
 	return output, nil
 }
+
+type BulkOperation struct {
+	Action    string         `json:"action"`
+	IndexName string         `json:"index-name"`
+	ID        string         `json:"id"`
+	Data      map[string]any `json:"data"`
+	Upsert    bool           `json:"upsert"`
+}
+
+type BulkInput struct {
+	IndexName  string          `json:"index-name"`
+	Operations []BulkOperation `json:"operations"`
+}
+
+type BulkItem struct {
+	Action    string `json:"action"`
+	IndexName string `json:"index-name"`
+	ID        string `json:"id"`
+	Status    int    `json:"status"`
+	Result    string `json:"result,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type BulkOutput struct {
+	Status     string     `json:"status"`
+	Items      []BulkItem `json:"items"`
+	ErrorCount int        `json:"error-count"`
+}
+
+type BulkResponse struct {
+	Errors bool                          `json:"errors"`
+	Items  []map[string]BulkResponseItem `json:"items"`
+	Took   int                           `json:"took"`
+}
+
+type BulkResponseItem struct {
+	Index  string `json:"_index"`
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Result string `json:"result"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
+type Script struct {
+	Source string         `json:"source"`
+	Lang   string         `json:"lang"`
+	Params map[string]any `json:"params"`
+}
+
+type UpdateByQueryInput struct {
+	IndexName string         `json:"index-name"`
+	Filter    map[string]any `json:"filter"`
+	FilterSQL string         `json:"filter-sql"`
+	Query     string         `json:"query"`
+	Script    Script         `json:"script"`
+	Conflicts string         `json:"conflicts"`
+	MaxDocs   int            `json:"max-docs"`
+}
+
+type UpdateByQueryOutput struct {
+	Status           string           `json:"status"`
+	Total            int              `json:"total"`
+	Updated          int              `json:"updated"`
+	VersionConflicts int              `json:"version-conflicts"`
+	Failures         []map[string]any `json:"failures"`
+}
+
+type ReindexInput struct {
+	SourceIndex      string         `json:"source-index"`
+	DestinationIndex string         `json:"destination-index"`
+	Filter           map[string]any `json:"filter"`
+	FilterSQL        string         `json:"filter-sql"`
+	Script           *Script        `json:"script"`
+	Conflicts        string         `json:"conflicts"`
+	OpType           string         `json:"op-type"`
+	MaxDocs          int            `json:"max-docs"`
+}
+
+type ReindexOutput struct {
+	Status           string           `json:"status"`
+	Total            int              `json:"total"`
+	Created          int              `json:"created"`
+	Updated          int              `json:"updated"`
+	VersionConflicts int              `json:"version-conflicts"`
+	Failures         []map[string]any `json:"failures"`
+}
+
+const (
+	bulkActionIndex  = "index"
+	bulkActionCreate = "create"
+	bulkActionUpdate = "update"
+	bulkActionDelete = "delete"
+)
+
+// index-name of an operation is optional, empty means the index-name of the input
+// id is optional for index and create, empty means an auto-generated id
+// upsert is only used by update, it indexes the data when the document doesn't exist
+func BulkDocument(es *esapi.Bulk, inputStruct BulkInput) ([]BulkItem, error) {
+	if len(inputStruct.Operations) == 0 {
+		return nil, fmt.Errorf("operations must have at least one element")
+	}
+
+	var dataJSON strings.Builder
+
+	for i, op := range inputStruct.Operations {
+		indexName := op.IndexName
+		if indexName == "" {
+			indexName = inputStruct.IndexName
+		}
+		if indexName == "" {
+			return nil, fmt.Errorf("operation %d: index-name must be provided", i)
+		}
+
+		var source any
+		switch op.Action {
+		case bulkActionIndex, bulkActionCreate:
+			if op.Data == nil {
+				return nil, fmt.Errorf("operation %d: data must be provided", i)
+			}
+			source = op.Data
+		case bulkActionUpdate:
+			if op.Data == nil {
+				return nil, fmt.Errorf("operation %d: data must be provided", i)
+			}
+			source = map[string]any{"doc": op.Data, "doc_as_upsert": op.Upsert}
+		case bulkActionDelete:
+		default:
+			return nil, fmt.Errorf("operation %d: unsupported action: %s", i, op.Action)
+		}
+
+		if op.ID == "" && (op.Action == bulkActionUpdate || op.Action == bulkActionDelete) {
+			return nil, fmt.Errorf("operation %d: id must be provided", i)
+		}
+
+		innerMetadata := map[string]any{"_index": indexName}
+		if op.ID != "" {
+			innerMetadata["_id"] = op.ID
+		}
+
+		metaDataJSON, err := json.Marshal(map[string]any{op.Action: innerMetadata})
+		if err != nil {
+			return nil, err
+		}
+		dataJSON.Write(metaDataJSON)
+		dataJSON.WriteString("\n")
+
+		if source != nil {
+			sourceJSON, err := json.Marshal(source)
+			if err != nil {
+				return nil, err
+			}
+			dataJSON.Write(sourceJSON)
+			dataJSON.WriteString("\n")
+		}
+	}
+
+	esClient := ESBulk(*es)
+
+	res, err := esClient(strings.NewReader(dataJSON.String()), func(r *esapi.BulkRequest) {
+		r.Refresh = "true"
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("error executing bulk operations: %s", res.Status())
+	}
+
+	var response BulkResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	// Elasticsearch returns one item per operation, in the same order, keyed
+	// by the action.
+	items := make([]BulkItem, 0, len(response.Items))
+	for _, responseItem := range response.Items {
+		for action, r := range responseItem {
+			item := BulkItem{
+				Action:    action,
+				IndexName: r.Index,
+				ID:        r.ID,
+				Status:    r.Status,
+				Result:    r.Result,
+			}
+			if r.Error != nil {
+				item.Error = fmt.Sprintf("%s: %s", r.Error.Type, r.Error.Reason)
+			}
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// resolveFilter returns the query DSL of a filter, translating filter-sql if
+// no filter is provided.
+func resolveFilter(esSQLTranslate *esapi.SQLTranslate, filter map[string]any, filterSQL string, indexName string) (map[string]any, error) {
+	if filterSQL != "" && filter == nil {
+		return translateSQLQuery(esSQLTranslate, filterSQL, indexName)
+	}
+	return filter, nil
+}
+
+func newScript(script Script) map[string]any {
+	lang := script.Lang
+	if lang == "" {
+		lang = "painless"
+	}
+
+	s := map[string]any{
+		"source": script.Source,
+		"lang":   lang,
+	}
+	if script.Params != nil {
+		s["params"] = script.Params
+	}
+	return s
+}
+
+func validateConflicts(conflicts string) error {
+	switch conflicts {
+	case "", "abort", "proceed":
+		return nil
+	}
+	return fmt.Errorf("unsupported conflicts: %s", conflicts)
+}
+
+// filter, filter-sql and query are optional, empty means all documents
+// conflicts is optional, empty means abort on version conflicts
+// max-docs is optional, empty means no limit
+func UpdateByQuery(es *esapi.UpdateByQuery, esSQLTranslate *esapi.SQLTranslate, inputStruct UpdateByQueryInput) (*DeleteUpdateResponse, error) {
+	indexName := inputStruct.IndexName
+
+	if inputStruct.Script.Source == "" {
+		return nil, fmt.Errorf("script source must be provided")
+	}
+	if err := validateConflicts(inputStruct.Conflicts); err != nil {
+		return nil, err
+	}
+
+	filter, err := resolveFilter(esSQLTranslate, inputStruct.Filter, inputStruct.FilterSQL, indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	updateByQueryReq := map[string]any{
+		"script": newScript(inputStruct.Script),
+	}
+	if filter != nil {
+		updateByQueryReq["query"] = filter
+	}
+
+	updateJSON, err := json.Marshal(updateByQueryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	esClient := ESUpdate(*es)
+
+	res, err := esClient([]string{indexName}, func(r *esapi.UpdateByQueryRequest) {
+		r.Body = bytes.NewReader(updateJSON)
+		r.Query = inputStruct.Query
+		r.Conflicts = inputStruct.Conflicts
+		r.Refresh = esapi.BoolPtr(true)
+		if inputStruct.MaxDocs > 0 {
+			r.MaxDocs = esapi.IntPtr(inputStruct.MaxDocs)
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("error updating by query: %s", res.Status())
+	}
+
+	var response DeleteUpdateResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// filter and filter-sql are optional, empty means all documents
+// script is optional, empty means the documents are copied as they are
+// op-type is optional, empty means existing documents are overwritten, create only copies missing documents
+func Reindex(es *esapi.Reindex, esSQLTranslate *esapi.SQLTranslate, inputStruct ReindexInput) (*DeleteUpdateResponse, error) {
+	if inputStruct.SourceIndex == "" || inputStruct.DestinationIndex == "" {
+		return nil, fmt.Errorf("source-index and destination-index must be provided")
+	}
+	if err := validateConflicts(inputStruct.Conflicts); err != nil {
+		return nil, err
+	}
+
+	filter, err := resolveFilter(esSQLTranslate, inputStruct.Filter, inputStruct.FilterSQL, inputStruct.SourceIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	source := map[string]any{"index": inputStruct.SourceIndex}
+	if filter != nil {
+		source["query"] = filter
+	}
+
+	dest := map[string]any{"index": inputStruct.DestinationIndex}
+	switch inputStruct.OpType {
+	case "":
+	case "index", "create":
+		dest["op_type"] = inputStruct.OpType
+	default:
+		return nil, fmt.Errorf("unsupported op-type: %s", inputStruct.OpType)
+	}
+
+	reindexReq := map[string]any{
+		"source": source,
+		"dest":   dest,
+	}
+	if inputStruct.Script != nil && inputStruct.Script.Source != "" {
+		reindexReq["script"] = newScript(*inputStruct.Script)
+	}
+	if inputStruct.Conflicts != "" {
+		reindexReq["conflicts"] = inputStruct.Conflicts
+	}
+
+	reindexJSON, err := json.Marshal(reindexReq)
+	if err != nil {
+		return nil, err
+	}
+
+	esClient := ESReindex(*es)
+
+	res, err := esClient(bytes.NewReader(reindexJSON), func(r *esapi.ReindexRequest) {
+		r.Refresh = esapi.BoolPtr(true)
+		r.WaitForCompletion = esapi.BoolPtr(true)
+		if inputStruct.MaxDocs > 0 {
+			r.MaxDocs = esapi.IntPtr(inputStruct.MaxDocs)
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("error reindexing: %s", res.Status())
+	}
+
+	var response DeleteUpdateResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Failed operations don't fail the task, they are reported in the items.
+func (e *execution) bulk(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct BulkInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := BulkDocument(&e.client.bulkClient, inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	errorCount := 0
+	for _, item := range items {
+		if item.Error != "" {
+			errorCount++
+		}
+	}
+
+	outputStruct := BulkOutput{
+		Status:     fmt.Sprintf("Successfully executed %d of %d operations", len(items)-errorCount, len(items)),
+		Items:      items,
+		ErrorCount: errorCount,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (e *execution) updateByQuery(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct UpdateByQueryInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := UpdateByQuery(&e.client.updateClient, &e.client.sqlTranslateClient, inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := response.Failures
+	if failures == nil {
+		failures = []map[string]any{}
+	}
+
+	outputStruct := UpdateByQueryOutput{
+		Status:           fmt.Sprintf("Successfully updated %d documents", response.Updated),
+		Total:            response.Total,
+		Updated:          response.Updated,
+		VersionConflicts: response.VersionConflicts,
+		Failures:         failures,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (e *execution) reindex(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct ReindexInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := Reindex(&e.client.reindexClient, &e.client.sqlTranslateClient, inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := response.Failures
+	if failures == nil {
+		failures = []map[string]any{}
+	}
+
+	outputStruct := ReindexOutput{
+		Status:           fmt.Sprintf("Successfully reindexed %d documents", response.Created+response.Updated),
+		Total:            response.Total,
+		Created:          response.Created,
+		Updated:          response.Updated,
+		VersionConflicts: response.VersionConflicts,
+		Failures:         failures,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
@@ -0,0 +1,60 @@
+package googlecloudstorage
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+)
+
+type CopyObjectInput struct {
+	BucketName            string `json:"bucket-name"`
+	ObjectName            string `json:"object-name"`
+	DestinationBucketName string `json:"destination-bucket-name"`
+	DestinationObjectName string `json:"destination-object-name"`
+	DeleteSource          bool   `json:"delete-source"`
+}
+
+type CopyObjectOutput struct {
+	Result     string     `json:"result"`
+	GsutilURI  string     `json:"gsutil-uri"`
+	Attributes Attributes `json:"attributes"`
+}
+
+func copyObject(input CopyObjectInput, client *storage.Client, ctx context.Context) (CopyObjectOutput, error) {
+	output := CopyObjectOutput{}
+
+	dstBucket := input.DestinationBucketName
+	if dstBucket == "" {
+		dstBucket = input.BucketName
+	}
+	dstObject := input.DestinationObjectName
+	if dstObject == "" {
+		dstObject = input.ObjectName
+	}
+	if dstBucket == input.BucketName && dstObject == input.ObjectName {
+		return output, fmt.Errorf("copyObject: source and destination are the same object")
+	}
+
+	src := client.Bucket(input.BucketName).Object(input.ObjectName)
+	dst := client.Bucket(dstBucket).Object(dstObject)
+
+	attrs, err := dst.CopierFrom(src).Run(ctx)
+	if err != nil {
+		return output, fmt.Errorf("copyObject: %w", err)
+	}
+
+	// Moving an object is a copy followed by the deletion of the source, as
+	// Cloud Storage has no native rename operation.
+	if input.DeleteSource {
+		if err := src.Delete(ctx); err != nil {
+			return output, fmt.Errorf("copyObject: deleting source object: %w", err)
+		}
+	}
+
+	output.Result = "Success"
+	output.GsutilURI = fmt.Sprintf("gs://%s/%s", dstBucket, dstObject)
+	output.Attributes = newAttributes(attrs)
+
+	return output, nil
+}
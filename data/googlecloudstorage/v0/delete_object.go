@@ -0,0 +1,28 @@
+package googlecloudstorage
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+)
+
+type DeleteObjectInput struct {
+	BucketName string `json:"bucket-name"`
+	ObjectName string `json:"object-name"`
+}
+
+type DeleteObjectOutput struct {
+	Result string `json:"result"`
+}
+
+func deleteObject(input DeleteObjectInput, client *storage.Client, ctx context.Context) (DeleteObjectOutput, error) {
+	output := DeleteObjectOutput{}
+
+	if err := client.Bucket(input.BucketName).Object(input.ObjectName).Delete(ctx); err != nil {
+		return output, fmt.Errorf("deleteObject: %w", err)
+	}
+
+	output.Result = "Success"
+	return output, nil
+}
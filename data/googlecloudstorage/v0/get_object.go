@@ -0,0 +1,75 @@
+package googlecloudstorage
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+type GetObjectInput struct {
+	BucketName string `json:"bucket-name"`
+	ObjectName string `json:"object-name"`
+}
+
+type GetObjectOutput struct {
+	Data       string     `json:"data"`
+	Attributes Attributes `json:"attributes"`
+}
+
+func getObject(input GetObjectInput, client *storage.Client, ctx context.Context) (GetObjectOutput, error) {
+	output := GetObjectOutput{}
+	obj := client.Bucket(input.BucketName).Object(input.ObjectName)
+
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return output, fmt.Errorf("getObject: %w", err)
+	}
+
+	rc, err := obj.NewReader(ctx)
+	if err != nil {
+		return output, fmt.Errorf("getObject: %w", err)
+	}
+	defer rc.Close()
+
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		return output, fmt.Errorf("getObject: %w", err)
+	}
+
+	contentType := attrs.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// The content is returned as a data URI so it can be passed directly to
+	// the upload task or to any component accepting base64 files.
+	output.Data = fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(b))
+	output.Attributes = newAttributes(attrs)
+
+	return output, nil
+}
+
+func newAttributes(attrs *storage.ObjectAttrs) Attributes {
+	attribute := Attributes{
+		Name:               attrs.Name,
+		ContentType:        attrs.ContentType,
+		ContentLanguage:    attrs.ContentLanguage,
+		Owner:              attrs.Owner,
+		Size:               attrs.Size,
+		ContentEncoding:    attrs.ContentEncoding,
+		ContentDisposition: attrs.ContentDisposition,
+		MD5:                attrs.MD5,
+		MediaLink:          attrs.MediaLink,
+		Metadata:           attrs.Metadata,
+		StorageClass:       attrs.StorageClass,
+	}
+
+	if attrs.Metadata == nil {
+		attribute.Metadata = map[string]string{}
+	}
+
+	return attribute
+}
@@ -16,9 +16,14 @@ import (
 )
 
 const (
-	taskUpload       = "TASK_UPLOAD"
-	taskReadObjects  = "TASK_READ_OBJECTS"
-	taskCreateBucket = "TASK_CREATE_BUCKET"
+	taskUpload               = "TASK_UPLOAD"
+	taskReadObjects          = "TASK_READ_OBJECTS"
+	taskCreateBucket         = "TASK_CREATE_BUCKET"
+	taskGetObject            = "TASK_GET_OBJECT"
+	taskDeleteObject         = "TASK_DELETE_OBJECT"
+	taskCopyObject           = "TASK_COPY_OBJECT"
+	taskGenerateSignedURL    = "TASK_GENERATE_SIGNED_URL"
+	taskUpdateObjectMetadata = "TASK_UPDATE_OBJECT_METADATA"
 )
 
 //go:embed config/definition.json
@@ -86,7 +91,12 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 		case taskUpload, "":
 			objectName := input.GetFields()["object-name"].GetStringValue()
 			data := input.GetFields()["data"].GetStringValue()
-			err = uploadToGCS(client, bucketName, objectName, data)
+			contentType := input.GetFields()["content-type"].GetStringValue()
+			metadata := map[string]string{}
+			for k, v := range input.GetFields()["metadata"].GetStructValue().GetFields() {
+				metadata[k] = v.GetStringValue()
+			}
+			err = uploadToGCS(client, bucketName, objectName, data, contentType, metadata)
 			if err != nil {
 				job.Error.Error(ctx, err)
 				continue
@@ -149,6 +159,106 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 				continue
 			}
 
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+		case taskGetObject:
+			inputStruct := GetObjectInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := getObject(inputStruct, client, ctx)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+		case taskDeleteObject:
+			inputStruct := DeleteObjectInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := deleteObject(inputStruct, client, ctx)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+		case taskCopyObject:
+			inputStruct := CopyObjectInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := copyObject(inputStruct, client, ctx)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+		case taskGenerateSignedURL:
+			inputStruct := GenerateSignedURLInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := generateSignedURL(inputStruct, client, getJSONKey(e.Setup))
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+		case taskUpdateObjectMetadata:
+			inputStruct := UpdateObjectMetadataInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := updateObjectMetadata(inputStruct, client, ctx)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
 			output, err = base.ConvertToStructpb(outputStruct)
 			if err != nil {
 				job.Error.Error(ctx, err)
@@ -1,4 +1,484 @@
 package googlecloudstorage
 
-// TODO: chuang8511: add test cases by mocking the GCS client
-// It will be done in 2024-06-26
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// fakeObject is an object stored in the fake GCS server.
+type fakeObject struct {
+	data        []byte
+	contentType string
+	metadata    map[string]string
+}
+
+// fakeGCSServer is an in-memory stand-in for the subset of the Cloud Storage
+// JSON and XML APIs used by the component.
+type fakeGCSServer struct {
+	mu      sync.Mutex
+	objects map[string]*fakeObject
+}
+
+func newFakeGCSServer() *fakeGCSServer {
+	return &fakeGCSServer{objects: map[string]*fakeObject{}}
+}
+
+func (s *fakeGCSServer) put(bucket, name string, obj *fakeObject) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[bucket+"/"+name] = obj
+}
+
+func (s *fakeGCSServer) get(bucket, name string) (*fakeObject, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[bucket+"/"+name]
+	return obj, ok
+}
+
+func (s *fakeGCSServer) resource(bucket, name string, obj *fakeObject) map[string]any {
+	sum := md5.Sum(obj.data)
+	return map[string]any{
+		"kind":         "storage#object",
+		"bucket":       bucket,
+		"name":         name,
+		"contentType":  obj.contentType,
+		"size":         strconv.Itoa(len(obj.data)),
+		"md5Hash":      base64.StdEncoding.EncodeToString(sum[:]),
+		"metadata":     obj.metadata,
+		"generation":   "1",
+		"storageClass": "STANDARD",
+		"timeCreated":  "2024-10-01T00:00:00Z",
+		"updated":      "2024-10-01T00:00:00Z",
+	}
+}
+
+func (s *fakeGCSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+
+	switch {
+	case strings.HasPrefix(path, "/upload/storage/v1/b/"):
+		s.upload(w, r, strings.TrimPrefix(path, "/upload/storage/v1/b/"))
+	case strings.HasPrefix(path, "/storage/v1/b/"):
+		s.jsonAPI(w, r, strings.TrimPrefix(path, "/storage/v1/b/"))
+	default:
+		s.download(w, r, strings.TrimPrefix(path, "/"))
+	}
+}
+
+func (s *fakeGCSServer) upload(w http.ResponseWriter, r *http.Request, path string) {
+	bucket := strings.TrimSuffix(path, "/o")
+
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	metaPart, err := mr.NextPart()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	meta := struct {
+		Name        string            `json:"name"`
+		ContentType string            `json:"contentType"`
+		Metadata    map[string]string `json:"metadata"`
+	}{}
+	if err := json.NewDecoder(metaPart).Decode(&meta); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	dataPart, err := mr.NextPart()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, _ := io.ReadAll(dataPart)
+
+	if meta.ContentType == "" {
+		meta.ContentType = dataPart.Header.Get("Content-Type")
+	}
+	obj := &fakeObject{data: data, contentType: meta.ContentType, metadata: meta.Metadata}
+	s.put(bucket, meta.Name, obj)
+	writeJSON(w, s.resource(bucket, meta.Name, obj))
+}
+
+func (s *fakeGCSServer) jsonAPI(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 || parts[1] != "o" {
+		http.Error(w, "unsupported path", http.StatusNotImplemented)
+		return
+	}
+	bucket := parts[0]
+	name, _ := url.PathUnescape(parts[2])
+
+	obj, ok := s.get(bucket, name)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	// Rewrite: /{bucket}/o/{object}/rewriteTo/b/{bucket}/o/{object}
+	if len(parts) == 8 && parts[3] == "rewriteTo" {
+		dstBucket := parts[5]
+		dstName, _ := url.PathUnescape(parts[7])
+		cp := &fakeObject{data: obj.data, contentType: obj.contentType, metadata: obj.metadata}
+		s.put(dstBucket, dstName, cp)
+		writeJSON(w, map[string]any{
+			"kind":                "storage#rewriteResponse",
+			"done":                true,
+			"totalBytesRewritten": strconv.Itoa(len(cp.data)),
+			"objectSize":          strconv.Itoa(len(cp.data)),
+			"resource":            s.resource(dstBucket, dstName, cp),
+		})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, s.resource(bucket, name, obj))
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, bucket+"/"+name)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPatch:
+		patch := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if ct, ok := patch["contentType"].(string); ok {
+			obj.contentType = ct
+		}
+		if md, ok := patch["metadata"]; ok {
+			if md == nil {
+				obj.metadata = nil
+			} else {
+				if obj.metadata == nil {
+					obj.metadata = map[string]string{}
+				}
+				for k, v := range md.(map[string]any) {
+					obj.metadata[k] = v.(string)
+				}
+			}
+		}
+		writeJSON(w, s.resource(bucket, name, obj))
+	default:
+		http.Error(w, "unsupported method", http.StatusNotImplemented)
+	}
+}
+
+func (s *fakeGCSServer) download(w http.ResponseWriter, r *http.Request, path string) {
+	bucket, escapedName, _ := strings.Cut(path, "/")
+	name, _ := url.PathUnescape(escapedName)
+
+	obj, ok := s.get(bucket, name)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", obj.contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+	w.Header().Set("X-Goog-Generation", "1")
+	_, _ = w.Write(obj.data)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func notFound(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "No such object"}}`))
+}
+
+func newTestClient(c *qt.C, srv *httptest.Server) *storage.Client {
+	client, err := storage.NewClient(
+		context.Background(),
+		option.WithEndpoint(srv.URL+"/storage/v1/"),
+		option.WithoutAuthentication(),
+	)
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestUploadAndGetObject(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	fake := newFakeGCSServer()
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+	client := newTestClient(c, srv)
+
+	data := base64.StdEncoding.EncodeToString([]byte("hello world"))
+	err := uploadToGCS(client, "bucket", "folder/hello.txt", "data:text/plain;base64,"+data, "text/plain", map[string]string{"source": "pipeline"})
+	c.Assert(err, qt.IsNil)
+
+	c.Run("ok - get object", func(c *qt.C) {
+		got, err := getObject(GetObjectInput{BucketName: "bucket", ObjectName: "folder/hello.txt"}, client, ctx)
+		c.Assert(err, qt.IsNil)
+		c.Check(got.Data, qt.Equals, "data:text/plain;base64,"+data)
+		c.Check(got.Attributes.Name, qt.Equals, "folder/hello.txt")
+		c.Check(got.Attributes.ContentType, qt.Equals, "text/plain")
+		c.Check(got.Attributes.Size, qt.Equals, int64(11))
+		c.Check(got.Attributes.Metadata, qt.DeepEquals, map[string]string{"source": "pipeline"})
+	})
+
+	c.Run("nok - object not found", func(c *qt.C) {
+		_, err := getObject(GetObjectInput{BucketName: "bucket", ObjectName: "missing.txt"}, client, ctx)
+		c.Check(err, qt.ErrorMatches, "getObject: storage: object doesn't exist")
+	})
+}
+
+func TestDeleteObject(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	fake := newFakeGCSServer()
+	fake.put("bucket", "a.txt", &fakeObject{data: []byte("a"), contentType: "text/plain"})
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+	client := newTestClient(c, srv)
+
+	got, err := deleteObject(DeleteObjectInput{BucketName: "bucket", ObjectName: "a.txt"}, client, ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(got.Result, qt.Equals, "Success")
+
+	_, ok := fake.get("bucket", "a.txt")
+	c.Check(ok, qt.IsFalse)
+
+	_, err = deleteObject(DeleteObjectInput{BucketName: "bucket", ObjectName: "a.txt"}, client, ctx)
+	c.Check(err, qt.ErrorMatches, "deleteObject: storage: object doesn't exist")
+}
+
+func TestCopyObject(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	testcases := []struct {
+		name       string
+		input      CopyObjectInput
+		wantBucket string
+		wantObject string
+		wantSource bool
+		wantErr    string
+	}{
+		{
+			name: "ok - copy across buckets",
+			input: CopyObjectInput{
+				BucketName:            "raw",
+				ObjectName:            "doc.pdf",
+				DestinationBucketName: "processed",
+			},
+			wantBucket: "processed",
+			wantObject: "doc.pdf",
+			wantSource: true,
+		},
+		{
+			name: "ok - move within bucket",
+			input: CopyObjectInput{
+				BucketName:            "raw",
+				ObjectName:            "doc.pdf",
+				DestinationObjectName: "archive/doc.pdf",
+				DeleteSource:          true,
+			},
+			wantBucket: "raw",
+			wantObject: "archive/doc.pdf",
+			wantSource: false,
+		},
+		{
+			name: "nok - same source and destination",
+			input: CopyObjectInput{
+				BucketName: "raw",
+				ObjectName: "doc.pdf",
+			},
+			wantErr: "copyObject: source and destination are the same object",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			fake := newFakeGCSServer()
+			fake.put("raw", "doc.pdf", &fakeObject{data: []byte("%PDF"), contentType: "application/pdf"})
+			srv := httptest.NewServer(fake)
+			c.Cleanup(srv.Close)
+			client := newTestClient(c, srv)
+
+			got, err := copyObject(tc.input, client, ctx)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(got.Result, qt.Equals, "Success")
+			c.Check(got.GsutilURI, qt.Equals, fmt.Sprintf("gs://%s/%s", tc.wantBucket, tc.wantObject))
+			c.Check(got.Attributes.Name, qt.Equals, tc.wantObject)
+
+			_, ok := fake.get(tc.wantBucket, tc.wantObject)
+			c.Check(ok, qt.IsTrue)
+			_, ok = fake.get("raw", "doc.pdf")
+			c.Check(ok, qt.Equals, tc.wantSource)
+		})
+	}
+}
+
+func TestUpdateObjectMetadata(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	testcases := []struct {
+		name  string
+		input UpdateObjectMetadataInput
+		want  map[string]string
+	}{
+		{
+			name: "ok - merge metadata",
+			input: UpdateObjectMetadataInput{
+				Metadata: map[string]string{"status": "processed"},
+			},
+			want: map[string]string{"source": "crawler", "status": "processed"},
+		},
+		{
+			name: "ok - replace metadata",
+			input: UpdateObjectMetadataInput{
+				Metadata:      map[string]string{"status": "processed"},
+				ClearMetadata: true,
+			},
+			want: map[string]string{"status": "processed"},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			fake := newFakeGCSServer()
+			fake.put("bucket", "a.txt", &fakeObject{
+				data:        []byte("a"),
+				contentType: "text/plain",
+				metadata:    map[string]string{"source": "crawler"},
+			})
+			srv := httptest.NewServer(fake)
+			c.Cleanup(srv.Close)
+			client := newTestClient(c, srv)
+
+			tc.input.BucketName = "bucket"
+			tc.input.ObjectName = "a.txt"
+			tc.input.ContentType = "text/markdown"
+
+			got, err := updateObjectMetadata(tc.input, client, ctx)
+			c.Assert(err, qt.IsNil)
+			c.Check(got.Result, qt.Equals, "Success")
+			c.Check(got.Attributes.Metadata, qt.DeepEquals, tc.want)
+			c.Check(got.Attributes.ContentType, qt.Equals, "text/markdown")
+		})
+	}
+}
+
+func TestGenerateSignedURL(t *testing.T) {
+	c := qt.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, qt.IsNil)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	jsonKey, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "pipeline@project.iam.gserviceaccount.com",
+		"private_key":  string(pemKey),
+	})
+	c.Assert(err, qt.IsNil)
+
+	srv := httptest.NewServer(newFakeGCSServer())
+	c.Cleanup(srv.Close)
+	client := newTestClient(c, srv)
+
+	testcases := []struct {
+		name           string
+		input          GenerateSignedURLInput
+		wantMethod     string
+		wantExpiration time.Duration
+		wantErr        string
+	}{
+		{
+			name:           "ok - default method and expiration",
+			input:          GenerateSignedURLInput{BucketName: "bucket", ObjectName: "a.txt"},
+			wantMethod:     "GET",
+			wantExpiration: 15 * time.Minute,
+		},
+		{
+			name: "ok - upload URL",
+			input: GenerateSignedURLInput{
+				BucketName:  "bucket",
+				ObjectName:  "a.txt",
+				Method:      "put",
+				Expiration:  3600,
+				ContentType: "text/plain",
+			},
+			wantMethod:     "PUT",
+			wantExpiration: time.Hour,
+		},
+		{
+			name: "nok - expiration too long",
+			input: GenerateSignedURLInput{
+				BucketName: "bucket",
+				ObjectName: "a.txt",
+				Expiration: 8 * 24 * 3600,
+			},
+			wantErr: "generateSignedURL: expiration can't exceed 604800 seconds",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			got, err := generateSignedURL(tc.input, client, string(jsonKey))
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(got.Method, qt.Equals, tc.wantMethod)
+
+			expires, err := time.Parse(time.RFC3339, got.ExpirationTime)
+			c.Assert(err, qt.IsNil)
+			c.Check(time.Until(expires) <= tc.wantExpiration, qt.IsTrue)
+			c.Check(time.Until(expires) > tc.wantExpiration-time.Minute, qt.IsTrue)
+
+			u, err := url.Parse(got.URL)
+			c.Assert(err, qt.IsNil)
+			c.Check(u.Path, qt.Equals, "/bucket/a.txt")
+			c.Check(u.Query().Get("X-Goog-Algorithm"), qt.Equals, "GOOG4-RSA-SHA256")
+			c.Check(u.Query().Get("X-Goog-Credential"), qt.Contains, "pipeline@project.iam.gserviceaccount.com")
+		})
+	}
+}
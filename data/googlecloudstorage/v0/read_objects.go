@@ -111,23 +111,7 @@ func readObjects(input ReadInput, client *storage.Client, ctx context.Context) (
 			return output, fmt.Errorf("readObjects: %v", err)
 		}
 
-		attribute := Attributes{
-			Name:               attrs.Name,
-			ContentType:        attrs.ContentType,
-			ContentLanguage:    attrs.ContentLanguage,
-			Owner:              attrs.Owner,
-			Size:               attrs.Size,
-			ContentEncoding:    attrs.ContentEncoding,
-			ContentDisposition: attrs.ContentDisposition,
-			MD5:                attrs.MD5,
-			MediaLink:          attrs.MediaLink,
-			Metadata:           attrs.Metadata,
-			StorageClass:       attrs.StorageClass,
-		}
-
-		if attrs.Metadata == nil {
-			attribute.Metadata = map[string]string{}
-		}
+		attribute := newAttributes(attrs)
 
 		if strings.Contains(attrs.ContentType, "text") {
 			textObject := TextObject{
@@ -0,0 +1,83 @@
+package googlecloudstorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+const (
+	defaultSignedURLExpiration = 15 * time.Minute
+	// V4 signatures can't be valid for more than 7 days.
+	maxSignedURLExpiration = 7 * 24 * time.Hour
+)
+
+type GenerateSignedURLInput struct {
+	BucketName  string `json:"bucket-name"`
+	ObjectName  string `json:"object-name"`
+	Method      string `json:"method"`
+	Expiration  int    `json:"expiration"`
+	ContentType string `json:"content-type"`
+}
+
+type GenerateSignedURLOutput struct {
+	URL            string `json:"url"`
+	Method         string `json:"method"`
+	ExpirationTime string `json:"expiration-time"`
+}
+
+// serviceAccountKey holds the fields of the JSON key file that are needed to
+// sign URLs.
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+func generateSignedURL(input GenerateSignedURLInput, client *storage.Client, jsonKey string) (GenerateSignedURLOutput, error) {
+	output := GenerateSignedURLOutput{}
+
+	method := strings.ToUpper(input.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	expiration := defaultSignedURLExpiration
+	if input.Expiration > 0 {
+		expiration = time.Duration(input.Expiration) * time.Second
+	}
+	if expiration > maxSignedURLExpiration {
+		return output, fmt.Errorf("generateSignedURL: expiration can't exceed %d seconds", int(maxSignedURLExpiration.Seconds()))
+	}
+
+	expires := time.Now().Add(expiration)
+	opts := &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
+		Method:  method,
+		Expires: expires,
+	}
+	if input.ContentType != "" {
+		opts.ContentType = input.ContentType
+	}
+
+	// When the key file contains a private key, the URL is signed locally.
+	// Otherwise, the client falls back to its default credentials.
+	key := serviceAccountKey{}
+	if err := json.Unmarshal([]byte(jsonKey), &key); err == nil && key.PrivateKey != "" {
+		opts.GoogleAccessID = key.ClientEmail
+		opts.PrivateKey = []byte(key.PrivateKey)
+	}
+
+	url, err := client.Bucket(input.BucketName).SignedURL(input.ObjectName, opts)
+	if err != nil {
+		return output, fmt.Errorf("generateSignedURL: %w", err)
+	}
+
+	output.URL = url
+	output.Method = method
+	output.ExpirationTime = expires.UTC().Format(time.RFC3339)
+
+	return output, nil
+}
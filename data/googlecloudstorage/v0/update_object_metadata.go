@@ -0,0 +1,64 @@
+package googlecloudstorage
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+)
+
+type UpdateObjectMetadataInput struct {
+	BucketName         string            `json:"bucket-name"`
+	ObjectName         string            `json:"object-name"`
+	Metadata           map[string]string `json:"metadata"`
+	ClearMetadata      bool              `json:"clear-metadata"`
+	ContentType        string            `json:"content-type"`
+	ContentLanguage    string            `json:"content-language"`
+	ContentDisposition string            `json:"content-disposition"`
+	CacheControl       string            `json:"cache-control"`
+}
+
+type UpdateObjectMetadataOutput struct {
+	Result     string     `json:"result"`
+	Attributes Attributes `json:"attributes"`
+}
+
+func updateObjectMetadata(input UpdateObjectMetadataInput, client *storage.Client, ctx context.Context) (UpdateObjectMetadataOutput, error) {
+	output := UpdateObjectMetadataOutput{}
+	obj := client.Bucket(input.BucketName).Object(input.ObjectName)
+
+	// A metadata patch merges the provided keys into the existing ones, so
+	// clearing the current metadata requires a dedicated update.
+	if input.ClearMetadata {
+		if _, err := obj.Update(ctx, storage.ObjectAttrsToUpdate{Metadata: map[string]string{}}); err != nil {
+			return output, fmt.Errorf("updateObjectMetadata: clearing metadata: %w", err)
+		}
+	}
+
+	toUpdate := storage.ObjectAttrsToUpdate{}
+	if len(input.Metadata) > 0 {
+		toUpdate.Metadata = input.Metadata
+	}
+	if input.ContentType != "" {
+		toUpdate.ContentType = input.ContentType
+	}
+	if input.ContentLanguage != "" {
+		toUpdate.ContentLanguage = input.ContentLanguage
+	}
+	if input.ContentDisposition != "" {
+		toUpdate.ContentDisposition = input.ContentDisposition
+	}
+	if input.CacheControl != "" {
+		toUpdate.CacheControl = input.CacheControl
+	}
+
+	attrs, err := obj.Update(ctx, toUpdate)
+	if err != nil {
+		return output, fmt.Errorf("updateObjectMetadata: %w", err)
+	}
+
+	output.Result = "Success"
+	output.Attributes = newAttributes(attrs)
+
+	return output, nil
+}
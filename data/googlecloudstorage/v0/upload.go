@@ -11,8 +11,10 @@ import (
 	"github.com/instill-ai/component/base"
 )
 
-func uploadToGCS(client *storage.Client, bucketName, objectName, data string) error {
+func uploadToGCS(client *storage.Client, bucketName, objectName, data, contentType string, metadata map[string]string) error {
 	wc := client.Bucket(bucketName).Object(objectName).NewWriter(context.Background())
+	wc.ContentType = contentType
+	wc.Metadata = metadata
 	b, _ := base64.StdEncoding.DecodeString(base.TrimBase64Mime(data))
 	if _, err := io.Writer.Write(wc, b); err != nil {
 		return err
@@ -462,10 +462,12 @@ type SearchChunksInput struct {
 	CatalogID  string `json:"catalog-id"`
 	TextPrompt string `json:"text-prompt"`
 	TopK       uint32 `json:"top-k"`
+	ChunkFilter
 }
 
 type SearchChunksOutput struct {
-	Chunks []SimilarityChunk `json:"chunks"`
+	Chunks     []SimilarityChunk `json:"chunks"`
+	Incomplete bool              `json:"incomplete"`
 }
 
 type SimilarityChunk struct {
@@ -473,6 +475,9 @@ type SimilarityChunk struct {
 	SimilarityScore float32 `json:"similarity-score"`
 	TextContent     string  `json:"text-content"`
 	SourceFileName  string  `json:"source-file-name"`
+	FileUID         string  `json:"file-uid"`
+	StartPosition   uint32  `json:"start-position"`
+	EndPosition     uint32  `json:"end-position"`
 }
 
 func (e *execution) searchChunks(input *structpb.Struct) (*structpb.Struct, error) {
@@ -491,28 +496,35 @@ func (e *execution) searchChunks(input *structpb.Struct) (*structpb.Struct, erro
 	defer cancel()
 	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
 
+	scope, err := e.newChunkScope(ctx, inputStruct.Namespace, inputStruct.CatalogID, inputStruct.ChunkFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	output := SearchChunksOutput{
+		Chunks: []SimilarityChunk{},
+	}
+
+	if !scope.matchesCatalog() {
+		return base.ConvertToStructpb(output)
+	}
+
 	searchRes, err := artifactClient.SimilarityChunksSearch(ctx, &artifactPB.SimilarityChunksSearchRequest{
 		NamespaceId: inputStruct.Namespace,
 		CatalogId:   inputStruct.CatalogID,
 		TextPrompt:  inputStruct.TextPrompt,
-		TopK:        inputStruct.TopK,
+		TopK:        inputStruct.ChunkFilter.searchTopK(inputStruct.TopK),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to search chunks: %w", err)
 	}
 
-	output := SearchChunksOutput{
-		Chunks: []SimilarityChunk{},
+	err = e.loadFileTypes(ctx, inputStruct.Namespace, inputStruct.CatalogID, scope, searchRes.SimilarChunks)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, chunkPB := range searchRes.SimilarChunks {
-		output.Chunks = append(output.Chunks, SimilarityChunk{
-			ChunkUID:        chunkPB.ChunkUid,
-			SimilarityScore: chunkPB.SimilarityScore,
-			TextContent:     chunkPB.TextContent,
-			SourceFileName:  chunkPB.SourceFile,
-		})
-	}
+	output.Chunks, output.Incomplete = scope.chunks(searchRes.SimilarChunks, int(inputStruct.TopK))
 
 	return base.ConvertToStructpb(output)
 }
@@ -522,6 +534,7 @@ type QueryInput struct {
 	CatalogID string `json:"catalog-id"`
 	Question  string `json:"question"`
 	TopK      int32  `json:"top-k"`
+	ChunkFilter
 }
 
 type QueryOutput struct {
@@ -541,10 +554,31 @@ func (e *execution) query(input *structpb.Struct) (*structpb.Struct, error) {
 
 	defer connection.Close()
 
+	// The backend generates the answer from all the chunks it retrieves, so
+	// filtering the returned chunks would leave excluded content in the
+	// answer.
+	if inputStruct.ChunkFilter.filtersChunks() {
+		return nil, fmt.Errorf("the file UID, file type and minimum score filters aren't supported by the ask task, use the retrieve task instead")
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
 
+	scope, err := e.newChunkScope(ctx, inputStruct.Namespace, inputStruct.CatalogID, inputStruct.ChunkFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	output := QueryOutput{
+		Chunks: []SimilarityChunk{},
+	}
+
+	// A catalog that doesn't match the tags isn't asked at all.
+	if !scope.matchesCatalog() {
+		return base.ConvertToStructpb(output)
+	}
+
 	queryRes, err := artifactClient.QuestionAnswering(ctx, &artifactPB.QuestionAnsweringRequest{
 		NamespaceId: inputStruct.Namespace,
 		CatalogId:   inputStruct.CatalogID,
@@ -555,18 +589,9 @@ func (e *execution) query(input *structpb.Struct) (*structpb.Struct, error) {
 		return nil, fmt.Errorf("failed to question answering: %w", err)
 	}
 
-	output := QueryOutput{
-		Answer: queryRes.Answer,
-		Chunks: []SimilarityChunk{},
-	}
-
+	output.Answer = queryRes.Answer
 	for _, chunkPB := range queryRes.SimilarChunks {
-		output.Chunks = append(output.Chunks, SimilarityChunk{
-			ChunkUID:        chunkPB.ChunkUid,
-			SimilarityScore: chunkPB.SimilarityScore,
-			TextContent:     chunkPB.TextContent,
-			SourceFileName:  chunkPB.SourceFile,
-		})
+		output.Chunks = append(output.Chunks, toSimilarityChunk(chunkPB))
 	}
 
 	return base.ConvertToStructpb(output)
@@ -624,3 +649,277 @@ func (e *execution) matchFileStatus(input *structpb.Struct) (*structpb.Struct, e
 	}
 
 }
+
+type DeleteFileInput struct {
+	FileUID string `json:"file-uid"`
+}
+
+type DeleteFileOutput struct {
+	FileUID string `json:"file-uid"`
+}
+
+func (e *execution) deleteFile(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := DeleteFileInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	deleteRes, err := artifactClient.DeleteCatalogFile(ctx, &artifactPB.DeleteCatalogFileRequest{
+		FileUid: inputStruct.FileUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return base.ConvertToStructpb(DeleteFileOutput{
+		FileUID: deleteRes.FileUid,
+	})
+}
+
+type CatalogOutput struct {
+	CatalogUID  string   `json:"catalog-uid"`
+	CatalogID   string   `json:"catalog-id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	CreateTime  string   `json:"create-time"`
+	UpdateTime  string   `json:"update-time"`
+	OwnerName   string   `json:"owner-name"`
+	Tags        []string `json:"tags"`
+}
+
+func toCatalogOutput(catalogPB *artifactPB.Catalog) CatalogOutput {
+	tags := catalogPB.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return CatalogOutput{
+		CatalogUID:  catalogPB.CatalogUid,
+		CatalogID:   catalogPB.CatalogId,
+		Name:        catalogPB.Name,
+		Description: catalogPB.Description,
+		CreateTime:  catalogPB.CreateTime,
+		UpdateTime:  catalogPB.UpdateTime,
+		OwnerName:   catalogPB.OwnerName,
+		Tags:        tags,
+	}
+}
+
+type ListCatalogsInput struct {
+	Namespace string `json:"namespace"`
+}
+
+type ListCatalogsOutput struct {
+	Catalogs []CatalogOutput `json:"catalogs"`
+}
+
+func (e *execution) listCatalogs(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := ListCatalogsInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	catalogsRes, err := artifactClient.ListCatalogs(ctx, &artifactPB.ListCatalogsRequest{
+		NamespaceId: inputStruct.Namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs: %w", err)
+	}
+
+	output := ListCatalogsOutput{
+		Catalogs: []CatalogOutput{},
+	}
+
+	for _, catalogPB := range catalogsRes.Catalogs {
+		output.Catalogs = append(output.Catalogs, toCatalogOutput(catalogPB))
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type CreateCatalogInput struct {
+	Namespace   string   `json:"namespace"`
+	CatalogID   string   `json:"catalog-id"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
+
+type CatalogResultOutput struct {
+	Catalog CatalogOutput `json:"catalog"`
+}
+
+func (e *execution) createCatalog(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := CreateCatalogInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	createRes, err := artifactClient.CreateCatalog(ctx, &artifactPB.CreateCatalogRequest{
+		NamespaceId: inputStruct.Namespace,
+		Name:        inputStruct.CatalogID,
+		Description: inputStruct.Description,
+		Tags:        inputStruct.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create catalog: %w", err)
+	}
+
+	return base.ConvertToStructpb(CatalogResultOutput{
+		Catalog: toCatalogOutput(createRes.Catalog),
+	})
+}
+
+type DeleteCatalogInput struct {
+	Namespace string `json:"namespace"`
+	CatalogID string `json:"catalog-id"`
+}
+
+func (e *execution) deleteCatalog(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := DeleteCatalogInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	deleteRes, err := artifactClient.DeleteCatalog(ctx, &artifactPB.DeleteCatalogRequest{
+		NamespaceId: inputStruct.Namespace,
+		CatalogId:   inputStruct.CatalogID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete catalog: %w", err)
+	}
+
+	return base.ConvertToStructpb(CatalogResultOutput{
+		Catalog: toCatalogOutput(deleteRes.Catalog),
+	})
+}
+
+type UpdateChunkInput struct {
+	ChunkUID    string `json:"chunk-uid"`
+	Retrievable bool   `json:"retrievable"`
+}
+
+type UpdateChunkOutput struct {
+	Chunk ChunkOutput `json:"chunk"`
+}
+
+func (e *execution) updateChunk(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := UpdateChunkInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	updateRes, err := artifactClient.UpdateChunk(ctx, &artifactPB.UpdateChunkRequest{
+		ChunkUid:    inputStruct.ChunkUID,
+		Retrievable: inputStruct.Retrievable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chunk: %w", err)
+	}
+
+	chunkPB := updateRes.Chunk
+	output := UpdateChunkOutput{
+		Chunk: ChunkOutput{
+			ChunkUID:        chunkPB.ChunkUid,
+			Retrievable:     chunkPB.Retrievable,
+			StartPosition:   chunkPB.StartPos,
+			EndPosition:     chunkPB.EndPos,
+			TokenCount:      chunkPB.Tokens,
+			CreateTime:      chunkPB.CreateTime.AsTime().Format(time.RFC3339),
+			OriginalFileUID: chunkPB.OriginalFileUid,
+		},
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type ReprocessFileInput struct {
+	FileUID string `json:"file-uid"`
+}
+
+type ReprocessFileOutput struct {
+	FileUID       string `json:"file-uid"`
+	ProcessStatus string `json:"process-status"`
+}
+
+func (e *execution) reprocessFile(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := ReprocessFileInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	processRes, err := artifactClient.ProcessCatalogFiles(ctx, &artifactPB.ProcessCatalogFilesRequest{
+		FileUids: []string{inputStruct.FileUID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reprocess file: %w", err)
+	}
+
+	if len(processRes.Files) == 0 {
+		return nil, fmt.Errorf("file %s was not found", inputStruct.FileUID)
+	}
+
+	return base.ConvertToStructpb(ReprocessFileOutput{
+		FileUID:       processRes.Files[0].FileUid,
+		ProcessStatus: artifactPB.FileProcessStatus_name[int32(processRes.Files[0].ProcessStatus)],
+	})
+}
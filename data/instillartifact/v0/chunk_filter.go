@@ -0,0 +1,209 @@
+package instillartifact
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	artifactPB "github.com/instill-ai/protogen-go/artifact/artifact/v1alpha"
+)
+
+// maxTopK is the maximum number of chunks the artifact backend returns in a
+// single search.
+const maxTopK = 20
+
+// ChunkFilter scopes the chunks returned by the retrieve and ask tasks. The
+// artifact backend doesn't support filters on similarity search, so they are
+// applied to the chunks it returns.
+type ChunkFilter struct {
+	FileUIDs  []string `json:"file-uids"`
+	FileTypes []string `json:"file-types"`
+	// Tags are matched against the tags of the catalog, as the artifact
+	// backend doesn't expose the tags of the files. All of them must be
+	// present for the catalog chunks to be returned.
+	Tags     []string `json:"tags"`
+	MinScore float32  `json:"min-score"`
+}
+
+// filtersChunks returns whether the filter discards individual chunks, as
+// opposed to the tag filter, which applies to the whole catalog.
+func (f ChunkFilter) filtersChunks() bool {
+	return len(f.FileUIDs) > 0 || len(f.FileTypes) > 0 || f.MinScore > 0
+}
+
+// searchTopK returns the top-k to request to the backend. When chunks are
+// filtered, as many chunks as the backend allows are requested so that top-k
+// chunks are likely to remain after filtering.
+func (f ChunkFilter) searchTopK(topK uint32) uint32 {
+	if !f.filtersChunks() || topK == 0 {
+		return topK
+	}
+	return maxTopK
+}
+
+func normalizeFileType(fileType string) string {
+	fileType = strings.ToUpper(strings.TrimSpace(fileType))
+	if !strings.HasPrefix(fileType, "FILE_TYPE_") {
+		fileType = "FILE_TYPE_" + fileType
+	}
+	return fileType
+}
+
+// chunkScope holds the catalog and file information needed to filter the
+// chunks of a catalog.
+type chunkScope struct {
+	filter      ChunkFilter
+	catalogTags []string
+	fileTypes   map[string]string
+}
+
+// newChunkScope builds the scope of a filter. The catalog is only looked up
+// when the filter has tags.
+func (e *execution) newChunkScope(ctx context.Context, namespace, catalogID string, filter ChunkFilter) (*chunkScope, error) {
+	scope := &chunkScope{
+		filter:      filter,
+		catalogTags: []string{},
+		fileTypes:   map[string]string{},
+	}
+
+	if len(filter.Tags) == 0 {
+		return scope, nil
+	}
+
+	catalogsRes, err := e.client.ListCatalogs(ctx, &artifactPB.ListCatalogsRequest{
+		NamespaceId: namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs: %w", err)
+	}
+
+	for _, catalog := range catalogsRes.Catalogs {
+		if catalog.CatalogId == catalogID || catalog.Name == catalogID {
+			if catalog.Tags != nil {
+				scope.catalogTags = catalog.Tags
+			}
+			break
+		}
+	}
+
+	return scope, nil
+}
+
+// matchesCatalog returns whether the catalog has all the tags of the filter.
+func (s *chunkScope) matchesCatalog() bool {
+	tags := make(map[string]bool, len(s.catalogTags))
+	for _, tag := range s.catalogTags {
+		tags[tag] = true
+	}
+	for _, tag := range s.filter.Tags {
+		if !tags[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// loadFileTypes fetches the type of the files the chunks belong to. It is
+// only needed when filtering by file type.
+func (e *execution) loadFileTypes(ctx context.Context, namespace, catalogID string, scope *chunkScope, chunks []*artifactPB.SimilarityChunk) error {
+	if len(scope.filter.FileTypes) == 0 {
+		return nil
+	}
+
+	fileUIDs := []string{}
+	for _, chunkPB := range chunks {
+		if uid := chunkPB.GetChunkMetadata().GetOriginalFileUid(); uid != "" && !slices.Contains(fileUIDs, uid) {
+			fileUIDs = append(fileUIDs, uid)
+		}
+	}
+	if len(fileUIDs) == 0 {
+		return nil
+	}
+
+	req := &artifactPB.ListCatalogFilesRequest{
+		NamespaceId: namespace,
+		CatalogId:   catalogID,
+		Filter: &artifactPB.ListCatalogFilesFilter{
+			FileUids: fileUIDs,
+		},
+	}
+	for {
+		filesRes, err := e.client.ListCatalogFiles(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to list catalog files: %w", err)
+		}
+
+		for _, filePB := range filesRes.Files {
+			scope.fileTypes[filePB.FileUid] = artifactPB.FileType_name[int32(filePB.Type)]
+		}
+
+		if filesRes.NextPageToken == "" {
+			return nil
+		}
+		req.PageToken = filesRes.NextPageToken
+	}
+}
+
+func (s *chunkScope) matchesChunk(chunkPB *artifactPB.SimilarityChunk) bool {
+	if chunkPB.SimilarityScore < s.filter.MinScore {
+		return false
+	}
+
+	fileUID := chunkPB.GetChunkMetadata().GetOriginalFileUid()
+	if len(s.filter.FileUIDs) > 0 && !slices.Contains(s.filter.FileUIDs, fileUID) {
+		return false
+	}
+
+	if len(s.filter.FileTypes) > 0 {
+		fileType, ok := s.fileTypes[fileUID]
+		if !ok {
+			return false
+		}
+		matched := false
+		for _, t := range s.filter.FileTypes {
+			if normalizeFileType(t) == fileType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// chunks filters the chunks returned by the backend, keeping at most topK
+// of them. It also returns whether the result may be incomplete, i.e., fewer
+// than topK chunks matched the filter but the backend returned as many
+// chunks as it allows, so more matching chunks may exist beyond them.
+func (s *chunkScope) chunks(chunks []*artifactPB.SimilarityChunk, topK int) ([]SimilarityChunk, bool) {
+	out := []SimilarityChunk{}
+	for _, chunkPB := range chunks {
+		if !s.matchesChunk(chunkPB) {
+			continue
+		}
+		if topK > 0 && len(out) == topK {
+			break
+		}
+		out = append(out, toSimilarityChunk(chunkPB))
+	}
+
+	incomplete := s.filter.filtersChunks() && len(out) < topK && len(chunks) >= maxTopK
+	return out, incomplete
+}
+
+func toSimilarityChunk(chunkPB *artifactPB.SimilarityChunk) SimilarityChunk {
+	chunkMetadata := chunkPB.GetChunkMetadata()
+	return SimilarityChunk{
+		ChunkUID:        chunkPB.ChunkUid,
+		SimilarityScore: chunkPB.SimilarityScore,
+		TextContent:     chunkPB.TextContent,
+		SourceFileName:  chunkPB.SourceFile,
+		FileUID:         chunkMetadata.GetOriginalFileUid(),
+		StartPosition:   chunkMetadata.GetStartPos(),
+		EndPosition:     chunkMetadata.GetEndPos(),
+	}
+}
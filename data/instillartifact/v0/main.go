@@ -24,6 +24,12 @@ const (
 	taskMatchFileStatus   string = "TASK_MATCH_FILE_STATUS"
 	taskSearchChunks      string = "TASK_RETRIEVE"
 	taskQuery             string = "TASK_ASK"
+	taskDeleteFile        string = "TASK_DELETE_FILE"
+	taskListCatalogs      string = "TASK_LIST_CATALOGS"
+	taskCreateCatalog     string = "TASK_CREATE_CATALOG"
+	taskDeleteCatalog     string = "TASK_DELETE_CATALOG"
+	taskUpdateChunk       string = "TASK_UPDATE_CHUNK"
+	taskReprocessFile     string = "TASK_REPROCESS_FILE"
 )
 
 var (
@@ -86,6 +92,18 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.searchChunks
 	case taskQuery:
 		e.execute = e.query
+	case taskDeleteFile:
+		e.execute = e.deleteFile
+	case taskListCatalogs:
+		e.execute = e.listCatalogs
+	case taskCreateCatalog:
+		e.execute = e.createCatalog
+	case taskDeleteCatalog:
+		e.execute = e.deleteCatalog
+	case taskUpdateChunk:
+		e.execute = e.updateChunk
+	case taskReprocessFile:
+		e.execute = e.reprocessFile
 	default:
 		return nil, fmt.Errorf("%s task is not supported", x.Task)
 	}
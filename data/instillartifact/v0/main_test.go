@@ -589,6 +589,199 @@ func Test_searchChunks(t *testing.T) {
 	})
 }
 
+func Test_searchChunksWithFilters(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	similarChunks := []*artifactPB.SimilarityChunk{
+		{ChunkUid: "chunk1", SimilarityScore: 0.9, ChunkMetadata: &artifactPB.Chunk{OriginalFileUid: "file1", StartPos: 0, EndPos: 10}},
+		{ChunkUid: "chunk2", SimilarityScore: 0.8, ChunkMetadata: &artifactPB.Chunk{OriginalFileUid: "file2", StartPos: 0, EndPos: 20}},
+		{ChunkUid: "chunk3", SimilarityScore: 0.7, ChunkMetadata: &artifactPB.Chunk{OriginalFileUid: "file1", StartPos: 10, EndPos: 20}},
+		{ChunkUid: "chunk4", SimilarityScore: 0.3, ChunkMetadata: &artifactPB.Chunk{OriginalFileUid: "file1", StartPos: 20, EndPos: 30}},
+	}
+
+	// The backend returns at most maxTopK chunks, so matching chunks may
+	// exist beyond a full result.
+	fullResult := []*artifactPB.SimilarityChunk{}
+	for i := range maxTopK {
+		fileUID := "file2"
+		if i%10 == 0 {
+			fileUID = "file1"
+		}
+		fullResult = append(fullResult, &artifactPB.SimilarityChunk{
+			ChunkUid:        fmt.Sprintf("chunk%d", i+1),
+			SimilarityScore: 0.9,
+			ChunkMetadata:   &artifactPB.Chunk{OriginalFileUid: fileUID, StartPos: 0, EndPos: 10},
+		})
+	}
+
+	testCases := []struct {
+		name           string
+		filter         ChunkFilter
+		topK           uint32
+		wantTopK       uint32
+		searchResult   []*artifactPB.SimilarityChunk
+		catalogTags    []string
+		fileTypes      map[string]artifactPB.FileType
+		expected       []string
+		wantIncomplete bool
+	}{
+		{
+			name:     "filter by file UID and min score",
+			filter:   ChunkFilter{FileUIDs: []string{"file1"}, MinScore: 0.5},
+			topK:     2,
+			wantTopK: 20,
+			expected: []string{"chunk1", "chunk3"},
+		},
+		{
+			name:           "fewer chunks than top-k match a full result",
+			filter:         ChunkFilter{FileUIDs: []string{"file1"}},
+			topK:           5,
+			wantTopK:       20,
+			searchResult:   fullResult,
+			expected:       []string{"chunk1", "chunk11"},
+			wantIncomplete: true,
+		},
+		{
+			name:     "top-k is applied after filtering",
+			filter:   ChunkFilter{MinScore: 0.5},
+			topK:     10,
+			wantTopK: 20,
+			expected: []string{"chunk1", "chunk2", "chunk3"},
+		},
+		{
+			name:   "filter by file type",
+			filter: ChunkFilter{FileTypes: []string{"markdown"}},
+			topK:   5,
+			fileTypes: map[string]artifactPB.FileType{
+				"file1": artifactPB.FileType_FILE_TYPE_PDF,
+				"file2": artifactPB.FileType_FILE_TYPE_MARKDOWN,
+			},
+			wantTopK: 20,
+			expected: []string{"chunk2"},
+		},
+		{
+			name:        "catalog matches tags",
+			filter:      ChunkFilter{Tags: []string{"customer-a"}},
+			topK:        5,
+			wantTopK:    5,
+			catalogTags: []string{"customer-a", "docs"},
+			expected:    []string{"chunk1", "chunk2", "chunk3", "chunk4"},
+		},
+		{
+			name:        "catalog doesn't match tags",
+			filter:      ChunkFilter{Tags: []string{"customer-b"}},
+			topK:        5,
+			catalogTags: []string{"customer-a"},
+			expected:    []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			component := Init(base.Component{})
+
+			sysVar := map[string]interface{}{
+				"__ARTIFACT_BACKEND":       "http://localhost:8082",
+				"__PIPELINE_USER_UID":      "fakeUser",
+				"__PIPELINE_REQUESTER_UID": "fakeRequester",
+			}
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskSearchChunks},
+			}
+
+			e.execute = e.searchChunks
+
+			inputStruct, _ := base.ConvertToStructpb(SearchChunksInput{
+				Namespace:   "fakeNs",
+				CatalogID:   "fakeID",
+				TextPrompt:  "fakePrompt",
+				TopK:        tc.topK,
+				ChunkFilter: tc.filter,
+			})
+
+			clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+			if len(tc.filter.Tags) > 0 {
+				clientMock.ListCatalogsMock.
+					Expect(minimock.AnyContext, &artifactPB.ListCatalogsRequest{
+						NamespaceId: "fakeNs",
+					}).
+					Times(1).
+					Return(&artifactPB.ListCatalogsResponse{
+						Catalogs: []*artifactPB.Catalog{
+							{CatalogId: "otherID", Tags: []string{"customer-b"}},
+							{CatalogId: "fakeID", Tags: tc.catalogTags},
+						},
+					}, nil)
+			}
+
+			searchResult := similarChunks
+			if tc.searchResult != nil {
+				searchResult = tc.searchResult
+			}
+
+			if tc.wantTopK > 0 {
+				clientMock.SimilarityChunksSearchMock.
+					Expect(minimock.AnyContext, &artifactPB.SimilarityChunksSearchRequest{
+						NamespaceId: "fakeNs",
+						CatalogId:   "fakeID",
+						TextPrompt:  "fakePrompt",
+						TopK:        tc.wantTopK,
+					}).
+					Times(1).
+					Return(&artifactPB.SimilarityChunksSearchResponse{
+						SimilarChunks: searchResult,
+					}, nil)
+			}
+
+			if tc.fileTypes != nil {
+				files := []*artifactPB.File{}
+				for uid, fileType := range tc.fileTypes {
+					files = append(files, &artifactPB.File{FileUid: uid, Type: fileType})
+				}
+				clientMock.ListCatalogFilesMock.
+					Expect(minimock.AnyContext, &artifactPB.ListCatalogFilesRequest{
+						NamespaceId: "fakeNs",
+						CatalogId:   "fakeID",
+						Filter: &artifactPB.ListCatalogFilesFilter{
+							FileUids: []string{"file1", "file2"},
+						},
+					}).
+					Times(1).
+					Return(&artifactPB.ListCatalogFilesResponse{
+						Files: files,
+					}, nil)
+			}
+
+			e.client = clientMock
+			e.connection = fakeConnection{}
+
+			output, err := e.execute(inputStruct)
+
+			c.Assert(err, quicktest.IsNil)
+
+			var outputStruct SearchChunksOutput
+			err = base.ConvertFromStructpb(output, &outputStruct)
+
+			c.Assert(err, quicktest.IsNil)
+
+			got := []string{}
+			for _, chunk := range outputStruct.Chunks {
+				got = append(got, chunk.ChunkUID)
+			}
+			c.Assert(got, quicktest.DeepEquals, tc.expected)
+			c.Check(outputStruct.Incomplete, quicktest.Equals, tc.wantIncomplete)
+
+			if len(outputStruct.Chunks) > 0 {
+				c.Check(outputStruct.Chunks[0].FileUID, quicktest.Not(quicktest.Equals), "")
+				c.Check(outputStruct.Chunks[0].EndPosition > 0, quicktest.IsTrue)
+			}
+		})
+	}
+}
+
 func Test_query(t *testing.T) {
 	c := quicktest.New(t)
 	mc := minimock.NewController(t)
@@ -661,6 +854,118 @@ func Test_query(t *testing.T) {
 
 }
 
+func Test_queryWithFilters(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	testCases := []struct {
+		name           string
+		filter         ChunkFilter
+		expectedAnswer string
+		expected       []string
+		wantErr        string
+	}{
+		{
+			name:           "catalog matches tags",
+			filter:         ChunkFilter{Tags: []string{"customer-a"}},
+			expectedAnswer: "fakeAnswer",
+			expected:       []string{"chunk1", "chunk2"},
+		},
+		{
+			name:     "catalog doesn't match tags",
+			filter:   ChunkFilter{Tags: []string{"customer-b"}},
+			expected: []string{},
+		},
+		{
+			name:    "chunk filters aren't supported",
+			filter:  ChunkFilter{Tags: []string{"customer-a"}, MinScore: 0.5},
+			wantErr: "the file UID, file type and minimum score filters aren't supported by the ask task.*",
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			component := Init(base.Component{})
+
+			sysVar := map[string]interface{}{
+				"__ARTIFACT_BACKEND":       "http://localhost:8082",
+				"__PIPELINE_USER_UID":      "fakeUser",
+				"__PIPELINE_REQUESTER_UID": "fakeRequester",
+			}
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskQuery},
+			}
+
+			e.execute = e.query
+
+			inputStruct, _ := base.ConvertToStructpb(QueryInput{
+				Namespace:   "fakeNs",
+				CatalogID:   "fakeID",
+				Question:    "fakeQuestion",
+				TopK:        2,
+				ChunkFilter: tc.filter,
+			})
+
+			clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+			if tc.wantErr == "" {
+				clientMock.ListCatalogsMock.
+					Expect(minimock.AnyContext, &artifactPB.ListCatalogsRequest{
+						NamespaceId: "fakeNs",
+					}).
+					Times(1).
+					Return(&artifactPB.ListCatalogsResponse{
+						Catalogs: []*artifactPB.Catalog{
+							{CatalogId: "fakeID", Tags: []string{"customer-a"}},
+						},
+					}, nil)
+			}
+
+			if tc.expectedAnswer != "" {
+				clientMock.QuestionAnsweringMock.
+					Expect(minimock.AnyContext, &artifactPB.QuestionAnsweringRequest{
+						NamespaceId: "fakeNs",
+						CatalogId:   "fakeID",
+						Question:    "fakeQuestion",
+						TopK:        2,
+					}).
+					Times(1).
+					Return(&artifactPB.QuestionAnsweringResponse{
+						Answer: "fakeAnswer",
+						SimilarChunks: []*artifactPB.SimilarityChunk{
+							{ChunkUid: "chunk1", SimilarityScore: 0.9},
+							{ChunkUid: "chunk2", SimilarityScore: 0.2},
+						},
+					}, nil)
+			}
+
+			e.client = clientMock
+			e.connection = fakeConnection{}
+
+			output, err := e.execute(inputStruct)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, quicktest.IsNil)
+
+			var outputStruct QueryOutput
+			err = base.ConvertFromStructpb(output, &outputStruct)
+
+			c.Assert(err, quicktest.IsNil)
+			c.Assert(outputStruct.Answer, quicktest.Equals, tc.expectedAnswer)
+
+			got := []string{}
+			for _, chunk := range outputStruct.Chunks {
+				got = append(got, chunk.ChunkUID)
+			}
+			c.Assert(got, quicktest.DeepEquals, tc.expected)
+		})
+	}
+}
+
 func Test_matchFileStatus(t *testing.T) {
 	c := quicktest.New(t)
 	mc := minimock.NewController(t)
@@ -747,6 +1052,387 @@ func Test_matchFileStatus(t *testing.T) {
 
 }
 
+func Test_deleteFile(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("delete file", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskDeleteFile},
+		}
+
+		e.execute = e.deleteFile
+
+		inputStruct, _ := base.ConvertToStructpb(DeleteFileInput{
+			FileUID: "fakeFileID",
+		})
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.DeleteCatalogFileMock.Expect(minimock.AnyContext, &artifactPB.DeleteCatalogFileRequest{
+			FileUid: "fakeFileID",
+		}).Times(1).Return(&artifactPB.DeleteCatalogFileResponse{
+			FileUid: "fakeFileID",
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct DeleteFileOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(outputStruct.FileUID, quicktest.Equals, "fakeFileID")
+	})
+
+}
+
+func Test_listCatalogs(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("list catalogs", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskListCatalogs},
+		}
+
+		e.execute = e.listCatalogs
+
+		inputStruct, _ := base.ConvertToStructpb(ListCatalogsInput{
+			Namespace: "fakeNs",
+		})
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.ListCatalogsMock.Expect(minimock.AnyContext, &artifactPB.ListCatalogsRequest{
+			NamespaceId: "fakeNs",
+		}).Times(1).Return(&artifactPB.ListCatalogsResponse{
+			Catalogs: []*artifactPB.Catalog{
+				{
+					CatalogUid: "fakeCatalogUID",
+					CatalogId:  "fakeID",
+					Name:       "fakeID",
+					CreateTime: "1970-01-01T00:00:01Z",
+					UpdateTime: "1970-01-01T00:00:01Z",
+					OwnerName:  "users/fakeUser",
+					Tags:       []string{"docs"},
+				},
+				{
+					CatalogId: "emptyID",
+					Name:      "emptyID",
+				},
+			},
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct ListCatalogsOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(outputStruct.Catalogs, quicktest.DeepEquals, []CatalogOutput{
+			{
+				CatalogUID: "fakeCatalogUID",
+				CatalogID:  "fakeID",
+				Name:       "fakeID",
+				CreateTime: "1970-01-01T00:00:01Z",
+				UpdateTime: "1970-01-01T00:00:01Z",
+				OwnerName:  "users/fakeUser",
+				Tags:       []string{"docs"},
+			},
+			{
+				CatalogID: "emptyID",
+				Name:      "emptyID",
+				Tags:      []string{},
+			},
+		})
+	})
+
+}
+
+func Test_createCatalog(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("create catalog", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskCreateCatalog},
+		}
+
+		e.execute = e.createCatalog
+
+		inputStruct, _ := base.ConvertToStructpb(CreateCatalogInput{
+			Namespace:   "fakeNs",
+			CatalogID:   "fakeID",
+			Description: "fake description",
+			Tags:        []string{"docs"},
+		})
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.CreateCatalogMock.Expect(minimock.AnyContext, &artifactPB.CreateCatalogRequest{
+			NamespaceId: "fakeNs",
+			Name:        "fakeID",
+			Description: "fake description",
+			Tags:        []string{"docs"},
+		}).Times(1).Return(&artifactPB.CreateCatalogResponse{
+			Catalog: &artifactPB.Catalog{
+				CatalogUid:  "fakeCatalogUID",
+				CatalogId:   "fakeID",
+				Name:        "fakeID",
+				Description: "fake description",
+				Tags:        []string{"docs"},
+			},
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct CatalogResultOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(outputStruct.Catalog.CatalogUID, quicktest.Equals, "fakeCatalogUID")
+		c.Assert(outputStruct.Catalog.CatalogID, quicktest.Equals, "fakeID")
+		c.Assert(outputStruct.Catalog.Description, quicktest.Equals, "fake description")
+		c.Assert(outputStruct.Catalog.Tags, quicktest.DeepEquals, []string{"docs"})
+	})
+
+}
+
+func Test_deleteCatalog(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("delete catalog", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskDeleteCatalog},
+		}
+
+		e.execute = e.deleteCatalog
+
+		inputStruct, _ := base.ConvertToStructpb(DeleteCatalogInput{
+			Namespace: "fakeNs",
+			CatalogID: "fakeID",
+		})
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.DeleteCatalogMock.Expect(minimock.AnyContext, &artifactPB.DeleteCatalogRequest{
+			NamespaceId: "fakeNs",
+			CatalogId:   "fakeID",
+		}).Times(1).Return(&artifactPB.DeleteCatalogResponse{
+			Catalog: &artifactPB.Catalog{
+				CatalogUid: "fakeCatalogUID",
+				CatalogId:  "fakeID",
+				Name:       "fakeID",
+			},
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct CatalogResultOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(outputStruct.Catalog.CatalogUID, quicktest.Equals, "fakeCatalogUID")
+		c.Assert(outputStruct.Catalog.CatalogID, quicktest.Equals, "fakeID")
+	})
+
+}
+
+func Test_updateChunk(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("update chunk", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskUpdateChunk},
+		}
+
+		e.execute = e.updateChunk
+
+		inputStruct, _ := base.ConvertToStructpb(UpdateChunkInput{
+			ChunkUID:    "fakeChunkID",
+			Retrievable: false,
+		})
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.UpdateChunkMock.Expect(minimock.AnyContext, &artifactPB.UpdateChunkRequest{
+			ChunkUid:    "fakeChunkID",
+			Retrievable: false,
+		}).Times(1).Return(&artifactPB.UpdateChunkResponse{
+			Chunk: &artifactPB.Chunk{
+				ChunkUid:    "fakeChunkID",
+				Retrievable: false,
+				StartPos:    0,
+				EndPos:      1,
+				Tokens:      1,
+				CreateTime: &timestamppb.Timestamp{
+					Seconds: 1,
+					Nanos:   1,
+				},
+				OriginalFileUid: "fakeFileID",
+			},
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct UpdateChunkOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(outputStruct.Chunk.ChunkUID, quicktest.Equals, "fakeChunkID")
+		c.Assert(outputStruct.Chunk.Retrievable, quicktest.Equals, false)
+		c.Assert(outputStruct.Chunk.EndPosition, quicktest.Equals, uint32(1))
+		c.Assert(outputStruct.Chunk.CreateTime, quicktest.Equals, "1970-01-01T00:00:01Z")
+		c.Assert(outputStruct.Chunk.OriginalFileUID, quicktest.Equals, "fakeFileID")
+	})
+
+}
+
+func Test_reprocessFile(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	testCases := []struct {
+		name     string
+		files    []*artifactPB.File
+		expected string
+		wantErr  string
+	}{
+		{
+			name: "reprocess file",
+			files: []*artifactPB.File{
+				{
+					FileUid:       "fakeFileID",
+					ProcessStatus: artifactPB.FileProcessStatus_FILE_PROCESS_STATUS_WAITING,
+				},
+			},
+			expected: "FILE_PROCESS_STATUS_WAITING",
+		},
+		{
+			name:    "file not found",
+			files:   []*artifactPB.File{},
+			wantErr: "file fakeFileID was not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			component := Init(base.Component{})
+
+			sysVar := map[string]interface{}{
+				"__ARTIFACT_BACKEND":       "http://localhost:8082",
+				"__PIPELINE_USER_UID":      "fakeUser",
+				"__PIPELINE_REQUESTER_UID": "fakeRequester",
+			}
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskReprocessFile},
+			}
+
+			e.execute = e.reprocessFile
+
+			inputStruct, _ := base.ConvertToStructpb(ReprocessFileInput{
+				FileUID: "fakeFileID",
+			})
+
+			clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+			clientMock.ProcessCatalogFilesMock.Expect(minimock.AnyContext, &artifactPB.ProcessCatalogFilesRequest{
+				FileUids: []string{"fakeFileID"},
+			}).Times(1).Return(&artifactPB.ProcessCatalogFilesResponse{
+				Files: tc.files,
+			}, nil)
+
+			e.client = clientMock
+			e.connection = fakeConnection{}
+
+			output, err := e.execute(inputStruct)
+
+			if tc.wantErr != "" {
+				c.Assert(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, quicktest.IsNil)
+
+			var outputStruct ReprocessFileOutput
+			err = base.ConvertFromStructpb(output, &outputStruct)
+
+			c.Assert(err, quicktest.IsNil)
+			c.Assert(outputStruct.FileUID, quicktest.Equals, "fakeFileID")
+			c.Assert(outputStruct.ProcessStatus, quicktest.Equals, tc.expected)
+		})
+	}
+
+}
+
 type fakeConnection struct{}
 
 func (f fakeConnection) Close() error {
@@ -0,0 +1,64 @@
+package localvector
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type BatchUpsertInput struct {
+	CollectionName string           `json:"collection-name"`
+	ArrayID        []string         `json:"array-id"`
+	ArrayMetadata  []map[string]any `json:"array-metadata"`
+	ArrayVector    [][]float64      `json:"array-vector"`
+}
+
+type BatchUpsertOutput struct {
+	Status string `json:"status"`
+}
+
+func (e *execution) batchUpsert(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct BatchUpsertInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.ArrayID) != len(inputStruct.ArrayVector) {
+		return nil, fmt.Errorf("array-id and array-vector must have the same length")
+	}
+	if inputStruct.ArrayMetadata != nil && len(inputStruct.ArrayMetadata) != len(inputStruct.ArrayID) {
+		return nil, fmt.Errorf("array-metadata and array-id must have the same length")
+	}
+
+	points := make([]Point, len(inputStruct.ArrayID))
+	for i, id := range inputStruct.ArrayID {
+		points[i] = Point{
+			ID:     id,
+			Vector: inputStruct.ArrayVector[i],
+		}
+		if inputStruct.ArrayMetadata != nil {
+			points[i].Metadata = inputStruct.ArrayMetadata[i]
+		}
+	}
+
+	err = e.store.update(inputStruct.CollectionName, func(col *collection) error {
+		return col.upsert(points)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := BatchUpsertOutput{
+		Status: fmt.Sprintf("Successfully batch upserted %d points", len(points)),
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
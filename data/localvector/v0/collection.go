@@ -0,0 +1,343 @@
+package localvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const collectionFileExt = ".json"
+
+var collectionNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Point is a vector with its metadata.
+type Point struct {
+	ID       string         `json:"id"`
+	Vector   []float64      `json:"vector"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+// CollectionConfig holds the parameters of a collection. M, EfConstruction
+// and EfSearch only apply to HNSW indexes.
+type CollectionConfig struct {
+	Dimension      int    `json:"dimension"`
+	Metric         string `json:"metric"`
+	Index          string `json:"index"`
+	M              int    `json:"m,omitempty"`
+	EfConstruction int    `json:"ef-construction,omitempty"`
+	EfSearch       int    `json:"ef-search,omitempty"`
+}
+
+func (c *CollectionConfig) validate() error {
+	if c.Dimension <= 0 {
+		return fmt.Errorf("dimension must be greater than 0")
+	}
+
+	if c.Metric == "" {
+		c.Metric = metricCosine
+	}
+	switch c.Metric {
+	case metricCosine, metricDot, metricL2:
+	default:
+		return fmt.Errorf("unsupported metric: %s", c.Metric)
+	}
+
+	if c.Index == "" {
+		c.Index = indexFlat
+	}
+	switch c.Index {
+	case indexFlat, indexHNSW:
+	default:
+		return fmt.Errorf("unsupported index: %s", c.Index)
+	}
+
+	return nil
+}
+
+// collection is a set of points of the same dimension, with the index used
+// to search them.
+type collection struct {
+	mu     sync.RWMutex
+	name   string
+	config CollectionConfig
+	points map[string]Point
+	index  vectorIndex
+}
+
+// collectionFile is the format of a persisted collection. Only the points
+// are stored, the index is rebuilt when the collection is loaded.
+type collectionFile struct {
+	Name   string           `json:"name"`
+	Config CollectionConfig `json:"config"`
+	Points []Point          `json:"points"`
+}
+
+func newCollection(name string, config CollectionConfig) (*collection, error) {
+	idx, err := newIndex(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection{
+		name:   name,
+		config: config,
+		points: map[string]Point{},
+		index:  idx,
+	}, nil
+}
+
+func (c *collection) upsert(points []Point) error {
+	for _, p := range points {
+		if p.ID == "" {
+			return fmt.Errorf("point ID can't be empty")
+		}
+		if len(p.Vector) != c.config.Dimension {
+			return fmt.Errorf("vector of point %s has dimension %d, expected %d", p.ID, len(p.Vector), c.config.Dimension)
+		}
+	}
+
+	for _, p := range points {
+		if p.Metadata == nil {
+			p.Metadata = map[string]any{}
+		}
+		c.points[p.ID] = p
+		c.index.add(p.ID, p.Vector)
+	}
+	return nil
+}
+
+// delete removes the points with the given ID or matching the filter, and
+// returns the number of deleted points.
+func (c *collection) delete(id string, filter *Filter) int {
+	var ids []string
+	for pid, p := range c.points {
+		if id != "" && pid != id {
+			continue
+		}
+		if filter != nil && !filter.matches(p) {
+			continue
+		}
+		ids = append(ids, pid)
+	}
+
+	for _, pid := range ids {
+		delete(c.points, pid)
+		c.index.remove(pid)
+	}
+	return len(ids)
+}
+
+type hit struct {
+	point Point
+	score float64
+}
+
+func (c *collection) search(vector []float64, limit int, filter *Filter, minScore float64) ([]hit, error) {
+	if len(vector) != c.config.Dimension {
+		return nil, fmt.Errorf("query vector has dimension %d, expected %d", len(vector), c.config.Dimension)
+	}
+
+	if limit <= 0 {
+		limit = len(c.points)
+	}
+
+	var accept func(string) bool
+	if filter != nil {
+		accept = func(id string) bool {
+			return filter.matches(c.points[id])
+		}
+	}
+
+	hits := []hit{}
+	for _, cand := range c.index.search(vector, limit, accept) {
+		s := score(c.config.Metric, cand.dist)
+		if s < minScore {
+			break
+		}
+		hits = append(hits, hit{point: c.points[cand.id], score: s})
+	}
+	return hits, nil
+}
+
+// store holds the collections of a directory. Collections of the in-memory
+// store, with an empty directory, are lost when the process stops.
+type store struct {
+	mu          sync.Mutex
+	dir         string
+	collections map[string]*collection
+}
+
+func openStore(dir string) (*store, error) {
+	s := &store{
+		dir:         dir,
+		collections: map[string]*collection{},
+	}
+	if dir == "" {
+		return s, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating store directory: %w", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+collectionFileExt))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		col, err := loadCollection(f)
+		if err != nil {
+			return nil, err
+		}
+		s.collections[col.name] = col
+	}
+
+	return s, nil
+}
+
+func loadCollection(path string) (*collection, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading collection file: %w", err)
+	}
+
+	var cf collectionFile
+	if err := json.Unmarshal(b, &cf); err != nil {
+		return nil, fmt.Errorf("decoding collection file %s: %w", path, err)
+	}
+	if cf.Name == "" {
+		cf.Name = strings.TrimSuffix(filepath.Base(path), collectionFileExt)
+	}
+
+	col, err := newCollection(cf.Name, cf.Config)
+	if err != nil {
+		return nil, err
+	}
+	if err := col.upsert(cf.Points); err != nil {
+		return nil, fmt.Errorf("loading collection %s: %w", cf.Name, err)
+	}
+	return col, nil
+}
+
+func validateCollectionName(name string) error {
+	if !collectionNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid collection name %q: only letters, digits, '-' and '_' are allowed", name)
+	}
+	return nil
+}
+
+func (s *store) createCollection(name string, config CollectionConfig) error {
+	if err := validateCollectionName(name); err != nil {
+		return err
+	}
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.collections[name]; ok {
+		return fmt.Errorf("collection %s already exists", name)
+	}
+
+	col, err := newCollection(name, config)
+	if err != nil {
+		return err
+	}
+	if err := s.persist(col); err != nil {
+		return err
+	}
+
+	s.collections[name] = col
+	return nil
+}
+
+func (s *store) deleteCollection(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.collections[name]; !ok {
+		return fmt.Errorf("collection %s doesn't exist", name)
+	}
+
+	if s.dir != "" {
+		err := os.Remove(s.collectionPath(name))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing collection file: %w", err)
+		}
+	}
+
+	delete(s.collections, name)
+	return nil
+}
+
+func (s *store) collection(name string) (*collection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	col, ok := s.collections[name]
+	if !ok {
+		return nil, fmt.Errorf("collection %s doesn't exist", name)
+	}
+	return col, nil
+}
+
+func (s *store) collectionPath(name string) string {
+	return filepath.Join(s.dir, name+collectionFileExt)
+}
+
+// persist writes the collection to the store directory. The file is written
+// to a temporary path first so a failure doesn't corrupt the collection.
+// The caller must hold a lock on the collection.
+func (s *store) persist(col *collection) error {
+	if s.dir == "" {
+		return nil
+	}
+
+	cf := collectionFile{
+		Name:   col.name,
+		Config: col.config,
+		Points: make([]Point, 0, len(col.points)),
+	}
+	for _, p := range col.points {
+		cf.Points = append(cf.Points, p)
+	}
+	sort.Slice(cf.Points, func(i, j int) bool { return cf.Points[i].ID < cf.Points[j].ID })
+
+	b, err := json.Marshal(cf)
+	if err != nil {
+		return err
+	}
+
+	path := s.collectionPath(col.name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("writing collection file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing collection file: %w", err)
+	}
+	return nil
+}
+
+// update applies a change to a collection and persists it.
+func (s *store) update(name string, change func(col *collection) error) error {
+	col, err := s.collection(name)
+	if err != nil {
+		return err
+	}
+
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	if err := change(col); err != nil {
+		return err
+	}
+	return s.persist(col)
+}
@@ -0,0 +1,594 @@
+package localvector
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+)
+
+const mockNamespace = "mock-namespace"
+
+// execute runs a task of the component and returns its output, or the error
+// passed to the job error handler.
+func execute(c *qt.C, cmp *component, dir, task string, input any) (*structpb.Struct, error) {
+	return executeInNamespace(c, cmp, mockNamespace, dir, task, input)
+}
+
+// useTempBaseDirectory sets a temporary base directory for the persisted
+// stores and returns the setup directory of a store under it.
+func useTempBaseDirectory(c *qt.C, cmp *component) string {
+	cmp.WithBaseDirectory(map[string]any{"basedirectory": c.TempDir()})
+	c.Cleanup(func() { cmp.WithBaseDirectory(nil) })
+	return "collections"
+}
+
+// executeInNamespace runs a task of the component in a pipeline owned by a
+// namespace.
+func executeInNamespace(c *qt.C, cmp *component, namespace, dir, task string, input any) (*structpb.Struct, error) {
+	setup, err := structpb.NewStruct(map[string]any{"directory": dir})
+	c.Assert(err, qt.IsNil)
+
+	exec, err := cmp.CreateExecution(base.ComponentExecution{
+		Component:       cmp,
+		SystemVariables: map[string]any{"__PIPELINE_USER_UID": namespace},
+		Setup:           setup,
+		Task:            task,
+	})
+	c.Assert(err, qt.IsNil)
+
+	pbIn, err := base.ConvertToStructpb(input)
+	c.Assert(err, qt.IsNil)
+
+	var output *structpb.Struct
+	var execErr error
+
+	ir, ow, eh, job := base.GenerateMockJob(c)
+	ir.ReadMock.Return(pbIn, nil)
+	ow.WriteMock.Optional().Set(func(ctx context.Context, out *structpb.Struct) error {
+		output = out
+		return nil
+	})
+	eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+		execErr = err
+	})
+
+	err = exec.Execute(context.Background(), []*base.Job{job})
+	c.Assert(err, qt.IsNil)
+
+	return output, execErr
+}
+
+// seed creates a collection with three points.
+func seed(c *qt.C, cmp *component, dir, name string, config CollectionConfig) {
+	_, err := execute(c, cmp, dir, TaskCreateCollection, CreateCollectionInput{
+		CollectionName: name,
+		Config:         config,
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = execute(c, cmp, dir, TaskBatchUpsert, BatchUpsertInput{
+		CollectionName: name,
+		ArrayID:        []string{"a", "b", "c"},
+		ArrayMetadata: []map[string]any{
+			{"city": "London", "year": 2020, "tags": []any{"news"}},
+			{"city": "Paris", "year": 2021, "tags": []any{"blog", "news"}},
+			{"city": "London", "year": 2023},
+		},
+		ArrayVector: [][]float64{{1, 0}, {0, 1}, {0.9, 0.1}},
+	})
+	c.Assert(err, qt.IsNil)
+}
+
+func TestComponent_ExecuteVectorSearchTask(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	testcases := []struct {
+		name     string
+		config   CollectionConfig
+		input    VectorSearchInput
+		wantResp VectorSearchOutput
+		wantErr  string
+	}{
+		{
+			name:   "ok to vector search",
+			config: CollectionConfig{Dimension: 2},
+			input: VectorSearchInput{
+				Vector: []float64{1, 0},
+				Limit:  2,
+			},
+			wantResp: VectorSearchOutput{
+				Status: "Successfully vector searched 2 points",
+				Result: Result{
+					Ids: []string{"a", "c"},
+					Points: []map[string]any{
+						{"id": "a", "score": 1, "city": "London", "year": 2020, "tags": []any{"news"}, "vector": []float64{1, 0}},
+						{"id": "c", "score": 0.9938837346736189, "city": "London", "year": 2023, "vector": []float64{0.9, 0.1}},
+					},
+					Vectors: [][]float64{{1, 0}, {0.9, 0.1}},
+					Metadata: []map[string]any{
+						{"city": "London", "year": 2020, "tags": []any{"news"}},
+						{"city": "London", "year": 2023},
+					},
+				},
+			},
+		},
+		{
+			name:   "ok to vector search with filter and payloads",
+			config: CollectionConfig{Dimension: 2, Metric: metricDot, Index: indexHNSW},
+			input: VectorSearchInput{
+				Vector: []float64{1, 0},
+				Limit:  5,
+				Filter: map[string]any{
+					"must": []any{
+						map[string]any{"key": "tags", "match": map[string]any{"value": "news"}},
+						map[string]any{"key": "year", "range": map[string]any{"gte": 2021}},
+					},
+				},
+				Payloads: []string{"city"},
+			},
+			wantResp: VectorSearchOutput{
+				Status: "Successfully vector searched 1 points",
+				Result: Result{
+					Ids: []string{"b"},
+					Points: []map[string]any{
+						{"id": "b", "score": 0, "city": "Paris", "vector": []float64{0, 1}},
+					},
+					Vectors:  [][]float64{{0, 1}},
+					Metadata: []map[string]any{{"city": "Paris"}},
+				},
+			},
+		},
+		{
+			name:   "ok to vector search with min score",
+			config: CollectionConfig{Dimension: 2, Metric: metricL2},
+			input: VectorSearchInput{
+				Vector:   []float64{1, 0},
+				MinScore: 0.5,
+				Payloads: []string{"year"},
+			},
+			wantResp: VectorSearchOutput{
+				Status: "Successfully vector searched 2 points",
+				Result: Result{
+					Ids: []string{"a", "c"},
+					Points: []map[string]any{
+						{"id": "a", "score": 1, "year": 2020, "vector": []float64{1, 0}},
+						{"id": "c", "score": 0.8761006569007046, "year": 2023, "vector": []float64{0.9, 0.1}},
+					},
+					Vectors:  [][]float64{{1, 0}, {0.9, 0.1}},
+					Metadata: []map[string]any{{"year": 2020}, {"year": 2023}},
+				},
+			},
+		},
+		{
+			name:   "nok - wrong dimension",
+			config: CollectionConfig{Dimension: 2},
+			input: VectorSearchInput{
+				Vector: []float64{1, 0, 0},
+			},
+			wantErr: "query vector has dimension 3, expected 2",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			dir := useTempBaseDirectory(c, cmp)
+			seed(c, cmp, dir, "mock-collection", tc.config)
+
+			tc.input.CollectionName = "mock-collection"
+			output, err := execute(c, cmp, dir, TaskVectorSearch, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+		})
+	}
+}
+
+func TestComponent_ExecuteDeleteTask(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	testcases := []struct {
+		name     string
+		input    DeleteInput
+		wantResp DeleteOutput
+		wantIDs  []string
+		wantErr  string
+	}{
+		{
+			name:     "ok to delete by id",
+			input:    DeleteInput{ID: "b"},
+			wantResp: DeleteOutput{Status: "Successfully deleted 1 points"},
+			wantIDs:  []string{"a", "c"},
+		},
+		{
+			name: "ok to delete by filter",
+			input: DeleteInput{
+				Filter: map[string]any{
+					"must": []any{
+						map[string]any{"key": "city", "match": map[string]any{"value": "London"}},
+					},
+				},
+			},
+			wantResp: DeleteOutput{Status: "Successfully deleted 2 points"},
+			wantIDs:  []string{"b"},
+		},
+		{
+			name:    "nok - no id nor filter",
+			input:   DeleteInput{},
+			wantErr: "either id or filter must be provided",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			dir := useTempBaseDirectory(c, cmp)
+			seed(c, cmp, dir, "mock-collection", CollectionConfig{Dimension: 2})
+
+			tc.input.CollectionName = "mock-collection"
+			output, err := execute(c, cmp, dir, TaskDelete, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+
+			output, err = execute(c, cmp, dir, TaskVectorSearch, VectorSearchInput{
+				CollectionName: "mock-collection",
+				Vector:         []float64{1, 1},
+			})
+			c.Assert(err, qt.IsNil)
+
+			var got VectorSearchOutput
+			c.Assert(base.ConvertFromStructpb(output, &got), qt.IsNil)
+			c.Check(got.Result.Ids, qt.ContentEquals, tc.wantIDs)
+		})
+	}
+}
+
+func TestComponent_ExecuteUpsertTask(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	testcases := []struct {
+		name     string
+		input    UpsertInput
+		wantResp UpsertOutput
+		wantErr  string
+	}{
+		{
+			name: "ok to upsert",
+			input: UpsertInput{
+				CollectionName: "mock-collection",
+				ID:             "a",
+				Metadata:       map[string]any{"name": "a"},
+				Vector:         []float64{0.1, 0.2},
+			},
+			wantResp: UpsertOutput{Status: "Successfully upserted 1 point"},
+		},
+		{
+			name: "nok - wrong dimension",
+			input: UpsertInput{
+				CollectionName: "mock-collection",
+				ID:             "a",
+				Vector:         []float64{0.1},
+			},
+			wantErr: "vector of point a has dimension 1, expected 2",
+		},
+		{
+			name: "nok - collection doesn't exist",
+			input: UpsertInput{
+				CollectionName: "other-collection",
+				ID:             "a",
+				Vector:         []float64{0.1, 0.2},
+			},
+			wantErr: "collection other-collection doesn't exist",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			dir := useTempBaseDirectory(c, cmp)
+			_, err := execute(c, cmp, dir, TaskCreateCollection, CreateCollectionInput{
+				CollectionName: "mock-collection",
+				Config:         CollectionConfig{Dimension: 2},
+			})
+			c.Assert(err, qt.IsNil)
+
+			output, err := execute(c, cmp, dir, TaskUpsert, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+		})
+	}
+}
+
+func TestComponent_ExecuteBatchUpsertTask(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	testcases := []struct {
+		name     string
+		input    BatchUpsertInput
+		wantResp BatchUpsertOutput
+		wantErr  string
+	}{
+		{
+			name: "ok to batch upsert",
+			input: BatchUpsertInput{
+				ArrayID:     []string{"a", "b"},
+				ArrayVector: [][]float64{{0.1, 0.2}, {0.2, 0.3}},
+			},
+			wantResp: BatchUpsertOutput{Status: "Successfully batch upserted 2 points"},
+		},
+		{
+			name: "nok - mismatched lengths",
+			input: BatchUpsertInput{
+				ArrayID:     []string{"a", "b"},
+				ArrayVector: [][]float64{{0.1, 0.2}},
+			},
+			wantErr: "array-id and array-vector must have the same length",
+		},
+		{
+			name: "nok - mismatched metadata",
+			input: BatchUpsertInput{
+				ArrayID:       []string{"a"},
+				ArrayMetadata: []map[string]any{{}, {}},
+				ArrayVector:   [][]float64{{0.1, 0.2}},
+			},
+			wantErr: "array-metadata and array-id must have the same length",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			dir := useTempBaseDirectory(c, cmp)
+			_, err := execute(c, cmp, dir, TaskCreateCollection, CreateCollectionInput{
+				CollectionName: "mock-collection",
+				Config:         CollectionConfig{Dimension: 2},
+			})
+			c.Assert(err, qt.IsNil)
+
+			tc.input.CollectionName = "mock-collection"
+			output, err := execute(c, cmp, dir, TaskBatchUpsert, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+		})
+	}
+}
+
+func TestComponent_ExecuteCollectionTasks(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	c.Run("ok to create and delete a collection", func(c *qt.C) {
+		dir := useTempBaseDirectory(c, cmp)
+
+		output, err := execute(c, cmp, dir, TaskCreateCollection, CreateCollectionInput{
+			CollectionName: "mock-collection",
+			Config:         CollectionConfig{Dimension: 2, Index: indexHNSW},
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(output.Fields["status"].GetStringValue(), qt.Equals, "Successfully created 1 collection")
+
+		_, err = execute(c, cmp, dir, TaskCreateCollection, CreateCollectionInput{
+			CollectionName: "mock-collection",
+			Config:         CollectionConfig{Dimension: 2},
+		})
+		c.Check(err, qt.ErrorMatches, "collection mock-collection already exists")
+
+		output, err = execute(c, cmp, dir, TaskDeleteCollection, DeleteCollectionInput{
+			CollectionName: "mock-collection",
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(output.Fields["status"].GetStringValue(), qt.Equals, "Successfully deleted 1 collection")
+
+		_, err = execute(c, cmp, dir, TaskDeleteCollection, DeleteCollectionInput{
+			CollectionName: "mock-collection",
+		})
+		c.Check(err, qt.ErrorMatches, "collection mock-collection doesn't exist")
+	})
+
+	c.Run("nok - invalid config", func(c *qt.C) {
+		dir := useTempBaseDirectory(c, cmp)
+
+		_, err := execute(c, cmp, dir, TaskCreateCollection, CreateCollectionInput{
+			CollectionName: "../escape",
+			Config:         CollectionConfig{Dimension: 2},
+		})
+		c.Check(err, qt.ErrorMatches, `invalid collection name "../escape".*`)
+
+		_, err = execute(c, cmp, dir, TaskCreateCollection, CreateCollectionInput{
+			CollectionName: "mock-collection",
+			Config:         CollectionConfig{Dimension: 2, Metric: "manhattan"},
+		})
+		c.Check(err, qt.ErrorMatches, "unsupported metric: manhattan")
+	})
+
+	c.Run("ok to use the in-memory store", func(c *qt.C) {
+		seed(c, cmp, "", "in-memory-collection", CollectionConfig{Dimension: 2})
+		c.Cleanup(func() {
+			_, err := execute(c, cmp, "", TaskDeleteCollection, DeleteCollectionInput{
+				CollectionName: "in-memory-collection",
+			})
+			c.Check(err, qt.IsNil)
+		})
+
+		output, err := execute(c, cmp, "", TaskVectorSearch, VectorSearchInput{
+			CollectionName: "in-memory-collection",
+			Vector:         []float64{0, 1},
+			Limit:          1,
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(output.Fields["result"].GetStructValue().Fields["ids"].GetListValue().AsSlice(), qt.DeepEquals, []any{"b"})
+	})
+
+	c.Run("in-memory stores are scoped by namespace", func(c *qt.C) {
+		_, err := executeInNamespace(c, cmp, "namespace-a", "", TaskCreateCollection, CreateCollectionInput{
+			CollectionName: "docs",
+			Config:         CollectionConfig{Dimension: 2},
+		})
+		c.Assert(err, qt.IsNil)
+		c.Cleanup(func() {
+			_, err := executeInNamespace(c, cmp, "namespace-a", "", TaskDeleteCollection, DeleteCollectionInput{
+				CollectionName: "docs",
+			})
+			c.Check(err, qt.IsNil)
+		})
+
+		_, err = executeInNamespace(c, cmp, "namespace-b", "", TaskVectorSearch, VectorSearchInput{
+			CollectionName: "docs",
+			Vector:         []float64{0, 1},
+			Limit:          1,
+		})
+		c.Check(err, qt.ErrorMatches, "collection docs doesn't exist")
+
+		_, err = executeInNamespace(c, cmp, "namespace-b", "", TaskDeleteCollection, DeleteCollectionInput{
+			CollectionName: "docs",
+		})
+		c.Check(err, qt.ErrorMatches, "collection docs doesn't exist")
+	})
+	c.Run("persisted stores are scoped by namespace", func(c *qt.C) {
+		dir := useTempBaseDirectory(c, cmp)
+
+		_, err := executeInNamespace(c, cmp, "namespace-a", dir, TaskCreateCollection, CreateCollectionInput{
+			CollectionName: "docs",
+			Config:         CollectionConfig{Dimension: 2},
+		})
+		c.Assert(err, qt.IsNil)
+
+		_, err = executeInNamespace(c, cmp, "namespace-b", dir, TaskVectorSearch, VectorSearchInput{
+			CollectionName: "docs",
+			Vector:         []float64{0, 1},
+			Limit:          1,
+		})
+		c.Check(err, qt.ErrorMatches, "collection docs doesn't exist")
+	})
+}
+
+func TestComponent_StorePath(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	testcases := []struct {
+		name      string
+		noBaseDir bool
+		namespace string
+		dir       string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "ok - relative directory",
+			namespace: mockNamespace,
+			dir:       "docs/./collections/",
+			want:      filepath.Join(mockNamespace, "docs", "collections"),
+		},
+		{
+			name:      "nok - no base directory",
+			noBaseDir: true,
+			namespace: mockNamespace,
+			dir:       "docs",
+			wantErr:   "persisted stores aren't enabled",
+		},
+		{
+			name:    "nok - no namespace",
+			dir:     "docs",
+			wantErr: "persisted stores require a pipeline namespace",
+		},
+		{
+			name:      "nok - absolute directory",
+			namespace: mockNamespace,
+			dir:       "/etc",
+			wantErr:   "absolute store directory: /etc",
+		},
+		{
+			name:      "nok - parent segment",
+			namespace: mockNamespace,
+			dir:       "docs/../../namespace-b",
+			wantErr:   "store directory out of the namespace: docs/../../namespace-b",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			useTempBaseDirectory(c, cmp)
+			if tc.noBaseDir {
+				cmp.WithBaseDirectory(nil)
+			}
+
+			got, err := cmp.storePath(tc.dir, tc.namespace)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(got, qt.Equals, filepath.Join(cmp.baseDir, tc.want))
+		})
+	}
+}
+
+func TestStore_Persistence(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+	dir := useTempBaseDirectory(c, cmp)
+
+	seed(c, cmp, dir, "mock-collection", CollectionConfig{Dimension: 2, Index: indexHNSW})
+
+	// A fresh store reads the collections from the directory, as a new
+	// process would.
+	path, err := cmp.storePath(dir, mockNamespace)
+	c.Assert(err, qt.IsNil)
+	c.Check(path, qt.Equals, filepath.Join(cmp.baseDir, mockNamespace, dir))
+
+	s, err := openStore(path)
+	c.Assert(err, qt.IsNil)
+
+	col, err := s.collection("mock-collection")
+	c.Assert(err, qt.IsNil)
+	c.Check(col.config, qt.DeepEquals, CollectionConfig{Dimension: 2, Metric: metricCosine, Index: indexHNSW})
+	c.Check(col.points, qt.HasLen, 3)
+	c.Check(col.points["b"].Metadata["city"], qt.Equals, "Paris")
+
+	hits, err := col.search([]float64{0, 1}, 1, nil, 0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(hits, qt.HasLen, 1)
+	c.Check(hits[0].point.ID, qt.Equals, "b")
+
+	c.Assert(s.deleteCollection("mock-collection"), qt.IsNil)
+	s, err = openStore(path)
+	c.Assert(err, qt.IsNil)
+	c.Check(s.collections, qt.HasLen, 0)
+}
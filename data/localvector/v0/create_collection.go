@@ -0,0 +1,40 @@
+package localvector
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type CreateCollectionInput struct {
+	CollectionName string           `json:"collection-name"`
+	Config         CollectionConfig `json:"config"`
+}
+
+type CreateCollectionOutput struct {
+	Status string `json:"status"`
+}
+
+func (e *execution) createCollection(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CreateCollectionInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.store.createCollection(inputStruct.CollectionName, inputStruct.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := CreateCollectionOutput{
+		Status: "Successfully created 1 collection",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
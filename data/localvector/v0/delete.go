@@ -0,0 +1,58 @@
+package localvector
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type DeleteInput struct {
+	CollectionName string         `json:"collection-name"`
+	ID             string         `json:"id"`
+	Filter         map[string]any `json:"filter"`
+}
+
+type DeleteOutput struct {
+	Status string `json:"status"`
+}
+
+func (e *execution) delete(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct DeleteInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseFilter(inputStruct.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleting every point of a collection by mistake is easy to do with an
+	// empty input, so at least one of the selectors is required.
+	if inputStruct.ID == "" && filter == nil {
+		return nil, fmt.Errorf("either id or filter must be provided")
+	}
+
+	var deleted int
+	err = e.store.update(inputStruct.CollectionName, func(col *collection) error {
+		deleted = col.delete(inputStruct.ID, filter)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := DeleteOutput{
+		Status: fmt.Sprintf("Successfully deleted %d points", deleted),
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
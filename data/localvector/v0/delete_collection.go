@@ -0,0 +1,39 @@
+package localvector
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type DeleteCollectionInput struct {
+	CollectionName string `json:"collection-name"`
+}
+
+type DeleteCollectionOutput struct {
+	Status string `json:"status"`
+}
+
+func (e *execution) deleteCollection(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct DeleteCollectionInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.store.deleteCollection(inputStruct.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := DeleteCollectionOutput{
+		Status: "Successfully deleted 1 collection",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
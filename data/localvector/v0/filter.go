@@ -0,0 +1,196 @@
+package localvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Filter selects points by their metadata. It follows the shape of the
+// Qdrant filters, so pipelines can switch between both components:
+//
+//	{"must": [{"key": "city", "match": {"value": "London"}}]}
+type Filter struct {
+	Must    []Condition `json:"must"`
+	Should  []Condition `json:"should"`
+	MustNot []Condition `json:"must_not"`
+}
+
+// Condition is a single clause of a filter. A condition with must, should or
+// must_not clauses is a nested filter.
+type Condition struct {
+	// Key is the metadata field to check. Nested fields are separated by
+	// dots, e.g. "country.name".
+	Key     string          `json:"key"`
+	Match   *MatchCondition `json:"match"`
+	Range   *RangeCondition `json:"range"`
+	IsEmpty *struct {
+		Key string `json:"key"`
+	} `json:"is_empty"`
+	HasID []string `json:"has_id"`
+
+	Filter
+}
+
+// MatchCondition checks the value of a field. Only one of its fields is
+// expected.
+type MatchCondition struct {
+	Value  any    `json:"value"`
+	Any    []any  `json:"any"`
+	Except []any  `json:"except"`
+	Text   string `json:"text"`
+}
+
+// RangeCondition checks that a numeric field is in a range.
+type RangeCondition struct {
+	Gt  *float64 `json:"gt"`
+	Gte *float64 `json:"gte"`
+	Lt  *float64 `json:"lt"`
+	Lte *float64 `json:"lte"`
+}
+
+func parseFilter(in map[string]any) (*Filter, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	f := new(Filter)
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return f, nil
+}
+
+func (f *Filter) isEmpty() bool {
+	return len(f.Must) == 0 && len(f.Should) == 0 && len(f.MustNot) == 0
+}
+
+func (f *Filter) matches(p Point) bool {
+	for _, c := range f.Must {
+		if !c.matches(p) {
+			return false
+		}
+	}
+	for _, c := range f.MustNot {
+		if c.matches(p) {
+			return false
+		}
+	}
+	if len(f.Should) == 0 {
+		return true
+	}
+	for _, c := range f.Should {
+		if c.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Condition) matches(p Point) bool {
+	switch {
+	case len(c.HasID) > 0:
+		return slices.Contains(c.HasID, p.ID)
+	case c.IsEmpty != nil:
+		v, ok := lookup(p.Metadata, c.IsEmpty.Key)
+		if !ok || v == nil {
+			return true
+		}
+		arr, isArr := v.([]any)
+		return isArr && len(arr) == 0
+	case c.Match != nil:
+		v, ok := lookup(p.Metadata, c.Key)
+		return ok && anyValue(v, c.Match.matches)
+	case c.Range != nil:
+		v, ok := lookup(p.Metadata, c.Key)
+		return ok && anyValue(v, c.Range.matches)
+	case !c.Filter.isEmpty():
+		return c.Filter.matches(p)
+	}
+	return false
+}
+
+// anyValue applies a check to a value. For arrays, the check passes if any of
+// the elements passes it.
+func anyValue(v any, check func(any) bool) bool {
+	if arr, ok := v.([]any); ok {
+		for _, el := range arr {
+			if check(el) {
+				return true
+			}
+		}
+		return false
+	}
+	return check(v)
+}
+
+func (m *MatchCondition) matches(v any) bool {
+	switch {
+	case m.Any != nil:
+		return slices.ContainsFunc(m.Any, func(x any) bool { return equal(x, v) })
+	case m.Except != nil:
+		return !slices.ContainsFunc(m.Except, func(x any) bool { return equal(x, v) })
+	case m.Text != "":
+		s, ok := v.(string)
+		return ok && strings.Contains(s, m.Text)
+	}
+	return equal(m.Value, v)
+}
+
+func (r *RangeCondition) matches(v any) bool {
+	f, ok := toFloat(v)
+	if !ok {
+		return false
+	}
+	return (r.Gt == nil || f > *r.Gt) &&
+		(r.Gte == nil || f >= *r.Gte) &&
+		(r.Lt == nil || f < *r.Lt) &&
+		(r.Lte == nil || f <= *r.Lte)
+}
+
+func lookup(metadata map[string]any, key string) (any, bool) {
+	var v any = metadata
+	for _, part := range strings.Split(key, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func equal(a, b any) bool {
+	fa, aIsNum := toFloat(a)
+	fb, bIsNum := toFloat(b)
+	if aIsNum && bIsNum {
+		return fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
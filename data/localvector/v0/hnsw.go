@@ -0,0 +1,317 @@
+package localvector
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+const (
+	defaultM              = 16
+	defaultEfConstruction = 200
+	defaultEfSearch       = 64
+)
+
+// hnswIndex is a Hierarchical Navigable Small World graph, as described in
+// https://arxiv.org/abs/1603.09320. Searches are approximate, but don't need
+// to compare the query with every vector.
+type hnswIndex struct {
+	metric         string
+	m              int
+	efConstruction int
+	efSearch       int
+	levelMult      float64
+	rng            *rand.Rand
+
+	nodes    map[string]*hnswNode
+	entry    string
+	maxLevel int
+}
+
+type hnswNode struct {
+	vector []float64
+	level  int
+	// neighbors holds the IDs of the connected nodes on each level.
+	neighbors [][]string
+}
+
+func newHNSWIndex(metric string, m, efConstruction, efSearch int) *hnswIndex {
+	if m < 2 {
+		m = defaultM
+	}
+	if efConstruction <= 0 {
+		efConstruction = defaultEfConstruction
+	}
+	if efSearch <= 0 {
+		efSearch = defaultEfSearch
+	}
+
+	return &hnswIndex{
+		metric:         metric,
+		m:              m,
+		efConstruction: efConstruction,
+		efSearch:       efSearch,
+		levelMult:      1 / math.Log(float64(m)),
+		// A fixed seed keeps the graph reproducible for a given insertion
+		// order.
+		rng:   rand.New(rand.NewSource(1)),
+		nodes: map[string]*hnswNode{},
+	}
+}
+
+// maxConn is the maximum number of neighbors of a node on a level. The
+// bottom level is denser, as it holds every node.
+func (h *hnswIndex) maxConn(level int) int {
+	if level == 0 {
+		return 2 * h.m
+	}
+	return h.m
+}
+
+func (h *hnswIndex) randomLevel() int {
+	return int(math.Floor(-math.Log(1-h.rng.Float64()) * h.levelMult))
+}
+
+func (h *hnswIndex) dist(query []float64, id string) float64 {
+	return distance(h.metric, query, h.nodes[id].vector)
+}
+
+func (h *hnswIndex) add(id string, vector []float64) {
+	if _, ok := h.nodes[id]; ok {
+		h.remove(id)
+	}
+
+	level := h.randomLevel()
+	node := &hnswNode{
+		vector:    vector,
+		level:     level,
+		neighbors: make([][]string, level+1),
+	}
+	h.nodes[id] = node
+
+	if h.entry == "" {
+		h.entry = id
+		h.maxLevel = level
+		return
+	}
+
+	entries := []candidate{{id: h.entry, dist: h.dist(vector, h.entry)}}
+	for l := h.maxLevel; l > level; l-- {
+		entries = h.searchLayer(vector, entries, 1, l)[:1]
+	}
+
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		cands := h.searchLayer(vector, entries, h.efConstruction, l)
+
+		neighbors := make([]string, 0, h.maxConn(l))
+		for _, c := range cands {
+			if c.id == id {
+				continue
+			}
+			if len(neighbors) == h.maxConn(l) {
+				break
+			}
+			neighbors = append(neighbors, c.id)
+		}
+		node.neighbors[l] = neighbors
+
+		for _, nid := range neighbors {
+			n := h.nodes[nid]
+			n.neighbors[l] = append(n.neighbors[l], id)
+			if len(n.neighbors[l]) > h.maxConn(l) {
+				n.neighbors[l] = h.closest(n.vector, n.neighbors[l], h.maxConn(l))
+			}
+		}
+
+		entries = cands
+	}
+
+	if level > h.maxLevel {
+		h.maxLevel = level
+		h.entry = id
+	}
+}
+
+// closest returns the n IDs closest to the vector.
+func (h *hnswIndex) closest(vector []float64, ids []string, n int) []string {
+	cands := make([]candidate, 0, len(ids))
+	for _, id := range ids {
+		cands = append(cands, candidate{id: id, dist: h.dist(vector, id)})
+	}
+	sortCandidates(cands)
+
+	closest := make([]string, 0, n)
+	for _, c := range cands {
+		if len(closest) == n {
+			break
+		}
+		closest = append(closest, c.id)
+	}
+	return closest
+}
+
+// remove deletes a node and reconnects the nodes that linked to it with the
+// neighbors of the removed node, so the graph stays navigable.
+func (h *hnswIndex) remove(id string) {
+	node, ok := h.nodes[id]
+	if !ok {
+		return
+	}
+	delete(h.nodes, id)
+
+	for nid, n := range h.nodes {
+		for l := 0; l <= min(n.level, node.level); l++ {
+			idx := -1
+			for i, link := range n.neighbors[l] {
+				if link == id {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				continue
+			}
+
+			links := append(n.neighbors[l][:idx:idx], n.neighbors[l][idx+1:]...)
+			for _, cand := range node.neighbors[l] {
+				if cand != nid && !slices.Contains(links, cand) {
+					links = append(links, cand)
+				}
+			}
+			n.neighbors[l] = h.closest(n.vector, links, h.maxConn(l))
+		}
+	}
+
+	if h.entry != id {
+		return
+	}
+
+	h.entry, h.maxLevel = "", 0
+	for nid, n := range h.nodes {
+		if h.entry == "" || n.level > h.maxLevel || (n.level == h.maxLevel && nid < h.entry) {
+			h.entry, h.maxLevel = nid, n.level
+		}
+	}
+}
+
+func (h *hnswIndex) search(query []float64, k int, accept func(id string) bool) []candidate {
+	if h.entry == "" || k <= 0 {
+		return nil
+	}
+
+	// Returning every node doesn't need the graph, and the exhaustive search
+	// doesn't miss the nodes that removals left poorly connected.
+	if k >= len(h.nodes) {
+		return bruteForce(h.metric, h.vectors(), query, k, accept)
+	}
+
+	entries := []candidate{{id: h.entry, dist: h.dist(query, h.entry)}}
+	for l := h.maxLevel; l > 0; l-- {
+		entries = h.searchLayer(query, entries, 1, l)[:1]
+	}
+
+	cands := h.searchLayer(query, entries, max(h.efSearch, k), 0)
+
+	results := make([]candidate, 0, k)
+	for _, c := range cands {
+		if accept != nil && !accept(c.id) {
+			continue
+		}
+		results = append(results, c)
+		if len(results) == k {
+			return results
+		}
+	}
+
+	// A restrictive filter can leave fewer than k results among the
+	// explored nodes. The accepted nodes are then compared exhaustively.
+	if accept != nil && len(results) < len(h.nodes) {
+		accepted := bruteForce(h.metric, h.vectors(), query, k, accept)
+		if len(accepted) > len(results) {
+			return accepted
+		}
+	}
+
+	return results
+}
+
+func (h *hnswIndex) vectors() map[string][]float64 {
+	vectors := make(map[string][]float64, len(h.nodes))
+	for id, n := range h.nodes {
+		vectors[id] = n.vector
+	}
+	return vectors
+}
+
+// searchLayer performs a greedy beam search of width ef on a level, starting
+// from the entry candidates. It returns the closest nodes found, sorted by
+// distance.
+func (h *hnswIndex) searchLayer(query []float64, entries []candidate, ef, level int) []candidate {
+	visited := make(map[string]bool, ef*2)
+	toVisit := &candidateHeap{}
+	found := &candidateHeap{furthestFirst: true}
+
+	for _, e := range entries {
+		visited[e.id] = true
+		heap.Push(toVisit, e)
+		heap.Push(found, e)
+	}
+
+	for toVisit.Len() > 0 {
+		c := heap.Pop(toVisit).(candidate)
+		if found.Len() >= ef && c.dist > found.items[0].dist {
+			break
+		}
+
+		n := h.nodes[c.id]
+		if level > n.level {
+			continue
+		}
+		for _, nid := range n.neighbors[level] {
+			if visited[nid] {
+				continue
+			}
+			visited[nid] = true
+
+			d := h.dist(query, nid)
+			if found.Len() < ef || d < found.items[0].dist {
+				heap.Push(toVisit, candidate{id: nid, dist: d})
+				heap.Push(found, candidate{id: nid, dist: d})
+				if found.Len() > ef {
+					heap.Pop(found)
+				}
+			}
+		}
+	}
+
+	results := found.items
+	sortCandidates(results)
+	return results
+}
+
+// candidateHeap is a heap of candidates ordered by distance, closest first
+// unless furthestFirst is set.
+type candidateHeap struct {
+	items         []candidate
+	furthestFirst bool
+}
+
+func (h candidateHeap) Len() int { return len(h.items) }
+
+func (h candidateHeap) Less(i, j int) bool {
+	if h.furthestFirst {
+		return h.items[i].dist > h.items[j].dist
+	}
+	return h.items[i].dist < h.items[j].dist
+}
+
+func (h candidateHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *candidateHeap) Push(x any) { h.items = append(h.items, x.(candidate)) }
+
+func (h *candidateHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
@@ -0,0 +1,135 @@
+package localvector
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	metricCosine = "cosine"
+	metricDot    = "dot"
+	metricL2     = "l2"
+
+	indexFlat = "flat"
+	indexHNSW = "hnsw"
+)
+
+// vectorIndex finds the nearest neighbours of a query vector. Distances are
+// always "lower is closer", regardless of the metric.
+type vectorIndex interface {
+	add(id string, vector []float64)
+	remove(id string)
+	// search returns the k closest vectors accepted by the accept function,
+	// sorted by distance. A nil accept function accepts every vector.
+	search(query []float64, k int, accept func(id string) bool) []candidate
+}
+
+type candidate struct {
+	id   string
+	dist float64
+}
+
+func newIndex(config CollectionConfig) (vectorIndex, error) {
+	switch config.Index {
+	case indexFlat:
+		return newFlatIndex(config.Metric), nil
+	case indexHNSW:
+		return newHNSWIndex(config.Metric, config.M, config.EfConstruction, config.EfSearch), nil
+	}
+	return nil, fmt.Errorf("unsupported index: %s", config.Index)
+}
+
+func distance(metric string, a, b []float64) float64 {
+	switch metric {
+	case metricDot:
+		return -dot(a, b)
+	case metricL2:
+		var sum float64
+		for i := range a {
+			d := a[i] - b[i]
+			sum += d * d
+		}
+		return math.Sqrt(sum)
+	default:
+		norm := math.Sqrt(dot(a, a)) * math.Sqrt(dot(b, b))
+		if norm == 0 {
+			return 1
+		}
+		return 1 - dot(a, b)/norm
+	}
+}
+
+// score converts a distance into a score where higher means more similar:
+// the cosine similarity, the dot product, or 1 / (1 + distance) for L2.
+func score(metric string, dist float64) float64 {
+	switch metric {
+	case metricDot:
+		return -dist
+	case metricL2:
+		return 1 / (1 + dist)
+	default:
+		return 1 - dist
+	}
+}
+
+func dot(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// sortCandidates sorts by distance, breaking ties by ID so results are
+// deterministic.
+func sortCandidates(cands []candidate) {
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].dist != cands[j].dist {
+			return cands[i].dist < cands[j].dist
+		}
+		return cands[i].id < cands[j].id
+	})
+}
+
+// flatIndex compares the query with every vector. It is exact and the best
+// choice for small collections.
+type flatIndex struct {
+	metric  string
+	vectors map[string][]float64
+}
+
+func newFlatIndex(metric string) *flatIndex {
+	return &flatIndex{
+		metric:  metric,
+		vectors: map[string][]float64{},
+	}
+}
+
+func (f *flatIndex) add(id string, vector []float64) {
+	f.vectors[id] = vector
+}
+
+func (f *flatIndex) remove(id string) {
+	delete(f.vectors, id)
+}
+
+func (f *flatIndex) search(query []float64, k int, accept func(id string) bool) []candidate {
+	return bruteForce(f.metric, f.vectors, query, k, accept)
+}
+
+func bruteForce(metric string, vectors map[string][]float64, query []float64, k int, accept func(id string) bool) []candidate {
+	cands := make([]candidate, 0, len(vectors))
+	for id, v := range vectors {
+		if accept != nil && !accept(id) {
+			continue
+		}
+		cands = append(cands, candidate{id: id, dist: distance(metric, query, v)})
+	}
+
+	sortCandidates(cands)
+	if k < len(cands) {
+		cands = cands[:k]
+	}
+	return cands
+}
@@ -0,0 +1,197 @@
+package localvector
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func randomVectors(rng *rand.Rand, n, dim int) map[string][]float64 {
+	vectors := make(map[string][]float64, n)
+	for i := 0; i < n; i++ {
+		v := make([]float64, dim)
+		for j := range v {
+			v[j] = rng.Float64()*2 - 1
+		}
+		vectors[fmt.Sprintf("p%d", i)] = v
+	}
+	return vectors
+}
+
+func TestHNSWIndex_Recall(t *testing.T) {
+	c := qt.New(t)
+
+	const n, dim, k, queries = 1000, 16, 10, 20
+	rng := rand.New(rand.NewSource(42))
+	vectors := randomVectors(rng, n, dim)
+
+	for _, metric := range []string{metricCosine, metricDot, metricL2} {
+		c.Run(metric, func(c *qt.C) {
+			flat := newFlatIndex(metric)
+			hnsw := newHNSWIndex(metric, 0, 0, 0)
+			for i := 0; i < n; i++ {
+				id := fmt.Sprintf("p%d", i)
+				flat.add(id, vectors[id])
+				hnsw.add(id, vectors[id])
+			}
+
+			var found int
+			for _, q := range randomVectors(rng, queries, dim) {
+				want := map[string]bool{}
+				for _, cand := range flat.search(q, k, nil) {
+					want[cand.id] = true
+				}
+				for _, cand := range hnsw.search(q, k, nil) {
+					if want[cand.id] {
+						found++
+					}
+				}
+			}
+
+			recall := float64(found) / float64(k*queries)
+			c.Check(recall >= 0.9, qt.IsTrue, qt.Commentf("recall: %f", recall))
+		})
+	}
+}
+
+func TestHNSWIndex_Remove(t *testing.T) {
+	c := qt.New(t)
+
+	rng := rand.New(rand.NewSource(42))
+	vectors := randomVectors(rng, 200, 8)
+
+	h := newHNSWIndex(metricL2, 8, 100, 50)
+	for i := 0; i < 200; i++ {
+		id := fmt.Sprintf("p%d", i)
+		h.add(id, vectors[id])
+	}
+
+	// Removing half of the points, including the entry point, must leave a
+	// graph where every remaining point can be found.
+	for i := 0; i < 200; i += 2 {
+		h.remove(fmt.Sprintf("p%d", i))
+	}
+	h.remove(h.entry)
+	c.Assert(h.nodes, qt.HasLen, 99)
+
+	for id := range h.nodes {
+		got := h.search(vectors[id], 1, nil)
+		c.Assert(got, qt.HasLen, 1)
+		c.Check(got[0].id, qt.Equals, id)
+	}
+
+	for id := range h.nodes {
+		c.Check(h.search(vectors[id], 200, nil), qt.HasLen, 99)
+		break
+	}
+}
+
+func TestHNSWIndex_SearchWithFilter(t *testing.T) {
+	c := qt.New(t)
+
+	rng := rand.New(rand.NewSource(42))
+	vectors := randomVectors(rng, 300, 8)
+
+	h := newHNSWIndex(metricCosine, 0, 0, 0)
+	for id, v := range vectors {
+		h.add(id, v)
+	}
+
+	// Only a few points are accepted, so most of them are outside of the
+	// explored part of the graph.
+	accepted := map[string]bool{"p1": true, "p77": true, "p150": true, "p299": true}
+	accept := func(id string) bool { return accepted[id] }
+
+	ids := func(cands []candidate) []string {
+		ids := make([]string, len(cands))
+		for i, cand := range cands {
+			ids[i] = cand.id
+		}
+		return ids
+	}
+
+	got := h.search(vectors["p0"], 10, accept)
+	want := bruteForce(metricCosine, vectors, vectors["p0"], 10, accept)
+	c.Check(ids(got), qt.DeepEquals, ids(want))
+	c.Check(got, qt.HasLen, 4)
+}
+
+func TestFilter(t *testing.T) {
+	c := qt.New(t)
+
+	point := Point{
+		ID: "a",
+		Metadata: map[string]any{
+			"city":    "London",
+			"year":    float64(2020),
+			"tags":    []any{"news", "sport"},
+			"country": map[string]any{"name": "UK"},
+			"empty":   []any{},
+		},
+	}
+
+	testcases := []struct {
+		name   string
+		filter map[string]any
+		want   bool
+	}{
+		{
+			name:   "match value",
+			filter: map[string]any{"must": []any{map[string]any{"key": "city", "match": map[string]any{"value": "London"}}}},
+			want:   true,
+		},
+		{
+			name:   "match nested key",
+			filter: map[string]any{"must": []any{map[string]any{"key": "country.name", "match": map[string]any{"value": "FR"}}}},
+			want:   false,
+		},
+		{
+			name:   "match any in array",
+			filter: map[string]any{"must": []any{map[string]any{"key": "tags", "match": map[string]any{"any": []any{"sport", "music"}}}}},
+			want:   true,
+		},
+		{
+			name:   "match except",
+			filter: map[string]any{"must": []any{map[string]any{"key": "city", "match": map[string]any{"except": []any{"London"}}}}},
+			want:   false,
+		},
+		{
+			name:   "match text",
+			filter: map[string]any{"must": []any{map[string]any{"key": "city", "match": map[string]any{"text": "ondo"}}}},
+			want:   true,
+		},
+		{
+			name:   "range",
+			filter: map[string]any{"must": []any{map[string]any{"key": "year", "range": map[string]any{"gt": 2019, "lte": 2020}}}},
+			want:   true,
+		},
+		{
+			name:   "is empty",
+			filter: map[string]any{"must": []any{map[string]any{"is_empty": map[string]any{"key": "empty"}}}},
+			want:   true,
+		},
+		{
+			name:   "has id",
+			filter: map[string]any{"must_not": []any{map[string]any{"has_id": []any{"a"}}}},
+			want:   false,
+		},
+		{
+			name: "should with nested filter",
+			filter: map[string]any{"should": []any{
+				map[string]any{"key": "city", "match": map[string]any{"value": "Paris"}},
+				map[string]any{"must": []any{map[string]any{"key": "year", "match": map[string]any{"value": 2020}}}},
+			}},
+			want: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			f, err := parseFilter(tc.filter)
+			c.Assert(err, qt.IsNil)
+			c.Check(f.matches(point), qt.Equals, tc.want)
+		})
+	}
+}
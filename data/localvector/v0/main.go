@@ -0,0 +1,201 @@
+//go:generate compogen readme ./config ./README.mdx
+package localvector
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	_ "embed"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	TaskVectorSearch     = "TASK_VECTOR_SEARCH"
+	TaskDelete           = "TASK_DELETE"
+	TaskBatchUpsert      = "TASK_BATCH_UPSERT"
+	TaskUpsert           = "TASK_UPSERT"
+	TaskCreateCollection = "TASK_CREATE_COLLECTION"
+	TaskDeleteCollection = "TASK_DELETE_COLLECTION"
+)
+
+//go:embed config/definition.json
+var definitionJSON []byte
+
+//go:embed config/setup.json
+var setupJSON []byte
+
+//go:embed config/tasks.json
+var tasksJSON []byte
+
+var once sync.Once
+var comp *component
+
+type component struct {
+	base.Component
+
+	// baseDir is the server directory, set by the operator, under which the
+	// persisted stores are kept. Persisted stores are disabled when it is
+	// empty.
+	baseDir string
+
+	// Stores are shared by every execution of the component, so the
+	// in-memory collections outlive a single pipeline run. They are keyed by
+	// storeKey.
+	storesMu sync.Mutex
+	stores   map[string]*store
+}
+
+type execution struct {
+	base.ComponentExecution
+
+	execute func(*structpb.Struct) (*structpb.Struct, error)
+	store   *store
+}
+
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{
+			Component: bc,
+			stores:    map[string]*store{},
+		}
+		err := comp.LoadDefinition(definitionJSON, setupJSON, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	return comp
+}
+
+// WithBaseDirectory loads the operator configuration of the component, i.e.,
+// the server directory under which the persisted stores are kept.
+func (c *component) WithBaseDirectory(s map[string]any) *component {
+	c.baseDir = base.ReadFromGlobalConfig("base-directory", s)
+	return c
+}
+
+// storePath resolves the setup directory of a persisted store. The directory
+// is relative to a folder of the namespace under the base directory, so a
+// pipeline can only reach the stores of its namespace.
+func (c *component) storePath(dir, namespace string) (string, error) {
+	if c.baseDir == "" {
+		return "", errmsg.AddMessage(
+			fmt.Errorf("persisted stores aren't enabled"),
+			"Persisted collections aren't enabled on this server. Leave the directory empty to keep the collections in memory.",
+		)
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("persisted stores require a pipeline namespace")
+	}
+
+	if filepath.IsAbs(dir) {
+		return "", errmsg.AddMessage(
+			fmt.Errorf("absolute store directory: %s", dir),
+			"The directory must be a relative path.",
+		)
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(dir), "/") {
+		if segment == ".." {
+			return "", errmsg.AddMessage(
+				fmt.Errorf("store directory out of the namespace: %s", dir),
+				"The directory can't contain \"..\" segments.",
+			)
+		}
+	}
+
+	return filepath.Join(c.baseDir, namespace, filepath.Clean(dir)), nil
+}
+
+// storeKey identifies a store. Stores are scoped to the namespace that owns
+// the pipeline, so that different namespaces don't share their collections.
+// A persisted store is also identified by its resolved directory.
+func storeKey(path, namespace string) string {
+	if path == "" {
+		return "memory:" + namespace
+	}
+	return "dir:" + namespace + ":" + path
+}
+
+// getStore returns the store of a directory, loading its collections the
+// first time it is used. An empty directory selects the in-memory store of
+// the namespace.
+func (c *component) getStore(dir, namespace string) (*store, error) {
+	var path string
+	if dir != "" {
+		var err error
+		if path, err = c.storePath(dir, namespace); err != nil {
+			return nil, err
+		}
+	}
+	key := storeKey(path, namespace)
+
+	c.storesMu.Lock()
+	defer c.storesMu.Unlock()
+
+	if s, ok := c.stores[key]; ok {
+		return s, nil
+	}
+
+	s, err := openStore(path)
+	if err != nil {
+		return nil, err
+	}
+	c.stores[key] = s
+	return s, nil
+}
+
+func getDirectory(setup *structpb.Struct) string {
+	return setup.GetFields()["directory"].GetStringValue()
+}
+
+// getNamespace returns the UID of the namespace that owns the pipeline, or
+// an empty string when the component runs outside of a pipeline.
+func getNamespace(vars map[string]any) string {
+	namespace, _ := vars["__PIPELINE_USER_UID"].(string)
+	return namespace
+}
+
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	s, err := c.getStore(getDirectory(x.Setup), getNamespace(x.SystemVariables))
+	if err != nil {
+		return nil, err
+	}
+
+	e := &execution{
+		ComponentExecution: x,
+		store:              s,
+	}
+
+	switch x.Task {
+	case TaskDelete:
+		e.execute = e.delete
+	case TaskBatchUpsert:
+		e.execute = e.batchUpsert
+	case TaskUpsert:
+		e.execute = e.upsert
+	case TaskCreateCollection:
+		e.execute = e.createCollection
+	case TaskDeleteCollection:
+		e.execute = e.deleteCollection
+	case TaskVectorSearch:
+		e.execute = e.vectorSearch
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("not supported task: %s", x.Task),
+			fmt.Sprintf("%s task is not supported.", x.Task),
+		)
+	}
+
+	return e, nil
+}
+
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	return base.SequentialExecutor(ctx, jobs, e.execute)
+}
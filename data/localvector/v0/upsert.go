@@ -0,0 +1,50 @@
+package localvector
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type UpsertInput struct {
+	CollectionName string         `json:"collection-name"`
+	ID             string         `json:"id"`
+	Metadata       map[string]any `json:"metadata"`
+	Vector         []float64      `json:"vector"`
+}
+
+type UpsertOutput struct {
+	Status string `json:"status"`
+}
+
+func (e *execution) upsert(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct UpsertInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	point := Point{
+		ID:       inputStruct.ID,
+		Vector:   inputStruct.Vector,
+		Metadata: inputStruct.Metadata,
+	}
+
+	err = e.store.update(inputStruct.CollectionName, func(col *collection) error {
+		return col.upsert([]Point{point})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := UpsertOutput{
+		Status: "Successfully upserted 1 point",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,107 @@
+package localvector
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type VectorSearchInput struct {
+	CollectionName string         `json:"collection-name"`
+	Vector         []float64      `json:"vector"`
+	Filter         map[string]any `json:"filter"`
+	Limit          int            `json:"limit"`
+	Payloads       []string       `json:"payloads"`
+	MinScore       float64        `json:"min-score"`
+}
+
+type VectorSearchOutput struct {
+	Status string `json:"status"`
+	Result Result `json:"result"`
+}
+
+type Result struct {
+	Ids      []string         `json:"ids"`
+	Points   []map[string]any `json:"points"`
+	Vectors  [][]float64      `json:"vectors"`
+	Metadata []map[string]any `json:"metadata"`
+}
+
+func (e *execution) vectorSearch(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct VectorSearchInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseFilter(inputStruct.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := e.store.collection(inputStruct.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	col.mu.RLock()
+	hits, err := col.search(inputStruct.Vector, inputStruct.Limit, filter, inputStruct.MinScore)
+	col.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	result := Result{
+		Ids:      []string{},
+		Points:   []map[string]any{},
+		Vectors:  [][]float64{},
+		Metadata: []map[string]any{},
+	}
+
+	for _, h := range hits {
+		metadata := selectPayloads(h.point.Metadata, inputStruct.Payloads)
+
+		point := make(map[string]any, len(metadata)+3)
+		for k, v := range metadata {
+			point[k] = v
+		}
+		point["id"] = h.point.ID
+		point["score"] = h.score
+		point["vector"] = h.point.Vector
+
+		result.Ids = append(result.Ids, h.point.ID)
+		result.Points = append(result.Points, point)
+		result.Vectors = append(result.Vectors, h.point.Vector)
+		result.Metadata = append(result.Metadata, metadata)
+	}
+
+	outputStruct := VectorSearchOutput{
+		Status: fmt.Sprintf("Successfully vector searched %d points", len(hits)),
+		Result: result,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// selectPayloads returns the metadata fields in the payloads list, or the
+// whole metadata if the list is empty.
+func selectPayloads(metadata map[string]any, payloads []string) map[string]any {
+	if len(payloads) == 0 {
+		return metadata
+	}
+
+	selected := make(map[string]any, len(payloads))
+	for _, k := range payloads {
+		if v, ok := metadata[k]; ok {
+			selected[k] = v
+		}
+	}
+	return selected
+}
@@ -336,114 +336,6 @@ func TestComponent_ExecuteUpsertTask(t *testing.T) {
 	}
 }
 
-func TestComponent_ExecuteBatchUpsertTask(t *testing.T) {
-	c := qt.New(t)
-	ctx := context.Background()
-	bc := base.Component{Logger: zap.NewNop()}
-	cmp := Init(bc)
-
-	testcases := []struct {
-		name     string
-		input    BatchUpsertInput
-		wantResp BatchUpsertOutput
-		wantErr  string
-
-		wantClientPath string
-		wantClientReq  any
-		clientResp     string
-	}{
-		{
-			name: "ok to batch upsert search",
-			input: BatchUpsertInput{
-				CollectionName: "mock-collection",
-				ArrayData: []map[string]any{
-					{"vector": []float64{0.1, 0.2}, "name": "a"},
-					{"vector": []float64{0.2, 0.3}, "name": "b"},
-				},
-			},
-			wantResp: BatchUpsertOutput{
-				Status: "Successfully batch upserted 2 data",
-			},
-			wantClientPath: upsertPath,
-			wantClientReq: UpsertReq{
-				CollectionNameReq: "mock-collection",
-				DataReq: []map[string]any{
-					{"vector": []float64{0.1, 0.2}, "name": "a"},
-					{"vector": []float64{0.2, 0.3}, "name": "b"},
-				},
-			},
-			clientResp: `{
-				"code": 200,
-				"data": {
-					"upsertCount": 2,
-					"upsertIds": ["mockID1", "mockID2"]
-				}
-			}`,
-		},
-	}
-
-	for _, tc := range testcases {
-		c.Run(tc.name, func(c *qt.C) {
-			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				c.Check(r.Method, qt.Equals, http.MethodPost)
-				c.Check(r.URL.Path, qt.Equals, tc.wantClientPath)
-
-				c.Check(r.Header.Get("Content-Type"), qt.Equals, httpclient.MIMETypeJSON)
-				c.Check(r.Header.Get("Accept"), qt.Equals, httpclient.MIMETypeJSON)
-				c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer mock-root:Milvus")
-
-				c.Assert(r.Body, qt.IsNotNil)
-				defer r.Body.Close()
-
-				body, err := io.ReadAll(r.Body)
-				c.Assert(err, qt.IsNil)
-				c.Check(body, qt.JSONEquals, tc.wantClientReq)
-
-				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
-				fmt.Fprintln(w, tc.clientResp)
-			})
-
-			milvusServer := httptest.NewServer(h)
-			c.Cleanup(milvusServer.Close)
-
-			setup, _ := structpb.NewStruct(map[string]any{
-				"username": "mock-root",
-				"password": "Milvus",
-				"url":      milvusServer.URL,
-			})
-
-			exec, err := cmp.CreateExecution(base.ComponentExecution{
-				Component: cmp,
-				Setup:     setup,
-				Task:      TaskBatchUpsert,
-			})
-
-			c.Assert(err, qt.IsNil)
-
-			pbIn, err := base.ConvertToStructpb(tc.input)
-			c.Assert(err, qt.IsNil)
-
-			ir, ow, eh, job := base.GenerateMockJob(c)
-			ir.ReadMock.Return(pbIn, nil)
-			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
-				wantJSON, err := json.Marshal(tc.wantResp)
-				c.Assert(err, qt.IsNil)
-				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
-				return nil
-			})
-			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
-				if tc.wantErr != "" {
-					c.Assert(err, qt.ErrorMatches, tc.wantErr)
-				}
-			})
-
-			err = exec.Execute(ctx, []*base.Job{job})
-			c.Assert(err, qt.IsNil)
-
-		})
-	}
-}
-
 func TestComponent_ExecuteCreateCollectionTask(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -1041,3 +933,75 @@ func TestComponent_ExecuteDropIndexTask(t *testing.T) {
 		})
 	}
 }
+
+func TestComponent_ExecuteSharedTasks(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	cmp := Init(bc)
+
+	testcases := []struct {
+		task           string
+		wantClientPath string
+		wantResp       string
+	}{
+		{
+			task:           TaskLoadCollection,
+			wantClientPath: "/v2/vectordb/collections/load",
+			wantResp:       "Successfully loaded 1 collection",
+		},
+		{
+			task:           TaskReleaseCollection,
+			wantClientPath: "/v2/vectordb/collections/release",
+			wantResp:       "Successfully released 1 collection",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.task, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, tc.wantClientPath)
+
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, map[string]any{"collectionName": "mock-collection"})
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprintln(w, `{"code": 0, "data": {}}`)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, _ := structpb.NewStruct(map[string]any{
+				"username": "mock-user",
+				"password": "mock-password",
+				"url":      srv.URL,
+			})
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(map[string]any{"collection-name": "mock-collection"})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				c.Check(output.Fields["status"].GetStringValue(), qt.Equals, tc.wantResp)
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Errorf("unexpected error: %v", err)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
@@ -11,21 +11,28 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/milvusapi"
 	"github.com/instill-ai/component/internal/util/httpclient"
 	"github.com/instill-ai/x/errmsg"
 )
 
 const (
-	TaskVectorSearch     = "TASK_VECTOR_SEARCH"
-	TaskUpsert           = "TASK_UPSERT"
-	TaskBatchUpsert      = "TASK_BATCH_UPSERT"
-	TaskDelete           = "TASK_DELETE"
-	TaskCreateCollection = "TASK_CREATE_COLLECTION"
-	TaskDropCollection   = "TASK_DROP_COLLECTION"
-	TaskCreatePartition  = "TASK_CREATE_PARTITION"
-	TaskDropPartition    = "TASK_DROP_PARTITION"
-	TaskCreateIndex      = "TASK_CREATE_INDEX"
-	TaskDropIndex        = "TASK_DROP_INDEX"
+	TaskVectorSearch       = "TASK_VECTOR_SEARCH"
+	TaskUpsert             = "TASK_UPSERT"
+	TaskBatchUpsert        = "TASK_BATCH_UPSERT"
+	TaskDelete             = "TASK_DELETE"
+	TaskCreateCollection   = "TASK_CREATE_COLLECTION"
+	TaskDropCollection     = "TASK_DROP_COLLECTION"
+	TaskCreatePartition    = "TASK_CREATE_PARTITION"
+	TaskDropPartition      = "TASK_DROP_PARTITION"
+	TaskCreateIndex        = "TASK_CREATE_INDEX"
+	TaskDropIndex          = "TASK_DROP_INDEX"
+	TaskQuery              = "TASK_QUERY"
+	TaskGet                = "TASK_GET"
+	TaskSearchIterator     = "TASK_SEARCH_ITERATOR"
+	TaskLoadCollection     = "TASK_LOAD_COLLECTION"
+	TaskReleaseCollection  = "TASK_RELEASE_COLLECTION"
+	TaskDescribeCollection = "TASK_DESCRIBE_COLLECTION"
 )
 
 //go:embed config/definition.json
@@ -75,7 +82,7 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 	case TaskUpsert:
 		e.execute = e.upsert
 	case TaskBatchUpsert:
-		e.execute = e.batchUpsert
+		e.execute = e.shared(milvusapi.BatchUpsert)
 	case TaskDelete:
 		e.execute = e.delete
 	case TaskCreateCollection:
@@ -90,6 +97,18 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.createIndex
 	case TaskDropIndex:
 		e.execute = e.dropIndex
+	case TaskQuery:
+		e.execute = e.shared(milvusapi.Query)
+	case TaskGet:
+		e.execute = e.shared(milvusapi.Get)
+	case TaskSearchIterator:
+		e.execute = e.shared(milvusapi.SearchIterator)
+	case TaskLoadCollection:
+		e.execute = e.shared(milvusapi.LoadCollection)
+	case TaskReleaseCollection:
+		e.execute = e.shared(milvusapi.ReleaseCollection)
+	case TaskDescribeCollection:
+		e.execute = e.shared(milvusapi.DescribeCollection)
 	default:
 		return nil, errmsg.AddMessage(
 			fmt.Errorf("not supported task: %s", x.Task),
@@ -100,6 +119,14 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 	return e, nil
 }
 
+// shared returns the execution function of a task implemented in the
+// milvusapi package, which is shared with the Zilliz component.
+func (e *execution) shared(task func(*httpclient.Client, *structpb.Struct) (*structpb.Struct, error)) func(*structpb.Struct) (*structpb.Struct, error) {
+	return func(in *structpb.Struct) (*structpb.Struct, error) {
+		return task(e.client, in)
+	}
+}
+
 func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 	return base.SequentialExecutor(ctx, jobs, e.execute)
 }
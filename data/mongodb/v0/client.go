@@ -2,18 +2,61 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
-func newClient(ctx context.Context, setup *structpb.Struct) *mongo.Client {
-	client, _ := mongo.Connect(ctx, options.Client().ApplyURI(getURI(setup)))
+func newClient(ctx context.Context, setup *structpb.Struct) (*mongo.Client, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(getURI(setup)))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to MongoDB: %w", err)
+	}
 
-	return client
+	return client, nil
 }
 
 func getURI(setup *structpb.Struct) string {
 	return setup.GetFields()["uri"].GetStringValue()
 }
+
+// initClients sets the database, collection and index clients of a job. The
+// connection is opened on the first job and shared by the following ones,
+// which can target other databases and collections. Clients that are set
+// before the execution (e.g. mocks in tests) are kept.
+func (e *execution) initClients(ctx context.Context, databaseName, collectionName string) error {
+	if e.mongoClient == nil {
+		if e.client.databaseClient != nil || e.client.collectionClient != nil {
+			return nil
+		}
+
+		client, err := newClient(ctx, e.Setup)
+		if err != nil {
+			return err
+		}
+		e.mongoClient = client
+	}
+
+	db := e.mongoClient.Database(databaseName)
+	e.client.databaseClient = db
+
+	collection := db.Collection(collectionName)
+	e.client.collectionClient = collection
+	e.client.searchIndexClient = collection.SearchIndexes()
+	e.client.indexClient = collection.Indexes()
+
+	return nil
+}
+
+// disconnect closes the connection opened by the jobs, if any.
+func (e *execution) disconnect(ctx context.Context) error {
+	if e.mongoClient == nil {
+		return nil
+	}
+
+	err := e.mongoClient.Disconnect(ctx)
+	e.mongoClient = nil
+	return err
+}
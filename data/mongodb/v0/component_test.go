@@ -95,6 +95,18 @@ func (m *MockMongoClient) Aggregate(ctx context.Context, pipeline any, opts ...*
 	return mockCursor, nil
 }
 
+func (m *MockMongoClient) CountDocuments(ctx context.Context, filter any, opts ...*options.CountOptions) (int64, error) {
+	return 2, nil
+}
+
+func (m *MockMongoClient) Distinct(ctx context.Context, fieldName string, filter any, opts ...*options.DistinctOptions) ([]any, error) {
+	return []any{"John Doe", "Jane Smith"}, nil
+}
+
+func (m *MockMongoClient) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	return &mongo.BulkWriteResult{InsertedCount: int64(len(models))}, nil
+}
+
 func TestComponent_ExecuteInsertOneTask(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -775,3 +787,481 @@ func TestComponent_ExecuteInsertManyTask(t *testing.T) {
 		})
 	}
 }
+
+// runMemoryTask executes a task against an in-memory collection and returns
+// the task output or the error reported to the job.
+func runMemoryTask(c *qt.C, task string, coll *memoryCollection, input any) (map[string]any, error) {
+	setup, err := structpb.NewStruct(map[string]any{
+		"uri": "mongodb://localhost:27017",
+	})
+	c.Assert(err, qt.IsNil)
+
+	connector := Init(base.Component{Logger: zap.NewNop()})
+	exec, err := connector.CreateExecution(base.ComponentExecution{Component: connector, Setup: setup, Task: task})
+	c.Assert(err, qt.IsNil)
+
+	e := exec.(*execution)
+	e.client = &MongoClient{
+		collectionClient: coll,
+		indexClient:      coll,
+	}
+
+	pbIn, err := base.ConvertToStructpb(input)
+	c.Assert(err, qt.IsNil)
+
+	var output map[string]any
+	var jobErr error
+	ir, ow, eh, job := base.GenerateMockJob(c)
+	ir.ReadMock.Return(pbIn, nil)
+	ow.WriteMock.Optional().Set(func(ctx context.Context, o *structpb.Struct) error {
+		output = o.AsMap()
+		return nil
+	})
+	eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+		jobErr = err
+	})
+
+	err = e.Execute(context.Background(), []*base.Job{job})
+	c.Assert(err, qt.IsNil)
+
+	return output, jobErr
+}
+
+func newSalesCollection() *memoryCollection {
+	return newMemoryCollection(
+		map[string]any{"_id": "1", "region": "eu", "item": "pen", "amount": 10.0},
+		map[string]any{"_id": "2", "region": "us", "item": "pen", "amount": 5.0},
+		map[string]any{"_id": "3", "region": "eu", "item": "book", "amount": 30.0},
+		map[string]any{"_id": "4", "region": "apac", "item": "book", "amount": 20.0},
+	)
+}
+
+func TestComponent_ExecuteAggregateTask(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name         string
+		input        AggregateInput
+		wantResp     AggregateOutput
+		wantPipeline bson.A
+		wantErr      string
+	}{
+		{
+			name: "ok to group and sort",
+			input: AggregateInput{
+				Pipeline: []map[string]any{
+					{"$match": map[string]any{"amount": map[string]any{"$gte": 10}}},
+					{"$group": map[string]any{"_id": "$region", "total": map[string]any{"$sum": "$amount"}}},
+					{"$sort": map[string]any{"total": -1}},
+					{"$limit": 2},
+				},
+			},
+			wantResp: AggregateOutput{
+				Status: "Successfully aggregated 2 documents",
+				Documents: []map[string]any{
+					{"_id": "eu", "total": 40},
+					{"_id": "apac", "total": 20},
+				},
+			},
+			wantPipeline: bson.A{
+				map[string]any{"$match": map[string]any{"amount": map[string]any{"$gte": int64(10)}}},
+				map[string]any{"$group": map[string]any{"_id": "$region", "total": map[string]any{"$sum": "$amount"}}},
+				map[string]any{"$sort": map[string]any{"total": int64(-1)}},
+				map[string]any{"$limit": int64(2)},
+			},
+		},
+		{
+			name: "ok to pass through server-side stages",
+			input: AggregateInput{
+				Pipeline: []map[string]any{
+					{"$lookup": map[string]any{"from": "items", "localField": "item", "foreignField": "name", "as": "details"}},
+					{"$project": map[string]any{"item": 1, "_id": 0}},
+					{"$limit": 1},
+				},
+			},
+			wantResp: AggregateOutput{
+				Status:    "Successfully aggregated 1 documents",
+				Documents: []map[string]any{{"item": "pen"}},
+			},
+			wantPipeline: bson.A{
+				map[string]any{"$lookup": map[string]any{"from": "items", "localField": "item", "foreignField": "name", "as": "details"}},
+				map[string]any{"$project": map[string]any{"item": int64(1), "_id": int64(0)}},
+				map[string]any{"$limit": int64(1)},
+			},
+		},
+		{
+			name:    "nok - empty pipeline",
+			input:   AggregateInput{},
+			wantErr: "pipeline must have at least one stage",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			coll := newSalesCollection()
+			got, err := runMemoryTask(c, TaskAggregate, coll, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, got)
+			c.Check(coll.pipelines, qt.DeepEquals, []bson.A{tc.wantPipeline})
+		})
+	}
+}
+
+func TestComponent_ExecuteCountTask(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name     string
+		input    CountInput
+		wantResp CountOutput
+	}{
+		{
+			name:     "ok to count all documents",
+			input:    CountInput{},
+			wantResp: CountOutput{Status: "Successfully counted 4 documents", Count: 4},
+		},
+		{
+			name:     "ok to count with filter",
+			input:    CountInput{Filter: map[string]any{"region": map[string]any{"$in": []any{"eu", "us"}}}},
+			wantResp: CountOutput{Status: "Successfully counted 3 documents", Count: 3},
+		},
+		{
+			name:     "ok to count with limit",
+			input:    CountInput{Filter: map[string]any{"item": "book"}, Limit: 1},
+			wantResp: CountOutput{Status: "Successfully counted 1 documents", Count: 1},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			got, err := runMemoryTask(c, TaskCount, newSalesCollection(), tc.input)
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, got)
+		})
+	}
+}
+
+func TestComponent_ExecuteDistinctTask(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name     string
+		input    DistinctInput
+		wantResp DistinctOutput
+	}{
+		{
+			name:     "ok to find distinct values",
+			input:    DistinctInput{Field: "region"},
+			wantResp: DistinctOutput{Status: "Successfully found 3 distinct values", Values: []any{"eu", "us", "apac"}},
+		},
+		{
+			name:     "ok to find distinct values with filter",
+			input:    DistinctInput{Field: "item", Filter: map[string]any{"region": "eu"}},
+			wantResp: DistinctOutput{Status: "Successfully found 2 distinct values", Values: []any{"pen", "book"}},
+		},
+		{
+			name:     "ok with no matches",
+			input:    DistinctInput{Field: "item", Filter: map[string]any{"region": "latam"}},
+			wantResp: DistinctOutput{Status: "Successfully found 0 distinct values", Values: []any{}},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			got, err := runMemoryTask(c, TaskDistinct, newSalesCollection(), tc.input)
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, got)
+		})
+	}
+}
+
+func TestComponent_ExecuteBulkWriteTask(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name     string
+		input    BulkWriteInput
+		wantResp BulkWriteOutput
+		wantDocs []map[string]any
+		wantErr  string
+	}{
+		{
+			name: "ok to run mixed operations",
+			input: BulkWriteInput{
+				Operations: []BulkWriteOperation{
+					{Type: "insert", ID: "5", Data: map[string]any{"region": "us", "item": "ink", "amount": 2}},
+					{Type: "update-one", ID: "1", UpdateData: map[string]any{"amount": 12}},
+					{Type: "update-many", Filter: map[string]any{"item": "book"}, UpdateData: map[string]any{"$inc": map[string]any{"amount": 1}}},
+					{Type: "update-one", ID: "6", UpdateData: map[string]any{"item": "cap"}, Upsert: true},
+					{Type: "replace-one", ID: "2", Data: map[string]any{"region": "us", "item": "pencil"}},
+					{Type: "delete-many", Filter: map[string]any{"region": "apac"}},
+				},
+			},
+			wantResp: BulkWriteOutput{
+				Status:        "Successfully executed 6 operations",
+				InsertedCount: 1,
+				MatchedCount:  4,
+				ModifiedCount: 4,
+				DeletedCount:  1,
+				UpsertedCount: 1,
+				UpsertedIDs:   map[string]string{"3": "6"},
+			},
+			wantDocs: []map[string]any{
+				{"_id": "1", "region": "eu", "item": "pen", "amount": 12.0},
+				{"_id": "2", "region": "us", "item": "pencil"},
+				{"_id": "3", "region": "eu", "item": "book", "amount": 31.0},
+				{"_id": "5", "region": "us", "item": "ink", "amount": 2.0},
+				{"_id": "6", "item": "cap"},
+			},
+		},
+		{
+			name: "nok - mixed update operators and fields",
+			input: BulkWriteInput{
+				Operations: []BulkWriteOperation{
+					{Type: "update-one", ID: "1", UpdateData: map[string]any{"amount": 12, "$inc": map[string]any{"amount": 1}}},
+				},
+			},
+			wantErr: "operation 0: update data can't mix update operators and fields",
+		},
+		{
+			name: "nok - missing target",
+			input: BulkWriteInput{
+				Operations: []BulkWriteOperation{
+					{Type: "delete-one"},
+				},
+			},
+			wantErr: "operation 0: either id or filter must be provided",
+		},
+		{
+			name: "nok - unsupported operation",
+			input: BulkWriteInput{
+				Operations: []BulkWriteOperation{
+					{Type: "merge", ID: "1"},
+				},
+			},
+			wantErr: "operation 0: unsupported operation type: merge",
+		},
+		{
+			name: "nok - duplicate key",
+			input: BulkWriteInput{
+				Operations: []BulkWriteOperation{
+					{Type: "insert", ID: "1", Data: map[string]any{"item": "pen"}},
+				},
+			},
+			wantErr: "E11000 duplicate key error: _id 1",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			coll := newSalesCollection()
+			got, err := runMemoryTask(c, TaskBulkWrite, coll, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, got)
+			c.Check(coll.docs, qt.DeepEquals, tc.wantDocs)
+		})
+	}
+}
+
+func TestComponent_ExecuteCreateIndexTask(t *testing.T) {
+	c := qt.New(t)
+	ttl := int32(3600)
+
+	testcases := []struct {
+		name      string
+		input     CreateIndexInput
+		wantResp  CreateIndexOutput
+		wantKeys  bson.D
+		wantIndex func(c *qt.C, opts *options.IndexOptions)
+		wantErr   string
+	}{
+		{
+			name: "ok to create compound index",
+			input: CreateIndexInput{
+				Keys:   []IndexKey{{Field: "region"}, {Field: "amount", Type: "descending"}},
+				Unique: true,
+			},
+			wantResp: CreateIndexOutput{Status: "Successfully created 1 index", IndexName: "region_1_amount_-1"},
+			wantKeys: bson.D{{Key: "region", Value: 1}, {Key: "amount", Value: -1}},
+			wantIndex: func(c *qt.C, opts *options.IndexOptions) {
+				c.Check(*opts.Unique, qt.IsTrue)
+				c.Check(opts.Sparse, qt.IsNil)
+			},
+		},
+		{
+			name: "ok to create named TTL index with partial filter",
+			input: CreateIndexInput{
+				IndexName:          "expiry",
+				Keys:               []IndexKey{{Field: "created-at"}},
+				ExpireAfterSeconds: &ttl,
+				PartialFilter:      map[string]any{"amount": map[string]any{"$gt": 10}},
+			},
+			wantResp: CreateIndexOutput{Status: "Successfully created 1 index", IndexName: "expiry"},
+			wantKeys: bson.D{{Key: "created-at", Value: 1}},
+			wantIndex: func(c *qt.C, opts *options.IndexOptions) {
+				c.Check(*opts.ExpireAfterSeconds, qt.Equals, ttl)
+				c.Check(opts.PartialFilterExpression, qt.DeepEquals, map[string]any{"amount": map[string]any{"$gt": int64(10)}})
+			},
+		},
+		{
+			name: "ok to create text index",
+			input: CreateIndexInput{
+				Keys: []IndexKey{{Field: "item", Type: "text"}},
+			},
+			wantResp: CreateIndexOutput{Status: "Successfully created 1 index", IndexName: "item_text"},
+			wantKeys: bson.D{{Key: "item", Value: "text"}},
+		},
+		{
+			name: "nok - compound TTL index",
+			input: CreateIndexInput{
+				Keys:               []IndexKey{{Field: "region"}, {Field: "created-at"}},
+				ExpireAfterSeconds: &ttl,
+			},
+			wantErr: "TTL indexes must have a single key",
+		},
+		{
+			name: "nok - unsupported index type",
+			input: CreateIndexInput{
+				Keys: []IndexKey{{Field: "region", Type: "wildcard"}},
+			},
+			wantErr: "unsupported index type: wildcard",
+		},
+		{
+			name:    "nok - no keys",
+			input:   CreateIndexInput{},
+			wantErr: "keys must have at least one element",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			coll := newSalesCollection()
+			got, err := runMemoryTask(c, TaskCreateIndex, coll, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, got)
+
+			index, ok := coll.indexes[tc.wantResp.IndexName]
+			c.Assert(ok, qt.IsTrue)
+			c.Check(index.Keys, qt.DeepEquals, tc.wantKeys)
+			if tc.wantIndex != nil {
+				tc.wantIndex(c, index.Options)
+			}
+		})
+	}
+}
+
+func TestComponent_ExecuteDropIndexTask(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name     string
+		input    DropIndexInput
+		wantResp DropIndexOutput
+		wantErr  string
+	}{
+		{
+			name:     "ok to drop index",
+			input:    DropIndexInput{IndexName: "region_1"},
+			wantResp: DropIndexOutput{Status: "Successfully dropped 1 index"},
+		},
+		{
+			name:    "nok - index not found",
+			input:   DropIndexInput{IndexName: "item_1"},
+			wantErr: `index not found with name \[item_1\]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			coll := newSalesCollection()
+			coll.indexes["region_1"] = mongo.IndexModel{Keys: bson.D{{Key: "region", Value: 1}}}
+
+			got, err := runMemoryTask(c, TaskDropIndex, coll, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			wantJSON, err := json.Marshal(tc.wantResp)
+			c.Assert(err, qt.IsNil)
+			c.Check(wantJSON, qt.JSONEquals, got)
+			c.Check(coll.indexes, qt.HasLen, 0)
+		})
+	}
+}
+
+func TestExecution_InitClients(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	newExecution := func(c *qt.C, uri string) *execution {
+		setup, err := structpb.NewStruct(map[string]any{"uri": uri})
+		c.Assert(err, qt.IsNil)
+
+		return &execution{
+			ComponentExecution: base.ComponentExecution{Setup: setup},
+			client:             &MongoClient{},
+		}
+	}
+
+	c.Run("ok - connection is shared by the jobs", func(c *qt.C) {
+		e := newExecution(c, "mongodb://localhost:27017")
+
+		// The driver connects lazily, so no server is needed.
+		c.Assert(e.initClients(ctx, "db1", "coll1"), qt.IsNil)
+		client := e.mongoClient
+		c.Check(e.client.collectionClient.(*mongo.Collection).Name(), qt.Equals, "coll1")
+
+		c.Assert(e.initClients(ctx, "db2", "coll2"), qt.IsNil)
+		c.Check(e.mongoClient, qt.Equals, client)
+		c.Check(e.client.databaseClient.(*mongo.Database).Name(), qt.Equals, "db2")
+		c.Check(e.client.collectionClient.(*mongo.Collection).Name(), qt.Equals, "coll2")
+
+		c.Check(e.disconnect(ctx), qt.IsNil)
+		c.Check(e.mongoClient, qt.IsNil)
+	})
+
+	c.Run("ok - mocked database client is kept", func(c *qt.C) {
+		e := newExecution(c, "mongodb://localhost:27017")
+		mock := &MockMongoClient{}
+		e.client.databaseClient = mock
+
+		c.Assert(e.initClients(ctx, "db1", ""), qt.IsNil)
+		c.Check(e.client.databaseClient, qt.Equals, MongoDatabaseClient(mock))
+		c.Check(e.mongoClient, qt.IsNil)
+	})
+
+	c.Run("nok - invalid URI", func(c *qt.C) {
+		e := newExecution(c, "foo://localhost")
+		err := e.initClients(ctx, "db1", "coll1")
+		c.Check(err, qt.ErrorMatches, "connecting to MongoDB: .*")
+	})
+}
@@ -8,8 +8,10 @@ import (
 
 	_ "embed"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/component/base"
@@ -27,6 +29,12 @@ const (
 	TaskCreateSearchIndex = "TASK_CREATE_SEARCH_INDEX"
 	TaskDropSearchIndex   = "TASK_DROP_SEARCH_INDEX"
 	TaskVectorSearch      = "TASK_VECTOR_SEARCH"
+	TaskAggregate         = "TASK_AGGREGATE"
+	TaskCount             = "TASK_COUNT"
+	TaskDistinct          = "TASK_DISTINCT"
+	TaskBulkWrite         = "TASK_BULK_WRITE"
+	TaskCreateIndex       = "TASK_CREATE_INDEX"
+	TaskDropIndex         = "TASK_DROP_INDEX"
 )
 
 //go:embed config/definition.json
@@ -55,6 +63,9 @@ type MongoCollectionClient interface {
 
 	SearchIndexes() mongo.SearchIndexView
 	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+	Distinct(ctx context.Context, fieldName string, filter interface{}, opts ...*options.DistinctOptions) ([]interface{}, error)
+	BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error)
 }
 
 type MongoDatabaseClient interface {
@@ -66,10 +77,16 @@ type MongoSearchIndexClient interface {
 	DropOne(ctx context.Context, name string, _ ...*options.DropSearchIndexOptions) error
 }
 
+type MongoIndexClient interface {
+	CreateOne(ctx context.Context, model mongo.IndexModel, opts ...*options.CreateIndexesOptions) (string, error)
+	DropOne(ctx context.Context, name string, opts ...*options.DropIndexesOptions) (bson.Raw, error)
+}
+
 type MongoClient struct {
 	collectionClient  MongoCollectionClient
 	databaseClient    MongoDatabaseClient
 	searchIndexClient MongoSearchIndexClient
+	indexClient       MongoIndexClient
 }
 
 // dbClient for task DropDatabase
@@ -77,8 +94,9 @@ type MongoClient struct {
 type execution struct {
 	base.ComponentExecution
 
-	execute func(context.Context, *structpb.Struct) (*structpb.Struct, error)
-	client  *MongoClient
+	execute     func(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	client      *MongoClient
+	mongoClient *mongo.Client
 }
 
 // Init returns an implementation of IConnector that interacts with MongoDB.
@@ -121,6 +139,18 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.dropSearchIndex
 	case TaskVectorSearch:
 		e.execute = e.vectorSearch
+	case TaskAggregate:
+		e.execute = e.aggregate
+	case TaskCount:
+		e.execute = e.count
+	case TaskDistinct:
+		e.execute = e.distinct
+	case TaskBulkWrite:
+		e.execute = e.bulkWrite
+	case TaskCreateIndex:
+		e.execute = e.createIndex
+	case TaskDropIndex:
+		e.execute = e.dropIndex
 	default:
 		return nil, errmsg.AddMessage(
 			fmt.Errorf("not supported task: %s", x.Task),
@@ -135,6 +165,12 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 // collectionClient wont be nil on component test (use mock collectionClient)
 // collectionClient will be nil on task DropDatabase
 func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	// The connection is shared by the jobs and closed once they're done.
+	defer func() {
+		if err := e.disconnect(context.Background()); err != nil {
+			e.GetLogger().Warn("Failed to disconnect from MongoDB", zap.Error(err))
+		}
+	}()
 
 	for _, job := range jobs {
 		input, err := job.Input.Read(ctx)
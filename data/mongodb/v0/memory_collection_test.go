@@ -0,0 +1,539 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// memoryCollection is an in-memory stand-in for a MongoDB collection. It
+// supports the query operators and aggregation stages used in the tests:
+// equality, $eq, $ne, $in, $gt, $gte, $lt and $lte filters, and the $match,
+// $group, $sort, $limit and $project stages. Other stages are recorded and
+// passed through, so the pipeline sent to the server can be checked.
+type memoryCollection struct {
+	docs      []map[string]any
+	pipelines []bson.A
+	indexes   map[string]mongo.IndexModel
+}
+
+func newMemoryCollection(docs ...map[string]any) *memoryCollection {
+	return &memoryCollection{docs: docs, indexes: map[string]mongo.IndexModel{}}
+}
+
+func asMap(v any) (map[string]any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		return v, true
+	case bson.M:
+		return v, true
+	case bson.D:
+		m := make(map[string]any, len(v))
+		for _, e := range v {
+			m[e.Key] = e.Value
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func equal(a, b any) bool {
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if okA && okB {
+		return fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func compare(a, b any) int {
+	fa, okA := toFloat(a)
+	fb, okB := toFloat(b)
+	if okA && okB {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func matchCondition(value, cond any) bool {
+	ops, ok := asMap(cond)
+	if !ok || len(ops) == 0 {
+		return equal(value, cond)
+	}
+
+	for op, arg := range ops {
+		switch op {
+		case "$eq":
+			if !equal(value, arg) {
+				return false
+			}
+		case "$ne":
+			if equal(value, arg) {
+				return false
+			}
+		case "$in":
+			found := false
+			for _, v := range arg.([]any) {
+				found = found || equal(value, v)
+			}
+			if !found {
+				return false
+			}
+		case "$gt", "$gte", "$lt", "$lte":
+			if value == nil {
+				return false
+			}
+			c := compare(value, arg)
+			if (op == "$gt" && c <= 0) || (op == "$gte" && c < 0) || (op == "$lt" && c >= 0) || (op == "$lte" && c > 0) {
+				return false
+			}
+		default:
+			return equal(value, cond)
+		}
+	}
+	return true
+}
+
+func matches(doc map[string]any, filter any) bool {
+	f, _ := asMap(filter)
+	for field, cond := range f {
+		if !matchCondition(doc[field], cond) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *memoryCollection) filter(filter any) []map[string]any {
+	var found []map[string]any
+	for _, doc := range m.docs {
+		if matches(doc, filter) {
+			found = append(found, doc)
+		}
+	}
+	return found
+}
+
+func newCursor(docs []map[string]any) (*mongo.Cursor, error) {
+	anyDocs := make([]any, len(docs))
+	for i, doc := range docs {
+		anyDocs[i] = doc
+	}
+	return mongo.NewCursorFromDocuments(anyDocs, nil, nil)
+}
+
+func (m *memoryCollection) Find(ctx context.Context, filter any, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	found := m.filter(filter)
+	if o := options.MergeFindOptions(opts...); o.Limit != nil && *o.Limit > 0 && int(*o.Limit) < len(found) {
+		found = found[:*o.Limit]
+	}
+	return newCursor(found)
+}
+
+func (m *memoryCollection) insert(document any) (any, error) {
+	doc, ok := asMap(document)
+	if !ok {
+		return nil, fmt.Errorf("unsupported document type %T", document)
+	}
+
+	cp := make(map[string]any, len(doc))
+	for k, v := range doc {
+		cp[k] = v
+	}
+	if _, ok := cp["_id"]; !ok {
+		cp["_id"] = primitive.NewObjectID()
+	}
+	if len(m.filter(bson.M{"_id": cp["_id"]})) > 0 {
+		return nil, fmt.Errorf("E11000 duplicate key error: _id %v", cp["_id"])
+	}
+
+	m.docs = append(m.docs, cp)
+	return cp["_id"], nil
+}
+
+func (m *memoryCollection) InsertOne(ctx context.Context, document any, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	id, err := m.insert(document)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.InsertOneResult{InsertedID: id}, nil
+}
+
+func (m *memoryCollection) InsertMany(ctx context.Context, documents []any, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	res := &mongo.InsertManyResult{}
+	for _, document := range documents {
+		id, err := m.insert(document)
+		if err != nil {
+			return nil, err
+		}
+		res.InsertedIDs = append(res.InsertedIDs, id)
+	}
+	return res, nil
+}
+
+// applyUpdate supports the $set, $unset and $inc operators.
+func applyUpdate(doc map[string]any, update any) error {
+	u, _ := asMap(update)
+	for op, arg := range u {
+		fields, _ := asMap(arg)
+		for field, value := range fields {
+			switch op {
+			case "$set":
+				doc[field] = value
+			case "$unset":
+				delete(doc, field)
+			case "$inc":
+				current, _ := toFloat(doc[field])
+				inc, _ := toFloat(value)
+				doc[field] = current + inc
+			default:
+				return fmt.Errorf("unsupported update operator %s", op)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memoryCollection) update(filter, update any, many, upsert bool) (*mongo.UpdateResult, error) {
+	res := &mongo.UpdateResult{}
+	for _, doc := range m.filter(filter) {
+		res.MatchedCount++
+		if err := applyUpdate(doc, update); err != nil {
+			return nil, err
+		}
+		res.ModifiedCount++
+		if !many {
+			break
+		}
+	}
+
+	if res.MatchedCount == 0 && upsert {
+		// The upserted document is built from the equality conditions of the
+		// filter.
+		doc := map[string]any{}
+		f, _ := asMap(filter)
+		for field, cond := range f {
+			if _, isOp := asMap(cond); !isOp {
+				doc[field] = cond
+			}
+		}
+		if err := applyUpdate(doc, update); err != nil {
+			return nil, err
+		}
+		id, err := m.insert(doc)
+		if err != nil {
+			return nil, err
+		}
+		res.UpsertedCount = 1
+		res.UpsertedID = id
+	}
+
+	return res, nil
+}
+
+func (m *memoryCollection) UpdateMany(ctx context.Context, filter any, update any, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return m.update(filter, update, true, false)
+}
+
+func (m *memoryCollection) delete(filter any, many bool) int64 {
+	var deleted int64
+	kept := m.docs[:0]
+	for _, doc := range m.docs {
+		if (many || deleted == 0) && matches(doc, filter) {
+			deleted++
+			continue
+		}
+		kept = append(kept, doc)
+	}
+	m.docs = kept
+	return deleted
+}
+
+func (m *memoryCollection) DeleteMany(ctx context.Context, filter any, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return &mongo.DeleteResult{DeletedCount: m.delete(filter, true)}, nil
+}
+
+func (m *memoryCollection) Drop(ctx context.Context) error {
+	m.docs = nil
+	return nil
+}
+
+func (m *memoryCollection) SearchIndexes() mongo.SearchIndexView {
+	return mongo.SearchIndexView{}
+}
+
+func fieldPath(v any) (string, bool) {
+	s, ok := v.(string)
+	if !ok || !strings.HasPrefix(s, "$") {
+		return "", false
+	}
+	return strings.TrimPrefix(s, "$"), true
+}
+
+func group(docs []map[string]any, spec map[string]any) []map[string]any {
+	var keys []string
+	groups := map[string][]map[string]any{}
+	ids := map[string]any{}
+	for _, doc := range docs {
+		var id any
+		if path, ok := fieldPath(spec["_id"]); ok {
+			id = doc[path]
+		}
+		key := fmt.Sprint(id)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+			ids[key] = id
+		}
+		groups[key] = append(groups[key], doc)
+	}
+
+	out := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		result := map[string]any{"_id": ids[key]}
+		for field, acc := range spec {
+			if field == "_id" {
+				continue
+			}
+			accumulator, _ := asMap(acc)
+			for op, arg := range accumulator {
+				var values []any
+				for _, doc := range groups[key] {
+					if path, ok := fieldPath(arg); ok {
+						values = append(values, doc[path])
+					} else {
+						values = append(values, arg)
+					}
+				}
+
+				switch op {
+				case "$sum", "$avg":
+					sum := 0.0
+					for _, v := range values {
+						f, _ := toFloat(v)
+						sum += f
+					}
+					if op == "$avg" {
+						sum /= float64(len(values))
+					}
+					result[field] = sum
+				case "$push":
+					result[field] = values
+				}
+			}
+		}
+		out = append(out, result)
+	}
+	return out
+}
+
+func (m *memoryCollection) Aggregate(ctx context.Context, pipeline any, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	stages, ok := pipeline.(bson.A)
+	if !ok {
+		return nil, fmt.Errorf("unsupported pipeline type %T", pipeline)
+	}
+	m.pipelines = append(m.pipelines, stages)
+
+	docs := m.filter(bson.M{})
+	for _, s := range stages {
+		stage, _ := asMap(s)
+		for op, arg := range stage {
+			switch op {
+			case "$match":
+				var matched []map[string]any
+				for _, doc := range docs {
+					if matches(doc, arg) {
+						matched = append(matched, doc)
+					}
+				}
+				docs = matched
+			case "$group":
+				spec, _ := asMap(arg)
+				docs = group(docs, spec)
+			case "$sort":
+				spec, _ := asMap(arg)
+				for field, order := range spec {
+					desc := equal(order, -1)
+					sort.SliceStable(docs, func(i, j int) bool {
+						c := compare(docs[i][field], docs[j][field])
+						if desc {
+							return c > 0
+						}
+						return c < 0
+					})
+				}
+			case "$limit":
+				limit, ok := arg.(int64)
+				if !ok {
+					return nil, fmt.Errorf("the limit must be an integer, got %T", arg)
+				}
+				if int(limit) < len(docs) {
+					docs = docs[:limit]
+				}
+			case "$project":
+				spec, _ := asMap(arg)
+				projected := make([]map[string]any, 0, len(docs))
+				for _, doc := range docs {
+					p := map[string]any{}
+					for field, include := range spec {
+						if !equal(include, 0) {
+							p[field] = doc[field]
+						}
+					}
+					if _, ok := spec["_id"]; !ok {
+						p["_id"] = doc["_id"]
+					}
+					projected = append(projected, p)
+				}
+				docs = projected
+			}
+		}
+	}
+
+	return newCursor(docs)
+}
+
+func (m *memoryCollection) CountDocuments(ctx context.Context, filter any, opts ...*options.CountOptions) (int64, error) {
+	count := int64(len(m.filter(filter)))
+	if o := options.MergeCountOptions(opts...); o.Limit != nil && *o.Limit > 0 && *o.Limit < count {
+		count = *o.Limit
+	}
+	return count, nil
+}
+
+func (m *memoryCollection) Distinct(ctx context.Context, fieldName string, filter any, opts ...*options.DistinctOptions) ([]any, error) {
+	var values []any
+	seen := map[string]bool{}
+	for _, doc := range m.filter(filter) {
+		v, ok := doc[fieldName]
+		if !ok || seen[fmt.Sprint(v)] {
+			continue
+		}
+		seen[fmt.Sprint(v)] = true
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func (m *memoryCollection) BulkWrite(ctx context.Context, models []mongo.WriteModel, opts ...*options.BulkWriteOptions) (*mongo.BulkWriteResult, error) {
+	res := &mongo.BulkWriteResult{UpsertedIDs: map[int64]any{}}
+
+	addUpdate := func(i int, u *mongo.UpdateResult) {
+		res.MatchedCount += u.MatchedCount
+		res.ModifiedCount += u.ModifiedCount
+		res.UpsertedCount += u.UpsertedCount
+		if u.UpsertedID != nil {
+			res.UpsertedIDs[int64(i)] = u.UpsertedID
+		}
+	}
+	upsert := func(u *bool) bool { return u != nil && *u }
+
+	for i, model := range models {
+		switch model := model.(type) {
+		case *mongo.InsertOneModel:
+			if _, err := m.insert(model.Document); err != nil {
+				return nil, err
+			}
+			res.InsertedCount++
+		case *mongo.UpdateOneModel:
+			u, err := m.update(model.Filter, model.Update, false, upsert(model.Upsert))
+			if err != nil {
+				return nil, err
+			}
+			addUpdate(i, u)
+		case *mongo.UpdateManyModel:
+			u, err := m.update(model.Filter, model.Update, true, upsert(model.Upsert))
+			if err != nil {
+				return nil, err
+			}
+			addUpdate(i, u)
+		case *mongo.ReplaceOneModel:
+			replacement, _ := asMap(model.Replacement)
+			found := m.filter(model.Filter)
+			switch {
+			case len(found) > 0:
+				id := found[0]["_id"]
+				for k := range found[0] {
+					delete(found[0], k)
+				}
+				for k, v := range replacement {
+					found[0][k] = v
+				}
+				found[0]["_id"] = id
+				addUpdate(i, &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1})
+			case upsert(model.Upsert):
+				id, err := m.insert(replacement)
+				if err != nil {
+					return nil, err
+				}
+				addUpdate(i, &mongo.UpdateResult{UpsertedCount: 1, UpsertedID: id})
+			}
+		case *mongo.DeleteOneModel:
+			res.DeletedCount += m.delete(model.Filter, false)
+		case *mongo.DeleteManyModel:
+			res.DeletedCount += m.delete(model.Filter, true)
+		default:
+			return nil, fmt.Errorf("unsupported write model %T", model)
+		}
+	}
+
+	return res, nil
+}
+
+// CreateOne generates the index name in the same way as the driver.
+func (m *memoryCollection) CreateOne(ctx context.Context, model mongo.IndexModel, opts ...*options.CreateIndexesOptions) (string, error) {
+	var name string
+	if model.Options != nil && model.Options.Name != nil {
+		name = *model.Options.Name
+	} else {
+		keys, ok := model.Keys.(bson.D)
+		if !ok {
+			return "", fmt.Errorf("unsupported keys type %T", model.Keys)
+		}
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s_%v", k.Key, k.Value))
+		}
+		name = strings.Join(parts, "_")
+	}
+
+	if _, ok := m.indexes[name]; ok {
+		return "", fmt.Errorf("index already exists with a different name: %s", name)
+	}
+	m.indexes[name] = model
+	return name, nil
+}
+
+func (m *memoryCollection) DropOne(ctx context.Context, name string, opts ...*options.DropIndexesOptions) (bson.Raw, error) {
+	if _, ok := m.indexes[name]; !ok {
+		return nil, fmt.Errorf("index not found with name [%s]", name)
+	}
+	delete(m.indexes, name)
+	return bson.Raw{}, nil
+}
@@ -3,6 +3,8 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -149,18 +151,8 @@ func (e *execution) insert(ctx context.Context, in *structpb.Struct) (*structpb.
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	data := inputStruct.Data
@@ -197,18 +189,8 @@ func (e *execution) insertMany(ctx context.Context, in *structpb.Struct) (*struc
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	var anyArrayData []any
@@ -252,18 +234,8 @@ func (e *execution) find(ctx context.Context, in *structpb.Struct) (*structpb.St
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	limit := inputStruct.Limit
@@ -352,18 +324,8 @@ func (e *execution) update(ctx context.Context, in *structpb.Struct) (*structpb.
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	updateFields := inputStruct.UpdateData
@@ -422,18 +384,8 @@ func (e *execution) delete(ctx context.Context, in *structpb.Struct) (*structpb.
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	var filter map[string]any
@@ -476,18 +428,8 @@ func (e *execution) dropCollection(ctx context.Context, in *structpb.Struct) (*s
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	err = e.client.collectionClient.Drop(ctx)
@@ -513,12 +455,8 @@ func (e *execution) dropDatabase(ctx context.Context, in *structpb.Struct) (*str
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
+	if err := e.initClients(ctx, inputStruct.DatabaseName, ""); err != nil {
+		return nil, err
 	}
 
 	err = e.client.databaseClient.Drop(ctx)
@@ -544,18 +482,8 @@ func (e *execution) createSearchIndex(ctx context.Context, in *structpb.Struct)
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	syntax := inputStruct.Syntax
@@ -592,18 +520,8 @@ func (e *execution) dropSearchIndex(ctx context.Context, in *structpb.Struct) (*
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	indexName := inputStruct.IndexName
@@ -633,18 +551,8 @@ func (e *execution) vectorSearch(ctx context.Context, in *structpb.Struct) (*str
 		return nil, err
 	}
 
-	client := newClient(ctx, e.Setup)
-
-	var db *mongo.Database
-	if e.client.databaseClient == nil {
-		db = client.Database(inputStruct.DatabaseName)
-		e.client.databaseClient = db
-	}
-
-	if e.client.collectionClient == nil {
-		collection := db.Collection(inputStruct.CollectionName)
-		e.client.collectionClient = collection
-		e.client.searchIndexClient = collection.SearchIndexes()
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
 	}
 
 	exact := inputStruct.Exact
@@ -757,3 +665,515 @@ func (e *execution) vectorSearch(ctx context.Context, in *structpb.Struct) (*str
 
 	return output, nil
 }
+
+type AggregateInput struct {
+	DatabaseName   string           `json:"database-name"`
+	CollectionName string           `json:"collection-name"`
+	Pipeline       []map[string]any `json:"pipeline"`
+	AllowDiskUse   bool             `json:"allow-disk-use"`
+}
+
+type AggregateOutput struct {
+	Status    string           `json:"status"`
+	Documents []map[string]any `json:"documents"`
+}
+
+type CountInput struct {
+	DatabaseName   string         `json:"database-name"`
+	CollectionName string         `json:"collection-name"`
+	Filter         map[string]any `json:"filter"`
+	Limit          int            `json:"limit"`
+}
+
+type CountOutput struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+type DistinctInput struct {
+	DatabaseName   string         `json:"database-name"`
+	CollectionName string         `json:"collection-name"`
+	Field          string         `json:"field"`
+	Filter         map[string]any `json:"filter"`
+}
+
+type DistinctOutput struct {
+	Status string `json:"status"`
+	Values []any  `json:"values"`
+}
+
+// BulkWriteOperation is a single write operation in a bulk write. Depending on
+// the type, the target documents are selected by ID or by filter.
+type BulkWriteOperation struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id"`
+	Filter     map[string]any `json:"filter"`
+	Data       map[string]any `json:"data"`
+	UpdateData map[string]any `json:"update-data"`
+	Upsert     bool           `json:"upsert"`
+}
+
+type BulkWriteInput struct {
+	DatabaseName   string               `json:"database-name"`
+	CollectionName string               `json:"collection-name"`
+	Operations     []BulkWriteOperation `json:"operations"`
+	Ordered        *bool                `json:"ordered"`
+}
+
+type BulkWriteOutput struct {
+	Status        string            `json:"status"`
+	InsertedCount int64             `json:"inserted-count"`
+	MatchedCount  int64             `json:"matched-count"`
+	ModifiedCount int64             `json:"modified-count"`
+	DeletedCount  int64             `json:"deleted-count"`
+	UpsertedCount int64             `json:"upserted-count"`
+	UpsertedIDs   map[string]string `json:"upserted-ids"`
+}
+
+const (
+	bulkWriteInsert     = "insert"
+	bulkWriteUpdateOne  = "update-one"
+	bulkWriteUpdateMany = "update-many"
+	bulkWriteReplaceOne = "replace-one"
+	bulkWriteDeleteOne  = "delete-one"
+	bulkWriteDeleteMany = "delete-many"
+)
+
+type IndexKey struct {
+	Field string `json:"field"`
+	Type  string `json:"type"`
+}
+
+type CreateIndexInput struct {
+	DatabaseName       string         `json:"database-name"`
+	CollectionName     string         `json:"collection-name"`
+	IndexName          string         `json:"index-name"`
+	Keys               []IndexKey     `json:"keys"`
+	Unique             bool           `json:"unique"`
+	Sparse             bool           `json:"sparse"`
+	ExpireAfterSeconds *int32         `json:"expire-after-seconds"`
+	PartialFilter      map[string]any `json:"partial-filter"`
+}
+
+type CreateIndexOutput struct {
+	Status    string `json:"status"`
+	IndexName string `json:"index-name"`
+}
+
+type DropIndexInput struct {
+	DatabaseName   string `json:"database-name"`
+	CollectionName string `json:"collection-name"`
+	IndexName      string `json:"index-name"`
+}
+
+type DropIndexOutput struct {
+	Status string `json:"status"`
+}
+
+// The pipeline stages are passed as they are, so any stage supported by the
+// server (e.g. $lookup, $group or $vectorSearch on Atlas) can be used.
+func (e *execution) aggregate(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct AggregateInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.Pipeline) == 0 {
+		return nil, fmt.Errorf("pipeline must have at least one stage")
+	}
+
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
+	}
+
+	pipeline := bson.A{}
+	for _, stage := range inputStruct.Pipeline {
+		pipeline = append(pipeline, normalizeNumbers(stage))
+	}
+
+	aggregateOptions := options.Aggregate()
+	if inputStruct.AllowDiskUse {
+		aggregateOptions.SetAllowDiskUse(true)
+	}
+
+	cursor, err := e.client.collectionClient.Aggregate(ctx, pipeline, aggregateOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	documents := []map[string]any{}
+	for cursor.Next(ctx) {
+		var document map[string]any
+		if err := cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	outputStruct := AggregateOutput{
+		Status:    fmt.Sprintf("Successfully aggregated %v documents", len(documents)),
+		Documents: documents,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Limit is optional (default is 0, no limit)
+func (e *execution) count(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CountInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
+	}
+
+	filter := inputStruct.Filter
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	countOptions := options.Count()
+	if inputStruct.Limit > 0 {
+		countOptions.SetLimit(int64(inputStruct.Limit))
+	}
+
+	count, err := e.client.collectionClient.CountDocuments(ctx, filter, countOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := CountOutput{
+		Status: fmt.Sprintf("Successfully counted %v documents", count),
+		Count:  count,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (e *execution) distinct(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct DistinctInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
+	}
+
+	filter := inputStruct.Filter
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	values, err := e.client.collectionClient.Distinct(ctx, inputStruct.Field, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if values == nil {
+		values = []any{}
+	}
+
+	outputStruct := DistinctOutput{
+		Status: fmt.Sprintf("Successfully found %v distinct values", len(values)),
+		Values: values,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Ordered is optional (default is true), an ordered bulk write stops at the
+// first failed operation.
+func (e *execution) bulkWrite(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct BulkWriteInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.Operations) == 0 {
+		return nil, fmt.Errorf("operations must have at least one element")
+	}
+
+	models := make([]mongo.WriteModel, 0, len(inputStruct.Operations))
+	for i, op := range inputStruct.Operations {
+		model, err := newWriteModel(op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		models = append(models, model)
+	}
+
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
+	}
+
+	bulkWriteOptions := options.BulkWrite()
+	if inputStruct.Ordered != nil {
+		bulkWriteOptions.SetOrdered(*inputStruct.Ordered)
+	}
+
+	res, err := e.client.collectionClient.BulkWrite(ctx, models, bulkWriteOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	upsertedIDs := make(map[string]string, len(res.UpsertedIDs))
+	for i, id := range res.UpsertedIDs {
+		upsertedIDs[fmt.Sprint(i)] = idToString(id)
+	}
+
+	outputStruct := BulkWriteOutput{
+		Status:        fmt.Sprintf("Successfully executed %v operations", len(models)),
+		InsertedCount: res.InsertedCount,
+		MatchedCount:  res.MatchedCount,
+		ModifiedCount: res.ModifiedCount,
+		DeletedCount:  res.DeletedCount,
+		UpsertedCount: res.UpsertedCount,
+		UpsertedIDs:   upsertedIDs,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func newWriteModel(op BulkWriteOperation) (mongo.WriteModel, error) {
+	if op.Type == bulkWriteInsert {
+		if op.Data == nil {
+			return nil, fmt.Errorf("data must be provided")
+		}
+		data := op.Data
+		if op.ID != "" {
+			data["_id"] = toID(op.ID)
+		}
+		return mongo.NewInsertOneModel().SetDocument(data), nil
+	}
+
+	if op.ID == "" && op.Filter == nil {
+		return nil, fmt.Errorf("either id or filter must be provided")
+	}
+
+	var filter map[string]any
+	if op.ID != "" {
+		filter = bson.M{"_id": toID(op.ID)}
+	} else {
+		filter = op.Filter
+	}
+
+	switch op.Type {
+	case bulkWriteUpdateOne, bulkWriteUpdateMany:
+		update, err := newUpdateDocument(op.UpdateData)
+		if err != nil {
+			return nil, err
+		}
+		if op.Type == bulkWriteUpdateOne {
+			return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(op.Upsert), nil
+		}
+		return mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(update).SetUpsert(op.Upsert), nil
+	case bulkWriteReplaceOne:
+		if op.Data == nil {
+			return nil, fmt.Errorf("data must be provided")
+		}
+		return mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(op.Data).SetUpsert(op.Upsert), nil
+	case bulkWriteDeleteOne:
+		return mongo.NewDeleteOneModel().SetFilter(filter), nil
+	case bulkWriteDeleteMany:
+		return mongo.NewDeleteManyModel().SetFilter(filter), nil
+	}
+
+	return nil, fmt.Errorf("unsupported operation type: %s", op.Type)
+}
+
+// newUpdateDocument builds an update document. Plain fields are set on the
+// matching documents, as in the update task, while update operators (e.g.
+// $inc or $push) are used as they are. Both can't be mixed.
+func newUpdateDocument(updateData map[string]any) (bson.M, error) {
+	if len(updateData) == 0 {
+		return nil, fmt.Errorf("no valid update operations found")
+	}
+
+	operators, fields := 0, 0
+	for key := range updateData {
+		if strings.HasPrefix(key, "$") {
+			operators++
+		} else {
+			fields++
+		}
+	}
+
+	switch {
+	case operators > 0 && fields > 0:
+		return nil, fmt.Errorf("update data can't mix update operators and fields")
+	case operators > 0:
+		return normalizeNumbers(updateData).(map[string]any), nil
+	}
+
+	return bson.M{"$set": updateData}, nil
+}
+
+func (e *execution) createIndex(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CreateIndexInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.Keys) == 0 {
+		return nil, fmt.Errorf("keys must have at least one element")
+	}
+
+	// The key order determines the sort order of compound indexes, so the
+	// keys are kept in an ordered document.
+	keys := bson.D{}
+	for _, key := range inputStruct.Keys {
+		var value any
+		switch key.Type {
+		case "", "ascending":
+			value = 1
+		case "descending":
+			value = -1
+		case "text", "hashed", "2dsphere":
+			value = key.Type
+		default:
+			return nil, fmt.Errorf("unsupported index type: %s", key.Type)
+		}
+		keys = append(keys, bson.E{Key: key.Field, Value: value})
+	}
+
+	indexOptions := options.Index()
+	if inputStruct.IndexName != "" {
+		indexOptions.SetName(inputStruct.IndexName)
+	}
+	if inputStruct.Unique {
+		indexOptions.SetUnique(true)
+	}
+	if inputStruct.Sparse {
+		indexOptions.SetSparse(true)
+	}
+	if inputStruct.ExpireAfterSeconds != nil {
+		// MongoDB only supports TTL on single-field indexes.
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("TTL indexes must have a single key")
+		}
+		indexOptions.SetExpireAfterSeconds(*inputStruct.ExpireAfterSeconds)
+	}
+	if inputStruct.PartialFilter != nil {
+		indexOptions.SetPartialFilterExpression(normalizeNumbers(inputStruct.PartialFilter))
+	}
+
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
+	}
+
+	name, err := e.client.indexClient.CreateOne(ctx, mongo.IndexModel{
+		Keys:    keys,
+		Options: indexOptions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := CreateIndexOutput{
+		Status:    "Successfully created 1 index",
+		IndexName: name,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (e *execution) dropIndex(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct DropIndexInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.initClients(ctx, inputStruct.DatabaseName, inputStruct.CollectionName); err != nil {
+		return nil, err
+	}
+
+	_, err = e.client.indexClient.DropOne(ctx, inputStruct.IndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := DropIndexOutput{
+		Status: "Successfully dropped 1 index",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// toID converts an ID to an ObjectID when it has the right format, as in the
+// other tasks.
+func toID(id string) any {
+	if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+		return objectID
+	}
+	return id
+}
+
+func idToString(id any) string {
+	if objectID, ok := id.(primitive.ObjectID); ok {
+		return objectID.Hex()
+	}
+	return fmt.Sprint(id)
+}
+
+// normalizeNumbers converts the integral numbers in a JSON-like value to
+// integers. Numbers in structpb values are always floating point, but some
+// stages and operators (e.g. $limit or $vectorSearch's numCandidates) require
+// integers.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[key] = normalizeNumbers(value)
+		}
+		return m
+	case []any:
+		a := make([]any, len(v))
+		for i, value := range v {
+			a[i] = normalizeNumbers(value)
+		}
+		return a
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+	}
+	return v
+}
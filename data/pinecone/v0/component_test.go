@@ -115,7 +115,7 @@ func TestComponent_Execute(t *testing.T) {
 			wantExec: upsertOutput{RecordsUpserted: 1},
 
 			wantClientPath: upsertPath,
-			wantClientReq:  upsertReq{Vectors: []vector{vectorA}, Namespace: namespace},
+			wantClientReq:  upsertReq{Vectors: []apiVector{vectorA.asAPIVector()}, Namespace: namespace},
 			clientResp:     upsertOK,
 		},
 		{
@@ -313,3 +313,218 @@ func TestComponent_Execute(t *testing.T) {
 
 	})
 }
+
+func TestComponent_ExecuteWithFakeIndex(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	cmp := Init(base.Component{})
+
+	sparse := &sparseValues{Indices: []int64{3, 42}, Values: []float64{0.5, 0.25}}
+	seed := []vector{
+		{ID: "A", Values: []float64{1, 0}, Metadata: map[string]any{"color": "pumpkin"}},
+		{ID: "B", Values: []float64{0, 1}, Metadata: map[string]any{"color": "cerulean"}, SparseValues: sparse},
+		{ID: "C", Values: []float64{0.5, 0.5}, Metadata: map[string]any{"color": "pumpkin"}},
+		{ID: "D", Values: []float64{0.2, 0.1}},
+		{ID: "E", Values: []float64{0.1, 0.2}},
+	}
+
+	run := func(c *qt.C, srv string, task string, in any) ([]byte, error) {
+		setup, err := structpb.NewStruct(map[string]any{
+			"api-key": pineconeKey,
+			"url":     srv,
+		})
+		c.Assert(err, qt.IsNil)
+
+		exec, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Setup:     setup,
+			Task:      task,
+		})
+		c.Assert(err, qt.IsNil)
+
+		pbIn, err := base.ConvertToStructpb(in)
+		c.Assert(err, qt.IsNil)
+
+		var out []byte
+		var jobErr error
+		ir, ow, eh, job := base.GenerateMockJob(c)
+		ir.ReadMock.Return(pbIn, nil)
+		ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+			out, err = json.Marshal(output.AsMap())
+			return err
+		})
+		eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+			jobErr = err
+		})
+
+		c.Assert(exec.Execute(ctx, []*base.Job{job}), qt.IsNil)
+		return out, jobErr
+	}
+
+	// seeded returns a fake index with the seed vectors upserted in batches
+	// of 2.
+	seeded := func(c *qt.C) (*fakeIndex, string) {
+		idx, srv := newFakeIndex(c)
+		got, err := run(c, srv.URL, taskBatchUpsert, batchUpsertInput{
+			Vectors:   seed,
+			Namespace: namespace,
+			BatchSize: 2,
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.JSONEquals, batchUpsertOutput{RecordsUpserted: 5, BatchCount: 3})
+		c.Check(idx.upsertSizes, qt.DeepEquals, []int{2, 2, 1})
+		return idx, srv.URL
+	}
+
+	c.Run("ok - batch upsert", func(c *qt.C) {
+		idx, _ := seeded(c)
+		c.Check(idx.namespaces[namespace], qt.HasLen, 5)
+		c.Check(idx.namespaces[namespace]["B"].SparseValues, qt.DeepEquals, sparse)
+	})
+
+	c.Run("nok - batch upsert without vectors", func(c *qt.C) {
+		_, srv := newFakeIndex(c)
+		_, err := run(c, srv.URL, taskBatchUpsert, batchUpsertInput{Namespace: namespace})
+		c.Check(err, qt.ErrorMatches, "vectors must have at least one element")
+	})
+
+	c.Run("ok - fetch", func(c *qt.C) {
+		_, srv := seeded(c)
+		got, err := run(c, srv, taskFetch, fetchInput{IDs: []string{"B", "missing", "A"}, Namespace: namespace})
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.JSONEquals, fetchOutput{Namespace: namespace, Vectors: []vector{seed[1], seed[0]}})
+	})
+
+	c.Run("ok - update metadata", func(c *qt.C) {
+		idx, srv := seeded(c)
+		got, err := run(c, srv, taskUpdate, updateInput{
+			ID:        "A",
+			Metadata:  map[string]any{"shade": "dark"},
+			Namespace: namespace,
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.JSONEquals, updateOutput{Status: "Successfully updated 1 vector"})
+
+		a := idx.namespaces[namespace]["A"]
+		c.Check(a.Metadata, qt.DeepEquals, map[string]any{"color": "pumpkin", "shade": "dark"})
+		c.Check(a.Values, qt.DeepEquals, seed[0].Values)
+	})
+
+	c.Run("nok - update without changes", func(c *qt.C) {
+		_, srv := newFakeIndex(c)
+		_, err := run(c, srv.URL, taskUpdate, updateInput{ID: "A", Namespace: namespace})
+		c.Check(err, qt.ErrorMatches, "one of values, sparse-values or metadata must be provided")
+	})
+
+	c.Run("nok - update missing vector", func(c *qt.C) {
+		_, srv := newFakeIndex(c)
+		_, err := run(c, srv.URL, taskUpdate, updateInput{ID: "Z", Values: []float64{1, 1}, Namespace: namespace})
+		c.Check(errmsg.Message(err), qt.Equals, "Pinecone responded with a 404 status code. Vector Z not found")
+	})
+
+	deleteCases := []struct {
+		name     string
+		in       deleteInput
+		wantResp deleteOutput
+		wantLeft int
+	}{
+		{
+			name:     "ok - delete by ids",
+			in:       deleteInput{IDs: []string{"A", "B"}, Namespace: namespace},
+			wantResp: deleteOutput{Status: "Successfully deleted 2 vectors"},
+			wantLeft: 3,
+		},
+		{
+			name:     "ok - delete by filter",
+			in:       deleteInput{Filter: map[string]any{"color": "pumpkin"}, Namespace: namespace},
+			wantResp: deleteOutput{Status: "Successfully deleted the vectors matching the filter"},
+			wantLeft: 3,
+		},
+		{
+			name:     "ok - delete all",
+			in:       deleteInput{DeleteAll: true, Namespace: namespace},
+			wantResp: deleteOutput{Status: "Successfully deleted all vectors"},
+			wantLeft: 0,
+		},
+	}
+	for _, tc := range deleteCases {
+		c.Run(tc.name, func(c *qt.C) {
+			idx, srv := seeded(c)
+			got, err := run(c, srv, taskDelete, tc.in)
+			c.Assert(err, qt.IsNil)
+			c.Check(got, qt.JSONEquals, tc.wantResp)
+			c.Check(idx.namespaces[namespace], qt.HasLen, tc.wantLeft)
+		})
+	}
+
+	c.Run("nok - delete with several criteria", func(c *qt.C) {
+		_, srv := newFakeIndex(c)
+		_, err := run(c, srv.URL, taskDelete, deleteInput{IDs: []string{"A"}, DeleteAll: true})
+		c.Check(err, qt.ErrorMatches, "exactly one of ids, filter or delete-all must be provided")
+	})
+
+	c.Run("ok - describe index stats", func(c *qt.C) {
+		_, srv := seeded(c)
+		_, err := run(c, srv, taskUpsert, upsertInput{vector: vector{ID: "F", Values: []float64{1, 1}}, Namespace: "other"})
+		c.Assert(err, qt.IsNil)
+
+		got, err := run(c, srv, taskDescribeIndexStats, describeIndexStatsInput{})
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.JSONEquals, describeIndexStatsOutput{
+			Namespaces: []namespaceStats{
+				{Namespace: "other", VectorCount: 1},
+				{Namespace: namespace, VectorCount: 5},
+			},
+			Dimension:        2,
+			IndexFullness:    0.5,
+			TotalVectorCount: 6,
+		})
+	})
+
+	c.Run("ok - hybrid query", func(c *qt.C) {
+		_, srv := seeded(c)
+		got, err := run(c, srv, taskQuery, queryInput{
+			Namespace:     namespace,
+			TopK:          1,
+			Vector:        []float64{0.1, 0.1},
+			SparseVector:  &sparseValues{Indices: []int64{42}, Values: []float64{4}},
+			IncludeValues: true,
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.JSONEquals, queryResp{
+			Namespace: namespace,
+			Matches: []match{
+				{vector: vector{ID: "B", Values: seed[1].Values, SparseValues: sparse}, Score: 1.1},
+			},
+		})
+	})
+}
+
+func TestSplitBatches(t *testing.T) {
+	c := qt.New(t)
+
+	vectors := make([]apiVector, 5)
+	for i := range vectors {
+		vectors[i] = apiVector{ID: fmt.Sprint(i), Values: []float64{0.5, 0.5}}
+	}
+	b, err := json.Marshal(vectors[0])
+	c.Assert(err, qt.IsNil)
+	size := len(b) + 1
+
+	c.Run("ok - split by count", func(c *qt.C) {
+		batches, err := splitBatches(vectors, 2, maxUpsertRequestBytes)
+		c.Assert(err, qt.IsNil)
+		c.Check(batches, qt.DeepEquals, [][]apiVector{vectors[:2], vectors[2:4], vectors[4:]})
+	})
+
+	c.Run("ok - split by size", func(c *qt.C) {
+		batches, err := splitBatches(vectors, maxUpsertBatchSize, upsertRequestOverhead+3*size)
+		c.Assert(err, qt.IsNil)
+		c.Check(batches, qt.DeepEquals, [][]apiVector{vectors[:3], vectors[3:]})
+	})
+
+	c.Run("nok - vector over the size limit", func(c *qt.C) {
+		_, err := splitBatches(vectors, maxUpsertBatchSize, upsertRequestOverhead+size-1)
+		c.Check(err, qt.ErrorMatches, "vector 0 exceeds the maximum request size of .* bytes")
+	})
+}
@@ -0,0 +1,217 @@
+package pinecone
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// fakeIndex is an in-memory stand-in for a Pinecone index. Metadata filters
+// only support equality on top-level fields.
+type fakeIndex struct {
+	mu         sync.Mutex
+	namespaces map[string]map[string]apiVector
+	dimension  int64
+
+	// upsertSizes records the number of vectors of each upsert request.
+	upsertSizes []int
+}
+
+func newFakeIndex(c *qt.C) (*fakeIndex, *httptest.Server) {
+	idx := &fakeIndex{namespaces: map[string]map[string]apiVector{}, dimension: 2}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(upsertPath, func(w http.ResponseWriter, r *http.Request) {
+		var req upsertReq
+		c.Assert(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+
+		idx.mu.Lock()
+		defer idx.mu.Unlock()
+		idx.upsertSizes = append(idx.upsertSizes, len(req.Vectors))
+		for _, v := range req.Vectors {
+			idx.namespace(req.Namespace)[v.ID] = v
+		}
+		writeJSON(w, upsertResp{RecordsUpserted: int64(len(req.Vectors))})
+	})
+	mux.HandleFunc(deletePath, func(w http.ResponseWriter, r *http.Request) {
+		var req deleteReq
+		c.Assert(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+
+		idx.mu.Lock()
+		defer idx.mu.Unlock()
+		ns := idx.namespace(req.Namespace)
+		for id, v := range ns {
+			if req.DeleteAll || contains(req.IDs, id) || (req.Filter != nil && matchesFilter(v, req.Filter)) {
+				delete(ns, id)
+			}
+		}
+		writeJSON(w, map[string]any{})
+	})
+	mux.HandleFunc(fetchPath, func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodGet)
+		q := r.URL.Query()
+
+		idx.mu.Lock()
+		defer idx.mu.Unlock()
+		resp := fetchResp{Namespace: q.Get("namespace"), Vectors: map[string]apiVector{}}
+		for _, id := range q["ids"] {
+			if v, ok := idx.namespace(resp.Namespace)[id]; ok {
+				resp.Vectors[id] = v
+			}
+		}
+		writeJSON(w, resp)
+	})
+	mux.HandleFunc(updatePath, func(w http.ResponseWriter, r *http.Request) {
+		var req updateReq
+		c.Assert(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+
+		idx.mu.Lock()
+		defer idx.mu.Unlock()
+		ns := idx.namespace(req.Namespace)
+		v, ok := ns[req.ID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Vector "+req.ID+" not found")
+			return
+		}
+		if req.Values != nil {
+			v.Values = req.Values
+		}
+		if req.SparseValues != nil {
+			v.SparseValues = req.SparseValues
+		}
+		if req.SetMetadata != nil {
+			// setMetadata overwrites the given fields and keeps the others.
+			metadata, _ := v.Metadata.(map[string]any)
+			if metadata == nil {
+				metadata = map[string]any{}
+			}
+			for k, val := range req.SetMetadata.(map[string]any) {
+				metadata[k] = val
+			}
+			v.Metadata = metadata
+		}
+		ns[req.ID] = v
+		writeJSON(w, map[string]any{})
+	})
+	mux.HandleFunc(describeIndexStatsPath, func(w http.ResponseWriter, r *http.Request) {
+		var req describeIndexStatsReq
+		c.Assert(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+
+		idx.mu.Lock()
+		defer idx.mu.Unlock()
+		resp := map[string]any{"dimension": idx.dimension, "indexFullness": 0.5}
+		namespaces := map[string]any{}
+		var total int64
+		for name, ns := range idx.namespaces {
+			var count int64
+			for _, v := range ns {
+				if req.Filter == nil || matchesFilter(v, req.Filter) {
+					count++
+				}
+			}
+			namespaces[name] = map[string]any{"vectorCount": count}
+			total += count
+		}
+		resp["namespaces"] = namespaces
+		resp["totalVectorCount"] = total
+		writeJSON(w, resp)
+	})
+	mux.HandleFunc(queryPath, func(w http.ResponseWriter, r *http.Request) {
+		var req queryReq
+		c.Assert(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+
+		idx.mu.Lock()
+		defer idx.mu.Unlock()
+		ns := idx.namespace(req.Namespace)
+		var matches []apiMatch
+		for _, v := range ns {
+			if req.Filter != nil && !matchesFilter(v, req.Filter) {
+				continue
+			}
+
+			// Hybrid score: dot product of the dense and the sparse values.
+			var score float64
+			for i := range req.Vector {
+				if i < len(v.Values) {
+					score += req.Vector[i] * v.Values[i]
+				}
+			}
+			if req.SparseVector != nil && v.SparseValues != nil {
+				for i, qi := range req.SparseVector.Indices {
+					for j, vi := range v.SparseValues.Indices {
+						if qi == vi {
+							score += req.SparseVector.Values[i] * v.SparseValues.Values[j]
+						}
+					}
+				}
+			}
+
+			m := apiMatch{apiVector: apiVector{ID: v.ID}, Score: score}
+			if req.IncludeValues {
+				m.Values, m.SparseValues = v.Values, v.SparseValues
+			}
+			if req.IncludeMetadata {
+				m.Metadata = v.Metadata
+			}
+			matches = append(matches, m)
+		}
+
+		sort.Slice(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+		if int64(len(matches)) > req.TopK {
+			matches = matches[:req.TopK]
+		}
+		writeJSON(w, queryAPIResp{Namespace: req.Namespace, Matches: matches})
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Api-Key") != pineconeKey {
+			writeError(w, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	c.Cleanup(srv.Close)
+
+	return idx, srv
+}
+
+func (idx *fakeIndex) namespace(name string) map[string]apiVector {
+	if _, ok := idx.namespaces[name]; !ok {
+		idx.namespaces[name] = map[string]apiVector{}
+	}
+	return idx.namespaces[name]
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesFilter(v apiVector, filter any) bool {
+	metadata, _ := v.Metadata.(map[string]any)
+	for k, want := range filter.(map[string]any) {
+		if metadata[k] != want {
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errBody{Msg: msg})
+}
@@ -3,6 +3,7 @@ package pinecone
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	_ "embed"
@@ -12,14 +13,24 @@ import (
 
 	"github.com/instill-ai/component/base"
 	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
 )
 
 const (
-	taskQuery  = "TASK_QUERY"
-	taskUpsert = "TASK_UPSERT"
-
-	upsertPath = "/vectors/upsert"
-	queryPath  = "/query"
+	taskQuery              = "TASK_QUERY"
+	taskUpsert             = "TASK_UPSERT"
+	taskBatchUpsert        = "TASK_BATCH_UPSERT"
+	taskDelete             = "TASK_DELETE"
+	taskFetch              = "TASK_FETCH"
+	taskUpdate             = "TASK_UPDATE"
+	taskDescribeIndexStats = "TASK_DESCRIBE_INDEX_STATS"
+
+	upsertPath             = "/vectors/upsert"
+	queryPath              = "/query"
+	deletePath             = "/vectors/delete"
+	fetchPath              = "/vectors/fetch"
+	updatePath             = "/vectors/update"
+	describeIndexStatsPath = "/describe_index_stats"
 )
 
 //go:embed config/definition.json
@@ -40,6 +51,9 @@ type component struct {
 
 type execution struct {
 	base.ComponentExecution
+
+	execute func(*structpb.Struct) (*structpb.Struct, error)
+	client  *httpclient.Client
 }
 
 func Init(bc base.Component) *component {
@@ -54,9 +68,34 @@ func Init(bc base.Component) *component {
 }
 
 func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
-	return &execution{
+	e := &execution{
 		ComponentExecution: x,
-	}, nil
+		client:             newClient(x.Setup, x.GetLogger()),
+	}
+
+	switch x.Task {
+	case taskQuery:
+		e.execute = e.query
+	case taskUpsert:
+		e.execute = e.upsert
+	case taskBatchUpsert:
+		e.execute = e.batchUpsert
+	case taskDelete:
+		e.execute = e.delete
+	case taskFetch:
+		e.execute = e.fetch
+	case taskUpdate:
+		e.execute = e.update
+	case taskDescribeIndexStats:
+		e.execute = e.describeIndexStats
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("not supported task: %s", x.Task),
+			fmt.Sprintf("%s task is not supported.", x.Task),
+		)
+	}
+
+	return e, nil
 }
 
 func newClient(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
@@ -80,79 +119,7 @@ func getURL(setup *structpb.Struct) string {
 }
 
 func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
-
-	req := newClient(e.Setup, e.GetLogger()).R()
-
-	for _, job := range jobs {
-		input, err := job.Input.Read(ctx)
-		if err != nil {
-			job.Error.Error(ctx, err)
-			continue
-		}
-		var output *structpb.Struct
-		switch e.Task {
-		case taskQuery:
-			inputStruct := queryInput{}
-			err := base.ConvertFromStructpb(input, &inputStruct)
-			if err != nil {
-				job.Error.Error(ctx, err)
-				continue
-			}
-
-			// Each query request can contain only one of the parameters
-			// vector, or id.
-			// Ref: https://docs.pinecone.io/reference/query
-			if inputStruct.ID != "" {
-				inputStruct.Vector = nil
-			}
-
-			resp := queryResp{}
-			req.SetResult(&resp).SetBody(inputStruct.asRequest())
-
-			if _, err := req.Post(queryPath); err != nil {
-				job.Error.Error(ctx, httpclient.WrapURLError(err))
-				continue
-			}
-
-			resp = resp.filterOutBelowThreshold(inputStruct.MinScore)
-
-			output, err = base.ConvertToStructpb(resp)
-			if err != nil {
-				job.Error.Error(ctx, err)
-				continue
-			}
-		case taskUpsert:
-			v := upsertInput{}
-			err := base.ConvertFromStructpb(input, &v)
-			if err != nil {
-				job.Error.Error(ctx, err)
-				continue
-			}
-
-			resp := upsertResp{}
-			req.SetResult(&resp).SetBody(upsertReq{
-				Vectors:   []vector{v.vector},
-				Namespace: v.Namespace,
-			})
-
-			if _, err := req.Post(upsertPath); err != nil {
-				job.Error.Error(ctx, httpclient.WrapURLError(err))
-				continue
-			}
-
-			output, err = base.ConvertToStructpb(upsertOutput(resp))
-			if err != nil {
-				job.Error.Error(ctx, err)
-				continue
-			}
-		}
-		err = job.Output.Write(ctx, output)
-		if err != nil {
-			job.Error.Error(ctx, err)
-			continue
-		}
-	}
-	return nil
+	return base.SequentialExecutor(ctx, jobs, e.execute)
 }
 
 func (c *component) Test(sysVars map[string]any, setup *structpb.Struct) error {
@@ -1,24 +1,26 @@
 package pinecone
 
 type queryInput struct {
-	Namespace       string      `json:"namespace"`
-	TopK            int64       `json:"top-k"`
-	Vector          []float64   `json:"vector"`
-	IncludeValues   bool        `json:"include-values"`
-	IncludeMetadata bool        `json:"include-metadata"`
-	ID              string      `json:"id"`
-	Filter          interface{} `json:"filter"`
-	MinScore        float64     `json:"min-score"`
+	Namespace       string        `json:"namespace"`
+	TopK            int64         `json:"top-k"`
+	Vector          []float64     `json:"vector"`
+	SparseVector    *sparseValues `json:"sparse-vector"`
+	IncludeValues   bool          `json:"include-values"`
+	IncludeMetadata bool          `json:"include-metadata"`
+	ID              string        `json:"id"`
+	Filter          interface{}   `json:"filter"`
+	MinScore        float64       `json:"min-score"`
 }
 
 type queryReq struct {
-	Namespace       string      `json:"namespace"`
-	TopK            int64       `json:"topK"`
-	Vector          []float64   `json:"vector,omitempty"`
-	IncludeValues   bool        `json:"includeValues"`
-	IncludeMetadata bool        `json:"includeMetadata"`
-	ID              string      `json:"id,omitempty"`
-	Filter          interface{} `json:"filter,omitempty"`
+	Namespace       string        `json:"namespace"`
+	TopK            int64         `json:"topK"`
+	Vector          []float64     `json:"vector,omitempty"`
+	SparseVector    *sparseValues `json:"sparseVector,omitempty"`
+	IncludeValues   bool          `json:"includeValues"`
+	IncludeMetadata bool          `json:"includeMetadata"`
+	ID              string        `json:"id,omitempty"`
+	Filter          interface{}   `json:"filter,omitempty"`
 }
 
 func (q queryInput) asRequest() queryReq {
@@ -26,6 +28,7 @@ func (q queryInput) asRequest() queryReq {
 		Namespace:       q.Namespace,
 		TopK:            q.TopK,
 		Vector:          q.Vector,
+		SparseVector:    q.SparseVector,
 		IncludeValues:   q.IncludeValues,
 		IncludeMetadata: q.IncludeMetadata,
 		ID:              q.ID,
@@ -59,9 +62,33 @@ type match struct {
 	Score float64 `json:"score"`
 }
 
+// queryAPIResp is the response of the query endpoint, whose matches use the
+// API field names.
+type queryAPIResp struct {
+	Namespace string     `json:"namespace"`
+	Matches   []apiMatch `json:"matches"`
+}
+
+type apiMatch struct {
+	apiVector
+	Score float64 `json:"score"`
+}
+
+func (r queryAPIResp) asOutput() queryResp {
+	matches := make([]match, len(r.Matches))
+	for i, m := range r.Matches {
+		matches[i] = match{vector: m.asVector(), Score: m.Score}
+	}
+
+	return queryResp{
+		Namespace: r.Namespace,
+		Matches:   matches,
+	}
+}
+
 type upsertReq struct {
-	Vectors   []vector `json:"vectors"`
-	Namespace string   `json:"namespace,omitempty"`
+	Vectors   []apiVector `json:"vectors"`
+	Namespace string      `json:"namespace,omitempty"`
 }
 
 type upsertInput struct {
@@ -70,9 +97,33 @@ type upsertInput struct {
 }
 
 type vector struct {
-	ID       string      `json:"id"`
-	Values   []float64   `json:"values,omitempty"`
-	Metadata interface{} `json:"metadata,omitempty"`
+	ID           string        `json:"id"`
+	Values       []float64     `json:"values,omitempty"`
+	SparseValues *sparseValues `json:"sparse-values,omitempty"`
+	Metadata     interface{}   `json:"metadata,omitempty"`
+}
+
+// sparseValues holds the non-zero dimensions of a sparse vector, used for
+// hybrid search.
+type sparseValues struct {
+	Indices []int64   `json:"indices"`
+	Values  []float64 `json:"values"`
+}
+
+// apiVector is the representation of a vector in the Pinecone API.
+type apiVector struct {
+	ID           string        `json:"id"`
+	Values       []float64     `json:"values,omitempty"`
+	SparseValues *sparseValues `json:"sparseValues,omitempty"`
+	Metadata     interface{}   `json:"metadata,omitempty"`
+}
+
+func (v vector) asAPIVector() apiVector {
+	return apiVector(v)
+}
+
+func (v apiVector) asVector() vector {
+	return vector(v)
 }
 
 type upsertResp struct {
@@ -83,6 +134,99 @@ type upsertOutput struct {
 	RecordsUpserted int64 `json:"upserted-count"`
 }
 
+type batchUpsertInput struct {
+	Vectors   []vector `json:"vectors"`
+	Namespace string   `json:"namespace"`
+	BatchSize int      `json:"batch-size"`
+}
+
+type batchUpsertOutput struct {
+	RecordsUpserted int64 `json:"upserted-count"`
+	BatchCount      int   `json:"batch-count"`
+}
+
+type deleteInput struct {
+	IDs       []string    `json:"ids"`
+	Filter    interface{} `json:"filter"`
+	Namespace string      `json:"namespace"`
+	DeleteAll bool        `json:"delete-all"`
+}
+
+type deleteReq struct {
+	IDs       []string    `json:"ids,omitempty"`
+	DeleteAll bool        `json:"deleteAll,omitempty"`
+	Namespace string      `json:"namespace,omitempty"`
+	Filter    interface{} `json:"filter,omitempty"`
+}
+
+type deleteOutput struct {
+	Status string `json:"status"`
+}
+
+type fetchInput struct {
+	IDs       []string `json:"ids"`
+	Namespace string   `json:"namespace"`
+}
+
+type fetchResp struct {
+	Namespace string               `json:"namespace"`
+	Vectors   map[string]apiVector `json:"vectors"`
+}
+
+type fetchOutput struct {
+	Namespace string   `json:"namespace"`
+	Vectors   []vector `json:"vectors"`
+}
+
+type updateInput struct {
+	ID           string        `json:"id"`
+	Values       []float64     `json:"values"`
+	SparseValues *sparseValues `json:"sparse-values"`
+	Metadata     interface{}   `json:"metadata"`
+	Namespace    string        `json:"namespace"`
+}
+
+type updateReq struct {
+	ID           string        `json:"id"`
+	Values       []float64     `json:"values,omitempty"`
+	SparseValues *sparseValues `json:"sparseValues,omitempty"`
+	SetMetadata  interface{}   `json:"setMetadata,omitempty"`
+	Namespace    string        `json:"namespace,omitempty"`
+}
+
+type updateOutput struct {
+	Status string `json:"status"`
+}
+
+type describeIndexStatsInput struct {
+	Filter interface{} `json:"filter"`
+}
+
+type describeIndexStatsReq struct {
+	Filter interface{} `json:"filter,omitempty"`
+}
+
+type describeIndexStatsResp struct {
+	Namespaces map[string]struct {
+		VectorCount int64 `json:"vectorCount"`
+	} `json:"namespaces"`
+	Dimension        int64   `json:"dimension"`
+	IndexFullness    float64 `json:"indexFullness"`
+	TotalVectorCount int64   `json:"totalVectorCount"`
+}
+
+type namespaceStats struct {
+	Namespace   string `json:"namespace"`
+	VectorCount int64  `json:"vector-count"`
+}
+
+type describeIndexStatsOutput struct {
+	Namespaces       []namespaceStats `json:"namespaces"`
+	Dimension        int64            `json:"dimension"`
+	IndexFullness    float64          `json:"index-fullness"`
+	TotalVectorCount int64            `json:"total-vector-count"`
+}
+
 type errBody struct {
 	Msg string `json:"message"`
 }
@@ -0,0 +1,278 @@
+package pinecone
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	// Pinecone limits upsert requests to 1000 vectors and 2MB.
+	// Ref: https://docs.pinecone.io/guides/data/upsert-data#upsert-limits
+	maxUpsertBatchSize    = 1000
+	maxUpsertRequestBytes = 2 << 20
+)
+
+func (e *execution) query(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := queryInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	// Each query request can contain only one of the parameters
+	// vector, or id.
+	// Ref: https://docs.pinecone.io/reference/query
+	if inputStruct.ID != "" {
+		inputStruct.Vector = nil
+		inputStruct.SparseVector = nil
+	}
+
+	resp := queryAPIResp{}
+	req := e.client.R().SetResult(&resp).SetBody(inputStruct.asRequest())
+	if _, err := req.Post(queryPath); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	return base.ConvertToStructpb(resp.asOutput().filterOutBelowThreshold(inputStruct.MinScore))
+}
+
+func (e *execution) upsert(in *structpb.Struct) (*structpb.Struct, error) {
+	v := upsertInput{}
+	if err := base.ConvertFromStructpb(in, &v); err != nil {
+		return nil, err
+	}
+
+	resp := upsertResp{}
+	req := e.client.R().SetResult(&resp).SetBody(upsertReq{
+		Vectors:   []apiVector{v.vector.asAPIVector()},
+		Namespace: v.Namespace,
+	})
+
+	if _, err := req.Post(upsertPath); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	return base.ConvertToStructpb(upsertOutput(resp))
+}
+
+// batchUpsert splits the vectors in as many requests as needed to stay under
+// Pinecone's upsert limits.
+func (e *execution) batchUpsert(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := batchUpsertInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.Vectors) == 0 {
+		return nil, fmt.Errorf("vectors must have at least one element")
+	}
+
+	batchSize := inputStruct.BatchSize
+	if batchSize <= 0 || batchSize > maxUpsertBatchSize {
+		batchSize = maxUpsertBatchSize
+	}
+
+	vectors := make([]apiVector, len(inputStruct.Vectors))
+	for i, v := range inputStruct.Vectors {
+		vectors[i] = v.asAPIVector()
+	}
+
+	batches, err := splitBatches(vectors, batchSize, maxUpsertRequestBytes-len(inputStruct.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	output := batchUpsertOutput{BatchCount: len(batches)}
+	for i, batch := range batches {
+		resp := upsertResp{}
+		req := e.client.R().SetResult(&resp).SetBody(upsertReq{
+			Vectors:   batch,
+			Namespace: inputStruct.Namespace,
+		})
+
+		if _, err := req.Post(upsertPath); err != nil {
+			return nil, fmt.Errorf("upserting batch %d (%d vectors upserted): %w", i, output.RecordsUpserted, httpclient.WrapURLError(err))
+		}
+		output.RecordsUpserted += resp.RecordsUpserted
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+// upsertRequestOverhead is a generous estimation of the size of an upsert
+// request body without the vectors and the namespace.
+const upsertRequestOverhead = 64
+
+// splitBatches groups the vectors in batches of at most maxCount vectors
+// whose JSON encoding is at most maxBytes long.
+func splitBatches(vectors []apiVector, maxCount, maxBytes int) ([][]apiVector, error) {
+	var batches [][]apiVector
+	var batch []apiVector
+	batchBytes := upsertRequestOverhead
+
+	for _, v := range vectors {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		// Vectors are separated by a comma.
+		size := len(b) + 1
+		if upsertRequestOverhead+size > maxBytes {
+			return nil, fmt.Errorf("vector %s exceeds the maximum request size of %d bytes", v.ID, maxBytes)
+		}
+
+		if len(batch) == maxCount || batchBytes+size > maxBytes {
+			batches = append(batches, batch)
+			batch, batchBytes = nil, upsertRequestOverhead
+		}
+
+		batch = append(batch, v)
+		batchBytes += size
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+func (e *execution) delete(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := deleteInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	// The deletion criteria are mutually exclusive.
+	// Ref: https://docs.pinecone.io/reference/api/data-plane/delete
+	criteria := 0
+	if len(inputStruct.IDs) > 0 {
+		criteria++
+	}
+	if inputStruct.Filter != nil {
+		criteria++
+	}
+	if inputStruct.DeleteAll {
+		criteria++
+	}
+	if criteria != 1 {
+		return nil, fmt.Errorf("exactly one of ids, filter or delete-all must be provided")
+	}
+
+	req := e.client.R().SetBody(deleteReq{
+		IDs:       inputStruct.IDs,
+		DeleteAll: inputStruct.DeleteAll,
+		Namespace: inputStruct.Namespace,
+		Filter:    inputStruct.Filter,
+	})
+
+	if _, err := req.Post(deletePath); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	status := fmt.Sprintf("Successfully deleted %d vectors", len(inputStruct.IDs))
+	switch {
+	case inputStruct.DeleteAll:
+		status = "Successfully deleted all vectors"
+	case inputStruct.Filter != nil:
+		status = "Successfully deleted the vectors matching the filter"
+	}
+
+	return base.ConvertToStructpb(deleteOutput{Status: status})
+}
+
+func (e *execution) fetch(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := fetchInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.IDs) == 0 {
+		return nil, fmt.Errorf("ids must have at least one element")
+	}
+
+	params := url.Values{"ids": inputStruct.IDs}
+	if inputStruct.Namespace != "" {
+		params.Set("namespace", inputStruct.Namespace)
+	}
+
+	resp := fetchResp{}
+	req := e.client.R().SetResult(&resp).SetQueryParamsFromValues(params)
+	if _, err := req.Get(fetchPath); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	// The vectors are returned in the order of the requested IDs. IDs that
+	// don't exist are omitted.
+	output := fetchOutput{
+		Namespace: resp.Namespace,
+		Vectors:   make([]vector, 0, len(resp.Vectors)),
+	}
+	for _, id := range inputStruct.IDs {
+		if v, ok := resp.Vectors[id]; ok {
+			output.Vectors = append(output.Vectors, v.asVector())
+		}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+func (e *execution) update(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := updateInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.Values) == 0 && inputStruct.SparseValues == nil && inputStruct.Metadata == nil {
+		return nil, fmt.Errorf("one of values, sparse-values or metadata must be provided")
+	}
+
+	req := e.client.R().SetBody(updateReq{
+		ID:           inputStruct.ID,
+		Values:       inputStruct.Values,
+		SparseValues: inputStruct.SparseValues,
+		SetMetadata:  inputStruct.Metadata,
+		Namespace:    inputStruct.Namespace,
+	})
+
+	if _, err := req.Post(updatePath); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	return base.ConvertToStructpb(updateOutput{Status: "Successfully updated 1 vector"})
+}
+
+func (e *execution) describeIndexStats(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := describeIndexStatsInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	resp := describeIndexStatsResp{}
+	req := e.client.R().SetResult(&resp).SetBody(describeIndexStatsReq(inputStruct))
+	if _, err := req.Post(describeIndexStatsPath); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	output := describeIndexStatsOutput{
+		Namespaces:       make([]namespaceStats, 0, len(resp.Namespaces)),
+		Dimension:        resp.Dimension,
+		IndexFullness:    resp.IndexFullness,
+		TotalVectorCount: resp.TotalVectorCount,
+	}
+	for ns, stats := range resp.Namespaces {
+		output.Namespaces = append(output.Namespaces, namespaceStats{Namespace: ns, VectorCount: stats.VectorCount})
+	}
+	sort.Slice(output.Namespaces, func(i, j int) bool {
+		return output.Namespaces[i].Namespace < output.Namespaces[j].Namespace
+	})
+
+	return base.ConvertToStructpb(output)
+}
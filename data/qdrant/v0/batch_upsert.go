@@ -6,6 +6,7 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
 )
 
 const (
@@ -17,11 +18,15 @@ type BatchUpsertInput struct {
 	ArrayID        []string         `json:"array-id"`
 	ArrayMetadata  []map[string]any `json:"array-metadata"`
 	ArrayVector    [][]float64      `json:"array-vector"`
+	VectorName     string           `json:"vector-name"`
 	Ordering       string           `json:"ordering"`
+
+	batchupsert.Options
 }
 
 type BatchUpsertOutput struct {
-	Status string `json:"status"`
+	Status  string               `json:"status"`
+	Results []batchupsert.Result `json:"results"`
 }
 
 type BatchUpsertReq struct {
@@ -30,7 +35,7 @@ type BatchUpsertReq struct {
 
 type Batch struct {
 	IDs      []string         `json:"ids"`
-	Vectors  [][]float64      `json:"vectors"`
+	Vectors  any              `json:"vectors"`
 	Payloads []map[string]any `json:"payloads"`
 }
 
@@ -52,30 +57,50 @@ func (e *execution) batchUpsert(in *structpb.Struct) (*structpb.Struct, error) {
 		return nil, err
 	}
 
-	resp := BatchUpsertResp{}
-
-	reqParams := BatchUpsertReq{
-		Batch: Batch{
-			IDs:      inputStruct.ArrayID,
-			Vectors:  inputStruct.ArrayVector,
-			Payloads: inputStruct.ArrayMetadata,
-		},
+	n := len(inputStruct.ArrayVector)
+	if inputStruct.ArrayMetadata != nil && len(inputStruct.ArrayMetadata) != n {
+		return nil, fmt.Errorf("array-metadata and array-vector must have the same length")
 	}
 
-	req := e.client.R().SetBody(reqParams).SetResult(&resp)
-
-	res, err := req.Put(fmt.Sprintf(batchUpsertPath, inputStruct.CollectionName, inputStruct.Ordering))
-
+	// Qdrant only accepts UUIDs and unsigned integers as IDs, which the
+	// content-based IDs are.
+	ids, err := batchupsert.FillIDs(inputStruct.ArrayID, n, func(i int) []any {
+		return []any{inputStruct.ArrayVector[i], batchupsert.At(inputStruct.ArrayMetadata, i)}
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if res.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to batch upsert points: %s", res.String())
+	results, err := batchupsert.Upsert(ids, inputStruct.Options, func(start, end int) error {
+		resp := BatchUpsertResp{}
+
+		reqParams := BatchUpsertReq{
+			Batch: Batch{
+				IDs:      ids[start:end],
+				Vectors:  namedVectors(inputStruct.ArrayVector[start:end], inputStruct.VectorName),
+				Payloads: batchupsert.Slice(inputStruct.ArrayMetadata, start, end),
+			},
+		}
+
+		req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+		res, err := req.Put(fmt.Sprintf(batchUpsertPath, inputStruct.CollectionName, inputStruct.Ordering))
+		if err != nil {
+			return err
+		}
+
+		if res.StatusCode() != 200 {
+			return fmt.Errorf("failed to batch upsert points: %s", res.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	outputStruct := BatchUpsertOutput{
-		Status: fmt.Sprintf("Successfully batch upserted %d points", len(inputStruct.ArrayVector)),
+		Status:  batchupsert.Status("batch upserted", "points", results),
+		Results: results,
 	}
 
 	output, err := base.ConvertToStructpb(outputStruct)
@@ -15,6 +15,7 @@ import (
 	qt "github.com/frankban/quicktest"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
 	"github.com/instill-ai/component/internal/util/httpclient"
 )
 
@@ -87,6 +88,47 @@ func TestComponent_ExecuteVectorSearchTask(t *testing.T) {
 				]
 			}`,
 		},
+		{
+			name: "ok to vector search a named vector",
+			input: VectorSearchInput{
+				CollectionName: "mock-collection",
+				Vector:         []float64{0.1, 0.2},
+				VectorName:     "image",
+				Limit:          1,
+			},
+			wantResp: VectorSearchOutput{
+				Status: "Successfully vector searched 1 points",
+				Result: Result{
+					Ids: []string{"42"},
+					Points: []map[string]any{
+						{"id": "42", "score": 0.9, "name": "a", "vector": []float64{0.1, 0.2}},
+					},
+					Vectors:  [][]float64{{0.1, 0.2}},
+					Metadata: []map[string]any{{"name": "a"}},
+				},
+			},
+			wantClientPath: fmt.Sprintf(vectorSearchPath, "mock-collection"),
+			wantClientReq: VectorSearchReq{
+				Vector:     NamedVector{Name: "image", Vector: []float64{0.1, 0.2}},
+				Limit:      1,
+				Payloads:   true,
+				Filter:     map[string]any{},
+				Params:     map[string]any{},
+				WithVector: []string{"image"},
+			},
+			clientResp: `{
+				"time": 0.1,
+				"status": "ok",
+				"result": [
+					{
+						"id": 42,
+						"score": 0.9,
+						"payload": {"name": "a"},
+						"vector": {"image": [0.1, 0.2]}
+					}
+				]
+			}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -366,6 +408,12 @@ func TestComponent_ExecuteBatchUpsertTask(t *testing.T) {
 	bc := base.Component{Logger: zap.NewNop()}
 	cmp := Init(bc)
 
+	// Points without an ID get one derived from their content.
+	id1, err := batchupsert.ContentID([]float64{0.1, 0.2}, map[string]any{"name": "a"})
+	c.Assert(err, qt.IsNil)
+	id2, err := batchupsert.ContentID([]float64{0.2, 0.3}, map[string]any{"name": "b"})
+	c.Assert(err, qt.IsNil)
+
 	testcases := []struct {
 		name     string
 		input    BatchUpsertInput
@@ -388,11 +436,15 @@ func TestComponent_ExecuteBatchUpsertTask(t *testing.T) {
 			},
 			wantResp: BatchUpsertOutput{
 				Status: "Successfully batch upserted 2 points",
+				Results: []batchupsert.Result{
+					{Index: 0, ID: id1, Success: true},
+					{Index: 1, ID: id2, Success: true},
+				},
 			},
 			wantClientPath: fmt.Sprintf(batchUpsertPath, "mock-collection", "weak"),
 			wantClientReq: BatchUpsertReq{
 				Batch: Batch{
-					IDs:     nil,
+					IDs:     []string{id1, id2},
 					Vectors: [][]float64{{0.1, 0.2}, {0.2, 0.3}},
 					Payloads: []map[string]any{
 						{"name": "a"},
@@ -654,3 +706,333 @@ func TestComponent_ExecuteDeleteCollectionTask(t *testing.T) {
 		})
 	}
 }
+
+func TestComponent_ExecutePointTasks(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	cmp := Init(bc)
+
+	okResp := `{"time": 0.1, "status": "ok", "result": {"status": "completed", "operation_id": 1}}`
+
+	testcases := []struct {
+		name     string
+		task     string
+		input    any
+		wantResp any
+		wantErr  string
+
+		wantClientMethod string
+		wantClientPath   string
+		wantClientReq    any
+		clientResp       string
+	}{
+		{
+			name: "ok to upsert a named vector",
+			task: TaskUpsert,
+			input: UpsertInput{
+				CollectionName: "mock-collection",
+				ID:             "a",
+				Vector:         []float64{0.1, 0.2},
+				VectorName:     "image",
+				Ordering:       "weak",
+			},
+			wantResp: UpsertOutput{Status: "Successfully upserted 1 point"},
+
+			wantClientMethod: http.MethodPut,
+			wantClientPath:   fmt.Sprintf(batchUpsertPath, "mock-collection", "weak"),
+			wantClientReq: map[string]any{
+				"batch": map[string]any{
+					"ids":      []string{"a"},
+					"vectors":  map[string]any{"image": [][]float64{{0.1, 0.2}}},
+					"payloads": []any{nil},
+				},
+			},
+			clientResp: okResp,
+		},
+		{
+			name: "ok to get points",
+			task: TaskGetPoints,
+			input: GetPointsInput{
+				CollectionName: "mock-collection",
+				IDs:            []string{"18446744073709551615", "5c56c793-69f3-4fbf-87e6-c4bf54c28c26"},
+				WithVector:     true,
+			},
+			wantResp: GetPointsOutput{
+				Status: "Successfully got 2 points",
+				Points: []Point{
+					{ID: "18446744073709551615", Payload: map[string]any{"name": "a"}, Vector: []float64{0.1, 0.2}},
+					{
+						ID:           "5c56c793-69f3-4fbf-87e6-c4bf54c28c26",
+						Payload:      map[string]any{},
+						NamedVectors: map[string]any{"image": []any{0.3, 0.4}},
+					},
+				},
+			},
+
+			wantClientMethod: http.MethodPost,
+			wantClientPath:   fmt.Sprintf(getPointsPath, "mock-collection"),
+			wantClientReq: map[string]any{
+				"ids":          []any{uint64(18446744073709551615), "5c56c793-69f3-4fbf-87e6-c4bf54c28c26"},
+				"with_payload": true,
+				"with_vector":  true,
+			},
+			clientResp: `{
+				"time": 0.1,
+				"status": "ok",
+				"result": [
+					{"id": 18446744073709551615, "payload": {"name": "a"}, "vector": [0.1, 0.2]},
+					{"id": "5c56c793-69f3-4fbf-87e6-c4bf54c28c26", "vector": {"image": [0.3, 0.4]}}
+				]
+			}`,
+		},
+		{
+			name:    "nok - get points without IDs",
+			task:    TaskGetPoints,
+			input:   GetPointsInput{CollectionName: "mock-collection"},
+			wantErr: "ids must have at least one element",
+		},
+		{
+			name: "ok to scroll points",
+			task: TaskScroll,
+			input: ScrollInput{
+				CollectionName: "mock-collection",
+				Filter:         map[string]any{"must": []any{map[string]any{"key": "name", "match": map[string]any{"value": "a"}}}},
+				Limit:          1,
+				Offset:         "3",
+				Payloads:       []string{"name"},
+			},
+			wantResp: ScrollOutput{
+				Status:     "Successfully scrolled 1 points",
+				Points:     []Point{{ID: "3", Payload: map[string]any{"name": "a"}}},
+				NextOffset: "9007199254740993",
+			},
+
+			wantClientMethod: http.MethodPost,
+			wantClientPath:   fmt.Sprintf(scrollPath, "mock-collection"),
+			wantClientReq: map[string]any{
+				"filter":       map[string]any{"must": []any{map[string]any{"key": "name", "match": map[string]any{"value": "a"}}}},
+				"limit":        1,
+				"offset":       3,
+				"with_payload": []string{"name"},
+				"with_vector":  false,
+			},
+			clientResp: `{
+				"time": 0.1,
+				"status": "ok",
+				"result": {
+					"points": [{"id": 3, "payload": {"name": "a"}}],
+					"next_page_offset": 9007199254740993
+				}
+			}`,
+		},
+		{
+			name: "ok to scroll the last page",
+			task: TaskScroll,
+			input: ScrollInput{
+				CollectionName: "mock-collection",
+			},
+			wantResp: ScrollOutput{
+				Status: "Successfully scrolled 0 points",
+				Points: []Point{},
+			},
+
+			wantClientMethod: http.MethodPost,
+			wantClientPath:   fmt.Sprintf(scrollPath, "mock-collection"),
+			wantClientReq: map[string]any{
+				"with_payload": true,
+				"with_vector":  false,
+			},
+			clientResp: `{"time": 0.1, "status": "ok", "result": {"points": [], "next_page_offset": null}}`,
+		},
+		{
+			name: "ok to count points",
+			task: TaskCount,
+			input: CountInput{
+				CollectionName: "mock-collection",
+				Filter:         map[string]any{"must_not": []any{map[string]any{"is_empty": map[string]any{"key": "name"}}}},
+			},
+			wantResp: CountOutput{
+				Status: "Successfully counted 7 points",
+				Count:  7,
+			},
+
+			wantClientMethod: http.MethodPost,
+			wantClientPath:   fmt.Sprintf(countPath, "mock-collection"),
+			wantClientReq: map[string]any{
+				"filter": map[string]any{"must_not": []any{map[string]any{"is_empty": map[string]any{"key": "name"}}}},
+				"exact":  true,
+			},
+			clientResp: `{"time": 0.1, "status": "ok", "result": {"count": 7}}`,
+		},
+		{
+			name: "ok to set payload",
+			task: TaskSetPayload,
+			input: SetPayloadInput{
+				CollectionName: "mock-collection",
+				IDs:            []string{"1"},
+				Payload:        map[string]any{"name": "b"},
+				Ordering:       "weak",
+			},
+			wantResp: SetPayloadOutput{Status: "Successfully set payload"},
+
+			wantClientMethod: http.MethodPost,
+			wantClientPath:   fmt.Sprintf(setPayloadPath, "mock-collection", "weak"),
+			wantClientReq: map[string]any{
+				"payload": map[string]any{"name": "b"},
+				"points":  []any{1},
+			},
+			clientResp: okResp,
+		},
+		{
+			name: "ok to overwrite payload",
+			task: TaskSetPayload,
+			input: SetPayloadInput{
+				CollectionName: "mock-collection",
+				Filter:         map[string]any{"must": []any{}},
+				Payload:        map[string]any{"name": "b"},
+				Overwrite:      true,
+				Ordering:       "weak",
+			},
+			wantResp: SetPayloadOutput{Status: "Successfully set payload"},
+
+			wantClientMethod: http.MethodPut,
+			wantClientPath:   fmt.Sprintf(setPayloadPath, "mock-collection", "weak"),
+			wantClientReq: map[string]any{
+				"payload": map[string]any{"name": "b"},
+				"filter":  map[string]any{"must": []any{}},
+			},
+			clientResp: okResp,
+		},
+		{
+			name: "nok - set payload without point selector",
+			task: TaskSetPayload,
+			input: SetPayloadInput{
+				CollectionName: "mock-collection",
+				Payload:        map[string]any{"name": "b"},
+			},
+			wantErr: "either ids or filter is required",
+		},
+		{
+			name: "ok to delete payload",
+			task: TaskDeletePayload,
+			input: DeletePayloadInput{
+				CollectionName: "mock-collection",
+				IDs:            []string{"1", "2"},
+				Keys:           []string{"name"},
+				Ordering:       "weak",
+			},
+			wantResp: DeletePayloadOutput{Status: "Successfully deleted 1 payload keys"},
+
+			wantClientMethod: http.MethodPost,
+			wantClientPath:   fmt.Sprintf(deletePayloadPath, "mock-collection", "weak"),
+			wantClientReq: map[string]any{
+				"keys":   []string{"name"},
+				"points": []any{1, 2},
+			},
+			clientResp: okResp,
+		},
+		{
+			name: "ok to create payload index",
+			task: TaskCreatePayloadIndex,
+			input: CreatePayloadIndexInput{
+				CollectionName: "mock-collection",
+				FieldName:      "name",
+				FieldSchema:    "keyword",
+				Ordering:       "weak",
+			},
+			wantResp: CreatePayloadIndexOutput{Status: "Successfully created 1 payload index on name"},
+
+			wantClientMethod: http.MethodPut,
+			wantClientPath:   fmt.Sprintf(createPayloadIndexPath, "mock-collection", "weak"),
+			wantClientReq: map[string]any{
+				"field_name":   "name",
+				"field_schema": "keyword",
+			},
+			clientResp: okResp,
+		},
+		{
+			name: "ok to create payload index with params",
+			task: TaskCreatePayloadIndex,
+			input: CreatePayloadIndexInput{
+				CollectionName: "mock-collection",
+				FieldName:      "description",
+				FieldSchema:    "text",
+				SchemaParams:   map[string]any{"tokenizer": "word", "lowercase": true},
+				Ordering:       "weak",
+			},
+			wantResp: CreatePayloadIndexOutput{Status: "Successfully created 1 payload index on description"},
+
+			wantClientMethod: http.MethodPut,
+			wantClientPath:   fmt.Sprintf(createPayloadIndexPath, "mock-collection", "weak"),
+			wantClientReq: map[string]any{
+				"field_name": "description",
+				"field_schema": map[string]any{
+					"type":      "text",
+					"tokenizer": "word",
+					"lowercase": true,
+				},
+			},
+			clientResp: okResp,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, tc.wantClientMethod)
+				c.Check(r.URL.RequestURI(), qt.Equals, tc.wantClientPath)
+
+				c.Check(r.Header.Get("Content-Type"), qt.Equals, httpclient.MIMETypeJSON)
+				c.Check(r.Header.Get("api-key"), qt.Equals, "mock-api-key")
+
+				c.Assert(r.Body, qt.IsNotNil)
+				defer r.Body.Close()
+
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, tc.wantClientReq)
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprintln(w, tc.clientResp)
+			})
+
+			qdrantServer := httptest.NewServer(h)
+			c.Cleanup(qdrantServer.Close)
+
+			setup, _ := structpb.NewStruct(map[string]any{
+				"api-key": "mock-api-key",
+				"url":     qdrantServer.URL,
+			})
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				if tc.wantErr != "" {
+					c.Check(err, qt.ErrorMatches, tc.wantErr)
+					return
+				}
+				c.Errorf("unexpected error: %v", err)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package qdrant
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	countPath = "/collections/%s/points/count"
+)
+
+type CountInput struct {
+	CollectionName string         `json:"collection-name"`
+	Filter         map[string]any `json:"filter"`
+	Exact          *bool          `json:"exact"`
+}
+
+type CountOutput struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+type CountReq struct {
+	Filter map[string]any `json:"filter,omitempty"`
+	Exact  bool           `json:"exact"`
+}
+
+type CountResp struct {
+	Time   float64 `json:"time"`
+	Status string  `json:"status"`
+	Result struct {
+		Count int `json:"count"`
+	} `json:"result"`
+}
+
+// exact is optional (default is true), an approximate count is faster on
+// large collections.
+func (e *execution) count(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CountInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := CountResp{}
+
+	reqParams := CountReq{
+		Filter: inputStruct.Filter,
+		Exact:  inputStruct.Exact == nil || *inputStruct.Exact,
+	}
+
+	req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+	res, err := req.Post(fmt.Sprintf(countPath, inputStruct.CollectionName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to count points: %s", res.String())
+	}
+
+	outputStruct := CountOutput{
+		Status: fmt.Sprintf("Successfully counted %d points", resp.Result.Count),
+		Count:  resp.Result.Count,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,83 @@
+package qdrant
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	createPayloadIndexPath = "/collections/%s/index?wait=true&ordering=%s"
+)
+
+type CreatePayloadIndexInput struct {
+	CollectionName string         `json:"collection-name"`
+	FieldName      string         `json:"field-name"`
+	FieldSchema    string         `json:"field-schema"`
+	SchemaParams   map[string]any `json:"schema-params"`
+	Ordering       string         `json:"ordering"`
+}
+
+type CreatePayloadIndexOutput struct {
+	Status string `json:"status"`
+}
+
+type CreatePayloadIndexReq struct {
+	FieldName   string `json:"field_name"`
+	FieldSchema any    `json:"field_schema"`
+}
+
+// schema-params is optional, it holds the parameters of the index type (e.g.
+// the tokenizer of a text index).
+func (e *execution) createPayloadIndex(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CreatePayloadIndexInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputStruct.FieldName == "" || inputStruct.FieldSchema == "" {
+		return nil, fmt.Errorf("field-name and field-schema are required")
+	}
+
+	var fieldSchema any = inputStruct.FieldSchema
+	if len(inputStruct.SchemaParams) > 0 {
+		params := map[string]any{"type": inputStruct.FieldSchema}
+		for k, v := range inputStruct.SchemaParams {
+			params[k] = v
+		}
+		fieldSchema = params
+	}
+
+	resp := PayloadResp{}
+
+	reqParams := CreatePayloadIndexReq{
+		FieldName:   inputStruct.FieldName,
+		FieldSchema: fieldSchema,
+	}
+
+	req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+	res, err := req.Put(fmt.Sprintf(createPayloadIndexPath, inputStruct.CollectionName, inputStruct.Ordering))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to create payload index: %s", res.String())
+	}
+
+	outputStruct := CreatePayloadIndexOutput{
+		Status: fmt.Sprintf("Successfully created 1 payload index on %s", inputStruct.FieldName),
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,87 @@
+package qdrant
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	getPointsPath = "/collections/%s/points"
+)
+
+type GetPointsInput struct {
+	CollectionName string   `json:"collection-name"`
+	IDs            []string `json:"ids"`
+	Payloads       []string `json:"payloads"`
+	WithVector     bool     `json:"with-vector"`
+}
+
+type GetPointsOutput struct {
+	Status string  `json:"status"`
+	Points []Point `json:"points"`
+}
+
+type GetPointsReq struct {
+	IDs         []any `json:"ids"`
+	WithPayload any   `json:"with_payload"`
+	WithVector  bool  `json:"with_vector"`
+}
+
+type GetPointsResp struct {
+	Time   float64     `json:"time"`
+	Status string      `json:"status"`
+	Result []PointResp `json:"result"`
+}
+
+// IDs that don't exist in the collection are omitted from the output.
+func (e *execution) getPoints(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct GetPointsInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.IDs) == 0 {
+		return nil, fmt.Errorf("ids must have at least one element")
+	}
+
+	resp := GetPointsResp{}
+
+	reqParams := GetPointsReq{
+		IDs:         parseIDs(inputStruct.IDs),
+		WithPayload: withPayload(inputStruct.Payloads),
+		WithVector:  inputStruct.WithVector,
+	}
+
+	req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+	res, err := req.Post(fmt.Sprintf(getPointsPath, inputStruct.CollectionName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to get points: %s", res.String())
+	}
+
+	points, err := toPoints(resp.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := GetPointsOutput{
+		Status: fmt.Sprintf("Successfully got %d points", len(points)),
+		Points: points,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -16,12 +16,18 @@ import (
 )
 
 const (
-	TaskVectorSearch     = "TASK_VECTOR_SEARCH"
-	TaskDelete           = "TASK_DELETE"
-	TaskBatchUpsert      = "TASK_BATCH_UPSERT"
-	TaskUpsert           = "TASK_UPSERT"
-	TaskCreateCollection = "TASK_CREATE_COLLECTION"
-	TaskDeleteCollection = "TASK_DELETE_COLLECTION"
+	TaskVectorSearch       = "TASK_VECTOR_SEARCH"
+	TaskDelete             = "TASK_DELETE"
+	TaskBatchUpsert        = "TASK_BATCH_UPSERT"
+	TaskUpsert             = "TASK_UPSERT"
+	TaskCreateCollection   = "TASK_CREATE_COLLECTION"
+	TaskDeleteCollection   = "TASK_DELETE_COLLECTION"
+	TaskGetPoints          = "TASK_GET_POINTS"
+	TaskScroll             = "TASK_SCROLL"
+	TaskCount              = "TASK_COUNT"
+	TaskSetPayload         = "TASK_SET_PAYLOAD"
+	TaskDeletePayload      = "TASK_DELETE_PAYLOAD"
+	TaskCreatePayloadIndex = "TASK_CREATE_PAYLOAD_INDEX"
 )
 
 //go:embed config/definition.json
@@ -78,6 +84,18 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.deleteCollection
 	case TaskVectorSearch:
 		e.execute = e.vectorSearch
+	case TaskGetPoints:
+		e.execute = e.getPoints
+	case TaskScroll:
+		e.execute = e.scroll
+	case TaskCount:
+		e.execute = e.count
+	case TaskSetPayload:
+		e.execute = e.setPayload
+	case TaskDeletePayload:
+		e.execute = e.deletePayload
+	case TaskCreatePayloadIndex:
+		e.execute = e.createPayloadIndex
 	default:
 		return nil, errmsg.AddMessage(
 			fmt.Errorf("not supported task: %s", x.Task),
@@ -0,0 +1,169 @@
+package qdrant
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	setPayloadPath    = "/collections/%s/points/payload?wait=true&ordering=%s"
+	deletePayloadPath = "/collections/%s/points/payload/delete?wait=true&ordering=%s"
+)
+
+type SetPayloadInput struct {
+	CollectionName string         `json:"collection-name"`
+	IDs            []string       `json:"ids"`
+	Filter         map[string]any `json:"filter"`
+	Payload        map[string]any `json:"payload"`
+	Overwrite      bool           `json:"overwrite"`
+	Ordering       string         `json:"ordering"`
+}
+
+type SetPayloadOutput struct {
+	Status string `json:"status"`
+}
+
+type SetPayloadReq struct {
+	Payload map[string]any `json:"payload"`
+	Points  []any          `json:"points,omitempty"`
+	Filter  map[string]any `json:"filter,omitempty"`
+}
+
+type DeletePayloadInput struct {
+	CollectionName string         `json:"collection-name"`
+	IDs            []string       `json:"ids"`
+	Filter         map[string]any `json:"filter"`
+	Keys           []string       `json:"keys"`
+	Ordering       string         `json:"ordering"`
+}
+
+type DeletePayloadOutput struct {
+	Status string `json:"status"`
+}
+
+type DeletePayloadReq struct {
+	Keys   []string       `json:"keys"`
+	Points []any          `json:"points,omitempty"`
+	Filter map[string]any `json:"filter,omitempty"`
+}
+
+// PayloadResp is the response of the payload endpoints.
+type PayloadResp struct {
+	Time   float64           `json:"time"`
+	Status string            `json:"status"`
+	Result BatchUpsertResult `json:"result"`
+}
+
+func validatePointSelector(ids []string, filter map[string]any) error {
+	if len(ids) == 0 && filter == nil {
+		return fmt.Errorf("either ids or filter is required")
+	}
+	if len(ids) > 0 && filter != nil {
+		return fmt.Errorf("ids and filter can't be used together")
+	}
+	return nil
+}
+
+// The payload fields are merged into the existing payload, unless overwrite
+// is set, in which case the whole payload is replaced.
+func (e *execution) setPayload(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct SetPayloadInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePointSelector(inputStruct.IDs, inputStruct.Filter); err != nil {
+		return nil, err
+	}
+
+	resp := PayloadResp{}
+
+	reqParams := SetPayloadReq{
+		Payload: inputStruct.Payload,
+		Filter:  inputStruct.Filter,
+	}
+	if len(inputStruct.IDs) > 0 {
+		reqParams.Points = parseIDs(inputStruct.IDs)
+	}
+
+	req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+	path := fmt.Sprintf(setPayloadPath, inputStruct.CollectionName, inputStruct.Ordering)
+	send := req.Post
+	if inputStruct.Overwrite {
+		send = req.Put
+	}
+
+	res, err := send(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to set payload: %s", res.String())
+	}
+
+	outputStruct := SetPayloadOutput{
+		Status: "Successfully set payload",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (e *execution) deletePayload(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct DeletePayloadInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePointSelector(inputStruct.IDs, inputStruct.Filter); err != nil {
+		return nil, err
+	}
+	if len(inputStruct.Keys) == 0 {
+		return nil, fmt.Errorf("keys must have at least one element")
+	}
+
+	resp := PayloadResp{}
+
+	reqParams := DeletePayloadReq{
+		Keys:   inputStruct.Keys,
+		Filter: inputStruct.Filter,
+	}
+	if len(inputStruct.IDs) > 0 {
+		reqParams.Points = parseIDs(inputStruct.IDs)
+	}
+
+	req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+	res, err := req.Post(fmt.Sprintf(deletePayloadPath, inputStruct.CollectionName, inputStruct.Ordering))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to delete payload: %s", res.String())
+	}
+
+	outputStruct := DeletePayloadOutput{
+		Status: fmt.Sprintf("Successfully deleted %d payload keys", len(inputStruct.Keys)),
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,137 @@
+package qdrant
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Point is a point read back from a collection. Named vectors are returned
+// by name, while the unnamed vector of a collection is returned as vector.
+type Point struct {
+	ID           string         `json:"id"`
+	Payload      map[string]any `json:"payload"`
+	Vector       []float64      `json:"vector,omitempty"`
+	NamedVectors map[string]any `json:"named-vectors,omitempty"`
+}
+
+type PointResp struct {
+	ID      pointID         `json:"id"`
+	Payload map[string]any  `json:"payload"`
+	Vector  json.RawMessage `json:"vector"`
+}
+
+func (p PointResp) toPoint() (Point, error) {
+	point := Point{
+		ID:      string(p.ID),
+		Payload: p.Payload,
+	}
+	if point.Payload == nil {
+		point.Payload = map[string]any{}
+	}
+
+	switch v := bytes.TrimSpace(p.Vector); {
+	case len(v) == 0 || bytes.Equal(v, []byte("null")):
+	case v[0] == '{':
+		if err := json.Unmarshal(v, &point.NamedVectors); err != nil {
+			return Point{}, fmt.Errorf("decoding named vectors of point %s: %w", point.ID, err)
+		}
+	default:
+		if err := json.Unmarshal(v, &point.Vector); err != nil {
+			return Point{}, fmt.Errorf("decoding vector of point %s: %w", point.ID, err)
+		}
+	}
+
+	return point, nil
+}
+
+func toPoints(resp []PointResp) ([]Point, error) {
+	points := make([]Point, 0, len(resp))
+	for _, p := range resp {
+		point, err := p.toPoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// parseID converts an ID to the type expected by Qdrant: IDs are either
+// unsigned integers or UUIDs.
+func parseID(id string) any {
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return n
+	}
+	return id
+}
+
+func parseIDs(ids []string) []any {
+	parsed := make([]any, len(ids))
+	for i, id := range ids {
+		parsed[i] = parseID(id)
+	}
+	return parsed
+}
+
+// pointID is a point ID read from a response. Integer IDs are kept as their
+// decimal representation, as decoding them as float64 corrupts the IDs
+// above 2^53.
+type pointID string
+
+func (id *pointID) UnmarshalJSON(b []byte) error {
+	switch b = bytes.TrimSpace(b); {
+	case bytes.Equal(b, []byte("null")):
+		*id = ""
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*id = pointID(s)
+	default:
+		var n json.Number
+		if err := json.Unmarshal(b, &n); err != nil {
+			return fmt.Errorf("decoding point ID: %w", err)
+		}
+		*id = pointID(n)
+	}
+	return nil
+}
+
+// withPayload returns the with_payload parameter of a request: the listed
+// payload fields, or all of them when none is provided.
+func withPayload(payloads []string) any {
+	if len(payloads) > 0 {
+		return payloads
+	}
+	return true
+}
+
+// namedVectors returns the vectors of a batch, keyed by their name when the
+// collection uses named vectors.
+func namedVectors(vectors [][]float64, vectorName string) any {
+	if vectorName == "" {
+		return vectors
+	}
+	return map[string][][]float64{vectorName: vectors}
+}
+
+func pickNamedVector(vectors map[string]any, name string) ([]float64, error) {
+	v, ok := vectors[name]
+	if !ok {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var vector []float64
+	if err := json.Unmarshal(b, &vector); err != nil {
+		return nil, fmt.Errorf("decoding vector %s: %w", name, err)
+	}
+	return vector, nil
+}
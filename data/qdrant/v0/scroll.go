@@ -0,0 +1,99 @@
+package qdrant
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	scrollPath = "/collections/%s/points/scroll"
+)
+
+type ScrollInput struct {
+	CollectionName string         `json:"collection-name"`
+	Filter         map[string]any `json:"filter"`
+	Limit          int            `json:"limit"`
+	Offset         string         `json:"offset"`
+	Payloads       []string       `json:"payloads"`
+	WithVector     bool           `json:"with-vector"`
+}
+
+type ScrollOutput struct {
+	Status     string  `json:"status"`
+	Points     []Point `json:"points"`
+	NextOffset string  `json:"next-offset"`
+}
+
+type ScrollReq struct {
+	Filter      map[string]any `json:"filter,omitempty"`
+	Limit       int            `json:"limit,omitempty"`
+	Offset      any            `json:"offset,omitempty"`
+	WithPayload any            `json:"with_payload"`
+	WithVector  bool           `json:"with_vector"`
+}
+
+type ScrollResult struct {
+	Points         []PointResp `json:"points"`
+	NextPageOffset pointID     `json:"next_page_offset"`
+}
+
+type ScrollResp struct {
+	Time   float64      `json:"time"`
+	Status string       `json:"status"`
+	Result ScrollResult `json:"result"`
+}
+
+// offset is optional, empty means the first page
+// next-offset is empty when there are no more points
+func (e *execution) scroll(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct ScrollInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := ScrollResp{}
+
+	reqParams := ScrollReq{
+		Filter:      inputStruct.Filter,
+		Limit:       inputStruct.Limit,
+		WithPayload: withPayload(inputStruct.Payloads),
+		WithVector:  inputStruct.WithVector,
+	}
+	if inputStruct.Offset != "" {
+		reqParams.Offset = parseID(inputStruct.Offset)
+	}
+
+	req := e.client.R().SetBody(reqParams).SetResult(&resp)
+
+	res, err := req.Post(fmt.Sprintf(scrollPath, inputStruct.CollectionName))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() != 200 {
+		return nil, fmt.Errorf("failed to scroll points: %s", res.String())
+	}
+
+	points, err := toPoints(resp.Result.Points)
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := ScrollOutput{
+		Status:     fmt.Sprintf("Successfully scrolled %d points", len(points)),
+		Points:     points,
+		NextOffset: string(resp.Result.NextPageOffset),
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -17,6 +17,7 @@ type UpsertInput struct {
 	ID             string         `json:"id"`
 	Metadata       map[string]any `json:"metadata"`
 	Vector         []float64      `json:"vector"`
+	VectorName     string         `json:"vector-name"`
 	Ordering       string         `json:"ordering"`
 }
 
@@ -32,7 +33,7 @@ func (e *execution) upsert(in *structpb.Struct) (*structpb.Struct, error) {
 	reqParams := BatchUpsertReq{
 		Batch: Batch{
 			IDs:      []string{inputStruct.ID},
-			Vectors:  [][]float64{inputStruct.Vector},
+			Vectors:  namedVectors([][]float64{inputStruct.Vector}, inputStruct.VectorName),
 			Payloads: []map[string]any{inputStruct.Metadata},
 		},
 	}
@@ -1,8 +1,8 @@
 package qdrant
 
 import (
+	"encoding/json"
 	"fmt"
-	"strconv"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -16,6 +16,7 @@ const (
 type VectorSearchInput struct {
 	CollectionName string         `json:"collection-name"`
 	Vector         []float64      `json:"vector"`
+	VectorName     string         `json:"vector-name"`
 	Filter         map[string]any `json:"filter"`
 	Limit          int            `json:"limit"`
 	Payloads       []string       `json:"payloads"`
@@ -36,15 +37,20 @@ type Result struct {
 }
 
 type VectorSearchReq struct {
-	Vector     []float64      `json:"vector"`
+	Vector     any            `json:"vector"`
 	Limit      int            `json:"limit"`
 	Payloads   any            `json:"with_payload"`
 	Filter     map[string]any `json:"filter"`
 	Params     map[string]any `json:"params"`
-	WithVector bool           `json:"with_vector"`
+	WithVector any            `json:"with_vector"`
 	MinScore   float64        `json:"score_threshold"`
 }
 
+type NamedVector struct {
+	Name   string    `json:"name"`
+	Vector []float64 `json:"vector"`
+}
+
 type VectorSearchResp struct {
 	Time   float64              `json:"time"`
 	Status string               `json:"status"`
@@ -52,13 +58,13 @@ type VectorSearchResp struct {
 }
 
 type VectorSearchResult struct {
-	ID         any            `json:"id"`
-	Version    int            `json:"version"`
-	Score      float64        `json:"score"`
-	Payload    map[string]any `json:"payload"`
-	Vector     []float64      `json:"vector"`
-	ShardKey   string         `json:"shard_key"`
-	OrderValue float64        `json:"order_value"`
+	ID         pointID         `json:"id"`
+	Version    int             `json:"version"`
+	Score      float64         `json:"score"`
+	Payload    map[string]any  `json:"payload"`
+	Vector     json.RawMessage `json:"vector"`
+	ShardKey   string          `json:"shard_key"`
+	OrderValue float64         `json:"order_value"`
 }
 
 func (e *execution) vectorSearch(in *structpb.Struct) (*structpb.Struct, error) {
@@ -79,6 +85,15 @@ func (e *execution) vectorSearch(in *structpb.Struct) (*structpb.Struct, error)
 		Payloads:   true,
 	}
 
+	// Collections with named vectors are searched on one of them and only
+	// return that vector.
+	if inputStruct.VectorName != "" {
+		reqParams.Vector = NamedVector{
+			Name:   inputStruct.VectorName,
+			Vector: inputStruct.Vector,
+		}
+		reqParams.WithVector = []string{inputStruct.VectorName}
+	}
 	if inputStruct.Payloads != nil {
 		reqParams.Payloads = inputStruct.Payloads
 	}
@@ -110,19 +125,24 @@ func (e *execution) vectorSearch(in *structpb.Struct) (*structpb.Struct, error)
 	var metadata []map[string]any
 
 	for _, result := range resp.Result {
+		p, err := PointResp{ID: result.ID, Vector: result.Vector}.toPoint()
+		if err != nil {
+			return nil, err
+		}
+		if inputStruct.VectorName != "" {
+			p.Vector, err = pickNamedVector(p.NamedVectors, inputStruct.VectorName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		point := make(map[string]any)
 		for k, v := range result.Payload {
 			point[k] = v
 		}
 
-		switch v := result.ID.(type) {
-		case string:
-			point["id"] = v
-			ids = append(ids, v)
-		case float64:
-			point["id"] = strconv.Itoa(int(v))
-			ids = append(ids, strconv.Itoa(int(v)))
-		}
+		point["id"] = p.ID
+		ids = append(ids, p.ID)
 
 		point["score"] = result.Score
 		if result.Version != 0 {
@@ -134,10 +154,10 @@ func (e *execution) vectorSearch(in *structpb.Struct) (*structpb.Struct, error)
 		if result.OrderValue != 0 {
 			point["order_value"] = result.OrderValue
 		}
-		point["vector"] = result.Vector
+		point["vector"] = p.Vector
 
 		points = append(points, point)
-		vectors = append(vectors, result.Vector)
+		vectors = append(vectors, p.Vector)
 		metadata = append(metadata, result.Payload)
 	}
 
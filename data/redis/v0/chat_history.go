@@ -3,7 +3,8 @@ package redis
 import (
 	"context"
 	"encoding/json"
-	"sort"
+	"slices"
+	"strconv"
 	"time"
 
 	goredis "github.com/redis/go-redis/v9"
@@ -14,6 +15,26 @@ var (
 	DefaultLatestK = 5
 )
 
+const (
+	// legacySystemMessagesKey is the hash where the system messages of all the
+	// sessions used to be stored. As a hash field can't expire, the system
+	// messages are now stored in a key per session. The hash is still read so
+	// the existing sessions keep their system message.
+	legacySystemMessagesKey = "chat_history:system_messages"
+)
+
+func messagesKey(sessionID string) string {
+	return "chat_history:" + sessionID + ":timestamps"
+}
+
+func systemMessageKey(sessionID string) string {
+	return "chat_history:" + sessionID + ":system_message"
+}
+
+func summaryKey(sessionID string) string {
+	return "chat_history:" + sessionID + ":summary"
+}
+
 type Message struct {
 	Role     string                  `json:"role"`
 	Content  string                  `json:"content"`
@@ -44,14 +65,27 @@ type MultiModalMessageWithTime struct {
 	Timestamp int64 `json:"timestamp"`
 }
 
+// SessionSettings holds the retention settings that are applied to a session
+// whenever a message is written to it.
+type SessionSettings struct {
+	// TTL is the number of seconds after which an idle session expires. Zero
+	// means the session never expires.
+	TTL int `json:"ttl,omitempty"`
+	// MaxLength is the maximum number of non-system messages kept in the
+	// session. Zero means no limit.
+	MaxLength int `json:"max-length,omitempty"`
+}
+
 type ChatMessageWriteInput struct {
 	SessionID string `json:"session-id"`
 	Message
+	SessionSettings
 }
 
 type ChatMultiModalMessageWriteInput struct {
 	SessionID string `json:"session-id"`
 	MultiModalMessage
+	SessionSettings
 }
 
 type ChatMessageWriteOutput struct {
@@ -62,12 +96,16 @@ type ChatHistoryRetrieveInput struct {
 	SessionID            string `json:"session-id"`
 	LatestK              *int   `json:"latest-k,omitempty"`
 	IncludeSystemMessage bool   `json:"include-system-message"`
+	IncludeSummary       *bool  `json:"include-summary,omitempty"`
+	MaxTokens            int    `json:"max-tokens,omitempty"`
+	ModelName            string `json:"model-name,omitempty"`
 }
 
 // ChatHistoryReadOutput is a wrapper struct for the messages associated with a session ID
 type ChatHistoryRetrieveOutput struct {
-	Messages []*MultiModalMessage `json:"messages"`
-	Status   bool                 `json:"status"`
+	Messages   []*MultiModalMessage `json:"messages"`
+	Status     bool                 `json:"status"`
+	TokenCount *int                 `json:"token-count,omitempty"`
 }
 
 // WriteSystemMessage writes system message for a given session ID
@@ -77,10 +115,31 @@ func WriteSystemMessage(client *goredis.Client, sessionID string, message MultiM
 		return err
 	}
 
-	// Store in a hash with a unique SessionID
-	return client.HSet(context.Background(), "chat_history:system_messages", sessionID, messageJSON).Err()
+	// Rewriting the system message shouldn't reset the expiration of the
+	// session. The legacy copy is removed so it doesn't outlive the session.
+	ctx := context.Background()
+	_, err = client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Set(ctx, systemMessageKey(sessionID), messageJSON, goredis.KeepTTL)
+		pipe.HDel(ctx, legacySystemMessagesKey, sessionID)
+		return nil
+	})
+	return err
 }
 
+// addMessageScript adds a message to the sorted set of a session. The score
+// is the current time in milliseconds, bumped above the latest score of the
+// session so the scores are strictly increasing, even for messages written in
+// the same millisecond.
+var addMessageScript = goredis.NewScript(`
+local score = tonumber(ARGV[1])
+local latest = redis.call('ZREVRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if latest[2] and tonumber(latest[2]) >= score then
+	score = tonumber(latest[2]) + 1
+end
+redis.call('ZADD', KEYS[1], score, ARGV[2])
+return tostring(score)
+`)
+
 func WriteNonSystemMessage(client *goredis.Client, sessionID string, message MultiModalMessageWithTime) error {
 	// Marshal the MessageWithTime struct to JSON
 	messageJSON, err := json.Marshal(message)
@@ -88,16 +147,19 @@ func WriteNonSystemMessage(client *goredis.Client, sessionID string, message Mul
 		return err
 	}
 
-	// Index by Timestamp: Add to the Sorted Set
-	return client.ZAdd(context.Background(), "chat_history:"+sessionID+":timestamps", goredis.Z{
-		Score:  float64(message.Timestamp),
-		Member: string(messageJSON),
-	}).Err()
+	// The messages are ordered by their score, which a summary uses to know
+	// which messages it covers.
+	keys := []string{messagesKey(sessionID)}
+	return addMessageScript.Run(context.Background(), client, keys, time.Now().UnixMilli(), string(messageJSON)).Err()
 }
 
 // RetrieveSystemMessage gets system message based on a given session ID
 func RetrieveSystemMessage(client *goredis.Client, sessionID string) (bool, *MultiModalMessageWithTime, error) {
-	serializedMessage, err := client.HGet(context.Background(), "chat_history:system_messages", sessionID).Result()
+	ctx := context.Background()
+	serializedMessage, err := client.Get(ctx, systemMessageKey(sessionID)).Result()
+	if err == goredis.Nil {
+		serializedMessage, err = client.HGet(ctx, legacySystemMessagesKey, sessionID).Result()
+	}
 
 	// Check if the messageID does not exist
 	if err == goredis.Nil {
@@ -140,17 +202,23 @@ func WriteMessage(client *goredis.Client, input ChatMessageWriteInput) ChatMessa
 		err := WriteSystemMessage(client, input.SessionID, messageWithTime)
 		if err != nil {
 			return ChatMessageWriteOutput{Status: false}
-		} else {
-			return ChatMessageWriteOutput{Status: true}
 		}
+
+		if err := ApplySessionSettings(client, input.SessionID, input.SessionSettings); err != nil {
+			return ChatMessageWriteOutput{Status: false}
+		}
+		return ChatMessageWriteOutput{Status: true}
 	}
 
 	err := WriteNonSystemMessage(client, input.SessionID, messageWithTime)
 	if err != nil {
 		return ChatMessageWriteOutput{Status: false}
-	} else {
-		return ChatMessageWriteOutput{Status: true}
 	}
+
+	if err := ApplySessionSettings(client, input.SessionID, input.SessionSettings); err != nil {
+		return ChatMessageWriteOutput{Status: false}
+	}
+	return ChatMessageWriteOutput{Status: true}
 }
 
 func WriteMultiModelMessage(client *goredis.Client, input ChatMultiModalMessageWriteInput) ChatMessageWriteOutput {
@@ -172,85 +240,133 @@ func WriteMultiModelMessage(client *goredis.Client, input ChatMultiModalMessageW
 		err := WriteSystemMessage(client, input.SessionID, messageWithTime)
 		if err != nil {
 			return ChatMessageWriteOutput{Status: false}
-		} else {
-			return ChatMessageWriteOutput{Status: true}
 		}
+
+		if err := ApplySessionSettings(client, input.SessionID, input.SessionSettings); err != nil {
+			return ChatMessageWriteOutput{Status: false}
+		}
+		return ChatMessageWriteOutput{Status: true}
 	}
 
 	err := WriteNonSystemMessage(client, input.SessionID, messageWithTime)
 	if err != nil {
 		return ChatMessageWriteOutput{Status: false}
-	} else {
-		return ChatMessageWriteOutput{Status: true}
 	}
+
+	if err := ApplySessionSettings(client, input.SessionID, input.SessionSettings); err != nil {
+		return ChatMessageWriteOutput{Status: false}
+	}
+	return ChatMessageWriteOutput{Status: true}
 }
 
-// RetrieveSessionMessages retrieves the latest K conversation turns from the Redis list for the given session ID
+// RetrieveSessionMessages retrieves the latest K conversation turns from the
+// Redis list for the given session ID. If a rolling summary exists, it is
+// prepended to the messages it doesn't cover. If a token budget is set, the
+// oldest messages are dropped until the conversation fits in it.
 func RetrieveSessionMessages(client *goredis.Client, input ChatHistoryRetrieveInput) ChatHistoryRetrieveOutput {
-	if input.LatestK == nil || *input.LatestK <= 0 {
+	// Without a latest-k value, a token budget is the only bound on the
+	// retrieved messages.
+	if (input.LatestK == nil || *input.LatestK <= 0) && input.MaxTokens <= 0 {
 		input.LatestK = &DefaultLatestK
 	}
-	key := input.SessionID
+	includeSummary := input.IncludeSummary == nil || *input.IncludeSummary
 
-	messagesWithTime := []MultiModalMessageWithTime{}
 	messages := []*MultiModalMessage{}
-	ctx := context.Background()
-
-	// Retrieve the latest K conversation turns associated with the session ID by descending timestamp order
-	messagesNum := *input.LatestK * 2
-	timestampMessages, err := client.ZRevRange(ctx, "chat_history:"+key+":timestamps", 0, int64(messagesNum-1)).Result()
-	if err != nil {
-		return ChatHistoryRetrieveOutput{
-			Messages: messages,
-			Status:   false,
-		}
-	}
-
-	// Iterate through the members and deserialize them into MessageWithTime
-	for _, member := range timestampMessages {
-		var messageWithTime MultiModalMessageWithTime
-		if err := json.Unmarshal([]byte(member), &messageWithTime); err != nil {
-			return ChatHistoryRetrieveOutput{
-				Messages: messages,
-				Status:   false,
-			}
-		}
-		messagesWithTime = append(messagesWithTime, messageWithTime)
+	failed := ChatHistoryRetrieveOutput{
+		Messages: messages,
+		Status:   false,
 	}
+	ctx := context.Background()
 
-	// Sort the messages by timestamp in ascending order (earliest first)
-	sort.SliceStable(messagesWithTime, func(i, j int) bool {
-		return messagesWithTime[i].Timestamp < messagesWithTime[j].Timestamp
-	})
+	var systemMessage, summaryMessage *MultiModalMessage
 
 	// Add System message if exist
 	if input.IncludeSystemMessage {
 		exist, sysMessage, err := RetrieveSystemMessage(client, input.SessionID)
 		if err != nil {
-			return ChatHistoryRetrieveOutput{
-				Messages: messages,
-				Status:   false,
-			}
+			return failed
 		}
 		if exist {
-			messages = append(messages, &MultiModalMessage{
+			systemMessage = &MultiModalMessage{
 				Role:     sysMessage.Role,
 				Content:  sysMessage.Content,
 				Metadata: sysMessage.Metadata,
-			})
+			}
 		}
 	}
 
+	// The messages covered by the summary are replaced by it.
+	minScore := "-inf"
+	if includeSummary {
+		exist, summary, err := RetrieveSummary(client, input.SessionID)
+		if err != nil {
+			return failed
+		}
+		if exist {
+			summaryMessage = summary.toMessage()
+			minScore = "(" + strconv.FormatInt(summary.CoveredUntil, 10)
+		}
+	}
+
+	// Retrieve the latest K conversation turns associated with the session ID
+	// by descending timestamp order
+	rangeBy := &goredis.ZRangeBy{Min: minScore, Max: "+inf"}
+	if input.LatestK != nil && *input.LatestK > 0 {
+		rangeBy.Count = int64(*input.LatestK * 2)
+	}
+	timestampMessages, err := client.ZRevRangeByScore(ctx, messagesKey(input.SessionID), rangeBy).Result()
+	if err != nil {
+		return failed
+	}
+
+	// Iterate through the members and deserialize them into MessageWithTime
+	messagesWithTime := make([]MultiModalMessageWithTime, 0, len(timestampMessages))
+	for _, member := range timestampMessages {
+		var messageWithTime MultiModalMessageWithTime
+		if err := json.Unmarshal([]byte(member), &messageWithTime); err != nil {
+			return failed
+		}
+		messagesWithTime = append(messagesWithTime, messageWithTime)
+	}
+
+	// Sort the messages in ascending order (earliest first). The scores are
+	// more precise than the timestamps, so the order of the sorted set is
+	// kept.
+	slices.Reverse(messagesWithTime)
+
 	// Convert the MessageWithTime structs to Message structs
+	history := make([]*MultiModalMessage, 0, len(messagesWithTime))
 	for _, m := range messagesWithTime {
-		messages = append(messages, &MultiModalMessage{
+		history = append(history, &MultiModalMessage{
 			Role:     m.Role,
 			Content:  m.Content,
 			Metadata: m.Metadata,
 		})
 	}
+
+	var tokenCount *int
+	if input.MaxTokens > 0 {
+		countTokens, err := newTokenCounter(input.ModelName)
+		if err != nil {
+			return failed
+		}
+
+		var count int
+		summaryMessage, history, count = fitInBudget(systemMessage, summaryMessage, history, input.MaxTokens, countTokens)
+		tokenCount = &count
+	}
+
+	if systemMessage != nil {
+		messages = append(messages, systemMessage)
+	}
+	if summaryMessage != nil {
+		messages = append(messages, summaryMessage)
+	}
+	messages = append(messages, history...)
+
 	return ChatHistoryRetrieveOutput{
-		Messages: messages,
-		Status:   true,
+		Messages:   messages,
+		Status:     true,
+		TokenCount: tokenCount,
 	}
 }
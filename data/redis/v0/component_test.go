@@ -0,0 +1,427 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/instill-ai/component/base"
+)
+
+func newTestClient(c *qt.C) (*miniredis.Miniredis, *goredis.Client) {
+	mr := miniredis.RunT(c)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	c.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+func textMessage(role, text string, timestamp int64) MultiModalMessageWithTime {
+	return MultiModalMessageWithTime{
+		MultiModalMessage: MultiModalMessage{
+			Role:    role,
+			Content: []MultiModalContent{{Type: "text", Text: &text}},
+		},
+		Timestamp: timestamp,
+	}
+}
+
+// writeTestMessages writes the messages with the same timestamp, as messages
+// are often written within the same second.
+func writeTestMessages(c *qt.C, client *goredis.Client, sessionID string, texts ...string) {
+	now := time.Now().Unix()
+	for i, text := range texts {
+		role := "user"
+		if i%2 == 1 {
+			role = "assistant"
+		}
+		err := WriteNonSystemMessage(client, sessionID, textMessage(role, text, now))
+		c.Assert(err, qt.IsNil)
+	}
+}
+
+func texts(messages []*MultiModalMessage) []string {
+	texts := make([]string, len(messages))
+	for i, m := range messages {
+		texts[i] = m.Role + ": " + *m.Content[0].Text
+	}
+	return texts
+}
+
+func TestRetrieveSessionMessages(t *testing.T) {
+	c := qt.New(t)
+
+	_, client := newTestClient(c)
+	writeTestMessages(c, client, "s1", "q1", "a1", "q2", "a2", "q3", "a3")
+
+	out := WriteMessage(client, ChatMessageWriteInput{
+		SessionID: "s1",
+		Message:   Message{Role: "system", Content: "be nice"},
+	})
+	c.Assert(out.Status, qt.IsTrue)
+
+	latestK := func(k int) *int { return &k }
+
+	testcases := []struct {
+		name  string
+		input ChatHistoryRetrieveInput
+		want  []string
+	}{
+		{
+			name:  "ok - latest k with system message",
+			input: ChatHistoryRetrieveInput{SessionID: "s1", LatestK: latestK(2), IncludeSystemMessage: true},
+			want:  []string{"system: be nice", "user: q2", "assistant: a2", "user: q3", "assistant: a3"},
+		},
+		{
+			name:  "ok - default latest k without system message",
+			input: ChatHistoryRetrieveInput{SessionID: "s1"},
+			want:  []string{"user: q1", "assistant: a1", "user: q2", "assistant: a2", "user: q3", "assistant: a3"},
+		},
+		{
+			name:  "ok - empty session",
+			input: ChatHistoryRetrieveInput{SessionID: "missing", IncludeSystemMessage: true},
+			want:  []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			got := RetrieveSessionMessages(client, tc.input)
+			c.Check(got.Status, qt.IsTrue)
+			c.Check(texts(got.Messages), qt.DeepEquals, tc.want)
+			c.Check(got.TokenCount, qt.IsNil)
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	c := qt.New(t)
+
+	_, client := newTestClient(c)
+	writeTestMessages(c, client, "s1", "q1", "a1", "q2", "a2")
+
+	out := WriteMessage(client, ChatMessageWriteInput{
+		SessionID: "s1",
+		Message:   Message{Role: "system", Content: "be nice"},
+	})
+	c.Assert(out.Status, qt.IsTrue)
+
+	sOut := WriteSummary(client, SummaryWriteInput{SessionID: "s1", Summary: "the user asked two questions"})
+	c.Assert(sOut.Status, qt.IsTrue)
+
+	// Messages written after the summary aren't covered by it, even within
+	// the same second.
+	err := WriteNonSystemMessage(client, "s1", textMessage("user", "q3", time.Now().Unix()))
+	c.Assert(err, qt.IsNil)
+
+	c.Run("ok - summary replaces covered messages", func(c *qt.C) {
+		got := RetrieveSessionMessages(client, ChatHistoryRetrieveInput{SessionID: "s1", IncludeSystemMessage: true})
+		c.Assert(got.Status, qt.IsTrue)
+		c.Check(texts(got.Messages), qt.DeepEquals, []string{
+			"system: be nice",
+			"system: the user asked two questions",
+			"user: q3",
+		})
+		c.Check(*got.Messages[1].Metadata, qt.DeepEquals, map[string]any{"summary": true})
+	})
+
+	c.Run("ok - summary excluded", func(c *qt.C) {
+		includeSummary := false
+		got := RetrieveSessionMessages(client, ChatHistoryRetrieveInput{SessionID: "s1", IncludeSummary: &includeSummary})
+		c.Assert(got.Status, qt.IsTrue)
+		c.Check(texts(got.Messages), qt.DeepEquals, []string{
+			"user: q1", "assistant: a1", "user: q2", "assistant: a2", "user: q3",
+		})
+	})
+}
+
+func TestSessionSettings(t *testing.T) {
+	c := qt.New(t)
+
+	mr, client := newTestClient(c)
+
+	out := WriteMessage(client, ChatMessageWriteInput{
+		SessionID: "s1",
+		Message:   Message{Role: "system", Content: "be nice"},
+	})
+	c.Assert(out.Status, qt.IsTrue)
+
+	for _, content := range []string{"q1", "a1", "q2"} {
+		out := WriteMessage(client, ChatMessageWriteInput{
+			SessionID:       "s1",
+			Message:         Message{Role: "user", Content: content},
+			SessionSettings: SessionSettings{TTL: 60, MaxLength: 2},
+		})
+		c.Assert(out.Status, qt.IsTrue)
+	}
+
+	c.Check(mr.TTL(messagesKey("s1")), qt.Equals, time.Minute)
+	c.Check(mr.TTL(systemMessageKey("s1")), qt.Equals, time.Minute)
+
+	n, err := client.ZCard(context.Background(), messagesKey("s1")).Result()
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, int64(2))
+
+	sOut := WriteSummary(client, SummaryWriteInput{SessionID: "s1", Summary: "summary"})
+	c.Assert(sOut.Status, qt.IsTrue)
+	c.Check(mr.TTL(summaryKey("s1")), qt.Equals, time.Duration(0))
+
+	// Rewriting the summary keeps its expiration.
+	sOut = WriteSummary(client, SummaryWriteInput{SessionID: "s1", Summary: "summary", SessionSettings: SessionSettings{TTL: 60}})
+	c.Assert(sOut.Status, qt.IsTrue)
+	sOut = WriteSummary(client, SummaryWriteInput{SessionID: "s1", Summary: "new summary"})
+	c.Assert(sOut.Status, qt.IsTrue)
+	c.Check(mr.TTL(summaryKey("s1")), qt.Equals, time.Minute)
+
+	mr.FastForward(61 * time.Second)
+
+	got := RetrieveSessionMessages(client, ChatHistoryRetrieveInput{SessionID: "s1", IncludeSystemMessage: true})
+	c.Assert(got.Status, qt.IsTrue)
+	c.Check(got.Messages, qt.HasLen, 0)
+
+	list := ListSessions(client, ListSessionsInput{})
+	c.Assert(list.Status, qt.IsTrue)
+	c.Check(list.SessionIDs, qt.HasLen, 0)
+}
+
+func TestDeleteAndListSessions(t *testing.T) {
+	c := qt.New(t)
+
+	_, client := newTestClient(c)
+	writeTestMessages(c, client, "user-1-a", "q1")
+	writeTestMessages(c, client, "user-1-b", "q1")
+	writeTestMessages(c, client, "user-2-a", "q1")
+
+	// A session with only a system message.
+	out := WriteMessage(client, ChatMessageWriteInput{
+		SessionID: "user-3-a",
+		Message:   Message{Role: "system", Content: "be nice"},
+	})
+	c.Assert(out.Status, qt.IsTrue)
+	out = WriteMessage(client, ChatMessageWriteInput{
+		SessionID: "user-1-a",
+		Message:   Message{Role: "system", Content: "be nice"},
+	})
+	c.Assert(out.Status, qt.IsTrue)
+
+	c.Run("ok - list all sessions", func(c *qt.C) {
+		got := ListSessions(client, ListSessionsInput{})
+		c.Check(got.Status, qt.IsTrue)
+		c.Check(got.SessionIDs, qt.DeepEquals, []string{"user-1-a", "user-1-b", "user-2-a", "user-3-a"})
+	})
+
+	c.Run("ok - list matching sessions", func(c *qt.C) {
+		got := ListSessions(client, ListSessionsInput{Match: "user-1-*"})
+		c.Check(got.Status, qt.IsTrue)
+		c.Check(got.SessionIDs, qt.DeepEquals, []string{"user-1-a", "user-1-b"})
+	})
+
+	c.Run("ok - delete session", func(c *qt.C) {
+		sOut := WriteSummary(client, SummaryWriteInput{SessionID: "user-1-a", Summary: "summary"})
+		c.Assert(sOut.Status, qt.IsTrue)
+
+		got := DeleteSession(client, DeleteSessionInput{SessionID: "user-1-a"})
+		c.Check(got.Status, qt.IsTrue)
+
+		exist, _, err := RetrieveSystemMessage(client, "user-1-a")
+		c.Check(err, qt.IsNil)
+		c.Check(exist, qt.IsFalse)
+
+		exist, _, err = RetrieveSummary(client, "user-1-a")
+		c.Check(err, qt.IsNil)
+		c.Check(exist, qt.IsFalse)
+
+		list := ListSessions(client, ListSessionsInput{})
+		c.Check(list.SessionIDs, qt.DeepEquals, []string{"user-1-b", "user-2-a", "user-3-a"})
+	})
+}
+
+func TestFitInBudget(t *testing.T) {
+	c := qt.New(t)
+
+	msg := func(role, text string) *MultiModalMessage {
+		return &MultiModalMessage{Role: role, Content: []MultiModalContent{{Type: "text", Text: &text}}}
+	}
+
+	// Each word is a token.
+	countWords := func(m *MultiModalMessage) int {
+		return len(strings.Fields(*m.Content[0].Text))
+	}
+
+	system := msg("system", "be nice")
+	summary := msg("system", "one two three")
+	history := []*MultiModalMessage{
+		msg("user", "a b c d"),
+		msg("assistant", "e f"),
+		msg("user", "g h i"),
+	}
+
+	testcases := []struct {
+		name        string
+		system      *MultiModalMessage
+		summary     *MultiModalMessage
+		maxTokens   int
+		wantSummary bool
+		wantHistory []*MultiModalMessage
+		wantCount   int
+	}{
+		{
+			name:        "ok - everything fits",
+			system:      system,
+			summary:     summary,
+			maxTokens:   100,
+			wantSummary: true,
+			wantHistory: history,
+			wantCount:   14,
+		},
+		{
+			name:        "ok - summary and earliest messages are dropped",
+			system:      system,
+			summary:     summary,
+			maxTokens:   10,
+			wantHistory: history[1:],
+			wantCount:   7,
+		},
+		{
+			name:        "ok - summary is dropped before the messages",
+			system:      system,
+			summary:     summary,
+			maxTokens:   12,
+			wantHistory: history,
+			wantCount:   11,
+		},
+		{
+			name:        "ok - messages are kept contiguous",
+			maxTokens:   6,
+			wantHistory: history[1:],
+			wantCount:   5,
+		},
+		{
+			name:        "ok - system message is always kept",
+			system:      system,
+			maxTokens:   1,
+			wantHistory: []*MultiModalMessage{},
+			wantCount:   2,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			gotSummary, gotHistory, gotCount := fitInBudget(tc.system, tc.summary, history, tc.maxTokens, countWords)
+			c.Check(gotSummary != nil, qt.Equals, tc.wantSummary)
+			c.Check(gotHistory, qt.DeepEquals, tc.wantHistory)
+			c.Check(gotCount, qt.Equals, tc.wantCount)
+		})
+	}
+}
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	mr, client := newTestClient(c)
+	writeTestMessages(c, client, "s1", "q1", "a1")
+
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+	setup, err := structpb.NewStruct(map[string]any{
+		"host": mr.Host(),
+		"port": mr.Server().Addr().Port,
+	})
+	c.Assert(err, qt.IsNil)
+
+	testcases := []struct {
+		task  string
+		input map[string]any
+		want  map[string]any
+	}{
+		{
+			task:  taskWriteSummary,
+			input: map[string]any{"session-id": "s1", "summary": "greetings", "ttl": 30},
+			want:  map[string]any{"status": true},
+		},
+		{
+			task:  taskListSessions,
+			input: map[string]any{},
+			want:  map[string]any{"status": true, "session-ids": []any{"s1"}},
+		},
+		{
+			task:  taskDeleteSession,
+			input: map[string]any{"session-id": "s1"},
+			want:  map[string]any{"status": true},
+		},
+		{
+			task:  taskListSessions,
+			input: map[string]any{},
+			want:  map[string]any{"status": true, "session-ids": []any{}},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.task, func(c *qt.C) {
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				c.Check(output.AsMap(), qt.DeepEquals, tc.want)
+				return nil
+			})
+			eh.ErrorMock.Optional()
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
+
+func TestLegacySystemMessage(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	_, client := newTestClient(c)
+
+	// Sessions created before the system messages had their own key.
+	msgJSON, err := json.Marshal(textMessage("system", "be nice", 1000))
+	c.Assert(err, qt.IsNil)
+	c.Assert(client.HSet(ctx, legacySystemMessagesKey, "s1", msgJSON).Err(), qt.IsNil)
+
+	exist, msg, err := RetrieveSystemMessage(client, "s1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(exist, qt.IsTrue)
+	c.Check(*msg.Content[0].Text, qt.Equals, "be nice")
+
+	list := ListSessions(client, ListSessionsInput{})
+	c.Check(list.SessionIDs, qt.DeepEquals, []string{"s1"})
+
+	// Rewriting the system message moves it to the session key.
+	out := WriteMessage(client, ChatMessageWriteInput{
+		SessionID: "s1",
+		Message:   Message{Role: "system", Content: "be concise"},
+	})
+	c.Assert(out.Status, qt.IsTrue)
+
+	n, err := client.HLen(ctx, legacySystemMessagesKey).Result()
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, int64(0))
+
+	exist, msg, err = RetrieveSystemMessage(client, "s1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(exist, qt.IsTrue)
+	c.Check(*msg.Content[0].Text, qt.Equals, "be concise")
+}
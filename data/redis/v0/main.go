@@ -17,6 +17,9 @@ const (
 	taskWriteChatMessage           = "TASK_WRITE_CHAT_MESSAGE"
 	taskWriteMultiModalChatMessage = "TASK_WRITE_MULTI_MODAL_CHAT_MESSAGE"
 	taskRetrieveChatHistory        = "TASK_RETRIEVE_CHAT_HISTORY"
+	taskWriteSummary               = "TASK_WRITE_SUMMARY"
+	taskDeleteSession              = "TASK_DELETE_SESSION"
+	taskListSessions               = "TASK_LIST_SESSIONS"
 )
 
 var (
@@ -111,6 +114,45 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 				job.Error.Error(ctx, err)
 				continue
 			}
+		case taskWriteSummary:
+			inputStruct := SummaryWriteInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			outputStruct := WriteSummary(client, inputStruct)
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+		case taskDeleteSession:
+			inputStruct := DeleteSessionInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			outputStruct := DeleteSession(client, inputStruct)
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+		case taskListSessions:
+			inputStruct := ListSessionsInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			outputStruct := ListSessions(client, inputStruct)
+			output, err = base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
 		default:
 			job.Error.Error(ctx, fmt.Errorf("unsupported task: %s", e.Task))
 			continue
@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type DeleteSessionInput struct {
+	SessionID string `json:"session-id"`
+}
+
+type DeleteSessionOutput struct {
+	Status bool `json:"status"`
+}
+
+type ListSessionsInput struct {
+	Match string `json:"match,omitempty"`
+}
+
+type ListSessionsOutput struct {
+	SessionIDs []string `json:"session-ids"`
+	Status     bool     `json:"status"`
+}
+
+// ApplySessionSettings trims the session to its maximum length and refreshes
+// its expiration time.
+func ApplySessionSettings(client *goredis.Client, sessionID string, settings SessionSettings) error {
+	ctx := context.Background()
+
+	if settings.MaxLength > 0 {
+		// Remove the oldest messages, keeping the last max-length ones.
+		err := client.ZRemRangeByRank(ctx, messagesKey(sessionID), 0, int64(-settings.MaxLength-1)).Err()
+		if err != nil {
+			return err
+		}
+	}
+
+	if settings.TTL > 0 {
+		ttl := time.Duration(settings.TTL) * time.Second
+		for _, key := range sessionKeys(sessionID) {
+			if err := client.Expire(ctx, key, ttl).Err(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sessionKeys returns the keys that hold the data of a session.
+func sessionKeys(sessionID string) []string {
+	return []string{messagesKey(sessionID), systemMessageKey(sessionID), summaryKey(sessionID)}
+}
+
+// DeleteSession removes the messages, the system message and the summary of a
+// session.
+func DeleteSession(client *goredis.Client, input DeleteSessionInput) DeleteSessionOutput {
+	ctx := context.Background()
+
+	_, err := client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, sessionKeys(input.SessionID)...)
+		pipe.HDel(ctx, legacySystemMessagesKey, input.SessionID)
+		return nil
+	})
+	if err != nil {
+		return DeleteSessionOutput{Status: false}
+	}
+
+	return DeleteSessionOutput{Status: true}
+}
+
+// ListSessions returns the IDs of the sessions with messages or a system
+// message, sorted alphabetically. The optional match parameter is a
+// glob-style pattern that the session IDs must match.
+func ListSessions(client *goredis.Client, input ListSessionsInput) ListSessionsOutput {
+	ctx := context.Background()
+
+	match := input.Match
+	if match == "" {
+		match = "*"
+	}
+
+	failed := ListSessionsOutput{SessionIDs: []string{}, Status: false}
+	ids := map[string]bool{}
+
+	for _, key := range []string{messagesKey(match), systemMessageKey(match)} {
+		suffix := strings.TrimPrefix(key, "chat_history:"+match)
+		iter := client.Scan(ctx, 0, key, 0).Iterator()
+		for iter.Next(ctx) {
+			id := strings.TrimSuffix(strings.TrimPrefix(iter.Val(), "chat_history:"), suffix)
+			ids[id] = true
+		}
+		if err := iter.Err(); err != nil {
+			return failed
+		}
+	}
+
+	hIter := client.HScan(ctx, legacySystemMessagesKey, 0, match, 0).Iterator()
+	for isKey := true; hIter.Next(ctx); isKey = !isKey {
+		// HSCAN returns the fields and their values alternately.
+		if isKey {
+			ids[hIter.Val()] = true
+		}
+	}
+	if err := hIter.Err(); err != nil {
+		return failed
+	}
+
+	sessionIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		sessionIDs = append(sessionIDs, id)
+	}
+	sort.Strings(sessionIDs)
+
+	return ListSessionsOutput{SessionIDs: sessionIDs, Status: true}
+}
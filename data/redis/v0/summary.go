@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// SummaryRole is the role of the message that holds the session summary.
+const SummaryRole = "system"
+
+// Summary is a rolling summary of the earliest messages of a session. Once
+// written, it replaces the messages it covers in the retrieved history.
+type Summary struct {
+	Content string `json:"content"`
+	// CoveredUntil is the score of the latest message covered by the
+	// summary. The scores of a session are strictly increasing, so the
+	// messages written after the summary have a greater score.
+	CoveredUntil int64 `json:"covered-until"`
+	Timestamp    int64 `json:"timestamp"`
+}
+
+type SummaryWriteInput struct {
+	SessionID string `json:"session-id"`
+	Summary   string `json:"summary"`
+	SessionSettings
+}
+
+type SummaryWriteOutput struct {
+	Status bool `json:"status"`
+}
+
+func (s *Summary) toMessage() *MultiModalMessage {
+	content := s.Content
+	metadata := map[string]interface{}{"summary": true}
+	return &MultiModalMessage{
+		Role: SummaryRole,
+		Content: []MultiModalContent{
+			{
+				Type: "text",
+				Text: &content,
+			},
+		},
+		Metadata: &metadata,
+	}
+}
+
+// WriteSummary stores the rolling summary of a session. The summary covers all
+// the messages written before it, so they won't be retrieved along with it.
+func WriteSummary(client *goredis.Client, input SummaryWriteInput) SummaryWriteOutput {
+	ctx := context.Background()
+
+	summary := Summary{
+		Content:   input.Summary,
+		Timestamp: time.Now().Unix(),
+	}
+
+	latest, err := client.ZRevRangeWithScores(ctx, messagesKey(input.SessionID), 0, 0).Result()
+	if err != nil {
+		return SummaryWriteOutput{Status: false}
+	}
+	if len(latest) > 0 {
+		summary.CoveredUntil = int64(latest[0].Score)
+	}
+
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		return SummaryWriteOutput{Status: false}
+	}
+
+	// Rewriting the summary shouldn't reset the expiration of the session.
+	if err := client.Set(ctx, summaryKey(input.SessionID), summaryJSON, goredis.KeepTTL).Err(); err != nil {
+		return SummaryWriteOutput{Status: false}
+	}
+
+	if err := ApplySessionSettings(client, input.SessionID, input.SessionSettings); err != nil {
+		return SummaryWriteOutput{Status: false}
+	}
+
+	return SummaryWriteOutput{Status: true}
+}
+
+// RetrieveSummary gets the rolling summary of a session, if any.
+func RetrieveSummary(client *goredis.Client, sessionID string) (bool, *Summary, error) {
+	serializedSummary, err := client.Get(context.Background(), summaryKey(sessionID)).Result()
+	if err == goredis.Nil {
+		return false, nil, nil
+	} else if err != nil {
+		return false, nil, err
+	}
+
+	var summary Summary
+	if err := json.Unmarshal([]byte(serializedSummary), &summary); err != nil {
+		return false, nil, err
+	}
+
+	return true, &summary, nil
+}
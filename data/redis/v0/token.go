@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"github.com/instill-ai/component/internal/tokens"
+)
+
+type tokenCounter func(*MultiModalMessage) int
+
+func newTokenCounter(modelName string) (tokenCounter, error) {
+	tc, err := tokens.ForModel(modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(m *MultiModalMessage) int {
+		var texts []string
+		for _, c := range m.Content {
+			if c.Text != nil {
+				texts = append(texts, *c.Text)
+			}
+		}
+		return tc.Message(m.Role, "", texts)
+	}, nil
+}
+
+// fitInBudget selects the latest messages that fit in the token budget. The
+// system message is always kept, and the messages are added from the latest to
+// the earliest until the budget is exhausted. The summary precedes the
+// earliest retrieved message, so it's only kept if all the messages and the
+// summary fit. It returns the kept summary (nil if dropped), the kept messages
+// in chronological order and the number of tokens they take.
+func fitInBudget(
+	system, summary *MultiModalMessage,
+	messages []*MultiModalMessage,
+	maxTokens int,
+	count tokenCounter,
+) (*MultiModalMessage, []*MultiModalMessage, int) {
+	used := 0
+	if system != nil {
+		used += count(system)
+	}
+
+	first := len(messages)
+	for i := len(messages) - 1; i >= 0; i-- {
+		n := count(messages[i])
+		if used+n > maxTokens {
+			break
+		}
+		used += n
+		first = i
+	}
+
+	if summary != nil {
+		if n := count(summary); first == 0 && used+n <= maxTokens {
+			used += n
+		} else {
+			summary = nil
+		}
+	}
+
+	return summary, messages[first:], used
+}
@@ -0,0 +1,63 @@
+package s3
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const defaultRegion = "us-east-1"
+
+func newClient(setup *structpb.Struct) (*s3.S3, error) {
+	cfg := &aws.Config{
+		Region: aws.String(getRegion(setup)),
+		Credentials: credentials.NewStaticCredentials(
+			getAccessKeyID(setup),
+			getSecretAccessKey(setup),
+			getSessionToken(setup),
+		),
+		// Most S3-compatible services (e.g. MinIO) don't support
+		// virtual-hosted-style requests.
+		S3ForcePathStyle: aws.Bool(getForcePathStyle(setup)),
+	}
+
+	if endpoint := getEndpoint(setup); endpoint != "" {
+		cfg.Endpoint = aws.String(endpoint)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.New(sess), nil
+}
+
+func getAccessKeyID(setup *structpb.Struct) string {
+	return setup.GetFields()["access-key-id"].GetStringValue()
+}
+
+func getSecretAccessKey(setup *structpb.Struct) string {
+	return setup.GetFields()["secret-access-key"].GetStringValue()
+}
+
+func getSessionToken(setup *structpb.Struct) string {
+	return setup.GetFields()["session-token"].GetStringValue()
+}
+
+func getRegion(setup *structpb.Struct) string {
+	if region := setup.GetFields()["region"].GetStringValue(); region != "" {
+		return region
+	}
+	return defaultRegion
+}
+
+func getEndpoint(setup *structpb.Struct) string {
+	return setup.GetFields()["endpoint"].GetStringValue()
+}
+
+func getForcePathStyle(setup *structpb.Struct) bool {
+	return setup.GetFields()["force-path-style"].GetBoolValue()
+}
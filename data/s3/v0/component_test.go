@@ -0,0 +1,646 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+)
+
+// fakeObject is an object stored in the fake S3 server.
+type fakeObject struct {
+	data        []byte
+	contentType string
+	metadata    map[string]string
+}
+
+// fakeS3Server is an in-memory stand-in for the subset of the S3 REST API
+// used by the component. Buckets are addressed in the URL path, as with
+// S3-compatible services like MinIO.
+type fakeS3Server struct {
+	mu      sync.Mutex
+	buckets map[string]map[string]*fakeObject
+}
+
+func newFakeS3Server(buckets ...string) *fakeS3Server {
+	s := &fakeS3Server{buckets: map[string]map[string]*fakeObject{}}
+	for _, b := range buckets {
+		s.buckets[b] = map[string]*fakeObject{}
+	}
+	return s
+}
+
+func (s *fakeS3Server) put(bucket, key string, obj *fakeObject) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buckets[bucket][key] = obj
+}
+
+func (s *fakeS3Server) get(bucket, key string) (*fakeObject, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.buckets[bucket][key]
+	return obj, ok
+}
+
+func etag(b []byte) string {
+	sum := md5.Sum(b)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+var lastModified = time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+
+func writeS3Error(w http.ResponseWriter, status int, code, msg string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, msg)
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	if key == "" {
+		s.serveBucket(w, r, bucket)
+		return
+	}
+
+	objects, ok := s.buckets[bucket]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if src := r.Header.Get("X-Amz-Copy-Source"); src != "" {
+			src, _ = url.PathUnescape(src)
+			srcBucket, srcKey, _ := strings.Cut(strings.TrimPrefix(src, "/"), "/")
+			srcObj, ok := s.buckets[srcBucket][srcKey]
+			if !ok {
+				writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+				return
+			}
+			cp := *srcObj
+			objects[key] = &cp
+			fmt.Fprintf(w, "<CopyObjectResult><ETag>%s</ETag><LastModified>%s</LastModified></CopyObjectResult>",
+				html(etag(cp.data)), lastModified.Format(time.RFC3339))
+			return
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		obj := &fakeObject{data: b, contentType: r.Header.Get("Content-Type"), metadata: map[string]string{}}
+		for k := range r.Header {
+			if name, ok := strings.CutPrefix(strings.ToLower(k), "x-amz-meta-"); ok {
+				obj.metadata[name] = r.Header.Get(k)
+			}
+		}
+		objects[key] = obj
+		w.Header().Set("ETag", etag(b))
+	case http.MethodGet, http.MethodHead:
+		obj, ok := objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.Header().Set("ETag", etag(obj.data))
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		for k, v := range obj.metadata {
+			w.Header().Set("X-Amz-Meta-"+k, v)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.data)
+		}
+	case http.MethodDelete:
+		delete(objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *fakeS3Server) serveBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	switch r.Method {
+	case http.MethodPut:
+		if _, ok := s.buckets[bucket]; ok {
+			writeS3Error(w, http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.")
+			return
+		}
+		s.buckets[bucket] = map[string]*fakeObject{}
+		w.Header().Set("Location", "/"+bucket)
+	case http.MethodGet:
+		objects, ok := s.buckets[bucket]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+			return
+		}
+
+		q := r.URL.Query()
+		prefix, delimiter, startAfter := q.Get("prefix"), q.Get("delimiter"), q.Get("start-after")
+
+		keys := make([]string, 0, len(objects))
+		for k := range objects {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var sb strings.Builder
+		sb.WriteString("<ListBucketResult><Name>" + bucket + "</Name><IsTruncated>false</IsTruncated>")
+		for _, k := range keys {
+			if !strings.HasPrefix(k, prefix) || k <= startAfter {
+				continue
+			}
+			if delimiter != "" && strings.Contains(strings.TrimPrefix(k, prefix), delimiter) {
+				continue
+			}
+			fmt.Fprintf(&sb, "<Contents><Key>%s</Key><Size>%d</Size><ETag>%s</ETag></Contents>",
+				html(k), len(objects[k].data), html(etag(objects[k].data)))
+		}
+		sb.WriteString("</ListBucketResult>")
+
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, sb.String())
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func html(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// runTask executes a task against the fake server and returns either the
+// output or the error passed to the job error handler.
+func runTask(c *qt.C, srv *httptest.Server, task string, input any) (map[string]any, error) {
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	setup, err := structpb.NewStruct(map[string]any{
+		"access-key-id":     "mock-key-id",
+		"secret-access-key": "mock-secret",
+		"endpoint":          srv.URL,
+		"force-path-style":  true,
+	})
+	c.Assert(err, qt.IsNil)
+
+	exec, err := cmp.CreateExecution(base.ComponentExecution{
+		Component: cmp,
+		Setup:     setup,
+		Task:      task,
+	})
+	c.Assert(err, qt.IsNil)
+
+	pbIn, err := base.ConvertToStructpb(input)
+	c.Assert(err, qt.IsNil)
+
+	var gotOutput map[string]any
+	var gotErr error
+
+	ir, ow, eh, job := base.GenerateMockJob(c)
+	ir.ReadMock.Return(pbIn, nil)
+	ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+		gotOutput = output.AsMap()
+		return nil
+	})
+	eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+		gotErr = err
+	})
+
+	err = exec.Execute(context.Background(), []*base.Job{job})
+	c.Assert(err, qt.IsNil)
+
+	return gotOutput, gotErr
+}
+
+func TestComponent_Upload(t *testing.T) {
+	c := qt.New(t)
+
+	fake := newFakeS3Server("bucket")
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+
+	data := base64.StdEncoding.EncodeToString([]byte("hello world"))
+
+	c.Run("ok - content type from data URI", func(c *qt.C) {
+		got, err := runTask(c, srv, taskUpload, UploadInput{
+			BucketName: "bucket",
+			ObjectName: "folder/hello.txt",
+			Data:       "data:text/plain;base64," + data,
+			Metadata:   map[string]string{"source": "pipeline"},
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(got["result"], qt.Equals, "Success")
+		c.Check(got["s3-uri"], qt.Equals, "s3://bucket/folder/hello.txt")
+		c.Check(got["etag"], qt.Equals, strings.Trim(etag([]byte("hello world")), `"`))
+
+		obj, ok := fake.get("bucket", "folder/hello.txt")
+		c.Assert(ok, qt.IsTrue)
+		c.Check(string(obj.data), qt.Equals, "hello world")
+		c.Check(obj.contentType, qt.Equals, "text/plain")
+		c.Check(obj.metadata, qt.DeepEquals, map[string]string{"source": "pipeline"})
+	})
+
+	c.Run("ok - explicit content type", func(c *qt.C) {
+		_, err := runTask(c, srv, taskUpload, UploadInput{
+			BucketName:  "bucket",
+			ObjectName:  "hello.md",
+			Data:        data,
+			ContentType: "text/markdown",
+		})
+		c.Assert(err, qt.IsNil)
+
+		obj, ok := fake.get("bucket", "hello.md")
+		c.Assert(ok, qt.IsTrue)
+		c.Check(obj.contentType, qt.Equals, "text/markdown")
+	})
+
+	c.Run("nok - missing bucket", func(c *qt.C) {
+		_, err := runTask(c, srv, taskUpload, UploadInput{
+			BucketName: "missing",
+			ObjectName: "hello.txt",
+			Data:       data,
+		})
+		c.Check(err, qt.ErrorMatches, "(?s)uploading object: NoSuchBucket: The specified bucket does not exist.*")
+	})
+}
+
+func TestComponent_GetObject(t *testing.T) {
+	c := qt.New(t)
+
+	fake := newFakeS3Server("bucket")
+	fake.put("bucket", "img.png", &fakeObject{
+		data:        []byte("png"),
+		contentType: "image/png",
+		metadata:    map[string]string{"owner": "me"},
+	})
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+
+	c.Run("ok - get object", func(c *qt.C) {
+		got, err := runTask(c, srv, taskGetObject, GetObjectInput{BucketName: "bucket", ObjectName: "img.png"})
+		c.Assert(err, qt.IsNil)
+		c.Check(got["data"], qt.Equals, "data:image/png;base64,"+base64.StdEncoding.EncodeToString([]byte("png")))
+
+		attrs := got["attributes"].(map[string]any)
+		c.Check(attrs["name"], qt.Equals, "img.png")
+		c.Check(attrs["content-type"], qt.Equals, "image/png")
+		c.Check(attrs["size"], qt.Equals, float64(3))
+		c.Check(attrs["last-modified"], qt.Equals, "2024-09-01T10:00:00Z")
+		c.Check(attrs["metadata"], qt.DeepEquals, map[string]any{"owner": "me"})
+	})
+
+	c.Run("nok - object not found", func(c *qt.C) {
+		_, err := runTask(c, srv, taskGetObject, GetObjectInput{BucketName: "bucket", ObjectName: "missing.png"})
+		c.Check(err, qt.ErrorMatches, "(?s)getting object missing.png: NoSuchKey: The specified key does not exist.*")
+	})
+}
+
+func TestComponent_ReadObjects(t *testing.T) {
+	c := qt.New(t)
+
+	fake := newFakeS3Server("bucket")
+	fake.put("bucket", "docs/a.txt", &fakeObject{data: []byte("a"), contentType: "text/plain"})
+	fake.put("bucket", "docs/b.md", &fakeObject{data: []byte("b"), contentType: "text/markdown"})
+	fake.put("bucket", "docs/nested/c.txt", &fakeObject{data: []byte("c"), contentType: "text/plain"})
+	fake.put("bucket", "docs/d.pdf", &fakeObject{data: []byte("d"), contentType: "application/pdf"})
+	fake.put("bucket", "img/e.png", &fakeObject{data: []byte("e"), contentType: "image/png"})
+	fake.put("bucket", "audio/f.mp3", &fakeObject{data: []byte("f"), contentType: "audio/mpeg"})
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+
+	names := func(objs any) []string {
+		var names []string
+		for _, o := range objs.([]any) {
+			names = append(names, o.(map[string]any)["attributes"].(map[string]any)["name"].(string))
+		}
+		return names
+	}
+
+	testcases := []struct {
+		name      string
+		input     ReadInput
+		wantText  []string
+		wantDocs  []string
+		wantImage []string
+		wantAudio []string
+		wantErr   string
+	}{
+		{
+			name:      "ok - all objects",
+			input:     ReadInput{BucketName: "bucket"},
+			wantText:  []string{"docs/a.txt", "docs/b.md", "docs/nested/c.txt"},
+			wantDocs:  []string{"docs/d.pdf"},
+			wantImage: []string{"img/e.png"},
+			wantAudio: []string{"audio/f.mp3"},
+		},
+		{
+			name:     "ok - prefix and delimiter",
+			input:    ReadInput{BucketName: "bucket", Prefix: "docs/", Delimiter: "/"},
+			wantText: []string{"docs/a.txt", "docs/b.md"},
+			wantDocs: []string{"docs/d.pdf"},
+		},
+		{
+			name:     "ok - recursive glob with alternation",
+			input:    ReadInput{BucketName: "bucket", MatchGlob: "docs/**/*.{txt,pdf}"},
+			wantText: []string{"docs/a.txt", "docs/nested/c.txt"},
+			wantDocs: []string{"docs/d.pdf"},
+		},
+		{
+			name:     "ok - max keys and start after",
+			input:    ReadInput{BucketName: "bucket", Prefix: "docs/", StartAfter: "docs/a.txt", MaxKeys: 2},
+			wantText: []string{"docs/b.md"},
+			wantDocs: []string{"docs/d.pdf"},
+		},
+		{
+			name:    "nok - invalid glob",
+			input:   ReadInput{BucketName: "bucket", MatchGlob: "docs/{a,b"},
+			wantErr: "invalid match glob: unterminated alternation",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			got, err := runTask(c, srv, taskReadObjects, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(names(got["text-objects"]), qt.DeepEquals, tc.wantText)
+			c.Check(names(got["document-objects"]), qt.DeepEquals, tc.wantDocs)
+			c.Check(names(got["image-objects"]), qt.DeepEquals, tc.wantImage)
+			c.Check(names(got["audio-objects"]), qt.DeepEquals, tc.wantAudio)
+			c.Check(got["video-objects"], qt.HasLen, 0)
+		})
+	}
+
+	c.Run("ok - text is plain, binary is a data URI", func(c *qt.C) {
+		got, err := runTask(c, srv, taskReadObjects, ReadInput{BucketName: "bucket", MatchGlob: "{docs/a.txt,img/*}"})
+		c.Assert(err, qt.IsNil)
+		c.Check(got["text-objects"].([]any)[0].(map[string]any)["data"], qt.Equals, "a")
+		c.Check(got["image-objects"].([]any)[0].(map[string]any)["data"], qt.Equals, "data:image/png;base64,ZQ==")
+	})
+}
+
+func TestComponent_DeleteObject(t *testing.T) {
+	c := qt.New(t)
+
+	fake := newFakeS3Server("bucket")
+	fake.put("bucket", "a.txt", &fakeObject{data: []byte("a"), contentType: "text/plain"})
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+
+	got, err := runTask(c, srv, taskDeleteObject, DeleteObjectInput{BucketName: "bucket", ObjectName: "a.txt"})
+	c.Assert(err, qt.IsNil)
+	c.Check(got["result"], qt.Equals, "Success")
+
+	_, ok := fake.get("bucket", "a.txt")
+	c.Check(ok, qt.IsFalse)
+}
+
+func TestComponent_CopyObject(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name       string
+		input      CopyObjectInput
+		wantBucket string
+		wantObject string
+		wantSource bool
+		wantErr    string
+	}{
+		{
+			name: "ok - copy across buckets",
+			input: CopyObjectInput{
+				BucketName:            "raw",
+				ObjectName:            "my doc.pdf",
+				DestinationBucketName: "processed",
+			},
+			wantBucket: "processed",
+			wantObject: "my doc.pdf",
+			wantSource: true,
+		},
+		{
+			name: "ok - move within bucket",
+			input: CopyObjectInput{
+				BucketName:            "raw",
+				ObjectName:            "my doc.pdf",
+				DestinationObjectName: "archive/my doc.pdf",
+				DeleteSource:          true,
+			},
+			wantBucket: "raw",
+			wantObject: "archive/my doc.pdf",
+			wantSource: false,
+		},
+		{
+			name: "nok - same object",
+			input: CopyObjectInput{
+				BucketName: "raw",
+				ObjectName: "my doc.pdf",
+			},
+			wantErr: "source and destination are the same object",
+		},
+		{
+			name: "nok - missing source",
+			input: CopyObjectInput{
+				BucketName:            "raw",
+				ObjectName:            "missing.pdf",
+				DestinationBucketName: "processed",
+			},
+			wantErr: "(?s)copying object: NoSuchKey: The specified key does not exist.*",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			fake := newFakeS3Server("raw", "processed")
+			fake.put("raw", "my doc.pdf", &fakeObject{data: []byte("pdf"), contentType: "application/pdf"})
+			srv := httptest.NewServer(fake)
+			c.Cleanup(srv.Close)
+
+			got, err := runTask(c, srv, taskCopyObject, tc.input)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(got["result"], qt.Equals, "Success")
+			c.Check(got["s3-uri"], qt.Equals, fmt.Sprintf("s3://%s/%s", tc.wantBucket, tc.wantObject))
+
+			dst, ok := fake.get(tc.wantBucket, tc.wantObject)
+			c.Assert(ok, qt.IsTrue)
+			c.Check(string(dst.data), qt.Equals, "pdf")
+
+			_, ok = fake.get("raw", "my doc.pdf")
+			c.Check(ok, qt.Equals, tc.wantSource)
+		})
+	}
+}
+
+func TestComponent_GeneratePresignedURL(t *testing.T) {
+	c := qt.New(t)
+
+	fake := newFakeS3Server("bucket")
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+
+	c.Run("ok - default method and expiration", func(c *qt.C) {
+		before := time.Now().UTC().Truncate(time.Second)
+		got, err := runTask(c, srv, taskGeneratePresignedURL, PresignedURLInput{
+			BucketName: "bucket",
+			ObjectName: "folder/a.txt",
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(got["method"], qt.Equals, "GET")
+
+		u, err := url.Parse(got["url"].(string))
+		c.Assert(err, qt.IsNil)
+		c.Check(u.Path, qt.Equals, "/bucket/folder/a.txt")
+		c.Check(u.Query().Get("X-Amz-Expires"), qt.Equals, "900")
+		c.Check(u.Query().Get("X-Amz-Signature"), qt.Not(qt.Equals), "")
+		c.Check(strings.HasPrefix(u.Query().Get("X-Amz-Credential"), "mock-key-id/"), qt.IsTrue)
+
+		expiresAt, err := time.Parse(time.RFC3339, got["expiration-time"].(string))
+		c.Assert(err, qt.IsNil)
+		c.Check(expiresAt.Before(before.Add(15*time.Minute)), qt.IsFalse)
+		c.Check(expiresAt.After(time.Now().Add(15*time.Minute)), qt.IsFalse)
+	})
+
+	c.Run("ok - PUT", func(c *qt.C) {
+		got, err := runTask(c, srv, taskGeneratePresignedURL, PresignedURLInput{
+			BucketName: "bucket",
+			ObjectName: "a.txt",
+			Method:     "put",
+			Expiration: 60,
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(got["method"], qt.Equals, "PUT")
+
+		u, err := url.Parse(got["url"].(string))
+		c.Assert(err, qt.IsNil)
+		c.Check(u.Query().Get("X-Amz-Expires"), qt.Equals, "60")
+	})
+
+	c.Run("nok - expiration too long", func(c *qt.C) {
+		_, err := runTask(c, srv, taskGeneratePresignedURL, PresignedURLInput{
+			BucketName: "bucket",
+			ObjectName: "a.txt",
+			Expiration: 604801,
+		})
+		c.Check(err, qt.ErrorMatches, "expiration can't exceed 604800 seconds")
+	})
+
+	c.Run("nok - unsupported method", func(c *qt.C) {
+		_, err := runTask(c, srv, taskGeneratePresignedURL, PresignedURLInput{
+			BucketName: "bucket",
+			ObjectName: "a.txt",
+			Method:     "POST",
+		})
+		c.Check(err, qt.ErrorMatches, "unsupported method: POST")
+	})
+}
+
+func TestComponent_CreateBucket(t *testing.T) {
+	c := qt.New(t)
+
+	fake := newFakeS3Server("existing")
+	srv := httptest.NewServer(fake)
+	c.Cleanup(srv.Close)
+
+	c.Run("ok - create bucket", func(c *qt.C) {
+		got, err := runTask(c, srv, taskCreateBucket, CreateBucketInput{BucketName: "new"})
+		c.Assert(err, qt.IsNil)
+		c.Check(got["result"], qt.Equals, "Success")
+		c.Check(got["name"], qt.Equals, "new")
+		c.Check(got["region"], qt.Equals, "us-east-1")
+		c.Check(got["location"], qt.Equals, "/new")
+	})
+
+	c.Run("nok - bucket exists", func(c *qt.C) {
+		_, err := runTask(c, srv, taskCreateBucket, CreateBucketInput{BucketName: "existing"})
+		c.Check(err, qt.ErrorMatches, "(?s)creating bucket: BucketAlreadyOwnedByYou: .*")
+	})
+}
+
+func TestComponent_UnsupportedTask(t *testing.T) {
+	c := qt.New(t)
+	cmp := Init(base.Component{Logger: zap.NewNop()})
+
+	setup, err := structpb.NewStruct(map[string]any{
+		"access-key-id":     "mock-key-id",
+		"secret-access-key": "mock-secret",
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = cmp.CreateExecution(base.ComponentExecution{
+		Component: cmp,
+		Setup:     setup,
+		Task:      "FOOBAR",
+	})
+	c.Check(err, qt.ErrorMatches, "not supported task: FOOBAR")
+}
+
+func TestGlob(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{pattern: "*.txt", key: "a.txt", want: true},
+		{pattern: "*.txt", key: "dir/a.txt", want: false},
+		{pattern: "**.txt", key: "dir/a.txt", want: true},
+		{pattern: "dir/**/*.txt", key: "dir/a.txt", want: true},
+		{pattern: "dir/**/*.txt", key: "dir/x/y/a.txt", want: true},
+		{pattern: "dir/**/*.txt", key: "other/a.txt", want: false},
+		{pattern: "file?.csv", key: "file1.csv", want: true},
+		{pattern: "file?.csv", key: "file10.csv", want: false},
+		{pattern: "file[0-9].csv", key: "file7.csv", want: true},
+		{pattern: "file[!0-9].csv", key: "file7.csv", want: false},
+		{pattern: "*.{jpg,png}", key: "a.png", want: true},
+		{pattern: "*.{jpg,png}", key: "a.gif", want: false},
+		{pattern: "a+b(1).txt", key: "a+b(1).txt", want: true},
+		{pattern: `\*.txt`, key: "*.txt", want: true},
+		{pattern: `\*.txt`, key: "a.txt", want: false},
+	}
+
+	for _, tc := range testcases {
+		c.Run(fmt.Sprintf("%s matches %s", tc.pattern, tc.key), func(c *qt.C) {
+			g, err := compileGlob(tc.pattern)
+			c.Assert(err, qt.IsNil)
+			c.Check(g.match(tc.key), qt.Equals, tc.want)
+		})
+	}
+
+	c.Run("nok - unterminated class", func(c *qt.C) {
+		_, err := compileGlob("file[0-9.csv")
+		c.Check(err, qt.ErrorMatches, "unterminated character class at position 4")
+	})
+}
@@ -0,0 +1,80 @@
+package s3
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type CopyObjectInput struct {
+	BucketName            string `json:"bucket-name"`
+	ObjectName            string `json:"object-name"`
+	DestinationBucketName string `json:"destination-bucket-name"`
+	DestinationObjectName string `json:"destination-object-name"`
+	DeleteSource          bool   `json:"delete-source"`
+}
+
+type CopyObjectOutput struct {
+	Result string `json:"result"`
+	S3URI  string `json:"s3-uri"`
+	ETag   string `json:"etag"`
+}
+
+func (e *execution) copyObject(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CopyObjectInput
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	dstBucket := inputStruct.DestinationBucketName
+	if dstBucket == "" {
+		dstBucket = inputStruct.BucketName
+	}
+	dstObject := inputStruct.DestinationObjectName
+	if dstObject == "" {
+		dstObject = inputStruct.ObjectName
+	}
+	if dstBucket == inputStruct.BucketName && dstObject == inputStruct.ObjectName {
+		return nil, fmt.Errorf("source and destination are the same object")
+	}
+
+	// The copy source is expressed as "bucket/key", where the key must be
+	// URL-encoded.
+	copySource := inputStruct.BucketName + "/" + url.PathEscape(inputStruct.ObjectName)
+	resp, err := e.client.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String(dstBucket),
+		Key:        aws.String(dstObject),
+		CopySource: aws.String(copySource),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("copying object: %w", err)
+	}
+
+	// Moving an object is a copy followed by the deletion of the source, as
+	// S3 has no native rename operation.
+	if inputStruct.DeleteSource {
+		_, err := e.client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(inputStruct.BucketName),
+			Key:    aws.String(inputStruct.ObjectName),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("deleting source object: %w", err)
+		}
+	}
+
+	output := CopyObjectOutput{
+		Result: "Success",
+		S3URI:  s3URI(dstBucket, dstObject),
+	}
+	if resp.CopyObjectResult != nil {
+		output.ETag = strings.Trim(aws.StringValue(resp.CopyObjectResult.ETag), `"`)
+	}
+
+	return base.ConvertToStructpb(output)
+}
@@ -0,0 +1,56 @@
+package s3
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type CreateBucketInput struct {
+	BucketName string `json:"bucket-name"`
+	Region     string `json:"region"`
+}
+
+type CreateBucketOutput struct {
+	Result   string `json:"result"`
+	Name     string `json:"name"`
+	Region   string `json:"region"`
+	Location string `json:"location"`
+}
+
+func (e *execution) createBucket(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CreateBucketInput
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	region := inputStruct.Region
+	if region == "" {
+		region = getRegion(e.Setup)
+	}
+
+	createInput := &s3.CreateBucketInput{Bucket: aws.String(inputStruct.BucketName)}
+
+	// S3 rejects an explicit location constraint for the default region.
+	if region != defaultRegion {
+		createInput.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(region),
+		}
+	}
+
+	resp, err := e.client.CreateBucket(createInput)
+	if err != nil {
+		return nil, fmt.Errorf("creating bucket: %w", err)
+	}
+
+	return base.ConvertToStructpb(CreateBucketOutput{
+		Result:   "Success",
+		Name:     inputStruct.BucketName,
+		Region:   region,
+		Location: aws.StringValue(resp.Location),
+	})
+}
@@ -0,0 +1,37 @@
+package s3
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type DeleteObjectInput struct {
+	BucketName string `json:"bucket-name"`
+	ObjectName string `json:"object-name"`
+}
+
+type DeleteObjectOutput struct {
+	Result string `json:"result"`
+}
+
+func (e *execution) deleteObject(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct DeleteObjectInput
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	_, err := e.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(inputStruct.BucketName),
+		Key:    aws.String(inputStruct.ObjectName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("deleting object: %w", err)
+	}
+
+	return base.ConvertToStructpb(DeleteObjectOutput{Result: "Success"})
+}
@@ -0,0 +1,105 @@
+package s3
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type GetObjectInput struct {
+	BucketName string `json:"bucket-name"`
+	ObjectName string `json:"object-name"`
+}
+
+type GetObjectOutput struct {
+	Data       string     `json:"data"`
+	Attributes Attributes `json:"attributes"`
+}
+
+type Attributes struct {
+	Name               string            `json:"name"`
+	ContentType        string            `json:"content-type"`
+	ContentLanguage    string            `json:"content-language"`
+	Size               int64             `json:"size"`
+	ContentEncoding    string            `json:"content-encoding"`
+	ContentDisposition string            `json:"content-disposition"`
+	ETag               string            `json:"etag"`
+	LastModified       string            `json:"last-modified"`
+	Metadata           map[string]string `json:"metadata"`
+	StorageClass       string            `json:"storage-class"`
+}
+
+func (e *execution) getObject(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct GetObjectInput
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	b, attrs, err := e.fetchObject(inputStruct.BucketName, inputStruct.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The content is returned as a data URI so it can be passed directly to
+	// the upload task or to any component accepting base64 files.
+	return base.ConvertToStructpb(GetObjectOutput{
+		Data:       toDataURI(attrs.ContentType, b),
+		Attributes: attrs,
+	})
+}
+
+func (e *execution) fetchObject(bucket, object string) ([]byte, Attributes, error) {
+	resp, err := e.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return nil, Attributes{}, fmt.Errorf("getting object %s: %w", object, err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, Attributes{}, fmt.Errorf("reading object %s: %w", object, err)
+	}
+
+	attrs := Attributes{
+		Name:               object,
+		ContentType:        aws.StringValue(resp.ContentType),
+		ContentLanguage:    aws.StringValue(resp.ContentLanguage),
+		Size:               aws.Int64Value(resp.ContentLength),
+		ContentEncoding:    aws.StringValue(resp.ContentEncoding),
+		ContentDisposition: aws.StringValue(resp.ContentDisposition),
+		ETag:               strings.Trim(aws.StringValue(resp.ETag), `"`),
+		Metadata:           map[string]string{},
+		StorageClass:       aws.StringValue(resp.StorageClass),
+	}
+	// S3 stores metadata keys in lowercase but the SDK canonicalizes the
+	// header names, so the keys are normalized back.
+	for k, v := range resp.Metadata {
+		attrs.Metadata[strings.ToLower(k)] = aws.StringValue(v)
+	}
+	if resp.LastModified != nil {
+		attrs.LastModified = resp.LastModified.UTC().Format(time.RFC3339)
+	}
+	if attrs.Size == 0 {
+		attrs.Size = int64(len(b))
+	}
+
+	return b, attrs, nil
+}
+
+func toDataURI(contentType string, b []byte) string {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(b))
+}
@@ -0,0 +1,101 @@
+package s3
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// globMatcher matches object keys against a glob pattern. S3 doesn't support
+// glob filtering natively, so the matching happens on the listed keys. The
+// supported syntax mirrors the one of Cloud Storage's matchGlob:
+//   - `*` matches any sequence of characters except `/`.
+//   - `**` matches any sequence of characters, including `/`.
+//   - `?` matches a single character other than `/`.
+//   - `[abc]`, `[a-z]` and `[!abc]` match character classes.
+//   - `{a,b}` matches any of the comma-separated alternatives.
+type globMatcher struct {
+	re *regexp.Regexp
+}
+
+func compileGlob(pattern string) (*globMatcher, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	inAlternation := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// `**/` also matches zero directories.
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+					continue
+				}
+				sb.WriteString(".*")
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class at position %d", i)
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case '{':
+			if inAlternation {
+				return nil, fmt.Errorf("nested alternation at position %d", i)
+			}
+			inAlternation = true
+			sb.WriteString("(?:")
+		case '}':
+			if !inAlternation {
+				return nil, fmt.Errorf("unexpected '}' at position %d", i)
+			}
+			inAlternation = false
+			sb.WriteString(")")
+		case ',':
+			if inAlternation {
+				sb.WriteString("|")
+				continue
+			}
+			sb.WriteString(",")
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+				continue
+			}
+			sb.WriteString(`\\`)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if inAlternation {
+		return nil, fmt.Errorf("unterminated alternation")
+	}
+
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &globMatcher{re: re}, nil
+}
+
+func (g *globMatcher) match(key string) bool {
+	return g.re.MatchString(key)
+}
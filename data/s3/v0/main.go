@@ -0,0 +1,116 @@
+//go:generate compogen readme ./config ./README.mdx
+package s3
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	_ "embed"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	taskUpload               = "TASK_UPLOAD"
+	taskReadObjects          = "TASK_READ_OBJECTS"
+	taskGetObject            = "TASK_GET_OBJECT"
+	taskDeleteObject         = "TASK_DELETE_OBJECT"
+	taskCopyObject           = "TASK_COPY_OBJECT"
+	taskGeneratePresignedURL = "TASK_GENERATE_PRESIGNED_URL"
+	taskCreateBucket         = "TASK_CREATE_BUCKET"
+)
+
+//go:embed config/definition.json
+var definitionJSON []byte
+
+//go:embed config/setup.json
+var setupJSON []byte
+
+//go:embed config/tasks.json
+var tasksJSON []byte
+
+var once sync.Once
+var comp *component
+
+type component struct {
+	base.Component
+}
+
+type execution struct {
+	base.ComponentExecution
+
+	execute func(*structpb.Struct) (*structpb.Struct, error)
+	client  *s3.S3
+}
+
+// Init returns an implementation of IComponent that interacts with Amazon S3
+// and S3-compatible object storage services.
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{Component: bc}
+		err := comp.LoadDefinition(definitionJSON, setupJSON, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	return comp
+}
+
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	client, err := newClient(x.Setup)
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 client: %w", err)
+	}
+
+	e := &execution{
+		ComponentExecution: x,
+		client:             client,
+	}
+
+	switch x.Task {
+	case taskUpload:
+		e.execute = e.upload
+	case taskReadObjects:
+		e.execute = e.readObjects
+	case taskGetObject:
+		e.execute = e.getObject
+	case taskDeleteObject:
+		e.execute = e.deleteObject
+	case taskCopyObject:
+		e.execute = e.copyObject
+	case taskGeneratePresignedURL:
+		e.execute = e.generatePresignedURL
+	case taskCreateBucket:
+		e.execute = e.createBucket
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("not supported task: %s", x.Task),
+			fmt.Sprintf("%s task is not supported.", x.Task),
+		)
+	}
+
+	return e, nil
+}
+
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	return base.SequentialExecutor(ctx, jobs, e.execute)
+}
+
+func (c *component) Test(sysVars map[string]any, setup *structpb.Struct) error {
+	client, err := newClient(setup)
+	if err != nil {
+		return fmt.Errorf("creating S3 client: %w", err)
+	}
+
+	if _, err := client.ListBuckets(&s3.ListBucketsInput{}); err != nil {
+		return fmt.Errorf("listing buckets: %w", err)
+	}
+
+	return nil
+}
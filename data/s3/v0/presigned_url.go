@@ -0,0 +1,86 @@
+package s3
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+const (
+	defaultPresignExpiration = 15 * time.Minute
+	// SigV4 presigned URLs can't be valid for longer than 7 days.
+	maxPresignExpiration = 7 * 24 * time.Hour
+)
+
+type PresignedURLInput struct {
+	BucketName  string `json:"bucket-name"`
+	ObjectName  string `json:"object-name"`
+	Method      string `json:"method"`
+	Expiration  int    `json:"expiration"`
+	ContentType string `json:"content-type"`
+}
+
+type PresignedURLOutput struct {
+	URL            string `json:"url"`
+	Method         string `json:"method"`
+	ExpirationTime string `json:"expiration-time"`
+}
+
+func (e *execution) generatePresignedURL(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct PresignedURLInput
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	expiration := defaultPresignExpiration
+	if inputStruct.Expiration > 0 {
+		expiration = time.Duration(inputStruct.Expiration) * time.Second
+	}
+	if expiration > maxPresignExpiration {
+		return nil, fmt.Errorf("expiration can't exceed %d seconds", int(maxPresignExpiration.Seconds()))
+	}
+
+	bucket, key := aws.String(inputStruct.BucketName), aws.String(inputStruct.ObjectName)
+
+	method := strings.ToUpper(inputStruct.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	var req *request.Request
+	switch method {
+	case "GET":
+		req, _ = e.client.GetObjectRequest(&s3.GetObjectInput{Bucket: bucket, Key: key})
+	case "PUT":
+		putInput := &s3.PutObjectInput{Bucket: bucket, Key: key}
+		if inputStruct.ContentType != "" {
+			putInput.ContentType = aws.String(inputStruct.ContentType)
+		}
+		req, _ = e.client.PutObjectRequest(putInput)
+	case "DELETE":
+		req, _ = e.client.DeleteObjectRequest(&s3.DeleteObjectInput{Bucket: bucket, Key: key})
+	case "HEAD":
+		req, _ = e.client.HeadObjectRequest(&s3.HeadObjectInput{Bucket: bucket, Key: key})
+	default:
+		return nil, fmt.Errorf("unsupported method: %s", inputStruct.Method)
+	}
+
+	signedAt := time.Now()
+	url, err := req.Presign(expiration)
+	if err != nil {
+		return nil, fmt.Errorf("presigning request: %w", err)
+	}
+
+	return base.ConvertToStructpb(PresignedURLOutput{
+		URL:            url,
+		Method:         method,
+		ExpirationTime: signedAt.Add(expiration).UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,112 @@
+package s3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type ReadInput struct {
+	BucketName string `json:"bucket-name"`
+	Prefix     string `json:"prefix"`
+	Delimiter  string `json:"delimiter"`
+	StartAfter string `json:"start-after"`
+	MatchGlob  string `json:"match-glob"`
+	MaxKeys    int64  `json:"max-keys"`
+}
+
+type ReadOutput struct {
+	TextObjects     []Object `json:"text-objects"`
+	ImageObjects    []Object `json:"image-objects"`
+	DocumentObjects []Object `json:"document-objects"`
+	AudioObjects    []Object `json:"audio-objects"`
+	VideoObjects    []Object `json:"video-objects"`
+}
+
+type Object struct {
+	Data       string     `json:"data"`
+	Attributes Attributes `json:"attributes"`
+}
+
+func (e *execution) readObjects(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct ReadInput
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	var glob *globMatcher
+	if inputStruct.MatchGlob != "" {
+		var err error
+		if glob, err = compileGlob(inputStruct.MatchGlob); err != nil {
+			return nil, fmt.Errorf("invalid match glob: %w", err)
+		}
+	}
+
+	listInput := &s3.ListObjectsV2Input{
+		Bucket: aws.String(inputStruct.BucketName),
+	}
+	if inputStruct.Prefix != "" {
+		listInput.Prefix = aws.String(inputStruct.Prefix)
+	}
+	if inputStruct.Delimiter != "" {
+		listInput.Delimiter = aws.String(inputStruct.Delimiter)
+	}
+	if inputStruct.StartAfter != "" {
+		listInput.StartAfter = aws.String(inputStruct.StartAfter)
+	}
+
+	var keys []string
+	err := e.client.ListObjectsV2Pages(listInput, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+			if glob != nil && !glob.match(key) {
+				continue
+			}
+
+			keys = append(keys, key)
+			if inputStruct.MaxKeys > 0 && int64(len(keys)) >= inputStruct.MaxKeys {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing objects: %w", err)
+	}
+
+	output := ReadOutput{
+		TextObjects:     []Object{},
+		ImageObjects:    []Object{},
+		DocumentObjects: []Object{},
+		AudioObjects:    []Object{},
+		VideoObjects:    []Object{},
+	}
+
+	for _, key := range keys {
+		b, attrs, err := e.fetchObject(inputStruct.BucketName, key)
+		if err != nil {
+			return nil, err
+		}
+
+		contentType := attrs.ContentType
+		switch {
+		case strings.Contains(contentType, "text"):
+			output.TextObjects = append(output.TextObjects, Object{Data: string(b), Attributes: attrs})
+		case strings.Contains(contentType, "image"):
+			output.ImageObjects = append(output.ImageObjects, Object{Data: toDataURI(contentType, b), Attributes: attrs})
+		case strings.Contains(contentType, "audio"):
+			output.AudioObjects = append(output.AudioObjects, Object{Data: toDataURI(contentType, b), Attributes: attrs})
+		case strings.Contains(contentType, "video"):
+			output.VideoObjects = append(output.VideoObjects, Object{Data: toDataURI(contentType, b), Attributes: attrs})
+		default:
+			output.DocumentObjects = append(output.DocumentObjects, Object{Data: toDataURI(contentType, b), Attributes: attrs})
+		}
+	}
+
+	return base.ConvertToStructpb(output)
+}
@@ -0,0 +1,88 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+type UploadInput struct {
+	BucketName  string            `json:"bucket-name"`
+	ObjectName  string            `json:"object-name"`
+	Data        string            `json:"data"`
+	ContentType string            `json:"content-type"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+type UploadOutput struct {
+	Result string `json:"result"`
+	S3URI  string `json:"s3-uri"`
+	ETag   string `json:"etag"`
+}
+
+func (e *execution) upload(in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct UploadInput
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	b, err := base64.StdEncoding.DecodeString(base.TrimBase64Mime(inputStruct.Data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding data: %w", err)
+	}
+
+	contentType := inputStruct.ContentType
+	if contentType == "" {
+		contentType = mimeFromDataURI(inputStruct.Data)
+	}
+
+	putInput := &s3.PutObjectInput{
+		Bucket: aws.String(inputStruct.BucketName),
+		Key:    aws.String(inputStruct.ObjectName),
+		Body:   bytes.NewReader(b),
+	}
+	if contentType != "" {
+		putInput.ContentType = aws.String(contentType)
+	}
+	if len(inputStruct.Metadata) > 0 {
+		putInput.Metadata = aws.StringMap(inputStruct.Metadata)
+	}
+
+	resp, err := e.client.PutObject(putInput)
+	if err != nil {
+		return nil, fmt.Errorf("uploading object: %w", err)
+	}
+
+	return base.ConvertToStructpb(UploadOutput{
+		Result: "Success",
+		S3URI:  s3URI(inputStruct.BucketName, inputStruct.ObjectName),
+		ETag:   strings.Trim(aws.StringValue(resp.ETag), `"`),
+	})
+}
+
+// mimeFromDataURI extracts the MIME type from a data URI, returning an empty
+// string if the input doesn't have a data URI prefix.
+func mimeFromDataURI(data string) string {
+	if !strings.HasPrefix(data, "data:") {
+		return ""
+	}
+
+	header, _, found := strings.Cut(strings.TrimPrefix(data, "data:"), ",")
+	if !found {
+		return ""
+	}
+
+	mime, _, _ := strings.Cut(header, ";")
+	return mime
+}
+
+func s3URI(bucket, object string) string {
+	return fmt.Sprintf("s3://%s/%s", bucket, object)
+}
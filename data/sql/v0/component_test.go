@@ -594,3 +594,337 @@ func TestComponent_ExecuteDropTableTask(t *testing.T) {
 		})
 	}
 }
+
+// MockVectorSQLClient checks the statements sent for the pgvector tasks and
+// returns the rows of a vector search.
+type MockVectorSQLClient struct {
+	c         *qt.C
+	wantQuery string
+	wantArgs  []any
+	rows      *sqlmock.Rows
+}
+
+func (m *MockVectorSQLClient) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	m.c.Check(query, qt.Equals, m.wantQuery)
+	m.c.Check(args, qt.DeepEquals, m.wantArgs)
+
+	mockDB, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+	mock.ExpectQuery(query).WillReturnRows(m.rows)
+
+	return sqlxDB.Queryx(query, args...)
+}
+
+func (m *MockVectorSQLClient) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	if strings.HasPrefix(query, "CREATE EXTENSION") {
+		return sqlmock.NewResult(0, 0), nil
+	}
+	m.c.Check(query, qt.Equals, m.wantQuery)
+	return sqlmock.NewResult(0, 1), nil
+}
+
+func TestComponent_ExecuteVectorSearchTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name      string
+		engine    string
+		input     VectorSearchInput
+		wantQuery string
+		wantResp  VectorSearchOutput
+		wantErr   string
+	}{
+		{
+			name:   "cosine search",
+			engine: "PostgreSQL",
+			input: VectorSearchInput{
+				TableName:    "items",
+				VectorColumn: "embedding",
+				Vector:       []float64{0.1, 0.2, 0.3},
+				Limit:        2,
+				Filter:       "category = 'book'",
+				Columns:      []string{"id", "embedding"},
+			},
+			wantQuery: "SELECT id, embedding, embedding <=> $1 AS distance FROM items WHERE (category = 'book') ORDER BY distance LIMIT 2",
+			wantResp: VectorSearchOutput{
+				Status: "Successfully selected 1 rows",
+				Rows: []map[string]any{
+					{"id": "1", "embedding": []float64{0.1, 0.2, 0.3}, "distance": 0.25, "similarity": 0.75},
+				},
+			},
+		},
+		{
+			name:   "l2 search",
+			engine: "PostgreSQL",
+			input: VectorSearchInput{
+				TableName:    "items",
+				VectorColumn: "embedding",
+				Vector:       []float64{1, 2},
+				Metric:       "l2",
+				Limit:        1,
+			},
+			wantQuery: "SELECT *, embedding <-> $1 AS distance FROM items ORDER BY distance LIMIT 1",
+			wantResp: VectorSearchOutput{
+				Status: "Successfully selected 1 rows",
+				Rows: []map[string]any{
+					{"id": "1", "distance": 1.0, "similarity": 0.5},
+				},
+			},
+		},
+		{
+			name:   "nok - unsupported engine",
+			engine: "MySQL",
+			input: VectorSearchInput{
+				TableName:    "items",
+				VectorColumn: "embedding",
+				Vector:       []float64{1, 2},
+				Limit:        1,
+			},
+			wantErr: `vector operations are only supported by the PostgreSQL engine, got "MySQL"`,
+		},
+		{
+			name:   "nok - unsupported metric",
+			engine: "PostgreSQL",
+			input: VectorSearchInput{
+				TableName:    "items",
+				VectorColumn: "embedding",
+				Vector:       []float64{1, 2},
+				Metric:       "hamming",
+				Limit:        1,
+			},
+			wantErr: "unsupported metric: hamming",
+		},
+		{
+			name:   "nok - injected vector column",
+			engine: "PostgreSQL",
+			input: VectorSearchInput{
+				TableName:    "items",
+				VectorColumn: "x,(SELECT/**/pg_sleep(10))",
+				Vector:       []float64{1, 2},
+				Limit:        1,
+			},
+			wantErr: `invalid vector column "x,\(SELECT/\*\*/pg_sleep\(10\)\)": .*`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"engine":   tc.engine,
+				"user":     "test_user",
+				"password": "test_pass",
+				"name":     "test_db",
+				"host":     "localhost",
+				"port":     "5432",
+				"ssl-tls": map[string]any{
+					"ssl-tls-type": "NO TLS",
+				},
+			})
+			c.Assert(err, qt.IsNil)
+
+			rows := sqlmock.NewRows([]string{"id", "distance"}).AddRow("1", 1.0)
+			if len(tc.input.Columns) > 0 {
+				rows = sqlmock.NewRows([]string{"id", "embedding", "distance"}).AddRow("1", []byte("[0.1,0.2,0.3]"), 0.25)
+			}
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskVectorSearch},
+				client: &MockVectorSQLClient{
+					c:         c,
+					wantQuery: tc.wantQuery,
+					wantArgs:  []any{formatVector(tc.input.Vector)},
+					rows:      rows,
+				},
+			}
+			e.execute = e.vectorSearch
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(tc.wantErr, qt.Not(qt.Equals), "")
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+		})
+	}
+}
+
+func TestComponent_ExecuteCreateVectorIndexTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name      string
+		input     CreateVectorIndexInput
+		wantQuery string
+		wantErr   string
+	}{
+		{
+			name: "hnsw index",
+			input: CreateVectorIndexInput{
+				TableName:      "items",
+				VectorColumn:   "embedding",
+				IndexName:      "items_embedding_idx",
+				IndexType:      "hnsw",
+				Metric:         "inner-product",
+				M:              16,
+				EfConstruction: 64,
+			},
+			wantQuery: "CREATE INDEX items_embedding_idx ON items USING hnsw (embedding vector_ip_ops) WITH (m = 16, ef_construction = 64);",
+		},
+		{
+			name: "ivfflat index",
+			input: CreateVectorIndexInput{
+				TableName:    "items",
+				VectorColumn: "embedding",
+				IndexType:    "ivfflat",
+				Metric:       "l2",
+				Lists:        100,
+			},
+			wantQuery: "CREATE INDEX ON items USING ivfflat (embedding vector_l2_ops) WITH (lists = 100);",
+		},
+		{
+			name: "nok - unsupported index type",
+			input: CreateVectorIndexInput{
+				TableName:    "items",
+				VectorColumn: "embedding",
+				IndexType:    "btree",
+			},
+			wantErr: "unsupported index type: btree",
+		},
+		{
+			name: "ok - table qualified by a schema",
+			input: CreateVectorIndexInput{
+				TableName:    "public.items",
+				VectorColumn: "embedding",
+				IndexType:    "hnsw",
+			},
+			wantQuery: "CREATE INDEX ON public.items USING hnsw (embedding vector_cosine_ops);",
+		},
+		{
+			name: "nok - injected index name",
+			input: CreateVectorIndexInput{
+				TableName:    "items",
+				VectorColumn: "embedding",
+				IndexName:    "idx;DROP/**/TABLE/**/items;--",
+				IndexType:    "hnsw",
+			},
+			wantErr: `invalid index name .*`,
+		},
+		{
+			name: "nok - injected table name",
+			input: CreateVectorIndexInput{
+				TableName:    "items(id);DROP/**/TABLE/**/items;--",
+				VectorColumn: "embedding",
+				IndexType:    "hnsw",
+			},
+			wantErr: `invalid table name .*`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"engine": "PostgreSQL",
+				"ssl-tls": map[string]any{
+					"ssl-tls-type": "NO TLS",
+				},
+			})
+			c.Assert(err, qt.IsNil)
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskCreateVectorIndex},
+				client:             &MockVectorSQLClient{c: c, wantQuery: tc.wantQuery},
+			}
+			e.execute = e.createVectorIndex
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				c.Check(output.Fields["status"].GetStringValue(), qt.Equals, "Successfully created 1 vector index")
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(tc.wantErr, qt.Not(qt.Equals), "")
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+		})
+	}
+}
+
+func TestComponent_ExecuteCreateTableWithVectorColumns(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	setup, err := structpb.NewStruct(map[string]any{
+		"engine": "PostgreSQL",
+		"ssl-tls": map[string]any{
+			"ssl-tls-type": "NO TLS",
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	e := &execution{
+		ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskCreateTable},
+		client:             &MockVectorSQLClient{c: c, wantQuery: "CREATE TABLE items (embedding vector(3));"},
+	}
+	e.execute = e.createTable
+
+	pbIn, err := base.ConvertToStructpb(CreateTableInput{
+		TableName:     "items",
+		VectorColumns: map[string]int{"embedding": 3},
+	})
+	c.Assert(err, qt.IsNil)
+
+	ir, ow, eh, job := base.GenerateMockJob(c)
+	ir.ReadMock.Return(pbIn, nil)
+	ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+		c.Check(output.Fields["status"].GetStringValue(), qt.Equals, "Successfully created 1 table")
+		return nil
+	})
+	eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+		c.Error(err)
+	})
+
+	err = e.Execute(ctx, []*base.Job{job})
+	c.Assert(err, qt.IsNil)
+}
+
+func TestFormatVectorValues(t *testing.T) {
+	c := qt.New(t)
+
+	got := formatVectorValues(map[string]any{
+		"id":        "1",
+		"embedding": []any{0.5, 1.0, -2.25},
+		"tags":      []any{"a", "b"},
+	})
+	c.Check(got, qt.DeepEquals, map[string]any{
+		"id":        "1",
+		"embedding": "[0.5,1,-2.25]",
+		"tags":      []any{"a", "b"},
+	})
+}
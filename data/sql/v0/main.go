@@ -24,6 +24,9 @@ const (
 	TaskDelete      = "TASK_DELETE"
 	TaskCreateTable = "TASK_CREATE_TABLE"
 	TaskDropTable   = "TASK_DROP_TABLE"
+
+	TaskVectorSearch      = "TASK_VECTOR_SEARCH"
+	TaskCreateVectorIndex = "TASK_CREATE_VECTOR_INDEX"
 )
 
 //go:embed config/definition.json
@@ -90,6 +93,10 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.dropTable
 	case TaskInsertMany:
 		e.execute = e.insertMany
+	case TaskVectorSearch:
+		e.execute = e.vectorSearch
+	case TaskCreateVectorIndex:
+		e.execute = e.createVectorIndex
 	default:
 		return nil, errmsg.AddMessage(
 			fmt.Errorf("not supported task: %s", x.Task),
@@ -0,0 +1,347 @@
+package sql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+)
+
+// pgvector support is only available for the PostgreSQL engine.
+const pgvectorEngine = "PostgreSQL"
+
+// pgvectorMetric holds the distance operator and the index operator class
+// pgvector uses for a given metric.
+type pgvectorMetric struct {
+	operator string
+	opsClass string
+}
+
+var pgvectorMetrics = map[string]pgvectorMetric{
+	"l2":            {operator: "<->", opsClass: "vector_l2_ops"},
+	"cosine":        {operator: "<=>", opsClass: "vector_cosine_ops"},
+	"inner-product": {operator: "<#>", opsClass: "vector_ip_ops"},
+}
+
+// identifierRegexp matches the unquoted identifiers. The identifiers of the
+// vector tasks are inserted into the statements, so anything else is
+// rejected.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isValidIdentifier checks an identifier of a vector task. Table names can be
+// qualified by a schema, e.g. public.items.
+func isValidIdentifier(kind, name string, allowSchema bool) error {
+	parts := []string{name}
+	if allowSchema {
+		parts = strings.SplitN(name, ".", 2)
+	}
+	for _, part := range parts {
+		if !identifierRegexp.MatchString(part) {
+			return fmt.Errorf("invalid %s %q: must start with a letter or underscore, followed by letters, digits or underscores", kind, name)
+		}
+	}
+	return nil
+}
+
+type VectorSearchInput struct {
+	TableName    string    `json:"table-name"`
+	VectorColumn string    `json:"vector-column"`
+	Vector       []float64 `json:"vector"`
+	Metric       string    `json:"metric"`
+	Limit        int       `json:"limit"`
+	Filter       string    `json:"filter"`
+	Columns      []string  `json:"columns"`
+}
+
+type VectorSearchOutput struct {
+	Rows   []map[string]any `json:"rows"`
+	Status string           `json:"status"`
+}
+
+type CreateVectorIndexInput struct {
+	TableName      string `json:"table-name"`
+	VectorColumn   string `json:"vector-column"`
+	IndexName      string `json:"index-name"`
+	IndexType      string `json:"index-type"`
+	Metric         string `json:"metric"`
+	Lists          int    `json:"lists"`
+	M              int    `json:"m"`
+	EfConstruction int    `json:"ef-construction"`
+}
+
+type CreateVectorIndexOutput struct {
+	Status string `json:"status"`
+}
+
+func requirePgvector(setup *structpb.Struct) error {
+	if engine := getEngine(setup); engine != pgvectorEngine {
+		return fmt.Errorf("vector operations are only supported by the %s engine, got %q", pgvectorEngine, engine)
+	}
+	return nil
+}
+
+func getPgvectorMetric(metric string) (pgvectorMetric, error) {
+	if metric == "" {
+		metric = "cosine"
+	}
+	m, ok := pgvectorMetrics[metric]
+	if !ok {
+		return pgvectorMetric{}, fmt.Errorf("unsupported metric: %s", metric)
+	}
+	return m, nil
+}
+
+// similarity converts a pgvector distance into a score where higher means
+// more similar. Note that <#> returns the negative inner product.
+func similarity(metric string, distance float64) float64 {
+	switch metric {
+	case "l2":
+		return 1 / (1 + distance)
+	case "inner-product":
+		return -distance
+	default:
+		return 1 - distance
+	}
+}
+
+// formatVector renders a vector as a pgvector literal, e.g. [1,2.5,3].
+func formatVector(vector []float64) string {
+	values := make([]string, len(vector))
+	for i, v := range vector {
+		values[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return "[" + strings.Join(values, ",") + "]"
+}
+
+// toVectorLiteral returns the pgvector literal of a value if it is a
+// non-empty array of numbers.
+func toVectorLiteral(value any) (string, bool) {
+	arr, ok := value.([]any)
+	if !ok || len(arr) == 0 {
+		return "", false
+	}
+	vector := make([]float64, len(arr))
+	for i, v := range arr {
+		f, ok := v.(float64)
+		if !ok {
+			return "", false
+		}
+		vector[i] = f
+	}
+	return formatVector(vector), true
+}
+
+// formatVectorValues replaces the numeric array values of a row with their
+// pgvector literal so they can be bound to vector columns.
+func formatVectorValues(data map[string]any) map[string]any {
+	formatted := make(map[string]any, len(data))
+	for k, v := range data {
+		if literal, ok := toVectorLiteral(v); ok {
+			formatted[k] = literal
+			continue
+		}
+		formatted[k] = v
+	}
+	return formatted
+}
+
+// parseVector converts a pgvector value returned by the database into a
+// list of numbers. Values that can't be parsed are returned as they are.
+func parseVector(value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	var vector []float64
+	if err := json.Unmarshal([]byte(s), &vector); err != nil {
+		return value
+	}
+	return vector
+}
+
+func buildSQLStatementVectorSearch(inputStruct VectorSearchInput, metric pgvectorMetric) string {
+	columns := "*"
+	if len(inputStruct.Columns) > 0 {
+		columns = strings.Join(inputStruct.Columns, ", ")
+	}
+
+	sqlStatement := fmt.Sprintf("SELECT %s, %s %s $1 AS distance FROM %s", columns, inputStruct.VectorColumn, metric.operator, inputStruct.TableName)
+	if inputStruct.Filter != "" {
+		sqlStatement += " WHERE (" + inputStruct.Filter + ")"
+	}
+	sqlStatement += fmt.Sprintf(" ORDER BY distance LIMIT %d", inputStruct.Limit)
+
+	return sqlStatement
+}
+
+func buildSQLStatementCreateVectorIndex(inputStruct CreateVectorIndexInput, metric pgvectorMetric) (string, error) {
+	var params []string
+	switch inputStruct.IndexType {
+	case "ivfflat":
+		if inputStruct.Lists > 0 {
+			params = append(params, fmt.Sprintf("lists = %d", inputStruct.Lists))
+		}
+	case "hnsw":
+		if inputStruct.M > 0 {
+			params = append(params, fmt.Sprintf("m = %d", inputStruct.M))
+		}
+		if inputStruct.EfConstruction > 0 {
+			params = append(params, fmt.Sprintf("ef_construction = %d", inputStruct.EfConstruction))
+		}
+	default:
+		return "", fmt.Errorf("unsupported index type: %s", inputStruct.IndexType)
+	}
+
+	sqlStatement := "CREATE INDEX "
+	if inputStruct.IndexName != "" {
+		sqlStatement += inputStruct.IndexName + " "
+	}
+	sqlStatement += fmt.Sprintf("ON %s USING %s (%s %s)", inputStruct.TableName, inputStruct.IndexType, inputStruct.VectorColumn, metric.opsClass)
+	if len(params) > 0 {
+		sqlStatement += " WITH (" + strings.Join(params, ", ") + ")"
+	}
+
+	return sqlStatement + ";", nil
+}
+
+func (e *execution) vectorSearch(in *structpb.Struct) (*structpb.Struct, error) {
+	if err := requirePgvector(e.Setup); err != nil {
+		return nil, err
+	}
+
+	var inputStruct VectorSearchInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+	if err := isValidIdentifier("table name", inputStruct.TableName, true); err != nil {
+		return nil, err
+	}
+	if err := isValidIdentifier("vector column", inputStruct.VectorColumn, false); err != nil {
+		return nil, err
+	}
+	if len(inputStruct.Vector) == 0 {
+		return nil, fmt.Errorf("vector can't be empty")
+	}
+	if inputStruct.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	metric, err := getPgvectorMetric(inputStruct.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	// The distance operators aren't understood by the query parser, so the
+	// filter and columns are validated through an equivalent select.
+	err = isValidQuery(buildSQLStatementSelect(inputStruct.TableName, inputStruct.Filter, inputStruct.Limit, inputStruct.Columns))
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStatement := buildSQLStatementVectorSearch(inputStruct, metric)
+	rows, err := e.client.Queryx(sqlStatement, formatVector(inputStruct.Vector))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []map[string]any{}
+
+	for rows.Next() {
+		rowMap := make(map[string]any)
+
+		err := rows.MapScan(rowMap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		for key, value := range rowMap {
+			switch v := value.(type) {
+			case []byte:
+				rowMap[key] = string(v)
+			}
+		}
+		if v, ok := rowMap[inputStruct.VectorColumn]; ok {
+			rowMap[inputStruct.VectorColumn] = parseVector(v)
+		}
+
+		distance, ok := rowMap["distance"].(float64)
+		if !ok {
+			distance, err = strconv.ParseFloat(fmt.Sprint(rowMap["distance"]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse distance: %v", err)
+			}
+		}
+		rowMap["distance"] = distance
+		rowMap["similarity"] = similarity(inputStruct.Metric, distance)
+
+		result = append(result, rowMap)
+	}
+
+	outputStruct := VectorSearchOutput{
+		Rows:   result,
+		Status: fmt.Sprintf("Successfully selected %d rows", len(result)),
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (e *execution) createVectorIndex(in *structpb.Struct) (*structpb.Struct, error) {
+	if err := requirePgvector(e.Setup); err != nil {
+		return nil, err
+	}
+
+	var inputStruct CreateVectorIndexInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+	if err := isValidIdentifier("table name", inputStruct.TableName, true); err != nil {
+		return nil, err
+	}
+	if err := isValidIdentifier("vector column", inputStruct.VectorColumn, false); err != nil {
+		return nil, err
+	}
+	// The index is created in the schema of the table, so its name can't be
+	// qualified. Without a name, PostgreSQL generates one.
+	if inputStruct.IndexName != "" {
+		if err := isValidIdentifier("index name", inputStruct.IndexName, false); err != nil {
+			return nil, err
+		}
+	}
+
+	metric, err := getPgvectorMetric(inputStruct.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStatement, err := buildSQLStatementCreateVectorIndex(inputStruct, metric)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = e.client.NamedExec(sqlStatement, map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := CreateVectorIndexOutput{
+		Status: "Successfully created 1 vector index",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
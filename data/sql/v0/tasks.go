@@ -62,6 +62,7 @@ type DeleteOutput struct {
 type CreateTableInput struct {
 	TableName        string            `json:"table-name"`
 	ColumnsStructure map[string]string `json:"columns-structure"`
+	VectorColumns    map[string]int    `json:"vector-columns"`
 }
 
 type CreateTableOutput struct {
@@ -119,12 +120,14 @@ func buildSQLStatementInsertMany(tableName string, data []map[string]any) (strin
 		columns = append(columns, dataKey)
 	}
 
+	// Placeholders follow the column order so every row binds its values to
+	// the right columns.
 	for no, dataMap := range data {
 		var placeholder []string
-		for dataKey, dataValue := range dataMap {
+		for _, dataKey := range columns {
 			modifiedDataKey := fmt.Sprintf("%s%d", dataKey, no)
 			placeholder = append(placeholder, ":"+modifiedDataKey)
-			values[modifiedDataKey] = dataValue
+			values[modifiedDataKey] = dataMap[dataKey]
 		}
 		placeholders = append(placeholders, "("+strings.Join(placeholder, ", ")+")")
 	}
@@ -221,6 +224,10 @@ func (e *execution) insert(in *structpb.Struct) (*structpb.Struct, error) {
 		return nil, err
 	}
 
+	if getEngine(e.Setup) == pgvectorEngine {
+		inputStruct.Data = formatVectorValues(inputStruct.Data)
+	}
+
 	sqlStatement, values := buildSQLStatementInsert(inputStruct.TableName, &inputStruct.Data)
 
 	err = isValidQuery(sqlStatement)
@@ -382,12 +389,36 @@ func (e *execution) createTable(in *structpb.Struct) (*structpb.Struct, error) {
 		return nil, err
 	}
 
+	// Vector columns rely on the pgvector extension, which is enabled before
+	// creating the table.
+	if len(inputStruct.VectorColumns) > 0 {
+		if err := requirePgvector(e.Setup); err != nil {
+			return nil, err
+		}
+		if inputStruct.ColumnsStructure == nil {
+			inputStruct.ColumnsStructure = map[string]string{}
+		}
+		for colName, dimension := range inputStruct.VectorColumns {
+			if dimension <= 0 {
+				return nil, fmt.Errorf("invalid dimension for vector column %s: %d", colName, dimension)
+			}
+			inputStruct.ColumnsStructure[colName] = fmt.Sprintf("vector(%d)", dimension)
+		}
+	}
+
 	sqlStatement, values := buildSQLStatementCreateTable(inputStruct.TableName, inputStruct.ColumnsStructure)
 	err = isValidQuery(sqlStatement)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(inputStruct.VectorColumns) > 0 {
+		_, err = e.client.NamedExec("CREATE EXTENSION IF NOT EXISTS vector;", map[string]any{})
+		if err != nil {
+			return nil, fmt.Errorf("enabling pgvector extension: %w", err)
+		}
+	}
+
 	_, err = e.client.NamedExec(sqlStatement, values)
 
 	if err != nil {
@@ -450,6 +481,12 @@ func (e *execution) insertMany(in *structpb.Struct) (*structpb.Struct, error) {
 		return nil, err
 	}
 
+	if getEngine(e.Setup) == pgvectorEngine {
+		for i, data := range inputStruct.ArrayData {
+			inputStruct.ArrayData[i] = formatVectorValues(data)
+		}
+	}
+
 	sqlStatement, values := buildSQLStatementInsertMany(inputStruct.TableName, inputStruct.ArrayData)
 	err = isValidQuery(sqlStatement)
 	if err != nil {
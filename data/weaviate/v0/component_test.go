@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	qt "github.com/frankban/quicktest"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
 )
 
 func TestComponent_ExecuteInsertTask(t *testing.T) {
@@ -163,6 +165,16 @@ func TestComponent_ExecuteDeleteCollectionTask(t *testing.T) {
 				Status: "Successfully deleted 1 collection",
 			},
 		},
+		{
+			name: "ok to delete tenant",
+			input: DeleteCollectionInput{
+				CollectionName: "test_coll",
+				Tenant:         "customer-a",
+			},
+			wantResp: DeleteCollectionOutput{
+				Status: "Successfully deleted tenant customer-a",
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -238,6 +250,15 @@ func TestComponent_ExecuteVectorSearchTask(t *testing.T) {
 			},
 			Successful: 1,
 		},
+		{
+			name: "nok - vector search with several near arguments",
+			input: VectorSearchInput{
+				CollectionName: "test_coll",
+				Vector:         []float32{0.1, 0.2},
+				ObjectID:       "36ddd591-2dee-4e7e-a3cc-eb86d30a4303",
+			},
+			wantErr: "only one of vector, object-id or text can be provided",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -287,30 +308,77 @@ func TestComponent_ExecuteBatchInsertTask(t *testing.T) {
 	bc := base.Component{Logger: zap.NewNop()}
 	connector := Init(bc)
 
+	metadata := []map[string]any{
+		{"name": "test1", "email": "test1@example.com"},
+		{"name": "test2", "email": "test2@example.com"},
+	}
+	vectors := [][]float32{
+		{0.1, 0.2},
+		{0.3, 0.4},
+	}
+
+	// Objects without an ID get one derived from their content.
+	id1, err := batchupsert.ContentID(vectors[0], metadata[0])
+	c.Assert(err, qt.IsNil)
+	id2, err := batchupsert.ContentID(vectors[1], metadata[1])
+	c.Assert(err, qt.IsNil)
+
 	testcases := []struct {
-		name       string
-		input      BatchInsertInput
-		wantResp   BatchInsertOutput
-		wantErr    string
-		Successful int
+		name        string
+		input       BatchInsertInput
+		wantResp    BatchInsertOutput
+		wantErr     string
+		batchErrors map[string]string
 	}{
 		{
 			name: "ok to insert many",
 			input: BatchInsertInput{
-				ArrayMetadata: []map[string]any{
-					{"name": "test1", "email": "test1@example.com"},
-					{"name": "test2", "email": "test2@example.com"},
-				},
-				ArrayVector: [][]float32{
-					{0.1, 0.2},
-					{0.3, 0.4},
-				},
+				ArrayMetadata:  metadata,
+				ArrayVector:    vectors,
 				CollectionName: "test_coll",
 			},
 			wantResp: BatchInsertOutput{
 				Status: "Successfully batch inserted 2 objects",
+				Results: []batchupsert.Result{
+					{Index: 0, ID: id1, Success: true},
+					{Index: 1, ID: id2, Success: true},
+				},
+			},
+		},
+		{
+			name: "ok to insert many with partial failure",
+			input: BatchInsertInput{
+				ArrayID:        []string{"36ddd591-2dee-4e7e-a3cc-eb86d30a4303", ""},
+				ArrayMetadata:  metadata,
+				ArrayVector:    vectors,
+				CollectionName: "test_coll",
+				Options: batchupsert.Options{
+					BatchSize:           1,
+					AllowPartialFailure: true,
+				},
+			},
+			batchErrors: map[string]string{
+				"36ddd591-2dee-4e7e-a3cc-eb86d30a4303": "invalid property email",
+			},
+			wantResp: BatchInsertOutput{
+				Status: "Successfully batch inserted 1 objects, 1 failed",
+				Results: []batchupsert.Result{
+					{Index: 0, ID: "36ddd591-2dee-4e7e-a3cc-eb86d30a4303", Error: "invalid property email"},
+					{Index: 1, ID: id2, Success: true},
+				},
+			},
+		},
+		{
+			name: "nok - insert many with failure",
+			input: BatchInsertInput{
+				ArrayMetadata:  metadata,
+				ArrayVector:    vectors,
+				CollectionName: "test_coll",
+			},
+			batchErrors: map[string]string{
+				id2: "invalid property email",
 			},
-			Successful: 2,
+			wantErr: "upserting record 1: invalid property email",
 		},
 	}
 
@@ -325,7 +393,7 @@ func TestComponent_ExecuteBatchInsertTask(t *testing.T) {
 			e := &execution{
 				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskBatchInsert},
 				mockClient: &MockWeaviateClient{
-					Successful: tc.Successful,
+					BatchErrors: tc.batchErrors,
 				},
 			}
 			e.execute = e.batchInsert
@@ -417,3 +485,309 @@ func TestComponent_ExecuteUpdateTask(t *testing.T) {
 		})
 	}
 }
+
+func TestComponent_ExecuteCreateCollectionTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name     string
+		input    CreateCollectionInput
+		wantResp CreateCollectionOutput
+		wantErr  string
+	}{
+		{
+			name: "ok to create collection",
+			input: CreateCollectionInput{
+				CollectionName: "test_coll",
+				Properties: []map[string]any{
+					{"name": "title", "dataType": []string{"text"}},
+				},
+				Vectorizer:   "text2vec-openai",
+				MultiTenancy: true,
+				Tenants:      []string{"customer-a"},
+			},
+			wantResp: CreateCollectionOutput{
+				Status: "Successfully created 1 collection",
+			},
+		},
+		{
+			name: "nok - tenants without multi-tenancy",
+			input: CreateCollectionInput{
+				CollectionName: "test_coll",
+				Tenants:        []string{"customer-a"},
+			},
+			wantErr: "tenants require multi-tenancy to be enabled",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"url":     "mock-url",
+				"api-key": "mock-api-key",
+			})
+			c.Assert(err, qt.IsNil)
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskCreateCollection},
+				mockClient:         &MockWeaviateClient{},
+			}
+			e.execute = e.createCollection
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				if tc.wantErr != "" {
+					c.Assert(err, qt.ErrorMatches, tc.wantErr)
+				}
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+
+		})
+	}
+}
+
+func TestComponent_ExecuteAggregateTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name      string
+		input     AggregateInput
+		wantResp  AggregateOutput
+		wantErr   string
+		Aggregate []AggregateGroup
+	}{
+		{
+			name: "ok to aggregate",
+			input: AggregateInput{
+				CollectionName: "test_coll",
+				GroupBy:        "category",
+				Aggregations:   map[string][]string{"price": {"mean"}},
+				Tenant:         "customer-a",
+			},
+			Aggregate: []AggregateGroup{
+				{GroupedBy: "books", Count: 2, Properties: map[string]any{"price": map[string]any{"mean": 12.5}}},
+			},
+			wantResp: AggregateOutput{
+				Status: "Successfully aggregated 1 groups",
+				Groups: []AggregateGroup{
+					{GroupedBy: "books", Count: 2, Properties: map[string]any{"price": map[string]any{"mean": 12.5}}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"url":     "mock-url",
+				"api-key": "mock-api-key",
+			})
+			c.Assert(err, qt.IsNil)
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskAggregate},
+				mockClient: &MockWeaviateClient{
+					Aggregate: tc.Aggregate,
+				},
+			}
+			e.execute = e.aggregate
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				if tc.wantErr != "" {
+					c.Assert(err, qt.ErrorMatches, tc.wantErr)
+				}
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+
+		})
+	}
+}
+
+func TestComponent_ExecuteAddReferenceTask(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	bc := base.Component{Logger: zap.NewNop()}
+	connector := Init(bc)
+
+	testcases := []struct {
+		name     string
+		input    AddReferenceInput
+		wantResp AddReferenceOutput
+		wantErr  string
+	}{
+		{
+			name: "ok to add reference",
+			input: AddReferenceInput{
+				CollectionName:       "test_coll",
+				ID:                   "36ddd591-2dee-4e7e-a3cc-eb86d30a4303",
+				ReferenceProperty:    "hasAuthor",
+				TargetCollectionName: "author",
+				TargetID:             "8f1a7e2c-1f6b-4d0e-9d0a-3a8f3c1b2d4e",
+			},
+			wantResp: AddReferenceOutput{
+				Status: "Successfully added 1 reference",
+			},
+		},
+		{
+			name: "nok - add reference without target",
+			input: AddReferenceInput{
+				CollectionName:    "test_coll",
+				ID:                "36ddd591-2dee-4e7e-a3cc-eb86d30a4303",
+				ReferenceProperty: "hasAuthor",
+			},
+			wantErr: "id and target-id must be provided",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			setup, err := structpb.NewStruct(map[string]any{
+				"url":     "mock-url",
+				"api-key": "mock-api-key",
+			})
+			c.Assert(err, qt.IsNil)
+
+			e := &execution{
+				ComponentExecution: base.ComponentExecution{Component: connector, SystemVariables: nil, Setup: setup, Task: TaskAddReference},
+				mockClient:         &MockWeaviateClient{},
+			}
+			e.execute = e.addReference
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantResp)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				if tc.wantErr != "" {
+					c.Assert(err, qt.ErrorMatches, tc.wantErr)
+				}
+			})
+
+			err = e.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+
+		})
+	}
+}
+
+func TestBuildClass(t *testing.T) {
+	c := qt.New(t)
+
+	class, err := buildClass(CreateCollectionInput{
+		CollectionName: "Article",
+		Properties: []map[string]any{
+			{"name": "title", "dataType": []any{"text"}, "tokenization": "word"},
+			{"name": "hasAuthor", "dataType": []any{"Author"}},
+		},
+		Vectorizer:         "text2vec-openai",
+		ModuleConfig:       map[string]any{"text2vec-openai": map[string]any{"model": "ada"}},
+		MultiTenancy:       true,
+		AutoTenantCreation: true,
+	})
+	c.Assert(err, qt.IsNil)
+
+	got, err := json.Marshal(class)
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.JSONEquals, map[string]any{
+		"class": "Article",
+		"properties": []any{
+			map[string]any{"name": "title", "dataType": []any{"text"}, "tokenization": "word"},
+			map[string]any{"name": "hasAuthor", "dataType": []any{"Author"}},
+		},
+		"vectorizer":   "text2vec-openai",
+		"moduleConfig": map[string]any{"text2vec-openai": map[string]any{"model": "ada"}},
+		"multiTenancyConfig": map[string]any{
+			"enabled":              true,
+			"autoTenantCreation":   true,
+			"autoTenantActivation": false,
+		},
+	})
+
+	_, err = buildClass(CreateCollectionInput{
+		CollectionName: "Article",
+		Properties:     []map[string]any{{"name": "title"}},
+	})
+	c.Check(err, qt.ErrorMatches, "properties must have a name and a dataType")
+}
+
+func TestAggregateFields(t *testing.T) {
+	c := qt.New(t)
+
+	got := aggregateFields("category", map[string][]string{
+		"price":    {"mean", "maximum"},
+		"category": {"topOccurrences"},
+	})
+	c.Check(got, qt.DeepEquals, []graphql.Field{
+		{Name: "meta", Fields: []graphql.Field{{Name: "count"}}},
+		{Name: "groupedBy", Fields: []graphql.Field{{Name: "value"}}},
+		{Name: "category", Fields: []graphql.Field{
+			{Name: "topOccurrences", Fields: []graphql.Field{{Name: "value"}, {Name: "occurs"}}},
+		}},
+		{Name: "price", Fields: []graphql.Field{{Name: "mean"}, {Name: "maximum"}}},
+	})
+}
+
+func TestParseAggregateResult(t *testing.T) {
+	c := qt.New(t)
+
+	var res map[string]any
+	err := json.Unmarshal([]byte(`{
+		"data": {
+			"Aggregate": {
+				"Product": [
+					{"meta": {"count": 2}, "groupedBy": {"value": "books", "path": ["category"]}, "price": {"mean": 12.5}},
+					{"meta": {"count": 1}, "groupedBy": {"value": "games", "path": ["category"]}, "price": {"mean": 40}}
+				]
+			}
+		}
+	}`), &res)
+	c.Assert(err, qt.IsNil)
+
+	groups, err := parseAggregateResult("Product", res)
+	c.Assert(err, qt.IsNil)
+	c.Check(groups, qt.DeepEquals, []AggregateGroup{
+		{GroupedBy: "books", Count: 2, Properties: map[string]any{"price": map[string]any{"mean": 12.5}}},
+		{GroupedBy: "games", Count: 1, Properties: map[string]any{"price": map[string]any{"mean": 40.0}}},
+	})
+
+	_, err = parseAggregateResult("Unknown", res)
+	c.Check(err, qt.ErrorMatches, "unexpected type for aggregate result")
+}
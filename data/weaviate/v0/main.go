@@ -3,6 +3,7 @@ package weaviate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
 	"github.com/instill-ai/x/errmsg"
 )
 
@@ -22,6 +24,9 @@ const (
 	TaskDelete           = "TASK_DELETE"
 	TaskBatchInsert      = "TASK_BATCH_INSERT"
 	TaskDeleteCollection = "TASK_DELETE_COLLECTION"
+	TaskCreateCollection = "TASK_CREATE_COLLECTION"
+	TaskAggregate        = "TASK_AGGREGATE"
+	TaskAddReference     = "TASK_ADD_REFERENCE"
 )
 
 //go:embed config/definition.json
@@ -51,6 +56,24 @@ type execution struct {
 type MockWeaviateClient struct {
 	Successful   int
 	VectorSearch Result
+	Aggregate    []AggregateGroup
+	// BatchErrors holds the errors of the objects that fail to be inserted
+	// in a batch, by object ID.
+	BatchErrors map[string]string
+}
+
+func (m *MockWeaviateClient) batchErrors(ids []string, start, end int) error {
+	errs := batchupsert.RecordErrors{}
+	for i := start; i < end; i++ {
+		if msg, ok := m.BatchErrors[ids[i]]; ok {
+			errs[i] = errors.New(msg)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
 }
 
 func Init(bc base.Component) *component {
@@ -84,6 +107,12 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.batchInsert
 	case TaskDeleteCollection:
 		e.execute = e.deleteCollection
+	case TaskCreateCollection:
+		e.execute = e.createCollection
+	case TaskAggregate:
+		e.execute = e.aggregate
+	case TaskAddReference:
+		e.execute = e.addReference
 	default:
 		return nil, errmsg.AddMessage(
 			fmt.Errorf("not supported task: %s", x.Task),
@@ -3,7 +3,10 @@ package weaviate
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/strfmt"
@@ -15,6 +18,7 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
 )
 
 type InsertInput struct {
@@ -22,6 +26,7 @@ type InsertInput struct {
 	CollectionName string         `json:"collection-name"`
 	Vector         []float32      `json:"vector"`
 	Metadata       map[string]any `json:"metadata"`
+	Tenant         string         `json:"tenant"`
 }
 
 type InsertOutput struct {
@@ -31,6 +36,8 @@ type InsertOutput struct {
 type VectorSearchInput struct {
 	CollectionName string         `json:"collection-name"`
 	Vector         []float32      `json:"vector"`
+	ObjectID       string         `json:"object-id"`
+	Text           []string       `json:"text"`
 	Filter         map[string]any `json:"filter"`
 	Limit          int            `json:"limit"`
 	Fields         []string       `json:"fields"`
@@ -54,6 +61,7 @@ type UpdateInput struct {
 	CollectionName string         `json:"collection-name"`
 	Metadata       map[string]any `json:"update-metadata"`
 	Vector         []float32      `json:"update-vector"`
+	Tenant         string         `json:"tenant"`
 }
 
 type UpdateOutput struct {
@@ -64,6 +72,7 @@ type DeleteInput struct {
 	ID             string         `json:"id"`
 	CollectionName string         `json:"collection-name"`
 	Filter         map[string]any `json:"filter"`
+	Tenant         string         `json:"tenant"`
 }
 
 type DeleteOutput struct {
@@ -75,20 +84,74 @@ type BatchInsertInput struct {
 	CollectionName string           `json:"collection-name"`
 	ArrayMetadata  []map[string]any `json:"array-metadata"`
 	ArrayVector    [][]float32      `json:"array-vector"`
+	Tenant         string           `json:"tenant"`
+
+	batchupsert.Options
 }
 
 type BatchInsertOutput struct {
-	Status string `json:"status"`
+	Status  string               `json:"status"`
+	Results []batchupsert.Result `json:"results"`
 }
 
 type DeleteCollectionInput struct {
 	CollectionName string `json:"collection-name"`
+	Tenant         string `json:"tenant"`
 }
 
 type DeleteCollectionOutput struct {
 	Status string `json:"status"`
 }
 
+type CreateCollectionInput struct {
+	CollectionName     string           `json:"collection-name"`
+	Description        string           `json:"description"`
+	Properties         []map[string]any `json:"properties"`
+	Vectorizer         string           `json:"vectorizer"`
+	ModuleConfig       map[string]any   `json:"module-config"`
+	VectorIndexConfig  map[string]any   `json:"vector-index-config"`
+	MultiTenancy       bool             `json:"multi-tenancy"`
+	AutoTenantCreation bool             `json:"auto-tenant-creation"`
+	Tenants            []string         `json:"tenants"`
+}
+
+type CreateCollectionOutput struct {
+	Status string `json:"status"`
+}
+
+type AggregateInput struct {
+	CollectionName string              `json:"collection-name"`
+	Filter         map[string]any      `json:"filter"`
+	GroupBy        string              `json:"group-by"`
+	Aggregations   map[string][]string `json:"aggregations"`
+	Limit          int                 `json:"limit"`
+	Tenant         string              `json:"tenant"`
+}
+
+type AggregateGroup struct {
+	GroupedBy  any            `json:"grouped-by,omitempty"`
+	Count      int            `json:"count"`
+	Properties map[string]any `json:"properties"`
+}
+
+type AggregateOutput struct {
+	Status string           `json:"status"`
+	Groups []AggregateGroup `json:"groups"`
+}
+
+type AddReferenceInput struct {
+	CollectionName       string `json:"collection-name"`
+	ID                   string `json:"id"`
+	ReferenceProperty    string `json:"reference-property"`
+	TargetCollectionName string `json:"target-collection-name"`
+	TargetID             string `json:"target-id"`
+	Tenant               string `json:"tenant"`
+}
+
+type AddReferenceOutput struct {
+	Status string `json:"status"`
+}
+
 func jsonToWhereBuilder(jsonWhere *map[string]any) (*filters.WhereBuilder, error) {
 	where := filters.Where()
 
@@ -207,7 +270,9 @@ func getAllFields(ctx context.Context, client *schema.ClassGetter, collectionNam
 	return fields, nil
 }
 
-// vector is optional, nil will return all objects
+// vector, object-id and text are optional and mutually exclusive, none will
+// return all objects
+// text needs a vectorizer module configured on the collection
 // fields is optional, nil will return all objects
 // limit is optional, 0 will return all objects
 // tenant is optional, required for multi-tenancy
@@ -223,11 +288,22 @@ func VectorSearch(ctx context.Context, client weaviate.Client, inputStruct Vecto
 	withBuilder := client.GraphQL().Get().
 		WithClassName(collectionName)
 
-	if vector != nil {
+	switch {
+	case vector != nil:
 		nearVector := client.GraphQL().NearVectorArgBuilder().
 			WithVector(vector)
 
 		withBuilder.WithNearVector(nearVector)
+	case inputStruct.ObjectID != "":
+		nearObject := client.GraphQL().NearObjectArgBuilder().
+			WithID(inputStruct.ObjectID)
+
+		withBuilder.WithNearObject(nearObject)
+	case len(inputStruct.Text) > 0:
+		nearText := client.GraphQL().NearTextArgBuilder().
+			WithConcepts(inputStruct.Text)
+
+		withBuilder.WithNearText(nearText)
 	}
 	if filter != nil {
 		where, err := jsonToWhereBuilder(&filter)
@@ -309,6 +385,9 @@ func (e *execution) insert(ctx context.Context, in *structpb.Struct) (*structpb.
 		if inputStruct.ID != "" {
 			creator.WithID(inputStruct.ID)
 		}
+		if inputStruct.Tenant != "" {
+			creator.WithTenant(inputStruct.Tenant)
+		}
 
 		_, err = creator.Do(ctx)
 		if err != nil {
@@ -334,6 +413,20 @@ func (e *execution) vectorSearch(ctx context.Context, in *structpb.Struct) (*str
 		return nil, err
 	}
 
+	var nearArgs int
+	if inputStruct.Vector != nil {
+		nearArgs++
+	}
+	if inputStruct.ObjectID != "" {
+		nearArgs++
+	}
+	if len(inputStruct.Text) > 0 {
+		nearArgs++
+	}
+	if nearArgs > 1 {
+		return nil, fmt.Errorf("only one of vector, object-id or text can be provided")
+	}
+
 	var result Result
 	var successful int
 	if e.mockClient == nil {
@@ -418,6 +511,9 @@ func (e *execution) update(ctx context.Context, in *structpb.Struct) (*structpb.
 			WithID(inputStruct.ID).
 			WithProperties(inputStruct.Metadata).
 			WithVector(inputStruct.Vector)
+		if inputStruct.Tenant != "" {
+			updater.WithTenant(inputStruct.Tenant)
+		}
 
 		err = updater.Do(ctx)
 		if err != nil {
@@ -455,22 +551,32 @@ func (e *execution) delete(ctx context.Context, in *structpb.Struct) (*structpb.
 	if err != nil {
 		return nil, err
 	}
+	tenant := inputStruct.Tenant
 
 	var res *models.BatchDeleteResponse
 	var successful int
 	if e.mockClient == nil {
 		if inputStruct.ID != "" {
-			err = e.client.Data().Deleter().WithClassName(collectionName).WithID(id).Do(ctx)
+			deleter := e.client.Data().Deleter().WithClassName(collectionName).WithID(id)
+			if tenant != "" {
+				deleter.WithTenant(tenant)
+			}
+
+			err = deleter.Do(ctx)
 			if err != nil {
 				return nil, err
 			}
 
 			successful = int(1)
 		} else {
-			res, err = e.client.Batch().ObjectsBatchDeleter().
+			batchDeleter := e.client.Batch().ObjectsBatchDeleter().
 				WithClassName(collectionName).
-				WithWhere(where).
-				Do(ctx)
+				WithWhere(where)
+			if tenant != "" {
+				batchDeleter.WithTenant(tenant)
+			}
+
+			res, err = batchDeleter.Do(ctx)
 			if err != nil {
 				return nil, err
 			}
@@ -502,38 +608,58 @@ func (e *execution) batchInsert(ctx context.Context, in *structpb.Struct) (*stru
 	collectionName := inputStruct.CollectionName
 	arrayMetadata := inputStruct.ArrayMetadata
 	arrayVector := inputStruct.ArrayVector
-	arrayID := inputStruct.ArrayID
 
-	var successful int
-	if e.mockClient == nil {
+	// vectors are optional when the collection has a vectorizer
+	if arrayVector != nil && len(arrayVector) != len(arrayMetadata) {
+		return nil, fmt.Errorf("array-vector and array-metadata must have the same length")
+	}
+	if inputStruct.ArrayID != nil && len(inputStruct.ArrayID) != len(arrayMetadata) {
+		return nil, fmt.Errorf("array-id and array-metadata must have the same length")
+	}
+
+	// Objects without an ID get a UUID derived from their content, so
+	// inserting them again replaces them instead of creating duplicates.
+	arrayID, err := batchupsert.FillIDs(inputStruct.ArrayID, len(arrayMetadata), func(i int) []any {
+		return []any{batchupsert.At(arrayVector, i), arrayMetadata[i]}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := batchupsert.Upsert(arrayID, inputStruct.Options, func(start, end int) error {
+		if e.mockClient != nil {
+			return e.mockClient.batchErrors(arrayID, start, end)
+		}
+
 		batcher := e.client.Batch().ObjectsBatcher()
-		for i, properties := range arrayMetadata {
+		for i := start; i < end; i++ {
 			modelsObject := &models.Object{
 				Class:      collectionName,
-				Properties: properties,
-				Vector:     arrayVector[i],
+				ID:         strfmt.UUID(arrayID[i]),
+				Properties: arrayMetadata[i],
+				Tenant:     inputStruct.Tenant,
 			}
-			if len(arrayID) == len(arrayMetadata) {
-				modelsObject.ID = strfmt.UUID(arrayID[i])
-			} else if arrayID != nil {
-				return nil, fmt.Errorf("array-id and array-metadata must have the same length")
+			if arrayVector != nil {
+				modelsObject.Vector = arrayVector[i]
 			}
 
 			batcher = batcher.WithObjects(modelsObject)
 		}
-		_, err = batcher.Do(ctx)
 
+		resp, err := batcher.Do(ctx)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		successful = len(arrayMetadata)
-	} else {
-		successful = e.mockClient.Successful
+		return objectErrors(resp, arrayID, start, end)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	outputStruct := BatchInsertOutput{
-		Status: fmt.Sprintf("Successfully batch inserted %d objects", successful),
+		Status:  batchupsert.Status("batch inserted", "objects", results),
+		Results: results,
 	}
 
 	output, err := base.ConvertToStructpb(outputStruct)
@@ -543,6 +669,38 @@ func (e *execution) batchInsert(ctx context.Context, in *structpb.Struct) (*stru
 	return output, nil
 }
 
+// objectErrors returns the errors of the objects that Weaviate failed to
+// insert in a batch.
+func objectErrors(resp []models.ObjectsGetResponse, ids []string, start, end int) error {
+	index := make(map[string]int, end-start)
+	for i := start; i < end; i++ {
+		index[ids[i]] = i
+	}
+
+	errs := batchupsert.RecordErrors{}
+	for _, obj := range resp {
+		if obj.Result == nil || obj.Result.Errors == nil || len(obj.Result.Errors.Error) == 0 {
+			continue
+		}
+
+		i, ok := index[string(obj.ID)]
+		if !ok {
+			continue
+		}
+
+		msgs := make([]string, 0, len(obj.Result.Errors.Error))
+		for _, item := range obj.Result.Errors.Error {
+			msgs = append(msgs, item.Message)
+		}
+		errs[i] = errors.New(strings.Join(msgs, "; "))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
 func (e *execution) deleteCollection(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
 	var inputStruct DeleteCollectionInput
 	err := base.ConvertFromStructpb(in, &inputStruct)
@@ -552,18 +710,316 @@ func (e *execution) deleteCollection(ctx context.Context, in *structpb.Struct) (
 
 	collectionName := inputStruct.CollectionName
 
+	status := "Successfully deleted 1 collection"
+
+	// With a tenant, only the tenant and its objects are deleted.
 	if e.mockClient == nil {
-		err = e.client.Schema().ClassDeleter().
-			WithClassName(collectionName).
-			Do(ctx)
+		if inputStruct.Tenant != "" {
+			err = e.client.Schema().TenantsDeleter().
+				WithClassName(collectionName).
+				WithTenants(inputStruct.Tenant).
+				Do(ctx)
+		} else {
+			err = e.client.Schema().ClassDeleter().
+				WithClassName(collectionName).
+				Do(ctx)
+		}
 
 		if err != nil {
 			return nil, err
 		}
 	}
+	if inputStruct.Tenant != "" {
+		status = fmt.Sprintf("Successfully deleted tenant %s", inputStruct.Tenant)
+	}
 
 	outputStruct := DeleteCollectionOutput{
-		Status: "Successfully deleted 1 collection",
+		Status: status,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// buildClass converts the collection input into a Weaviate class. Properties
+// follow the Weaviate property schema, e.g. {"name": "title", "dataType":
+// ["text"]}.
+func buildClass(inputStruct CreateCollectionInput) (*models.Class, error) {
+	class := &models.Class{
+		Class:       inputStruct.CollectionName,
+		Description: inputStruct.Description,
+		Vectorizer:  inputStruct.Vectorizer,
+		Properties:  []*models.Property{},
+	}
+
+	for _, rawProperty := range inputStruct.Properties {
+		b, err := json.Marshal(rawProperty)
+		if err != nil {
+			return nil, err
+		}
+
+		property := &models.Property{}
+		if err := json.Unmarshal(b, property); err != nil {
+			return nil, fmt.Errorf("invalid property: %w", err)
+		}
+		if property.Name == "" || len(property.DataType) == 0 {
+			return nil, fmt.Errorf("properties must have a name and a dataType")
+		}
+
+		class.Properties = append(class.Properties, property)
+	}
+
+	if inputStruct.ModuleConfig != nil {
+		class.ModuleConfig = inputStruct.ModuleConfig
+	}
+	if inputStruct.VectorIndexConfig != nil {
+		class.VectorIndexConfig = inputStruct.VectorIndexConfig
+	}
+	if inputStruct.MultiTenancy {
+		class.MultiTenancyConfig = &models.MultiTenancyConfig{
+			Enabled:            true,
+			AutoTenantCreation: inputStruct.AutoTenantCreation,
+		}
+	} else if len(inputStruct.Tenants) > 0 || inputStruct.AutoTenantCreation {
+		return nil, fmt.Errorf("tenants require multi-tenancy to be enabled")
+	}
+
+	return class, nil
+}
+
+func (e *execution) createCollection(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CreateCollectionInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	class, err := buildClass(inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.mockClient == nil {
+		err = e.client.Schema().ClassCreator().
+			WithClass(class).
+			Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(inputStruct.Tenants) > 0 {
+			tenants := make([]models.Tenant, len(inputStruct.Tenants))
+			for i, name := range inputStruct.Tenants {
+				tenants[i] = models.Tenant{Name: name}
+			}
+
+			err = e.client.Schema().TenantsCreator().
+				WithClassName(inputStruct.CollectionName).
+				WithTenants(tenants...).
+				Do(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	outputStruct := CreateCollectionOutput{
+		Status: "Successfully created 1 collection",
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// aggregateFields returns the fields of an aggregate query. The object count
+// is always requested, and the group value when grouping.
+func aggregateFields(groupBy string, aggregations map[string][]string) []graphql.Field {
+	fields := []graphql.Field{{Name: "meta", Fields: []graphql.Field{{Name: "count"}}}}
+	if groupBy != "" {
+		fields = append(fields, graphql.Field{Name: "groupedBy", Fields: []graphql.Field{{Name: "value"}}})
+	}
+
+	properties := make([]string, 0, len(aggregations))
+	for property := range aggregations {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	for _, property := range properties {
+		var aggregators []graphql.Field
+		for _, aggregator := range aggregations[property] {
+			switch aggregator {
+			case "topOccurrences":
+				aggregators = append(aggregators, graphql.Field{
+					Name:   aggregator,
+					Fields: []graphql.Field{{Name: "value"}, {Name: "occurs"}},
+				})
+			default:
+				aggregators = append(aggregators, graphql.Field{Name: aggregator})
+			}
+		}
+		fields = append(fields, graphql.Field{Name: property, Fields: aggregators})
+	}
+
+	return fields
+}
+
+// parseAggregateResult extracts the groups of an aggregate query response.
+// A query without group-by returns a single group.
+func parseAggregateResult(collectionName string, res map[string]any) ([]AggregateGroup, error) {
+	data, ok := res["data"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for data")
+	}
+	aggregate, ok := data["Aggregate"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for aggregate")
+	}
+	items, ok := aggregate[collectionName].([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for aggregate result")
+	}
+
+	groups := make([]AggregateGroup, 0, len(items))
+	for _, item := range items {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected item format")
+		}
+
+		group := AggregateGroup{Properties: map[string]any{}}
+		for key, value := range itemMap {
+			switch key {
+			case "meta":
+				if count, ok := value.(map[string]any)["count"].(float64); ok {
+					group.Count = int(count)
+				}
+			case "groupedBy":
+				if groupedBy, ok := value.(map[string]any); ok {
+					group.GroupedBy = groupedBy["value"]
+				}
+			default:
+				group.Properties[key] = value
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func (e *execution) aggregate(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct AggregateInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []AggregateGroup
+	if e.mockClient == nil {
+		builder := e.client.GraphQL().Aggregate().
+			WithClassName(inputStruct.CollectionName).
+			WithFields(aggregateFields(inputStruct.GroupBy, inputStruct.Aggregations)...)
+
+		if inputStruct.Filter != nil {
+			where, err := jsonToWhereBuilder(&inputStruct.Filter)
+			if err != nil {
+				return nil, err
+			}
+			builder.WithWhere(where)
+		}
+		if inputStruct.GroupBy != "" {
+			builder.WithGroupBy(inputStruct.GroupBy)
+		}
+		if inputStruct.Limit > 0 {
+			builder.WithLimit(inputStruct.Limit)
+		}
+		if inputStruct.Tenant != "" {
+			builder.WithTenant(inputStruct.Tenant)
+		}
+
+		res, err := builder.Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Errors) > 0 {
+			return nil, fmt.Errorf("failed to aggregate: %s", res.Errors[0].Message)
+		}
+
+		mapRes := make(map[string]any)
+		byteRes, err := res.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(byteRes, &mapRes)
+		if err != nil {
+			return nil, err
+		}
+
+		groups, err = parseAggregateResult(inputStruct.CollectionName, mapRes)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		groups = e.mockClient.Aggregate
+	}
+
+	outputStruct := AggregateOutput{
+		Status: fmt.Sprintf("Successfully aggregated %d groups", len(groups)),
+		Groups: groups,
+	}
+
+	output, err := base.ConvertToStructpb(outputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (e *execution) addReference(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct AddReferenceInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputStruct.ID == "" || inputStruct.TargetID == "" {
+		return nil, fmt.Errorf("id and target-id must be provided")
+	}
+
+	if e.mockClient == nil {
+		reference := e.client.Data().ReferencePayloadBuilder().
+			WithClassName(inputStruct.TargetCollectionName).
+			WithID(inputStruct.TargetID).
+			Payload()
+
+		creator := e.client.Data().ReferenceCreator().
+			WithClassName(inputStruct.CollectionName).
+			WithID(inputStruct.ID).
+			WithReferenceProperty(inputStruct.ReferenceProperty).
+			WithReference(reference)
+		if inputStruct.Tenant != "" {
+			creator.WithTenant(inputStruct.Tenant)
+		}
+
+		err = creator.Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	outputStruct := AddReferenceOutput{
+		Status: "Successfully added 1 reference",
 	}
 
 	output, err := base.ConvertToStructpb(outputStruct)
@@ -0,0 +1,188 @@
+// Package batchupsert splits the batch upserts of the vector store components
+// into sub-batches and reports the result of each record.
+package batchupsert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+)
+
+// DefaultBatchSize is the number of records sent in a request when the input
+// doesn't specify it.
+const DefaultBatchSize = 100
+
+// idNamespace is the UUID namespace of the content-based IDs. Changing it
+// would change every generated ID.
+var idNamespace = uuid.Must(uuid.FromString("8a6b3c5e-2f4d-4e1a-9c7b-0d2e4f6a8b1c"))
+
+// Options holds the batching fields shared by the batch upsert inputs.
+type Options struct {
+	// BatchSize is the maximum number of records sent in a single request.
+	BatchSize int `json:"batch-size"`
+	// AllowPartialFailure makes the upsert report the failing records
+	// instead of aborting on the first failure.
+	AllowPartialFailure bool `json:"allow-partial-failure"`
+}
+
+// Result is the outcome of the upsert of a record.
+type Result struct {
+	Index   int    `json:"index"`
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RecordErrors holds the errors of the records that failed in a sub-batch,
+// by record index. Upsert functions return it when the store reports the
+// failures of individual records, so the rest of the sub-batch is considered
+// successful.
+type RecordErrors map[int]error
+
+func (e RecordErrors) Error() string {
+	return fmt.Sprintf("%d records failed", len(e))
+}
+
+// UpsertFunc upserts the records in the [start, end) range.
+type UpsertFunc func(start, end int) error
+
+// Upsert upserts the records in sub-batches of opts.BatchSize and returns the
+// result of each record.
+//
+// When a sub-batch fails, the whole upsert fails unless partial failures are
+// allowed. In that case, the records of the failed sub-batch are retried one
+// by one to find the failing ones. Records of the previous sub-batches remain
+// upserted in both cases, and as their IDs are stable, the upsert can be
+// resumed by running it again.
+func Upsert(ids []string, opts Options, upsert UpsertFunc) ([]Result, error) {
+	size := opts.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+
+	results := make([]Result, len(ids))
+	for i, id := range ids {
+		results[i] = Result{Index: i, ID: id, Success: true}
+	}
+
+	fail := func(i int, err error) {
+		results[i].Success = false
+		results[i].Error = err.Error()
+	}
+
+	for start := 0; start < len(ids); start += size {
+		end := min(start+size, len(ids))
+
+		err := upsert(start, end)
+		if err == nil {
+			continue
+		}
+
+		var recErrs RecordErrors
+		if errors.As(err, &recErrs) {
+			for i := start; i < end; i++ {
+				recErr, ok := recErrs[i]
+				if !ok {
+					continue
+				}
+				if !opts.AllowPartialFailure {
+					return nil, fmt.Errorf("upserting record %d: %w", i, recErr)
+				}
+				fail(i, recErr)
+			}
+			continue
+		}
+
+		if !opts.AllowPartialFailure {
+			return nil, fmt.Errorf("upserting records %d to %d: %w", start, end-1, err)
+		}
+
+		if end-start == 1 {
+			fail(start, err)
+			continue
+		}
+		for i := start; i < end; i++ {
+			if err := upsert(i, i+1); err != nil {
+				var recErrs RecordErrors
+				if errors.As(err, &recErrs) && recErrs[i] != nil {
+					err = recErrs[i]
+				}
+				fail(i, err)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// Status summarizes the results in the status of the batch upsert output,
+// e.g. Status("batch upserted", "points", results).
+func Status(action, noun string, results []Result) string {
+	var failed int
+	for _, r := range results {
+		if !r.Success {
+			failed++
+		}
+	}
+
+	status := fmt.Sprintf("Successfully %s %d %s", action, len(results)-failed, noun)
+	if failed > 0 {
+		status += fmt.Sprintf(", %d failed", failed)
+	}
+	return status
+}
+
+// ContentID returns a UUID derived from the content of a record, so upserting
+// the same content twice doesn't create a duplicate.
+func ContentID(content ...any) (string, error) {
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("hashing record content: %w", err)
+	}
+	return uuid.NewV5(idNamespace, string(b)).String(), nil
+}
+
+// FillIDs returns the record IDs, generating a content-based ID for the
+// records without one. The content function returns the values that
+// identify the record at an index.
+func FillIDs(ids []string, n int, content func(i int) []any) ([]string, error) {
+	if ids != nil && len(ids) != n {
+		return nil, fmt.Errorf("expected %d ids, got %d", n, len(ids))
+	}
+
+	filled := make([]string, n)
+	for i := range filled {
+		if ids != nil && ids[i] != "" {
+			filled[i] = ids[i]
+			continue
+		}
+
+		id, err := ContentID(content(i)...)
+		if err != nil {
+			return nil, err
+		}
+		filled[i] = id
+	}
+	return filled, nil
+}
+
+// Slice returns the [start, end) range of an optional input array, or nil if
+// the array wasn't provided.
+func Slice[T any](s []T, start, end int) []T {
+	if s == nil {
+		return nil
+	}
+	return s[start:end]
+}
+
+// At returns the element of an optional input array at an index, or the zero
+// value if the array wasn't provided.
+func At[T any](s []T, i int) T {
+	var zero T
+	if s == nil {
+		return zero
+	}
+	return s[i]
+}
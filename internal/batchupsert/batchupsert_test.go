@@ -0,0 +1,109 @@
+package batchupsert
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestUpsert(t *testing.T) {
+	c := qt.New(t)
+
+	ids := []string{"a", "b", "c", "d", "e"}
+
+	// The store rejects any request containing the record "d".
+	rejectD := func(calls *[][2]int) UpsertFunc {
+		return func(start, end int) error {
+			*calls = append(*calls, [2]int{start, end})
+			if slices.Contains(ids[start:end], "d") {
+				return fmt.Errorf("invalid vector")
+			}
+			return nil
+		}
+	}
+
+	c.Run("ok - sub-batches", func(c *qt.C) {
+		var calls [][2]int
+		results, err := Upsert(ids, Options{BatchSize: 2}, func(start, end int) error {
+			calls = append(calls, [2]int{start, end})
+			return nil
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(calls, qt.DeepEquals, [][2]int{{0, 2}, {2, 4}, {4, 5}})
+		c.Check(results, qt.HasLen, 5)
+		c.Check(Status("batch upserted", "points", results), qt.Equals, "Successfully batch upserted 5 points")
+	})
+
+	c.Run("ok - default batch size", func(c *qt.C) {
+		var calls [][2]int
+		_, err := Upsert(ids, Options{}, func(start, end int) error {
+			calls = append(calls, [2]int{start, end})
+			return nil
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(calls, qt.DeepEquals, [][2]int{{0, 5}})
+	})
+
+	c.Run("nok - abort on failure", func(c *qt.C) {
+		var calls [][2]int
+		_, err := Upsert(ids, Options{BatchSize: 2}, rejectD(&calls))
+		c.Check(err, qt.ErrorMatches, "upserting records 2 to 3: invalid vector")
+		c.Check(calls, qt.DeepEquals, [][2]int{{0, 2}, {2, 4}})
+	})
+
+	c.Run("ok - partial failure", func(c *qt.C) {
+		var calls [][2]int
+		results, err := Upsert(ids, Options{BatchSize: 2, AllowPartialFailure: true}, rejectD(&calls))
+		c.Assert(err, qt.IsNil)
+		c.Check(calls, qt.DeepEquals, [][2]int{{0, 2}, {2, 4}, {2, 3}, {3, 4}, {4, 5}})
+		c.Check(results, qt.DeepEquals, []Result{
+			{Index: 0, ID: "a", Success: true},
+			{Index: 1, ID: "b", Success: true},
+			{Index: 2, ID: "c", Success: true},
+			{Index: 3, ID: "d", Success: false, Error: "invalid vector"},
+			{Index: 4, ID: "e", Success: true},
+		})
+		c.Check(Status("batch upserted", "points", results), qt.Equals, "Successfully batch upserted 4 points, 1 failed")
+	})
+
+	c.Run("ok - record errors", func(c *qt.C) {
+		var calls int
+		upsert := func(start, end int) error {
+			calls++
+			return RecordErrors{1: fmt.Errorf("invalid property")}
+		}
+
+		results, err := Upsert(ids[:3], Options{AllowPartialFailure: true}, upsert)
+		c.Assert(err, qt.IsNil)
+		c.Check(calls, qt.Equals, 1)
+		c.Check(results[1], qt.DeepEquals, Result{Index: 1, ID: "b", Error: "invalid property"})
+		c.Check(results[0].Success && results[2].Success, qt.IsTrue)
+
+		_, err = Upsert(ids[:3], Options{}, upsert)
+		c.Check(err, qt.ErrorMatches, "upserting record 1: invalid property")
+	})
+}
+
+func TestFillIDs(t *testing.T) {
+	c := qt.New(t)
+
+	vectors := [][]float64{{0.1, 0.2}, {0.3, 0.4}, {0.1, 0.2}}
+	content := func(i int) []any { return []any{vectors[i]} }
+
+	ids, err := FillIDs(nil, 3, content)
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.HasLen, 3)
+	c.Check(ids[0], qt.Equals, ids[2])
+	c.Check(ids[0], qt.Not(qt.Equals), ids[1])
+	c.Check(ids[0], qt.Matches, `[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+	// The IDs are stable across runs.
+	again, err := FillIDs([]string{"", "custom", ""}, 3, content)
+	c.Assert(err, qt.IsNil)
+	c.Check(again, qt.DeepEquals, []string{ids[0], "custom", ids[2]})
+
+	_, err = FillIDs([]string{"a"}, 3, content)
+	c.Check(err, qt.ErrorMatches, "expected 3 ids, got 1")
+}
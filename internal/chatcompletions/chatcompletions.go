@@ -0,0 +1,245 @@
+// Package chatcompletions implements the chat task on top of the OpenAI chat
+// completions API, which several providers (e.g. Groq, Mistral or Fireworks
+// AI) expose with small variations. It also holds the component plumbing
+// shared by the chat components, including those with their own API.
+package chatcompletions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+// finishReasons maps the finish reasons of the providers to the shared finish
+// reasons. Most providers follow the OpenAI values, but, e.g., Mistral reports
+// model_length when the context of the model is exhausted.
+var finishReasons = map[string]string{
+	"stop":           ai.FinishReasonStop,
+	"length":         ai.FinishReasonLength,
+	"model_length":   ai.FinishReasonLength,
+	"tool_calls":     ai.FinishReasonToolCalls,
+	"function_call":  ai.FinishReasonToolCalls,
+	"content_filter": ai.FinishReasonContentFilter,
+}
+
+// Request is the body of a chat completion request. The providers can adjust
+// the request built by NewRequest to their variation of the API.
+type Request struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	MaxTokens   *int      `json:"max_tokens,omitempty"`
+	Temperature *float32  `json:"temperature,omitempty"`
+	TopP        *float32  `json:"top_p,omitempty"`
+	N           *int      `json:"n,omitempty"`
+	Seed        *int      `json:"seed,omitempty"`
+	// RandomSeed replaces Seed in the Mistral API.
+	RandomSeed *int `json:"random_seed,omitempty"`
+	Stream     bool `json:"stream"`
+}
+
+// Message is a chat message. Its content is a string when the message only
+// holds text, as some providers don't accept content parts in every role.
+type Message struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+	Name    string `json:"name,omitempty"`
+}
+
+// ContentPart is an element of a multi-part message content.
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+// ImageURL references an image by URL or data URL.
+type ImageURL struct {
+	URL string `json:"url"`
+}
+
+type response struct {
+	Created int      `json:"created"`
+	Choices []choice `json:"choices"`
+	Usage   *usage   `json:"usage"`
+
+	// Groq reports the usage of streamed requests in this field.
+	XGroq *struct {
+		Usage *usage `json:"usage"`
+	} `json:"x_groq"`
+}
+
+type choice struct {
+	Index        int     `json:"index"`
+	FinishReason string  `json:"finish_reason"`
+	Message      message `json:"message"`
+	Delta        message `json:"delta"`
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// NewRequest builds a chat completion request from the chat input.
+func NewRequest(in ai.TextChatInput) Request {
+	p := in.Parameter
+	return Request{
+		Model:       in.Data.Model,
+		Messages:    BuildMessages(in.Data.Messages),
+		MaxTokens:   p.MaxTokens,
+		Temperature: p.Temperature,
+		TopP:        p.TopP,
+		N:           p.N,
+		Seed:        p.Seed,
+		Stream:      p.Stream,
+	}
+}
+
+// BuildMessages converts the chat messages to the chat completions format.
+func BuildMessages(in []ai.InputMessage) []Message {
+	messages := make([]Message, len(in))
+	for i, m := range in {
+		messages[i] = Message{Role: m.Role, Name: m.Name}
+
+		parts := make([]ContentPart, 0, len(m.Contents))
+		textOnly := true
+		for _, c := range m.Contents {
+			switch c.Type {
+			case ai.ContentTypeText:
+				parts = append(parts, ContentPart{Type: "text", Text: c.Text})
+			case ai.ContentTypeImageURL:
+				textOnly = false
+				parts = append(parts, ContentPart{Type: "image_url", ImageURL: &ImageURL{URL: c.ImageURL}})
+			case ai.ContentTypeImageBase64:
+				textOnly = false
+				parts = append(parts, ContentPart{Type: "image_url", ImageURL: &ImageURL{URL: util.GetDataURL(c.ImageBase64)}})
+			}
+		}
+
+		if textOnly {
+			messages[i].Content = m.Text()
+			continue
+		}
+		messages[i].Content = parts
+	}
+	return messages
+}
+
+// Send sends a chat completion request to the path of the API and returns the
+// chat output. The partial outputs of streamed requests are written to the
+// job. The name and errBody of the API are used to build the end-user errors
+// of the streamed requests.
+func Send(ctx context.Context, client httpclient.IClient, path string, req Request, job *base.Job, apiName string, errBody httpclient.ErrBody) (ai.TextChatOutput, error) {
+	if !req.Stream {
+		resp := response{}
+		if _, err := client.R().SetContext(ctx).SetBody(req).SetResult(&resp).Post(path); err != nil {
+			return ai.TextChatOutput{}, err
+		}
+
+		out := ai.TextChatOutput{Data: ai.OutputData{Choices: []ai.Choice{}}}
+		for _, c := range resp.Choices {
+			out.Data.Choices = append(out.Data.Choices, ai.Choice{
+				FinishReason: finishReason(c.FinishReason),
+				Index:        c.Index,
+				Message:      ai.OutputMessage{Content: c.Message.Content, Role: c.Message.Role},
+				Created:      resp.Created,
+			})
+		}
+		setUsage(&out, resp)
+		return out, nil
+	}
+
+	httpResp, err := client.R().SetContext(ctx).SetBody(req).SetDoNotParseResponse(true).Post(path)
+	if err != nil {
+		return ai.TextChatOutput{}, err
+	}
+	if err := httpclient.UnparsedResponseError(apiName, httpResp, errBody); err != nil {
+		return ai.TextChatOutput{}, err
+	}
+
+	body := httpResp.RawBody()
+	defer body.Close()
+
+	out := ai.TextChatOutput{Data: ai.OutputData{Choices: []ai.Choice{}}}
+	w := ai.NewStreamWriter(ctx, job)
+	err = ai.ScanEvents(body, func(data []byte) error {
+		chunk := response{}
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("unmarshalling chat completion chunk: %w", err)
+		}
+
+		for _, c := range chunk.Choices {
+			// The chunks of the different choices are interleaved, so the
+			// choice is located by its index.
+			for len(out.Data.Choices) <= c.Index {
+				out.Data.Choices = append(out.Data.Choices, ai.Choice{Index: len(out.Data.Choices)})
+			}
+
+			choice := &out.Data.Choices[c.Index]
+			choice.Message.Content += c.Delta.Content
+			choice.Created = chunk.Created
+			if c.Delta.Role != "" {
+				choice.Message.Role = c.Delta.Role
+			}
+			if c.FinishReason != "" {
+				choice.FinishReason = finishReason(c.FinishReason)
+			}
+		}
+		setUsage(&out, chunk)
+
+		return w.Update(out)
+	})
+	if err != nil {
+		return ai.TextChatOutput{}, err
+	}
+
+	// Some providers only send the role in the first chunk of the first
+	// choice.
+	for i := range out.Data.Choices {
+		if out.Data.Choices[i].Message.Role == "" {
+			out.Data.Choices[i].Message.Role = ai.RoleAssistant
+		}
+	}
+
+	return out, nil
+}
+
+// finishReason normalises the finish reason of a choice. The reasons without
+// a shared value, e.g., Mistral's error, fall back to stop, as the generation
+// ended anyway.
+func finishReason(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	if r, ok := finishReasons[reason]; ok {
+		return r
+	}
+	return ai.FinishReasonStop
+}
+
+func setUsage(out *ai.TextChatOutput, resp response) {
+	u := resp.Usage
+	if resp.XGroq != nil && resp.XGroq.Usage != nil {
+		u = resp.XGroq.Usage
+	}
+	if u == nil {
+		return
+	}
+
+	out.Metadata.Usage = ai.Usage{
+		CompletionTokens: u.CompletionTokens,
+		PromptTokens:     u.PromptTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+}
@@ -0,0 +1,186 @@
+package chatcompletions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+type errBody struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (e errBody) Message() string {
+	return e.Error.Message
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestNewRequest(t *testing.T) {
+	c := qt.New(t)
+
+	in := ai.TextChatInput{
+		Data: ai.InputData{
+			Model: "llama3-8b-8192",
+			Messages: []ai.InputMessage{
+				{Role: "system", Contents: []ai.Content{{Type: "text", Text: "You are a pirate."}}},
+				{Role: "user", Name: "Jack", Contents: []ai.Content{
+					{Type: "text", Text: "What's in this image?"},
+					{Type: "image-url", ImageURL: "https://example.com/ship.png"},
+				}},
+			},
+		},
+		Parameter: ai.Parameter{MaxTokens: ptr(50), Seed: ptr(1), TopP: ptr(float32(0.5))},
+	}
+
+	b, err := json.Marshal(NewRequest(in))
+	c.Assert(err, qt.IsNil)
+	c.Check(b, qt.JSONEquals, map[string]any{
+		"model": "llama3-8b-8192",
+		"messages": []any{
+			map[string]any{"role": "system", "content": "You are a pirate."},
+			map[string]any{"role": "user", "name": "Jack", "content": []any{
+				map[string]any{"type": "text", "text": "What's in this image?"},
+				map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/ship.png"}},
+			}},
+		},
+		"max_tokens": 50,
+		"seed":       1,
+		"top_p":      0.5,
+		"stream":     false,
+	})
+}
+
+func TestFinishReason(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		in   string
+		want string
+	}{
+		{in: "stop", want: ai.FinishReasonStop},
+		{in: "length", want: ai.FinishReasonLength},
+		{in: "model_length", want: ai.FinishReasonLength},
+		{in: "tool_calls", want: ai.FinishReasonToolCalls},
+		{in: "function_call", want: ai.FinishReasonToolCalls},
+		{in: "content_filter", want: ai.FinishReasonContentFilter},
+		{in: "error", want: ai.FinishReasonStop},
+		{in: "foo", want: ai.FinishReasonStop},
+		{in: "", want: ""},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.in, func(c *qt.C) {
+			c.Check(finishReason(tc.in), qt.Equals, tc.want)
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	const path = "/v1/chat/completions"
+	in := ai.TextChatInput{
+		Data: ai.InputData{
+			Model:    "llama3-8b-8192",
+			Messages: []ai.InputMessage{{Role: "user", Contents: []ai.Content{{Type: "text", Text: "Hi"}}}},
+		},
+	}
+
+	c.Run("ok", func(c *qt.C) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.Check(r.URL.Path, qt.Equals, path)
+
+			w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+			fmt.Fprintln(w, `{
+  "created": 1718000000,
+  "choices": [{"index": 0, "finish_reason": "model_length", "message": {"role": "assistant", "content": "Ahoy"}}],
+  "usage": {"prompt_tokens": 8, "completion_tokens": 2, "total_tokens": 10}
+}`)
+		})
+
+		srv := httptest.NewServer(h)
+		c.Cleanup(srv.Close)
+
+		client := httpclient.New("Groq", srv.URL, httpclient.WithEndUserError(new(errBody)))
+		got, err := Send(ctx, client, path, NewRequest(in), nil, "Groq", new(errBody))
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.DeepEquals, ai.TextChatOutput{
+			Data: ai.OutputData{Choices: []ai.Choice{{
+				FinishReason: ai.FinishReasonLength,
+				Message:      ai.OutputMessage{Content: "Ahoy", Role: "assistant"},
+				Created:      1718000000,
+			}}},
+			Metadata: ai.Metadata{Usage: ai.Usage{CompletionTokens: 2, PromptTokens: 8, TotalTokens: 10}},
+		})
+	})
+
+	c.Run("ok - stream", func(c *qt.C) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			c.Assert(err, qt.IsNil)
+			c.Check(body, qt.JSONEquals, map[string]any{
+				"model":    "llama3-8b-8192",
+				"messages": []any{map[string]any{"role": "user", "content": "Hi"}},
+				"n":        2,
+				"stream":   true,
+			})
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintln(w, `data: {"created":1,"choices":[{"index":0,"delta":{"role":"assistant","content":"Ah"}}]}`)
+			fmt.Fprintln(w, `data: {"created":1,"choices":[{"index":1,"delta":{"content":"Hello"},"finish_reason":"stop"}]}`)
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, `data: {"created":1,"choices":[{"index":0,"delta":{"content":"oy"},"finish_reason":"stop"}],"x_groq":{"usage":{"prompt_tokens":8,"completion_tokens":3,"total_tokens":11}}}`)
+			fmt.Fprintln(w, `data: [DONE]`)
+		})
+
+		srv := httptest.NewServer(h)
+		c.Cleanup(srv.Close)
+
+		streamIn := in
+		streamIn.Parameter = ai.Parameter{N: ptr(2), Stream: true}
+
+		client := httpclient.New("Groq", srv.URL, httpclient.WithEndUserError(new(errBody)))
+		got, err := Send(ctx, client, path, NewRequest(streamIn), nil, "Groq", new(errBody))
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.DeepEquals, ai.TextChatOutput{
+			Data: ai.OutputData{Choices: []ai.Choice{
+				{FinishReason: "stop", Index: 0, Message: ai.OutputMessage{Content: "Ahoy", Role: "assistant"}, Created: 1},
+				{FinishReason: "stop", Index: 1, Message: ai.OutputMessage{Content: "Hello", Role: "assistant"}, Created: 1},
+			}},
+			Metadata: ai.Metadata{Usage: ai.Usage{CompletionTokens: 3, PromptTokens: 8, TotalTokens: 11}},
+		})
+	})
+
+	c.Run("nok - stream 401", func(c *qt.C) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(w, `{"error": {"message": "Invalid API Key"}}`)
+		})
+
+		srv := httptest.NewServer(h)
+		c.Cleanup(srv.Close)
+
+		streamIn := in
+		streamIn.Parameter.Stream = true
+
+		client := httpclient.New("Groq", srv.URL, httpclient.WithEndUserError(new(errBody)))
+		_, err := Send(ctx, client, path, NewRequest(streamIn), nil, "Groq", new(errBody))
+		c.Check(err, qt.ErrorMatches, "unsuccessful HTTP response")
+		c.Check(errmsg.Message(err), qt.Equals, "Groq responded with a 401 status code. Invalid API Key")
+	})
+}
@@ -0,0 +1,125 @@
+package chatcompletions
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	// TextChatTask is the only task of the chat components.
+	TextChatTask = "TASK_CHAT"
+	// CfgAPIKey is the setup field that holds the API key of the provider.
+	CfgAPIKey = "api-key"
+)
+
+// ClientFactory builds the HTTP client of a provider from the component
+// setup.
+type ClientFactory func(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client
+
+// ChatExecutor generates the chat output of an input with a provider client.
+type ChatExecutor func(input ai.TextChatInput, client httpclient.IClient, job *base.Job, ctx context.Context) (*structpb.Struct, error)
+
+// Component is a chat component whose only task is TASK_CHAT. It holds the
+// plumbing shared by the chat providers, i.e., the resolution of the Instill
+// credentials and the creation of the executions, so that a provider only
+// defines its client and how it executes the chat task.
+type Component struct {
+	base.Component
+
+	instillAPIKey string
+	newClient     ClientFactory
+	executeChat   ChatExecutor
+}
+
+// NewComponent returns a chat component that executes the chat task with
+// the provided client and executor. The definition must still be loaded.
+func NewComponent(bc base.Component, newClient ClientFactory, executeChat ChatExecutor) *Component {
+	return &Component{
+		Component:   bc,
+		newClient:   newClient,
+		executeChat: executeChat,
+	}
+}
+
+type execution struct {
+	base.ComponentExecution
+	usesInstillCredentials bool
+	client                 *httpclient.Client
+	executeChat            ChatExecutor
+}
+
+// CreateExecution initializes a component executor that can be used in a
+// pipeline trigger.
+func (c *Component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	if x.Task != TextChatTask {
+		return nil, fmt.Errorf("unknown task: %s", x.Task)
+	}
+
+	resolvedSetup, resolved, err := c.resolveSetup(x.Setup)
+	if err != nil {
+		return nil, err
+	}
+
+	x.Setup = resolvedSetup
+
+	return &execution{
+		ComponentExecution:     x,
+		usesInstillCredentials: resolved,
+		client:                 c.newClient(x.Setup, x.GetLogger()),
+		executeChat:            c.executeChat,
+	}, nil
+}
+
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	return base.ConcurrentExecutor(ctx, jobs, e.execute)
+}
+
+func (e *execution) execute(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	inputStruct := ai.TextChatInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("failed to convert input to TextChatInput: %w", err)
+	}
+
+	return e.executeChat(inputStruct, e.client, job, ctx)
+}
+
+func (e *execution) UsesInstillCredentials() bool {
+	return e.usesInstillCredentials
+}
+
+// WithInstillCredentials loads Instill credentials into the component, which
+// can be used to configure it with globally defined parameters instead of with
+// user-defined credential values.
+func (c *Component) WithInstillCredentials(s map[string]any) *Component {
+	c.instillAPIKey = base.ReadFromGlobalConfig(CfgAPIKey, s)
+	return c
+}
+
+// resolveSetup checks whether the component is configured to use the Instill
+// credentials injected during initialization and, if so, returns a new setup
+// with the secret credential values.
+func (c *Component) resolveSetup(setup *structpb.Struct) (*structpb.Struct, bool, error) {
+	if setup == nil || setup.Fields == nil {
+		setup = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	}
+	if v, ok := setup.GetFields()[CfgAPIKey]; ok {
+		apiKey := v.GetStringValue()
+		if apiKey != "" && apiKey != base.SecretKeyword {
+			return setup, false, nil
+		}
+	}
+
+	if c.instillAPIKey == "" {
+		return nil, false, base.NewUnresolvedCredential(CfgAPIKey)
+	}
+
+	setup.GetFields()[CfgAPIKey] = structpb.NewStringValue(c.instillAPIKey)
+	return setup, true, nil
+}
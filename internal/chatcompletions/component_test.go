@@ -0,0 +1,116 @@
+package chatcompletions
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+func TestComponent_CreateExecution(t *testing.T) {
+	c := qt.New(t)
+
+	var gotAPIKey string
+	newClient := func(setup *structpb.Struct, logger *zap.Logger) *httpclient.Client {
+		gotAPIKey = setup.GetFields()[CfgAPIKey].GetStringValue()
+		return httpclient.New("Fake", "http://localhost")
+	}
+	executeChat := func(input ai.TextChatInput, client httpclient.IClient, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+		return base.ConvertToStructpb(ai.TextChatOutput{
+			Data: ai.OutputData{Choices: []ai.Choice{{Message: ai.OutputMessage{Content: input.Data.Model}}}},
+		})
+	}
+
+	testcases := []struct {
+		name           string
+		globalKey      string
+		apiKey         string
+		wantAPIKey     string
+		wantUsesGlobal bool
+		wantErr        string
+	}{
+		{
+			name:       "ok - user API key",
+			globalKey:  "global-key",
+			apiKey:     "user-key",
+			wantAPIKey: "user-key",
+		},
+		{
+			name:           "ok - global API key",
+			globalKey:      "global-key",
+			apiKey:         base.SecretKeyword,
+			wantAPIKey:     "global-key",
+			wantUsesGlobal: true,
+		},
+		{
+			name:    "nok - global API key not injected",
+			apiKey:  base.SecretKeyword,
+			wantErr: "unresolved global credential",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			cmp := NewComponent(base.Component{}, newClient, executeChat).
+				WithInstillCredentials(map[string]any{"apikey": tc.globalKey})
+
+			setup, err := structpb.NewStruct(map[string]any{CfgAPIKey: tc.apiKey})
+			c.Assert(err, qt.IsNil)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      TextChatTask,
+			})
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(gotAPIKey, qt.Equals, tc.wantAPIKey)
+			c.Check(exec.UsesInstillCredentials(), qt.Equals, tc.wantUsesGlobal)
+
+			pbIn, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{"model": "fake-model", "messages": []any{}},
+			})
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := base.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) error {
+				choices := output.Fields["data"].GetStructValue().Fields["choices"].GetListValue().GetValues()
+				c.Assert(choices, qt.HasLen, 1)
+				content := choices[0].GetStructValue().Fields["message"].GetStructValue().Fields["content"]
+				c.Check(content.GetStringValue(), qt.Equals, "fake-model")
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Fatalf("unexpected error: %v", err)
+			})
+
+			c.Check(exec.Execute(context.Background(), []*base.Job{job}), qt.IsNil)
+		})
+	}
+
+	c.Run("nok - unknown task", func(c *qt.C) {
+		cmp := NewComponent(base.Component{}, newClient, executeChat)
+
+		setup, err := structpb.NewStruct(map[string]any{CfgAPIKey: "user-key"})
+		c.Assert(err, qt.IsNil)
+
+		_, err = cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Setup:     setup,
+			Task:      "FOOBAR",
+		})
+		c.Check(err, qt.ErrorMatches, "unknown task: FOOBAR")
+	})
+}
@@ -0,0 +1,124 @@
+package milvusapi
+
+import (
+	"fmt"
+	"maps"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+type BatchUpsertInput struct {
+	CollectionName string           `json:"collection-name"`
+	PartitionName  string           `json:"partition-name"`
+	ArrayData      []map[string]any `json:"array-data"`
+	// IDField is the primary key field of the collection. When it is set,
+	// the records without it get an ID derived from their content.
+	IDField string `json:"id-field"`
+
+	batchupsert.Options
+}
+
+type BatchUpsertOutput struct {
+	Status  string               `json:"status"`
+	Results []batchupsert.Result `json:"results"`
+}
+
+type UpsertReq struct {
+	CollectionName string           `json:"collectionName"`
+	PartitionName  string           `json:"partitionName,omitempty"`
+	Data           []map[string]any `json:"data"`
+}
+
+type upsertData struct {
+	UpsertIDs []any `json:"upsertIds"`
+}
+
+// BatchUpsert upserts the records in sub-batches and reports the result of
+// each record. Collections with auto-generated IDs return them in the
+// response, other records are identified by their ID field.
+func BatchUpsert(client *httpclient.Client, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct BatchUpsertInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ids, err := fillIDs(inputStruct.ArrayData, inputStruct.IDField)
+	if err != nil {
+		return nil, err
+	}
+
+	upsertIDs := make(map[int]string)
+
+	results, err := batchupsert.Upsert(ids, inputStruct.Options, func(start, end int) error {
+		req := UpsertReq{
+			CollectionName: inputStruct.CollectionName,
+			PartitionName:  inputStruct.PartitionName,
+			Data:           data[start:end],
+		}
+
+		resp := upsertData{}
+		if err := post(client, upsertPath, req, &resp, "upsert data"); err != nil {
+			return err
+		}
+
+		if len(resp.UpsertIDs) == end-start {
+			for i, id := range resp.UpsertIDs {
+				upsertIDs[start+i] = fmt.Sprint(id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		if results[i].ID == "" {
+			results[i].ID = upsertIDs[i]
+		}
+	}
+
+	outputStruct := BatchUpsertOutput{
+		Status:  batchupsert.Status("batch upserted", "data", results),
+		Results: results,
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// fillIDs sets a content-based ID on the records without a value in the ID
+// field, and returns the records with their IDs. Without ID field, the IDs
+// are left empty.
+func fillIDs(data []map[string]any, idField string) ([]map[string]any, []string, error) {
+	ids := make([]string, len(data))
+	if idField == "" {
+		return data, ids, nil
+	}
+
+	filled := make([]map[string]any, len(data))
+	for i, record := range data {
+		if v, ok := record[idField]; ok && v != nil && v != "" {
+			filled[i] = record
+			ids[i] = fmt.Sprint(v)
+			continue
+		}
+
+		id, err := batchupsert.ContentID(record)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		filled[i] = maps.Clone(record)
+		if filled[i] == nil {
+			filled[i] = map[string]any{}
+		}
+		filled[i][idField] = id
+		ids[i] = id
+	}
+	return filled, ids, nil
+}
@@ -0,0 +1,134 @@
+package milvusapi
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+type CollectionInput struct {
+	CollectionName string `json:"collection-name"`
+}
+
+type CollectionReq struct {
+	CollectionName string `json:"collectionName"`
+}
+
+type StatusOutput struct {
+	Status string `json:"status"`
+}
+
+type DescribeCollectionOutput struct {
+	Status     string         `json:"status"`
+	Collection map[string]any `json:"collection"`
+}
+
+// CollectionDescription holds the fields of the collection description that
+// are used by the tasks.
+type CollectionDescription struct {
+	CollectionName string             `json:"collectionName"`
+	Fields         []FieldDescription `json:"fields"`
+	Indexes        []IndexDescription `json:"indexes"`
+}
+
+type FieldDescription struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primaryKey"`
+}
+
+type IndexDescription struct {
+	FieldName  string `json:"fieldName"`
+	IndexName  string `json:"indexName"`
+	MetricType string `json:"metricType"`
+}
+
+// PrimaryKey returns the primary key field of the collection.
+func (d *CollectionDescription) PrimaryKey() (FieldDescription, error) {
+	for _, field := range d.Fields {
+		if field.PrimaryKey {
+			return field, nil
+		}
+	}
+	return FieldDescription{}, fmt.Errorf("collection %s has no primary key", d.CollectionName)
+}
+
+// MetricType returns the metric type of the index on a vector field. If the
+// field is empty, the first index is used.
+func (d *CollectionDescription) MetricType(vectorField string) (string, error) {
+	for _, index := range d.Indexes {
+		if vectorField == "" || index.FieldName == vectorField {
+			return index.MetricType, nil
+		}
+	}
+	return "", fmt.Errorf("no index found on vector field %s", vectorField)
+}
+
+func describeCollection(client *httpclient.Client, collectionName string, data any) error {
+	req := CollectionReq{CollectionName: collectionName}
+	return post(client, describeCollectionPath, req, data, "describe collection")
+}
+
+// DescribeCollection returns the description of a collection.
+func DescribeCollection(client *httpclient.Client, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CollectionInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := map[string]any{}
+	err = describeCollection(client, inputStruct.CollectionName, &collection)
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := DescribeCollectionOutput{
+		Status:     "Successfully described 1 collection",
+		Collection: collection,
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// LoadCollection loads a collection into memory, which is required to search
+// or query it.
+func LoadCollection(client *httpclient.Client, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CollectionInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	req := CollectionReq{CollectionName: inputStruct.CollectionName}
+	err = post(client, loadCollectionPath, req, nil, "load collection")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(StatusOutput{
+		Status: "Successfully loaded 1 collection",
+	})
+}
+
+// ReleaseCollection releases a collection from memory.
+func ReleaseCollection(client *httpclient.Client, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct CollectionInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	req := CollectionReq{CollectionName: inputStruct.CollectionName}
+	err = post(client, releaseCollectionPath, req, nil, "release collection")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(StatusOutput{
+		Status: "Successfully released 1 collection",
+	})
+}
@@ -0,0 +1,78 @@
+// Package milvusapi implements the tasks shared by the Milvus and Zilliz
+// components. Zilliz Cloud serves the same RESTful API (v2) as Milvus, so
+// both components delegate to this package to keep the same behaviour.
+package milvusapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const (
+	queryPath              = "/v2/vectordb/entities/query"
+	getPath                = "/v2/vectordb/entities/get"
+	searchPath             = "/v2/vectordb/entities/search"
+	upsertPath             = "/v2/vectordb/entities/upsert"
+	describeCollectionPath = "/v2/vectordb/collections/describe"
+	loadCollectionPath     = "/v2/vectordb/collections/load"
+	releaseCollectionPath  = "/v2/vectordb/collections/release"
+)
+
+type apiResp struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// post sends a request to the API and decodes the data of the response into
+// data, if it isn't nil. Numbers are decoded as json.Number so that Int64
+// primary keys don't lose precision.
+func post(client *httpclient.Client, path string, body any, data any, action string) error {
+	res, err := client.R().SetBody(body).Post(path)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode() != 200 {
+		return fmt.Errorf("failed to %s: %s", action, res.String())
+	}
+
+	resp := apiResp{}
+	if err := json.Unmarshal(res.Body(), &resp); err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	// The API reports success with the code 0. Some errors come without a
+	// message.
+	if resp.Code != 0 {
+		msg := resp.Message
+		if msg == "" {
+			msg = fmt.Sprintf("unexpected error code %d", resp.Code)
+		}
+		return fmt.Errorf("failed to %s: %s", action, msg)
+	}
+
+	if data == nil || len(resp.Data) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(resp.Data))
+	dec.UseNumber()
+	if err := dec.Decode(data); err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	return nil
+}
+
+// primaryKeys returns the IDs of the entities as strings.
+func primaryKeys(data []map[string]any, primaryKeyField string) []string {
+	ids := make([]string, len(data))
+	for i, d := range data {
+		ids[i] = fmt.Sprintf("%v", d[primaryKeyField])
+	}
+	return ids
+}
@@ -0,0 +1,406 @@
+package milvusapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/batchupsert"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+const describeResp = `{
+	"code": 0,
+	"data": {
+		"collectionName": "mock-collection",
+		"fields": [
+			{"name": "id", "type": "Int64", "primaryKey": true},
+			{"name": "name", "type": "VarChar", "primaryKey": false},
+			{"name": "vector", "type": "FloatVector", "primaryKey": false}
+		],
+		"indexes": [{"fieldName": "vector", "indexName": "vector", "metricType": "L2"}]
+	}
+}`
+
+type clientCall struct {
+	path string
+	req  any
+	resp string
+}
+
+func TestTasks(t *testing.T) {
+	c := qt.New(t)
+
+	firstPage := cursor{Distance: 0.5, IDs: []any{json.Number("2"), json.Number("3")}}
+	firstCursor, err := firstPage.encode()
+	c.Assert(err, qt.IsNil)
+
+	generatedID, err := batchupsert.ContentID(map[string]any{"vector": []float64{0.2, 0.3}, "name": "b"})
+	c.Assert(err, qt.IsNil)
+
+	testcases := []struct {
+		name     string
+		task     func(*httpclient.Client, *structpb.Struct) (*structpb.Struct, error)
+		input    any
+		wantResp any
+		wantErr  string
+
+		clientCalls []clientCall
+	}{
+		{
+			name: "ok to query",
+			task: Query,
+			input: QueryInput{
+				CollectionName: "mock-collection",
+				Filter:         `name in ["a", "b"]`,
+				Fields:         []string{"name"},
+				Limit:          10,
+			},
+			wantResp: EntitiesOutput{
+				Status: "Successfully queried 2 entities",
+				Result: EntitiesResult{
+					Ids: []string{"1", "9007199254740993"},
+					Data: []map[string]any{
+						{"id": 1, "name": "a"},
+						{"id": 9007199254740993, "name": "b"},
+					},
+				},
+			},
+			clientCalls: []clientCall{
+				{path: describeCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: describeResp},
+				{
+					path: queryPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"filter":         `name in ["a", "b"]`,
+						"outputFields":   []string{"name"},
+						"limit":          10,
+					},
+					resp: `{"code": 0, "data": [{"id": 1, "name": "a"}, {"id": 9007199254740993, "name": "b"}]}`,
+				},
+			},
+		},
+		{
+			name:    "nok - query without filter",
+			task:    Query,
+			input:   QueryInput{CollectionName: "mock-collection"},
+			wantErr: "filter is required",
+		},
+		{
+			name: "ok to get",
+			task: Get,
+			input: GetInput{
+				CollectionName: "mock-collection",
+				PartitionName:  "mock-partition",
+				IDs:            []string{"1", "2"},
+			},
+			wantResp: EntitiesOutput{
+				Status: "Successfully got 1 entities",
+				Result: EntitiesResult{
+					Ids:  []string{"1"},
+					Data: []map[string]any{{"id": 1, "name": "a"}},
+				},
+			},
+			clientCalls: []clientCall{
+				{path: describeCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: describeResp},
+				{
+					path: getPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"partitionNames": []string{"mock-partition"},
+						"id":             []int{1, 2},
+					},
+					resp: `{"code": 0, "data": [{"id": 1, "name": "a"}]}`,
+				},
+			},
+		},
+		{
+			name: "nok - get with invalid ID",
+			task: Get,
+			input: GetInput{
+				CollectionName: "mock-collection",
+				IDs:            []string{"a"},
+			},
+			wantErr: `invalid Int64 primary key "a"`,
+			clientCalls: []clientCall{
+				{path: describeCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: describeResp},
+			},
+		},
+		{
+			name: "ok to search the first page",
+			task: SearchIterator,
+			input: SearchIteratorInput{
+				CollectionName: "mock-collection",
+				Vector:         []float32{0.1, 0.2},
+				VectorField:    "vector",
+				BatchSize:      3,
+			},
+			wantResp: SearchIteratorOutput{
+				Status: "Successfully searched 3 entities",
+				Result: EntitiesResult{
+					Ids: []string{"1", "2", "3"},
+					Data: []map[string]any{
+						{"id": 1, "distance": 0.1},
+						{"id": 2, "distance": 0.5},
+						{"id": 3, "distance": 0.5},
+					},
+				},
+				NextCursor: firstCursor,
+			},
+			clientCalls: []clientCall{
+				{path: describeCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: describeResp},
+				{
+					path: searchPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"data":           [][]float32{{0.1, 0.2}},
+						"annsField":      "vector",
+						"limit":          3,
+					},
+					resp: `{"code": 0, "data": [{"id": 1, "distance": 0.1}, {"id": 2, "distance": 0.5}, {"id": 3, "distance": 0.5}]}`,
+				},
+			},
+		},
+		{
+			name: "ok to search the last page",
+			task: SearchIterator,
+			input: SearchIteratorInput{
+				CollectionName: "mock-collection",
+				Vector:         []float32{0.1, 0.2},
+				VectorField:    "vector",
+				Filter:         `name != "c"`,
+				BatchSize:      3,
+				Cursor:         firstCursor,
+			},
+			wantResp: SearchIteratorOutput{
+				Status: "Successfully searched 1 entities",
+				Result: EntitiesResult{
+					Ids:  []string{"4"},
+					Data: []map[string]any{{"id": 4, "distance": 0.7}},
+				},
+			},
+			clientCalls: []clientCall{
+				{path: describeCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: describeResp},
+				{
+					path: searchPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"data":           [][]float32{{0.1, 0.2}},
+						"annsField":      "vector",
+						"filter":         `(name != "c") and (id not in [2, 3])`,
+						"limit":          3,
+						"searchParams": map[string]any{
+							"params": map[string]any{"range_filter": 0.5, "radius": math.MaxFloat32},
+						},
+					},
+					resp: `{"code": 0, "data": [{"id": 4, "distance": 0.7}]}`,
+				},
+			},
+		},
+		{
+			name: "ok to batch upsert in sub-batches",
+			task: BatchUpsert,
+			input: BatchUpsertInput{
+				CollectionName: "mock-collection",
+				PartitionName:  "mock-partition",
+				ArrayData: []map[string]any{
+					{"vector": []float64{0.1, 0.2}, "name": "a"},
+					{"vector": []float64{0.2, 0.3}, "name": "b"},
+				},
+				Options: batchupsert.Options{BatchSize: 1},
+			},
+			wantResp: BatchUpsertOutput{
+				Status: "Successfully batch upserted 2 data",
+				Results: []batchupsert.Result{
+					{Index: 0, ID: "mockID1", Success: true},
+					{Index: 1, ID: "9007199254740993", Success: true},
+				},
+			},
+			clientCalls: []clientCall{
+				{
+					path: upsertPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"partitionName":  "mock-partition",
+						"data":           []any{map[string]any{"vector": []float64{0.1, 0.2}, "name": "a"}},
+					},
+					resp: `{"code": 0, "data": {"upsertCount": 1, "upsertIds": ["mockID1"]}}`,
+				},
+				{
+					path: upsertPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"partitionName":  "mock-partition",
+						"data":           []any{map[string]any{"vector": []float64{0.2, 0.3}, "name": "b"}},
+					},
+					resp: `{"code": 0, "data": {"upsertCount": 1, "upsertIds": [9007199254740993]}}`,
+				},
+			},
+		},
+		{
+			name: "ok to batch upsert with content-based ids",
+			task: BatchUpsert,
+			input: BatchUpsertInput{
+				CollectionName: "mock-collection",
+				ArrayData: []map[string]any{
+					{"id": "mockID1", "vector": []float64{0.1, 0.2}, "name": "a"},
+					{"vector": []float64{0.2, 0.3}, "name": "b"},
+				},
+				IDField: "id",
+			},
+			wantResp: BatchUpsertOutput{
+				Status: "Successfully batch upserted 2 data",
+				Results: []batchupsert.Result{
+					{Index: 0, ID: "mockID1", Success: true},
+					{Index: 1, ID: generatedID, Success: true},
+				},
+			},
+			clientCalls: []clientCall{
+				{
+					path: upsertPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"data": []any{
+							map[string]any{"id": "mockID1", "vector": []float64{0.1, 0.2}, "name": "a"},
+							map[string]any{"id": generatedID, "vector": []float64{0.2, 0.3}, "name": "b"},
+						},
+					},
+					resp: `{"code": 0, "data": {"upsertCount": 2, "upsertIds": ["mockID1", "` + generatedID + `"]}}`,
+				},
+			},
+		},
+		{
+			name: "nok - batch upsert fails",
+			task: BatchUpsert,
+			input: BatchUpsertInput{
+				CollectionName: "mock-collection",
+				ArrayData:      []map[string]any{{"vector": []float64{0.1}, "name": "a"}},
+			},
+			wantErr: "upserting records 0 to 0: failed to upsert data: invalid dimension",
+			clientCalls: []clientCall{
+				{
+					path: upsertPath,
+					req: map[string]any{
+						"collectionName": "mock-collection",
+						"data":           []any{map[string]any{"vector": []float64{0.1}, "name": "a"}},
+					},
+					resp: `{"code": 1100, "message": "invalid dimension"}`,
+				},
+			},
+		},
+		{
+			name:    "nok - load collection with an error code and no message",
+			task:    LoadCollection,
+			input:   CollectionInput{CollectionName: "mock-collection"},
+			wantErr: "failed to load collection: unexpected error code 1100",
+			clientCalls: []clientCall{
+				{path: loadCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: `{"code": 1100, "data": {}}`},
+			},
+		},
+		{
+			name:     "ok to load collection",
+			task:     LoadCollection,
+			input:    CollectionInput{CollectionName: "mock-collection"},
+			wantResp: StatusOutput{Status: "Successfully loaded 1 collection"},
+			clientCalls: []clientCall{
+				{path: loadCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: `{"code": 0, "data": {}}`},
+			},
+		},
+		{
+			name:    "nok - release collection",
+			task:    ReleaseCollection,
+			input:   CollectionInput{CollectionName: "mock-collection"},
+			wantErr: "failed to release collection: collection not found",
+			clientCalls: []clientCall{
+				{path: releaseCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: `{"code": 100, "message": "collection not found"}`},
+			},
+		},
+		{
+			name:  "ok to describe collection",
+			task:  DescribeCollection,
+			input: CollectionInput{CollectionName: "mock-collection"},
+			wantResp: DescribeCollectionOutput{
+				Status: "Successfully described 1 collection",
+				Collection: map[string]any{
+					"collectionName": "mock-collection",
+					"fields": []any{
+						map[string]any{"name": "id", "type": "Int64", "primaryKey": true},
+						map[string]any{"name": "name", "type": "VarChar", "primaryKey": false},
+						map[string]any{"name": "vector", "type": "FloatVector", "primaryKey": false},
+					},
+					"indexes": []any{map[string]any{"fieldName": "vector", "indexName": "vector", "metricType": "L2"}},
+				},
+			},
+			clientCalls: []clientCall{
+				{path: describeCollectionPath, req: map[string]any{"collectionName": "mock-collection"}, resp: describeResp},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			var calls int
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Assert(calls < len(tc.clientCalls), qt.IsTrue, qt.Commentf("unexpected request %s", r.URL))
+				call := tc.clientCalls[calls]
+				calls++
+
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, call.path)
+
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				c.Check(body, qt.JSONEquals, call.req)
+
+				w.Header().Set("Content-Type", httpclient.MIMETypeJSON)
+				fmt.Fprintln(w, call.resp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			client := httpclient.New("Milvus", srv.URL, httpclient.WithLogger(zap.NewNop()))
+
+			pbIn, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, qt.IsNil)
+
+			got, err := tc.task(client, pbIn)
+			c.Check(calls, qt.Equals, len(tc.clientCalls))
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			gotJSON, err := json.Marshal(got.AsMap())
+			c.Assert(err, qt.IsNil)
+			c.Check(gotJSON, qt.JSONEquals, tc.wantResp)
+		})
+	}
+}
+
+func TestRangeSearchParams(t *testing.T) {
+	c := qt.New(t)
+
+	c.Check(rangeSearchParams(nil, "COSINE", 0.8), qt.DeepEquals, map[string]any{
+		"params": map[string]any{"range_filter": 0.8, "radius": -1.0},
+	})
+
+	c.Check(rangeSearchParams(map[string]any{
+		"metricType": "IP",
+		"params":     map[string]any{"radius": 0.2, "ef": 64},
+	}, "IP", 0.8), qt.DeepEquals, map[string]any{
+		"metricType": "IP",
+		"params":     map[string]any{"range_filter": 0.8, "radius": 0.2, "ef": 64},
+	})
+}
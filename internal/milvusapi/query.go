@@ -0,0 +1,181 @@
+package milvusapi
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+type QueryInput struct {
+	CollectionName string   `json:"collection-name"`
+	PartitionName  string   `json:"partition-name"`
+	Filter         string   `json:"filter"`
+	Fields         []string `json:"fields"`
+	Limit          int      `json:"limit"`
+	Offset         int      `json:"offset"`
+}
+
+type QueryReq struct {
+	CollectionName string   `json:"collectionName"`
+	PartitionNames []string `json:"partitionNames,omitempty"`
+	Filter         string   `json:"filter"`
+	OutputFields   []string `json:"outputFields,omitempty"`
+	Limit          int      `json:"limit,omitempty"`
+	Offset         int      `json:"offset,omitempty"`
+}
+
+type GetInput struct {
+	CollectionName string   `json:"collection-name"`
+	PartitionName  string   `json:"partition-name"`
+	IDs            []string `json:"ids"`
+	Fields         []string `json:"fields"`
+}
+
+type GetReq struct {
+	CollectionName string   `json:"collectionName"`
+	PartitionNames []string `json:"partitionNames,omitempty"`
+	ID             []any    `json:"id"`
+	OutputFields   []string `json:"outputFields,omitempty"`
+}
+
+type EntitiesOutput struct {
+	Status string         `json:"status"`
+	Result EntitiesResult `json:"result"`
+}
+
+type EntitiesResult struct {
+	Ids  []string         `json:"ids"`
+	Data []map[string]any `json:"data"`
+}
+
+func partitionNames(partitionName string) []string {
+	if partitionName == "" {
+		return nil
+	}
+	return []string{partitionName}
+}
+
+// Query returns the entities matching a scalar filter expression, e.g.
+// `color in ["red", "green"]`, without a vector.
+// fields is optional, empty returns the primary key and the scalar fields
+func Query(client *httpclient.Client, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct QueryInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputStruct.Filter == "" {
+		return nil, fmt.Errorf("filter is required")
+	}
+
+	desc := CollectionDescription{}
+	err = describeCollection(client, inputStruct.CollectionName, &desc)
+	if err != nil {
+		return nil, err
+	}
+	primaryKey, err := desc.PrimaryKey()
+	if err != nil {
+		return nil, err
+	}
+
+	req := QueryReq{
+		CollectionName: inputStruct.CollectionName,
+		PartitionNames: partitionNames(inputStruct.PartitionName),
+		Filter:         inputStruct.Filter,
+		OutputFields:   inputStruct.Fields,
+		Limit:          inputStruct.Limit,
+		Offset:         inputStruct.Offset,
+	}
+
+	data := []map[string]any{}
+	err = post(client, queryPath, req, &data, "query entities")
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := EntitiesOutput{
+		Status: fmt.Sprintf("Successfully queried %d entities", len(data)),
+		Result: EntitiesResult{
+			Ids:  primaryKeys(data, primaryKey.Name),
+			Data: data,
+		},
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// Get returns the entities with the given primary keys. IDs that don't exist
+// in the collection are omitted from the output.
+func Get(client *httpclient.Client, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct GetInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputStruct.IDs) == 0 {
+		return nil, fmt.Errorf("ids must have at least one element")
+	}
+
+	desc := CollectionDescription{}
+	err = describeCollection(client, inputStruct.CollectionName, &desc)
+	if err != nil {
+		return nil, err
+	}
+	primaryKey, err := desc.PrimaryKey()
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := parsePrimaryKeys(inputStruct.IDs, primaryKey.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	req := GetReq{
+		CollectionName: inputStruct.CollectionName,
+		PartitionNames: partitionNames(inputStruct.PartitionName),
+		ID:             ids,
+		OutputFields:   inputStruct.Fields,
+	}
+
+	data := []map[string]any{}
+	err = post(client, getPath, req, &data, "get entities")
+	if err != nil {
+		return nil, err
+	}
+
+	outputStruct := EntitiesOutput{
+		Status: fmt.Sprintf("Successfully got %d entities", len(data)),
+		Result: EntitiesResult{
+			Ids:  primaryKeys(data, primaryKey.Name),
+			Data: data,
+		},
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// parsePrimaryKeys converts the IDs to the type of the primary key field,
+// Int64 or VarChar.
+func parsePrimaryKeys(ids []string, primaryKeyType string) ([]any, error) {
+	parsed := make([]any, len(ids))
+	for i, id := range ids {
+		if primaryKeyType != "Int64" {
+			parsed[i] = id
+			continue
+		}
+
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Int64 primary key %q", id)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
@@ -0,0 +1,254 @@
+package milvusapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/component/base"
+	"github.com/instill-ai/component/internal/util/httpclient"
+)
+
+type SearchIteratorInput struct {
+	CollectionName string         `json:"collection-name"`
+	PartitionName  string         `json:"partition-name"`
+	Vector         []float32      `json:"vector"`
+	VectorField    string         `json:"vector-field"`
+	Filter         string         `json:"filter"`
+	BatchSize      int            `json:"batch-size"`
+	Fields         []string       `json:"fields"`
+	SearchParams   map[string]any `json:"search-params"`
+	Cursor         string         `json:"cursor"`
+}
+
+type SearchIteratorOutput struct {
+	Status     string         `json:"status"`
+	Result     EntitiesResult `json:"result"`
+	NextCursor string         `json:"next-cursor"`
+}
+
+type SearchReq struct {
+	CollectionName string         `json:"collectionName"`
+	PartitionNames []string       `json:"partitionNames,omitempty"`
+	Data           [][]float32    `json:"data"`
+	AnnsField      string         `json:"annsField,omitempty"`
+	Filter         string         `json:"filter,omitempty"`
+	Limit          int            `json:"limit"`
+	OutputFields   []string       `json:"outputFields,omitempty"`
+	SearchParams   map[string]any `json:"searchParams,omitempty"`
+}
+
+// cursor is the position of a search iterator: the distance of the last
+// returned entities and the primary keys returned at that distance, which are
+// excluded from the next page to handle ties.
+type cursor struct {
+	Distance float64 `json:"distance"`
+	IDs      []any   `json:"ids"`
+}
+
+func (c cursor) encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	c := &cursor{}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	if err := dec.Decode(c); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return c, nil
+}
+
+const defaultBatchSize = 100
+
+// higherIsCloser reports whether a greater distance means a closer match for
+// a metric type.
+func higherIsCloser(metricType string) bool {
+	return metricType == "IP" || metricType == "COSINE"
+}
+
+// SearchIterator iterates over the results of a vector search beyond the
+// topK limit of a single search. Each page is a range search starting at the
+// distance of the previous page, as returned in next-cursor. next-cursor is
+// empty when there are no more results.
+func SearchIterator(client *httpclient.Client, in *structpb.Struct) (*structpb.Struct, error) {
+	var inputStruct SearchIteratorInput
+	err := base.ConvertFromStructpb(in, &inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputStruct.BatchSize <= 0 {
+		inputStruct.BatchSize = defaultBatchSize
+	}
+
+	desc := CollectionDescription{}
+	err = describeCollection(client, inputStruct.CollectionName, &desc)
+	if err != nil {
+		return nil, err
+	}
+	primaryKey, err := desc.PrimaryKey()
+	if err != nil {
+		return nil, err
+	}
+	metricType, err := desc.MetricType(inputStruct.VectorField)
+	if err != nil {
+		return nil, err
+	}
+
+	req := SearchReq{
+		CollectionName: inputStruct.CollectionName,
+		PartitionNames: partitionNames(inputStruct.PartitionName),
+		Data:           [][]float32{inputStruct.Vector},
+		AnnsField:      inputStruct.VectorField,
+		Filter:         inputStruct.Filter,
+		Limit:          inputStruct.BatchSize,
+		OutputFields:   inputStruct.Fields,
+		SearchParams:   inputStruct.SearchParams,
+	}
+
+	var prev *cursor
+	if inputStruct.Cursor != "" {
+		prev, err = decodeCursor(inputStruct.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Filter = excludeFilter(inputStruct.Filter, primaryKey.Name, prev.IDs)
+		req.SearchParams = rangeSearchParams(inputStruct.SearchParams, metricType, prev.Distance)
+	}
+
+	data := []map[string]any{}
+	err = post(client, searchPath, req, &data, "search entities")
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor string
+	if len(data) == inputStruct.BatchSize {
+		next, err := nextPosition(data, primaryKey.Name, prev)
+		if err != nil {
+			return nil, err
+		}
+		if nextCursor, err = next.encode(); err != nil {
+			return nil, err
+		}
+	}
+
+	outputStruct := SearchIteratorOutput{
+		Status: fmt.Sprintf("Successfully searched %d entities", len(data)),
+		Result: EntitiesResult{
+			Ids:  primaryKeys(data, primaryKey.Name),
+			Data: data,
+		},
+		NextCursor: nextCursor,
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// nextPosition returns the cursor after a page. The primary keys at the last
+// distance of the previous page are kept if the page ends at the same
+// distance.
+func nextPosition(data []map[string]any, primaryKeyField string, prev *cursor) (cursor, error) {
+	last, err := distance(data[len(data)-1])
+	if err != nil {
+		return cursor{}, err
+	}
+
+	next := cursor{Distance: last}
+	if prev != nil && prev.Distance == last {
+		next.IDs = append(next.IDs, prev.IDs...)
+	}
+	for _, d := range data {
+		dist, err := distance(d)
+		if err != nil {
+			return cursor{}, err
+		}
+		if dist == last {
+			next.IDs = append(next.IDs, d[primaryKeyField])
+		}
+	}
+
+	return next, nil
+}
+
+func distance(d map[string]any) (float64, error) {
+	switch v := d["distance"].(type) {
+	case json.Number:
+		return v.Float64()
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("search result has no distance")
+}
+
+// excludeFilter adds the exclusion of the given primary keys to a filter
+// expression.
+func excludeFilter(filter, primaryKeyField string, ids []any) string {
+	if len(ids) == 0 {
+		return filter
+	}
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		switch v := id.(type) {
+		case string:
+			values[i] = strconv.Quote(v)
+		default:
+			values[i] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	exclude := fmt.Sprintf("%s not in [%s]", primaryKeyField, strings.Join(values, ", "))
+	if filter == "" {
+		return exclude
+	}
+	return fmt.Sprintf("(%s) and (%s)", filter, exclude)
+}
+
+// rangeSearchParams restricts a search to the entities at the given distance
+// or further. The radius of the search params, if any, bounds the range.
+func rangeSearchParams(searchParams map[string]any, metricType string, from float64) map[string]any {
+	rangeParams := map[string]any{}
+	for k, v := range searchParams {
+		rangeParams[k] = v
+	}
+
+	params := map[string]any{}
+	if p, ok := rangeParams["params"].(map[string]any); ok {
+		for k, v := range p {
+			params[k] = v
+		}
+	}
+
+	params["range_filter"] = from
+	if _, ok := params["radius"]; !ok {
+		switch {
+		case metricType == "COSINE":
+			params["radius"] = -1.0
+		case higherIsCloser(metricType):
+			params["radius"] = -math.MaxFloat32
+		default:
+			params["radius"] = math.MaxFloat32
+		}
+	}
+	rangeParams["params"] = params
+
+	return rangeParams
+}
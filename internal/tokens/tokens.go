@@ -0,0 +1,76 @@
+// Package tokens counts the tokens of the texts and chat messages sent to a
+// model. The components that compute token budgets share it so that they
+// agree on the counts.
+package tokens
+
+import (
+	tiktoken "github.com/pkoukk/tiktoken-go"
+)
+
+const (
+	// DefaultModel is the model whose tokenizer is used when none is
+	// specified.
+	DefaultModel = "gpt-4"
+
+	// PerReply are the tokens that prime the reply of the model after the
+	// messages.
+	PerReply = 3
+
+	// perMessage approximates the tokens that chat formats add around each
+	// message, e.g. for the role and the separators.
+	perMessage = 3
+)
+
+// Encoder encodes a text into tokens.
+type Encoder interface {
+	Encode(text string, allowedSpecial, disallowedSpecial []string) []int
+}
+
+// Counter counts the tokens of the texts and messages sent to a model.
+type Counter struct {
+	enc Encoder
+}
+
+// NewCounter returns a token counter with an encoder.
+func NewCounter(enc Encoder) *Counter {
+	return &Counter{enc: enc}
+}
+
+// ForModel returns a token counter with the tokenizer of a model. The models
+// without a known tokenizer (e.g. those of other providers than OpenAI) are
+// approximated with the GPT-4 tokenizer.
+func ForModel(model string) (*Counter, error) {
+	if model == "" {
+		model = DefaultModel
+	}
+
+	tkm, err := tiktoken.EncodingForModel(model)
+	if err != nil {
+		tkm, err = tiktoken.GetEncoding(tiktoken.MODEL_CL100K_BASE)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewCounter(tkm), nil
+}
+
+// Text counts the tokens of a text. The special tokens are encoded as
+// ordinary text.
+func (c *Counter) Text(text string) int {
+	return len(c.enc.Encode(text, nil, nil))
+}
+
+// Message counts the tokens of a chat message from its role, its optional
+// name and its text contents. Only the text content is counted, as the token
+// cost of images depends on the model.
+func (c *Counter) Message(role, name string, texts []string) int {
+	count := perMessage + c.Text(role)
+	if name != "" {
+		count += c.Text(name) + 1
+	}
+	for _, t := range texts {
+		count += c.Text(t)
+	}
+	return count
+}
@@ -0,0 +1,28 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// wordEncoder encodes each word of a text as a token, so the token counts
+// don't depend on the tokenizer files.
+type wordEncoder struct{}
+
+func (wordEncoder) Encode(text string, _, _ []string) []int {
+	return make([]int, len(strings.Fields(text)))
+}
+
+func TestCounter(t *testing.T) {
+	c := qt.New(t)
+	tc := NewCounter(wordEncoder{})
+
+	c.Check(tc.Text("Hello world."), qt.Equals, 2)
+
+	// The message takes 3 tokens for the format and 1 for the role.
+	c.Check(tc.Message("system", "", []string{"You are a pirate."}), qt.Equals, 8)
+	// The name takes 1 more token than its words.
+	c.Check(tc.Message("user", "Ada", []string{"What's on", "this flag?"}), qt.Equals, 10)
+}
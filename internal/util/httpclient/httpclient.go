@@ -2,10 +2,13 @@ package httpclient
 
 import (
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -152,13 +155,58 @@ func wrapWithErrMessage(apiName string) func(*resty.Client, *resty.Response) err
 			issue = resp.String()
 		}
 
-		if issue == "" {
-			issue = fmt.Sprintf("Please refer to %s's API reference for more information.", apiName)
-		}
+		return endUserError(apiName, resp.StatusCode(), issue)
+	}
+}
+
+// ResponseError is the cause of the end-user errors returned for unsuccessful
+// responses. It exposes the status code of the response so callers can tell,
+// e.g., whether the request can be retried.
+type ResponseError struct {
+	StatusCode int
+}
+
+func (e *ResponseError) Error() string {
+	return "unsuccessful HTTP response"
+}
 
-		msg := fmt.Sprintf("%s responded with a %d status code. %s", apiName, resp.StatusCode(), issue)
-		return errmsg.AddMessage(fmt.Errorf("unsuccessful HTTP response"), msg)
+func endUserError(apiName string, status int, issue string) error {
+	if issue == "" {
+		issue = fmt.Sprintf("Please refer to %s's API reference for more information.", apiName)
 	}
+
+	msg := fmt.Sprintf("%s responded with a %d status code. %s", apiName, status, issue)
+	return errmsg.AddMessage(&ResponseError{StatusCode: status}, msg)
+}
+
+// UnparsedResponseError returns the end-user error of an unsuccessful
+// response whose body hasn't been parsed, or nil if the response is
+// successful.
+//
+// Resty skips the response middlewares when a request is sent with
+// SetDoNotParseResponse, e.g. to stream the response body, so the errors of
+// such requests must be built by the caller. The body is read and closed and
+// the error message is extracted with errBody, as in WithEndUserError.
+func UnparsedResponseError(apiName string, resp *resty.Response, errBody ErrBody) error {
+	if !resp.IsError() {
+		return nil
+	}
+
+	var b []byte
+	if body := resp.RawBody(); body != nil {
+		defer body.Close()
+		b, _ = io.ReadAll(body)
+	}
+
+	var issue string
+	if errBody != nil && json.Unmarshal(b, errBody) == nil {
+		issue = errBody.Message()
+	}
+	if issue == "" {
+		issue = strings.TrimSpace(string(b))
+	}
+
+	return endUserError(apiName, resp.StatusCode(), issue)
 }
 
 // WithEndUserError will unmarshal error response bodies as the error struct
@@ -1,6 +1,7 @@
 package httpclient
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -134,6 +135,12 @@ func TestClient_SendReqAndUnmarshal(t *testing.T) {
 			c.Check(err, qt.IsNotNil)
 			c.Check(errmsg.Message(err), qt.Equals, tc.wantIssue)
 
+			if tc.wantIssue != "" {
+				respErr := new(ResponseError)
+				c.Assert(errors.As(err, &respErr), qt.IsTrue)
+				c.Check(respErr.StatusCode, qt.Equals, tc.gotStatus)
+			}
+
 			// Error log contains desired keys.
 			for _, k := range tc.wantLogFields {
 				logs := zLogs.FilterFieldKey(k)
@@ -150,6 +157,67 @@ func TestClient_SendReqAndUnmarshal(t *testing.T) {
 	}
 }
 
+func TestUnparsedResponseError(t *testing.T) {
+	c := qt.New(t)
+
+	const testName = "Pokédex"
+
+	testcases := []struct {
+		name      string
+		gotStatus int
+		gotBody   string
+		wantIssue string
+	}{
+		{
+			name:      "ok",
+			gotStatus: http.StatusOK,
+			gotBody:   `data: {"added": 1}`,
+		},
+		{
+			name:      "nok - 401",
+			gotStatus: http.StatusUnauthorized,
+			gotBody:   `{ "message": "Incorrect API key provided." }`,
+			wantIssue: fmt.Sprintf("%s responded with a 401 status code. Incorrect API key provided.", testName),
+		},
+		{
+			name:      "nok - 500 (unexpected response body)",
+			gotStatus: http.StatusInternalServerError,
+			gotBody:   `Internal error`,
+			wantIssue: fmt.Sprintf("%s responded with a 500 status code. Internal error", testName),
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.gotStatus)
+				fmt.Fprintln(w, tc.gotBody)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			client := New(testName, srv.URL, WithEndUserError(new(errBody)))
+			resp, err := client.R().SetDoNotParseResponse(true).Post("/")
+			c.Assert(err, qt.IsNil)
+
+			err = UnparsedResponseError(testName, resp, new(errBody))
+			if tc.wantIssue == "" {
+				c.Check(err, qt.IsNil)
+				resp.RawBody().Close()
+				return
+			}
+
+			c.Check(err, qt.ErrorMatches, "unsuccessful HTTP response")
+			c.Check(errmsg.Message(err), qt.Equals, tc.wantIssue)
+
+			respErr := new(ResponseError)
+			c.Assert(errors.As(err, &respErr), qt.IsTrue)
+			c.Check(respErr.StatusCode, qt.Equals, resp.StatusCode())
+		})
+	}
+}
+
 type okBody struct {
 	Added int `json:"added"`
 }
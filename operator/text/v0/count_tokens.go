@@ -0,0 +1,67 @@
+package text
+
+import (
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/internal/tokens"
+)
+
+type CountTokensInput struct {
+	Text     string            `json:"text"`
+	Messages []ai.InputMessage `json:"messages"`
+	Model    string            `json:"model"`
+}
+
+type CountTokensOutput struct {
+	TokenCount         int   `json:"token-count"`
+	MessageTokenCounts []int `json:"message-token-counts,omitempty"`
+}
+
+// tokenCounter counts the tokens of the texts and messages sent to a model.
+type tokenCounter struct {
+	*tokens.Counter
+}
+
+func newTokenCounter(model string) (*tokenCounter, error) {
+	tc, err := tokens.ForModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenCounter{Counter: tc}, nil
+}
+
+// countMessage counts the tokens of a chat message.
+func (tc *tokenCounter) countMessage(m ai.InputMessage) int {
+	var texts []string
+	for _, c := range m.Contents {
+		if c.Type == ai.ContentTypeText {
+			texts = append(texts, c.Text)
+		}
+	}
+	return tc.Message(m.Role, m.Name, texts)
+}
+
+func countTokens(input CountTokensInput) (CountTokensOutput, error) {
+	tc, err := newTokenCounter(input.Model)
+	if err != nil {
+		return CountTokensOutput{}, err
+	}
+
+	return tc.countTokens(input), nil
+}
+
+func (tc *tokenCounter) countTokens(input CountTokensInput) CountTokensOutput {
+	output := CountTokensOutput{TokenCount: tc.Text(input.Text)}
+	if len(input.Messages) == 0 {
+		return output
+	}
+
+	output.TokenCount += tokens.PerReply
+	output.MessageTokenCounts = make([]int, len(input.Messages))
+	for i, m := range input.Messages {
+		output.MessageTokenCounts[i] = tc.countMessage(m)
+		output.TokenCount += output.MessageTokenCounts[i]
+	}
+
+	return output
+}
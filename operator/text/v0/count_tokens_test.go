@@ -0,0 +1,67 @@
+package text
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/internal/tokens"
+)
+
+// wordEncoder encodes each word of a text as a token, so the token counts
+// don't depend on the tokenizer files.
+type wordEncoder struct{}
+
+func (wordEncoder) Encode(text string, _, _ []string) []int {
+	return make([]int, len(strings.Fields(text)))
+}
+
+func textMessage(role, text string) ai.InputMessage {
+	return ai.InputMessage{Role: role, Contents: []ai.Content{{Type: "text", Text: text}}}
+}
+
+func TestCountTokens(t *testing.T) {
+	c := quicktest.New(t)
+	tc := &tokenCounter{Counter: tokens.NewCounter(wordEncoder{})}
+
+	testCases := []struct {
+		name   string
+		input  CountTokensInput
+		output CountTokensOutput
+	}{
+		{
+			name:   "text",
+			input:  CountTokensInput{Text: "Hello world."},
+			output: CountTokensOutput{TokenCount: 2},
+		},
+		{
+			name: "messages",
+			input: CountTokensInput{Messages: []ai.InputMessage{
+				textMessage("system", "You are a pirate."),
+				{
+					Role: "user",
+					Name: "Ada",
+					Contents: []ai.Content{
+						{Type: "text", Text: "What's on this flag?"},
+						{Type: "image-url", ImageURL: "https://example.com/flag.png"},
+					},
+				},
+			}},
+			// Each message takes 3 tokens for the format and 1 for the
+			// role, the name takes 2 tokens and the images aren't counted.
+			// The reply takes 3 more tokens.
+			output: CountTokensOutput{
+				TokenCount:         3 + 8 + 10,
+				MessageTokenCounts: []int{8, 10},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		c.Run(tt.name, func(c *quicktest.C) {
+			c.Check(tc.countTokens(tt.input), quicktest.DeepEquals, tt.output)
+		})
+	}
+}
@@ -12,7 +12,10 @@ import (
 )
 
 const (
-	taskChunkText string = "TASK_CHUNK_TEXT"
+	taskChunkText        string = "TASK_CHUNK_TEXT"
+	taskRenderTemplate   string = "TASK_RENDER_TEMPLATE"
+	taskCountTokens      string = "TASK_COUNT_TOKENS"
+	taskTruncateMessages string = "TASK_TRUNCATE_MESSAGES"
 )
 
 var (
@@ -77,6 +80,75 @@ func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
 				outputStruct, err = chunkText(inputStruct)
 			}
 
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			output, err := base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			err = job.Output.Write(ctx, output)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+		case taskRenderTemplate:
+			inputStruct := RenderTemplateInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := renderTemplate(inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			output, err := base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			err = job.Output.Write(ctx, output)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+		case taskCountTokens:
+			inputStruct := CountTokensInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := countTokens(inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			output, err := base.ConvertToStructpb(outputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+			err = job.Output.Write(ctx, output)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+		case taskTruncateMessages:
+			inputStruct := TruncateMessagesInput{}
+			err := base.ConvertFromStructpb(input, &inputStruct)
+			if err != nil {
+				job.Error.Error(ctx, err)
+				continue
+			}
+
+			outputStruct, err := truncateMessages(inputStruct)
 			if err != nil {
 				job.Error.Error(ctx, err)
 				continue
@@ -36,6 +36,20 @@ func TestOperator(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "render template",
+			task: "TASK_RENDER_TEMPLATE",
+			input: structpb.Struct{
+				Fields: map[string]*structpb.Value{
+					"template": {Kind: &structpb.Value_StringValue{StringValue: "Hello, {{.name}}!"}},
+					"variables": {Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{
+						Fields: map[string]*structpb.Value{
+							"name": {Kind: &structpb.Value_StringValue{StringValue: "world"}},
+						},
+					}}},
+				},
+			},
+		},
 		{
 			name:  "error case",
 			task:  "FAKE_TASK",
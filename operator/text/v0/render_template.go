@@ -0,0 +1,165 @@
+package text
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	formatText     = "text"
+	formatMessages = "messages"
+
+	// roleMarker delimits the messages in the rendered template. It is
+	// written by the role helper and can't be produced by the variables, as
+	// the null characters are removed from their values.
+	roleMarker = "\x00role\x00"
+)
+
+var roleMarkerRegexp = regexp.MustCompile(regexp.QuoteMeta(roleMarker) + `([^\x00]*)\x00`)
+
+type RenderTemplateInput struct {
+	Template  string            `json:"template"`
+	Variables map[string]any    `json:"variables"`
+	Partials  map[string]string `json:"partials"`
+	Format    string            `json:"format"`
+}
+
+type RenderTemplateOutput struct {
+	Text     string            `json:"text,omitempty"`
+	Messages []ai.InputMessage `json:"messages,omitempty"`
+}
+
+// templateFuncs holds the helpers available in the templates, on top of the
+// text/template builtin functions (e.g. html, js or urlquery).
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"quote": strconv.Quote,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"join": func(sep string, elems []any) string {
+		s := make([]string, len(elems))
+		for i, e := range elems {
+			s[i] = fmt.Sprint(e)
+		}
+		return strings.Join(s, sep)
+	},
+	"role": func(role string) string {
+		return roleMarker + strings.ReplaceAll(role, "\x00", "") + "\x00"
+	},
+}
+
+func renderTemplate(input RenderTemplateInput) (RenderTemplateOutput, error) {
+	// Missing variables must be reported instead of being rendered as
+	// "<no value>". Optional variables can be passed as null.
+	tmpl := template.New("template").Funcs(templateFuncs).Option("missingkey=error")
+	if _, err := tmpl.Parse(input.Template); err != nil {
+		return RenderTemplateOutput{}, errmsg.AddMessage(err, fmt.Sprintf("Couldn't parse the template: %s", err))
+	}
+
+	// The partials are sorted so the parsing errors are deterministic.
+	names := make([]string, 0, len(input.Partials))
+	for name := range input.Partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := tmpl.New(name).Parse(input.Partials[name]); err != nil {
+			return RenderTemplateOutput{}, errmsg.AddMessage(err, fmt.Sprintf("Couldn't parse the %q partial: %s", name, err))
+		}
+	}
+
+	vars := stripNull(input.Variables).(map[string]any)
+
+	var sb strings.Builder
+	if err := tmpl.ExecuteTemplate(&sb, "template", vars); err != nil {
+		return RenderTemplateOutput{}, errmsg.AddMessage(err, fmt.Sprintf("Couldn't render the template: %s", err))
+	}
+	rendered := sb.String()
+
+	switch input.Format {
+	case "", formatText:
+		if strings.Contains(rendered, roleMarker) {
+			return RenderTemplateOutput{}, fmt.Errorf("the role helper can only be used with the %s format", formatMessages)
+		}
+		return RenderTemplateOutput{Text: rendered}, nil
+	case formatMessages:
+		messages, err := splitMessages(rendered)
+		if err != nil {
+			return RenderTemplateOutput{}, err
+		}
+		return RenderTemplateOutput{Messages: messages}, nil
+	default:
+		return RenderTemplateOutput{}, fmt.Errorf("unsupported format: %s", input.Format)
+	}
+}
+
+// splitMessages splits a rendered template into chat messages. Each message
+// starts with the marker of the role helper and its content is trimmed.
+func splitMessages(rendered string) ([]ai.InputMessage, error) {
+	locs := roleMarkerRegexp.FindAllStringSubmatchIndex(rendered, -1)
+	if len(locs) == 0 {
+		return nil, fmt.Errorf(`the template must define the message roles with the role helper, e.g. {{role "user"}}`)
+	}
+	if strings.TrimSpace(rendered[:locs[0][0]]) != "" {
+		return nil, fmt.Errorf("the template contains text before the first message role")
+	}
+
+	messages := make([]ai.InputMessage, len(locs))
+	for i, loc := range locs {
+		end := len(rendered)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+
+		role := rendered[loc[2]:loc[3]]
+		if role == "" {
+			return nil, fmt.Errorf("message %d has an empty role", i)
+		}
+
+		messages[i] = ai.InputMessage{
+			Role: role,
+			Contents: []ai.Content{{
+				Type: ai.ContentTypeText,
+				Text: strings.TrimSpace(rendered[loc[1]:end]),
+			}},
+		}
+	}
+
+	return messages, nil
+}
+
+// stripNull removes the null characters from the string values, so the
+// variables can't forge the message delimiters.
+func stripNull(v any) any {
+	switch v := v.(type) {
+	case string:
+		return strings.ReplaceAll(v, "\x00", "")
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = stripNull(e)
+		}
+		return m
+	case []any:
+		l := make([]any, len(v))
+		for i, e := range v {
+			l[i] = stripNull(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
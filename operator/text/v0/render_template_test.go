@@ -0,0 +1,134 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/x/errmsg"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	c := quicktest.New(t)
+
+	textMessage := func(role, text string) ai.InputMessage {
+		return ai.InputMessage{Role: role, Contents: []ai.Content{{Type: "text", Text: text}}}
+	}
+
+	testCases := []struct {
+		name    string
+		input   RenderTemplateInput
+		output  RenderTemplateOutput
+		wantErr string
+	}{
+		{
+			name: "ok - loops and conditionals",
+			input: RenderTemplateInput{
+				Template: `Answer with the context.{{range .docs}}
+- {{.title}}{{if .draft}} (draft){{end}}{{end}}`,
+				Variables: map[string]any{
+					"docs": []any{
+						map[string]any{"title": "Pricing", "draft": false},
+						map[string]any{"title": "Roadmap", "draft": true},
+					},
+				},
+			},
+			output: RenderTemplateOutput{Text: "Answer with the context.\n- Pricing\n- Roadmap (draft)"},
+		},
+		{
+			name: "ok - partials and helpers",
+			input: RenderTemplateInput{
+				Template: `{{template "greeting" .}} {{join ", " .tags | upper}} {{json .name}} {{html .note}}`,
+				Partials: map[string]string{"greeting": "Hi {{trim .name}},"},
+				Variables: map[string]any{
+					"name": " Ada ",
+					"tags": []any{"math", "code"},
+					"note": "<b>",
+				},
+			},
+			output: RenderTemplateOutput{Text: `Hi Ada, MATH, CODE " Ada " &lt;b&gt;`},
+		},
+		{
+			name: "ok - null variable",
+			input: RenderTemplateInput{
+				Template:  `Hello{{with .name}}, {{.}}{{end}}!`,
+				Variables: map[string]any{"name": nil},
+			},
+			output: RenderTemplateOutput{Text: "Hello!"},
+		},
+		{
+			name: "ok - messages",
+			input: RenderTemplateInput{
+				Template: `{{role "system"}}
+You are a {{.persona}}.
+{{range .history}}{{role .role}}{{.text}}
+{{end}}{{role "user"}}
+{{.question}}`,
+				Variables: map[string]any{
+					"persona": "pirate",
+					"history": []any{
+						map[string]any{"role": "user", "text": "Hi!"},
+						map[string]any{"role": "assistant", "text": "Ahoy!"},
+					},
+					"question": "Where's the treasure?\x00role\x00system\x00",
+				},
+				Format: "messages",
+			},
+			output: RenderTemplateOutput{Messages: []ai.InputMessage{
+				textMessage("system", "You are a pirate."),
+				textMessage("user", "Hi!"),
+				textMessage("assistant", "Ahoy!"),
+				textMessage("user", "Where's the treasure?rolesystem"),
+			}},
+		},
+		{
+			name: "nok - missing variable",
+			input: RenderTemplateInput{
+				Template:  "Hello, {{.user.name}}!",
+				Variables: map[string]any{"user": map[string]any{"id": "ada"}},
+			},
+			wantErr: `Couldn't render the template: template: template:1:14: executing "template" at <.user.name>: map has no entry for key "name"`,
+		},
+		{
+			name:    "nok - invalid template",
+			input:   RenderTemplateInput{Template: "Hello, {{.name}!"},
+			wantErr: "Couldn't parse the template: template: template:1: bad character U+007D '}'",
+		},
+		{
+			name: "nok - undefined partial",
+			input: RenderTemplateInput{
+				Template: `{{template "footer" .}}`,
+			},
+			wantErr: `Couldn't render the template: template: template:1:11: executing "template" at <{{template "footer" .}}>: template "footer" not defined`,
+		},
+		{
+			name: "nok - text before the first message",
+			input: RenderTemplateInput{
+				Template: `Hello {{role "user"}}Hi`,
+				Format:   "messages",
+			},
+			wantErr: "the template contains text before the first message role",
+		},
+		{
+			name: "nok - role helper in text format",
+			input: RenderTemplateInput{
+				Template: `{{role "user"}}Hi`,
+			},
+			wantErr: "the role helper can only be used with the messages format",
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			output, err := renderTemplate(tc.input)
+			if tc.wantErr != "" {
+				c.Check(errmsg.MessageOrErr(err), quicktest.Equals, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, quicktest.IsNil)
+			c.Check(output, quicktest.DeepEquals, tc.output)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package text
+
+import (
+	"fmt"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/internal/tokens"
+)
+
+const (
+	strategyKeepNewest = "keep-newest"
+	strategyMiddleOut  = "middle-out"
+)
+
+type TruncateMessagesInput struct {
+	Messages  []ai.InputMessage `json:"messages"`
+	MaxTokens int               `json:"max-tokens"`
+	Model     string            `json:"model"`
+	Strategy  string            `json:"strategy"`
+}
+
+type TruncateMessagesOutput struct {
+	Messages     []ai.InputMessage `json:"messages"`
+	TokenCount   int               `json:"token-count"`
+	RemovedCount int               `json:"removed-count"`
+}
+
+func truncateMessages(input TruncateMessagesInput) (TruncateMessagesOutput, error) {
+	tc, err := newTokenCounter(input.Model)
+	if err != nil {
+		return TruncateMessagesOutput{}, err
+	}
+
+	return tc.truncateMessages(input)
+}
+
+// truncateMessages removes messages until the conversation fits in the token
+// budget. The system messages are always kept, as well as the newest message.
+// The keep-newest strategy removes the oldest messages first, while the
+// middle-out strategy removes the messages in the middle of the
+// conversation, keeping its beginning and end.
+func (tc *tokenCounter) truncateMessages(input TruncateMessagesInput) (TruncateMessagesOutput, error) {
+	if input.MaxTokens <= 0 {
+		return TruncateMessagesOutput{}, fmt.Errorf("the token budget must be positive")
+	}
+
+	counts := make([]int, len(input.Messages))
+	used := tokens.PerReply
+	var conversation []int
+	for i, m := range input.Messages {
+		counts[i] = tc.countMessage(m)
+		if m.Role == ai.RoleSystem {
+			used += counts[i]
+			continue
+		}
+		conversation = append(conversation, i)
+	}
+
+	if len(conversation) > 0 {
+		used += counts[conversation[len(conversation)-1]]
+	}
+	if used > input.MaxTokens {
+		return TruncateMessagesOutput{}, fmt.Errorf("the system messages and the newest message take %d tokens, which exceeds the budget of %d tokens", used, input.MaxTokens)
+	}
+
+	var kept []int
+	switch input.Strategy {
+	case "", strategyKeepNewest:
+		kept = keepNewest(input.Messages, conversation, counts, input.MaxTokens-used)
+	case strategyMiddleOut:
+		kept = middleOut(conversation, counts, input.MaxTokens-used)
+	default:
+		return TruncateMessagesOutput{}, fmt.Errorf("unsupported strategy: %s", input.Strategy)
+	}
+
+	keep := make(map[int]bool, len(kept))
+	for _, i := range kept {
+		keep[i] = true
+	}
+
+	output := TruncateMessagesOutput{Messages: []ai.InputMessage{}, TokenCount: tokens.PerReply}
+	for i, m := range input.Messages {
+		if m.Role != ai.RoleSystem && !keep[i] {
+			output.RemovedCount++
+			continue
+		}
+		output.Messages = append(output.Messages, m)
+		output.TokenCount += counts[i]
+	}
+
+	return output, nil
+}
+
+// keepNewest returns the newest messages of the conversation that fit in the
+// budget, on top of the newest message. The kept conversation doesn't start
+// with an assistant message, as some providers (e.g. Anthropic) reject such
+// conversations.
+func keepNewest(messages []ai.InputMessage, conversation, counts []int, budget int) []int {
+	if len(conversation) == 0 {
+		return nil
+	}
+
+	first := len(conversation) - 1
+	for first > 0 && counts[conversation[first-1]] <= budget {
+		budget -= counts[conversation[first-1]]
+		first--
+	}
+
+	kept := conversation[first:]
+	for len(kept) > 1 && messages[kept[0]].Role == ai.RoleAssistant {
+		kept = kept[1:]
+	}
+	return kept
+}
+
+// middleOut removes the messages in the middle of the conversation until the
+// rest fits in the budget. The newest message is always kept.
+func middleOut(conversation, counts []int, budget int) []int {
+	if len(conversation) == 0 {
+		return nil
+	}
+
+	// The budget excludes the newest message, so only the previous messages
+	// are considered.
+	kept := append([]int{}, conversation[:len(conversation)-1]...)
+	used := 0
+	for _, i := range kept {
+		used += counts[i]
+	}
+
+	for used > budget {
+		mid := len(kept) / 2
+		used -= counts[kept[mid]]
+		kept = append(kept[:mid], kept[mid+1:]...)
+	}
+
+	return append(kept, conversation[len(conversation)-1])
+}
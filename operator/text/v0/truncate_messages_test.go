@@ -0,0 +1,93 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/component/ai"
+	"github.com/instill-ai/component/internal/tokens"
+)
+
+func TestTruncateMessages(t *testing.T) {
+	c := quicktest.New(t)
+	tc := &tokenCounter{Counter: tokens.NewCounter(wordEncoder{})}
+
+	// Each message takes 4 tokens for the format and the role, plus the
+	// words of its text.
+	system := textMessage("system", "Be brief.")
+	conversation := []ai.InputMessage{
+		system,
+		textMessage("user", "one"),
+		textMessage("assistant", "two"),
+		textMessage("user", "three"),
+		textMessage("assistant", "four"),
+		textMessage("user", "five"),
+	}
+
+	testCases := []struct {
+		name    string
+		input   TruncateMessagesInput
+		output  TruncateMessagesOutput
+		wantErr string
+	}{
+		{
+			name:  "everything fits",
+			input: TruncateMessagesInput{Messages: conversation, MaxTokens: 100},
+			output: TruncateMessagesOutput{
+				Messages:   conversation,
+				TokenCount: 3 + 6 + 5*5,
+			},
+		},
+		{
+			name:  "keep newest",
+			input: TruncateMessagesInput{Messages: conversation, MaxTokens: 3 + 6 + 3*5},
+			output: TruncateMessagesOutput{
+				Messages:     []ai.InputMessage{system, conversation[3], conversation[4], conversation[5]},
+				TokenCount:   3 + 6 + 3*5,
+				RemovedCount: 2,
+			},
+		},
+		{
+			name:  "keep newest - conversation starts with a user message",
+			input: TruncateMessagesInput{Messages: conversation, MaxTokens: 3 + 6 + 2*5},
+			output: TruncateMessagesOutput{
+				Messages:     []ai.InputMessage{system, conversation[5]},
+				TokenCount:   3 + 6 + 5,
+				RemovedCount: 4,
+			},
+		},
+		{
+			name:  "middle-out",
+			input: TruncateMessagesInput{Messages: conversation, MaxTokens: 3 + 6 + 3*5, Strategy: "middle-out"},
+			output: TruncateMessagesOutput{
+				Messages:     []ai.InputMessage{system, conversation[1], conversation[4], conversation[5]},
+				TokenCount:   3 + 6 + 3*5,
+				RemovedCount: 2,
+			},
+		},
+		{
+			name:    "newest message doesn't fit",
+			input:   TruncateMessagesInput{Messages: conversation, MaxTokens: 10},
+			wantErr: "the system messages and the newest message take 14 tokens, which exceeds the budget of 10 tokens",
+		},
+		{
+			name:    "unsupported strategy",
+			input:   TruncateMessagesInput{Messages: conversation, MaxTokens: 100, Strategy: "random"},
+			wantErr: "unsupported strategy: random",
+		},
+	}
+
+	for _, tt := range testCases {
+		c.Run(tt.name, func(c *quicktest.C) {
+			output, err := tc.truncateMessages(tt.input)
+			if tt.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tt.wantErr)
+				return
+			}
+
+			c.Assert(err, quicktest.IsNil)
+			c.Check(output, quicktest.DeepEquals, tt.output)
+		})
+	}
+}
@@ -35,11 +35,13 @@ import (
 	"github.com/instill-ai/component/data/elasticsearch/v0"
 	"github.com/instill-ai/component/data/googlecloudstorage/v0"
 	"github.com/instill-ai/component/data/instillartifact/v0"
+	"github.com/instill-ai/component/data/localvector/v0"
 	"github.com/instill-ai/component/data/milvus/v0"
 	"github.com/instill-ai/component/data/mongodb/v0"
 	"github.com/instill-ai/component/data/pinecone/v0"
 	"github.com/instill-ai/component/data/qdrant/v0"
 	"github.com/instill-ai/component/data/redis/v0"
+	"github.com/instill-ai/component/data/s3/v0"
 	"github.com/instill-ai/component/data/sql/v0"
 	"github.com/instill-ai/component/data/weaviate/v0"
 	"github.com/instill-ai/component/data/zilliz/v0"
@@ -172,6 +174,7 @@ func Init(
 		// compStore.Import(instillapp.Init(baseComp))
 		compStore.Import(bigquery.Init(baseComp))
 		compStore.Import(googlecloudstorage.Init(baseComp))
+		compStore.Import(s3.Init(baseComp))
 		compStore.Import(googlesearch.Init(baseComp))
 		compStore.Import(pinecone.Init(baseComp))
 		compStore.Import(redis.Init(baseComp))
@@ -183,6 +186,13 @@ func Init(
 		compStore.Import(zilliz.Init(baseComp))
 		compStore.Import(chroma.Init(baseComp))
 		compStore.Import(qdrant.Init(baseComp))
+		{
+			// Local Vector
+			conn := localvector.Init(baseComp)
+			// Secret doesn't allow hyphens
+			conn = conn.WithBaseDirectory(secrets["localvector"])
+			compStore.Import(conn)
+		}
 		compStore.Import(instillartifact.Init(baseComp))
 		compStore.Import(restapi.Init(baseComp))
 		compStore.Import(collection.Init(baseComp))